@@ -0,0 +1,22 @@
+package actions
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "actions",
+		Short: "manages the actions of ZITADEL",
+		Long:  `manages the actions of ZITADEL`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("no additional command provided")
+		},
+	}
+
+	cmd.AddCommand(newMigrateV1())
+
+	return cmd
+}
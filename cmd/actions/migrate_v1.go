@@ -0,0 +1,144 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/cmd/setup"
+	"github.com/zitadel/zitadel/internal/api/actionsrunner"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+)
+
+var (
+	instanceIDs []string
+	runnerURL   string
+	dryRun      bool
+)
+
+func newMigrateV1() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-v1",
+		Short: "migrates the actions of the organizations to executions",
+		Long: `migrates the actions of the organizations to executions
+
+For every action triggered in a flow of an organization a target is created,
+which calls the runner of ZITADEL hosting the script of the action.
+The targets are set on the executions of the functions of the flows,
+conditioned on the organization of the flow.
+
+Actions already migrated are not migrated again.
+The flows and actions are not changed, clear the flows after the migration is verified,
+as the actions would run twice otherwise.
+
+Scripts using the modules zitadel/http, zitadel/uuid or zitadel/log are reported,
+as the modules are not available in the runner and the scripts need manual attention.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := setup.MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKey(cmd)
+			logging.OnError(err).Fatal("unable to read master key")
+
+			migrateV1(cmd.Context(), cmd.OutOrStdout(), config, masterKey)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&instanceIDs, "instance", nil, "ids of the instances to migrate")
+	cmd.Flags().StringVar(&runnerURL, "runner-url", "", "URL of the runner called by the targets, defaults to the runner on the external domain")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only reports the actions which would be migrated")
+	logging.OnError(cmd.MarkFlagRequired("instance")).Fatal("unable to mark flag as required")
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
+
+func migrateV1(ctx context.Context, out io.Writer, config *setup.Config, masterKey string) {
+	queryDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
+	logging.OnError(err).Fatal("unable to connect to database")
+	esPusherDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeEventPusher)
+	logging.OnError(err).Fatal("unable to connect to database")
+
+	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey)
+	logging.OnError(err).Fatal("unable to start key storage")
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	logging.OnError(err).Fatal("unable to ensure encryption keys")
+
+	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
+	esV3 := new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.Pusher = esV3
+	config.Eventstore.Searcher = esV3
+	config.Eventstore.PersonalDataKeys = new_es.NewPersonalDataKeys(queryDBClient, keys.User)
+	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
+
+	commands, err := command.StartCommands(
+		eventstoreClient,
+		nil,
+		config.SystemDefaults,
+		config.InternalAuthZ.RolePermissionMappings,
+		nil,
+		nil,
+		config.ExternalDomain,
+		config.ExternalSecure,
+		config.ExternalPort,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		keys.Target,
+		nil,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		config.DefaultInstance.SecretGenerators,
+	)
+	logging.OnError(err).Fatal("unable to start commands")
+
+	if runnerURL == "" {
+		runnerURL = http_util.BuildHTTP(config.ExternalDomain, config.ExternalPort, config.ExternalSecure) + actionsrunner.HandlerPrefix
+	}
+
+	for _, instanceID := range instanceIDs {
+		migrated, err := commands.MigrateActionsV1(authz.WithInstanceID(ctx, instanceID), &command.MigrateActionsV1{
+			RunnerURL: runnerURL,
+			DryRun:    dryRun,
+		}, instanceID)
+		logging.WithFields("instance", instanceID).OnError(err).Fatal("unable to migrate actions")
+		printMigratedActionsV1(out, instanceID, migrated)
+	}
+}
+
+func printMigratedActionsV1(out io.Writer, instanceID string, migrated []*command.MigratedActionV1) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "instance %s: %d actions\n", instanceID, len(migrated))
+	fmt.Fprintln(w, "ORGANIZATION\tACTION\tNAME\tTARGET\tFUNCTIONS\tMANUAL ATTENTION")
+	for _, m := range migrated {
+		attention := strings.Join(m.Modules, ",")
+		if m.Inactive {
+			attention = "inactive, not migrated"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", m.ResourceOwner, m.ActionID, m.Name, m.TargetID, strings.Join(m.Functions, ","), attention)
+	}
+	logging.OnError(w.Flush()).Warn("unable to print migrated actions")
+}
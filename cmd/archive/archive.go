@@ -0,0 +1,89 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/cmd/setup"
+	event_archive "github.com/zitadel/zitadel/internal/archive"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+)
+
+var (
+	instanceID string
+	from, to   string
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "manages the archived events of ZITADEL",
+		Long: `manages the archived events of ZITADEL
+
+Events older than the audit log retention of the instance are moved into archives on the asset storage
+if the archival is enabled using the Archive configuration.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("no additional command provided")
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&instanceID, "instance", "", "id of the instance")
+	cmd.PersistentFlags().StringVar(&from, "from", "", "only archives containing events created at or after this time (RFC3339) are used")
+	cmd.PersistentFlags().StringVar(&to, "to", "", "only archives containing events created at or before this time (RFC3339) are used")
+	logging.OnError(cmd.MarkPersistentFlagRequired("instance")).Fatal("unable to mark flag required")
+
+	cmd.AddCommand(
+		newRead(),
+		newRestore(),
+	)
+
+	return cmd
+}
+
+// timeRange parses the from and to flags
+func timeRange() (fromTime, toTime time.Time, err error) {
+	if from != "" {
+		if fromTime, err = time.Parse(time.RFC3339, from); err != nil {
+			return fromTime, toTime, err
+		}
+	}
+	if to != "" {
+		if toTime, err = time.Parse(time.RFC3339, to); err != nil {
+			return fromTime, toTime, err
+		}
+	}
+	return fromTime, toTime, nil
+}
+
+func newArchiver(ctx context.Context, config *setup.Config, masterKey string) *event_archive.Archiver {
+	queryDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
+	logging.OnError(err).Fatal("unable to connect to database")
+	esPusherDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeEventPusher)
+	logging.OnError(err).Fatal("unable to connect to database")
+
+	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey)
+	logging.OnError(err).Fatal("unable to start key storage")
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	logging.OnError(err).Fatal("unable to ensure encryption keys")
+
+	storage, err := config.AssetStorage.NewStorage(queryDBClient.DB)
+	logging.OnError(err).Fatal("unable to start asset storage")
+
+	return event_archive.New(nil, esPusherDBClient, nil, nil, storage, keys.User, 0)
+}
+
+func archives(ctx context.Context, archiver *event_archive.Archiver) []*event_archive.Archive {
+	fromTime, toTime, err := timeRange()
+	logging.OnError(err).Fatal("unable to parse time range")
+
+	archives, err := archiver.Archives(ctx, instanceID, fromTime, toTime)
+	logging.WithFields("instance", instanceID).OnError(err).Fatal("unable to query archives")
+	return archives
+}
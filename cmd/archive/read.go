@@ -0,0 +1,51 @@
+package archive
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/cmd/setup"
+)
+
+func newRead() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "read",
+		Short: "writes the archived events of an instance to stdout",
+		Long: `writes the archived events of an instance to stdout
+
+The events are written as newline-delimited JSON ordered by archive.
+Events outside of the time range are skipped.`,
+		Example: `zitadel archive read --instance 263097340215509313 --from 2023-01-01T00:00:00Z --to 2023-07-01T00:00:00Z`,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := setup.MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKey(cmd)
+			logging.OnError(err).Fatal("unable to read master key")
+
+			fromTime, toTime, err := timeRange()
+			logging.OnError(err).Fatal("unable to parse time range")
+
+			archiver := newArchiver(cmd.Context(), config, masterKey)
+			encoder := json.NewEncoder(os.Stdout)
+			for _, archive := range archives(cmd.Context(), archiver) {
+				events, err := archiver.Events(cmd.Context(), archive)
+				logging.WithFields("archive", archive.ID).OnError(err).Fatal("unable to read archive")
+				for _, event := range events {
+					if event.CreatedAt.Before(fromTime) || (!toTime.IsZero() && event.CreatedAt.After(toTime)) {
+						continue
+					}
+					logging.OnError(encoder.Encode(event)).Fatal("unable to write event")
+				}
+			}
+		},
+	}
+
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
@@ -0,0 +1,39 @@
+package archive
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/cmd/setup"
+)
+
+func newRestore() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "moves archived events of an instance back into the eventstore",
+		Long: `moves archived events of an instance back into the eventstore
+
+All archives containing events in the time range are restored completely and removed afterwards.
+Restored events are archived again by the next archival if the archival is still enabled.`,
+		Example: `zitadel archive restore --instance 263097340215509313 --from 2023-01-01T00:00:00Z`,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := setup.MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKey(cmd)
+			logging.OnError(err).Fatal("unable to read master key")
+
+			archiver := newArchiver(cmd.Context(), config, masterKey)
+			for _, archive := range archives(cmd.Context(), archiver) {
+				err = archiver.Restore(cmd.Context(), archive)
+				logging.WithFields("archive", archive.ID).OnError(err).Fatal("unable to restore archive")
+				logging.WithFields("archive", archive.ID, "events", archive.EventCount).Info("archive restored")
+			}
+		},
+	}
+
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
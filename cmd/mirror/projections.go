@@ -119,10 +119,16 @@ func projections(
 	esPusherDBClient, err := database.Connect(config.Destination, false, dialect.DBPurposeEventPusher)
 	logging.OnError(err).Fatal("unable to connect eventstore push client")
 	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.PersonalDataKeys = new_es.NewPersonalDataKeys(client, keys.User)
 	es := eventstore.NewEventstore(config.Eventstore)
-	esV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(client, &es_v4_pg.Config{
-		MaxRetries: config.Eventstore.MaxRetries,
-	}))
+	esV4Config := &es_v4_pg.Config{
+		MaxRetries:   config.Eventstore.MaxRetries,
+		PersonalData: eventstore.NewPersonalDataDecrypter(config.Eventstore.PersonalDataKeys),
+	}
+	if config.Eventstore.EncryptPersonalData {
+		esV4Config.EncryptPersonalData = eventstore.NewPersonalDataEncrypter(config.Eventstore.PersonalDataKeys)
+	}
+	esV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(client, esV4Config))
 
 	sessionTokenVerifier := internal_authz.SessionTokenVerifier(keys.OIDC)
 
@@ -138,6 +144,7 @@ func projections(
 		keys.OTP,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		config.InternalAuthZ.RolePermissionMappings,
 		sessionTokenVerifier,
 		func(q *query.Queries) domain.PermissionCheck {
@@ -160,6 +167,7 @@ func projections(
 	}
 	commands, err := command.StartCommands(
 		es,
+		esV4,
 		config.SystemDefaults,
 		config.InternalAuthZ.RolePermissionMappings,
 		staticStorage,
@@ -175,6 +183,7 @@ func projections(
 		keys.DomainVerification,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		&http.Client{},
 		func(ctx context.Context, permission, orgID, resourceID string) (err error) {
 			return internal_authz.CheckPermission(ctx, authZRepo, config.InternalAuthZ.RolePermissionMappings, permission, orgID, resourceID)
@@ -197,6 +206,7 @@ func projections(
 		config.Projections.Customizations["notifications"],
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["backchannel_logout"],
 		*config.Telemetry,
 		config.ExternalDomain,
 		config.ExternalPort,
@@ -209,6 +219,7 @@ func projections(
 		keys.User,
 		keys.SMTP,
 		keys.SMS,
+		keys.OIDC,
 	)
 
 	config.Auth.Spooler.Client = client
@@ -0,0 +1,22 @@
+package projections
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "projections",
+		Short: "manages the projections of ZITADEL",
+		Long:  `manages the projections of ZITADEL`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("no additional command provided")
+		},
+	}
+
+	cmd.AddCommand(newRebuild())
+
+	return cmd
+}
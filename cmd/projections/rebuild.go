@@ -0,0 +1,80 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/cmd/setup"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+func newRebuild() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebuild [projection names]",
+		Short: "rebuilds projections without clearing their tables",
+		Long: `rebuilds projections without clearing their tables
+
+The events are projected into shadow tables in the schema of the projection suffixed with "_rebuild",
+e.g. projections_rebuild.users14 for the projection projections.users14.
+The tables of the projection stay available during the rebuild and are replaced by the shadow tables
+in a single transaction as soon as the shadow tables caught up with the projection.
+
+An interrupted rebuild continues where it stopped if it is started again.
+The projections are rebuilt one after another.`,
+		Example: `zitadel projections rebuild projections.users14 projections.login_names3`,
+		Args:    cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			config := setup.MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKey(cmd)
+			logging.OnError(err).Fatal("unable to read master key")
+
+			rebuild(cmd.Context(), config, masterKey, args)
+		},
+	}
+
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
+
+func rebuild(ctx context.Context, config *setup.Config, masterKey string, names []string) {
+	start := time.Now()
+
+	queryDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
+	logging.OnError(err).Fatal("unable to connect to database")
+	esPusherDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeEventPusher)
+	logging.OnError(err).Fatal("unable to connect to database")
+
+	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey)
+	logging.OnError(err).Fatal("unable to start key storage")
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	logging.OnError(err).Fatal("unable to ensure encryption keys")
+
+	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
+	esV3 := new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.Pusher = esV3
+	config.Eventstore.Searcher = esV3
+	config.Eventstore.PersonalDataKeys = new_es.NewPersonalDataKeys(queryDBClient, keys.User)
+	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
+
+	err = projection.Create(ctx, queryDBClient, eventstoreClient, config.Projections, keys.OIDC, keys.SAML, config.SystemAPIUsers)
+	logging.OnError(err).Fatal("unable to create projections")
+
+	err = projection.Rebuild(ctx, names...)
+	logging.WithFields("projections", names).OnError(err).Fatal("unable to rebuild projections")
+
+	logging.WithFields("projections", names, "took", time.Since(start)).Info("projections rebuilt")
+}
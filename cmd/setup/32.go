@@ -0,0 +1,29 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 32.sql
+	addTargetCallLogsTable string
+)
+
+type AddTargetCallLogsTable struct {
+	dbClient *database.DB
+	username string
+}
+
+func (mig *AddTargetCallLogsTable) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, strings.ReplaceAll(addTargetCallLogsTable, "%[1]s", mig.username))
+	return err
+}
+
+func (mig *AddTargetCallLogsTable) String() string {
+	return "32_add_target_call_logs_table"
+}
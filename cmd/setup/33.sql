@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+    instance_id TEXT NOT NULL
+    , snapshot_key TEXT NOT NULL
+    -- revision of the state of the write model, snapshots of other revisions are ignored
+    , revision TEXT NOT NULL
+    -- sequence of the last event reduced into the snapshot
+    , "sequence" INT8 NOT NULL
+
+    , aggregate_id TEXT NOT NULL
+    , resource_owner TEXT NOT NULL
+    , processed_sequence INT8 NOT NULL
+    , change_date TIMESTAMPTZ
+
+    , payload JSONB NOT NULL
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+
+    , PRIMARY KEY (instance_id, snapshot_key)
+);
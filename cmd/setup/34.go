@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 34.sql
+	addPersonalDataKeysTable string
+)
+
+type AddPersonalDataKeysTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddPersonalDataKeysTable) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addPersonalDataKeysTable)
+	return err
+}
+
+func (mig *AddPersonalDataKeysTable) String() string {
+	return "34_add_personal_data_keys_table"
+}
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS eventstore.personal_data_keys (
+    instance_id TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    -- the key is encrypted with the user encryption key of the instance
+    , "key" JSONB NOT NULL
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+
+    , PRIMARY KEY (instance_id, aggregate_id)
+);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 35.sql
+	addArchivesTable string
+)
+
+type AddArchivesTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddArchivesTable) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addArchivesTable)
+	return err
+}
+
+func (mig *AddArchivesTable) String() string {
+	return "35_add_archives_table"
+}
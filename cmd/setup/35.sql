@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS eventstore.archives (
+    instance_id TEXT NOT NULL
+    , id TEXT NOT NULL
+    -- name of the object in the asset storage
+    , object_name TEXT NOT NULL
+    -- the object is encrypted with the user encryption key of the instance
+    , key_id TEXT NOT NULL
+    , event_count INT8 NOT NULL
+    , oldest_created_at TIMESTAMPTZ NOT NULL
+    , newest_created_at TIMESTAMPTZ NOT NULL
+    , archived_at TIMESTAMPTZ NOT NULL DEFAULT now()
+
+    , PRIMARY KEY (instance_id, id)
+);
+
+CREATE INDEX IF NOT EXISTS archives_created_at ON eventstore.archives (instance_id, oldest_created_at, newest_created_at);
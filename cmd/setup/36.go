@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 36.sql
+	addBackChannelLogoutToOIDCConfigs string
+)
+
+type Apps7OIDCConfigsBackChannelLogout struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7OIDCConfigsBackChannelLogout) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addBackChannelLogoutToOIDCConfigs)
+	return err
+}
+
+func (mig *Apps7OIDCConfigsBackChannelLogout) String() string {
+	return "36_apps7_oidc_configs_add_back_channel_logout"
+}
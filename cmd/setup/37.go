@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 37.sql
+	addRequirePushedAuthRequestsToOIDCConfigs string
+)
+
+type Apps7OIDCConfigsPushedAuthRequests struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7OIDCConfigsPushedAuthRequests) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addRequirePushedAuthRequestsToOIDCConfigs)
+	return err
+}
+
+func (mig *Apps7OIDCConfigsPushedAuthRequests) String() string {
+	return "37_apps7_oidc_configs_add_require_pushed_auth_requests"
+}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 38.sql
+	addRequireDPoPToOIDCConfigs string
+)
+
+type Apps7OIDCConfigsDPoP struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7OIDCConfigsDPoP) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addRequireDPoPToOIDCConfigs)
+	return err
+}
+
+func (mig *Apps7OIDCConfigsDPoP) String() string {
+	return "38_apps7_oidc_configs_add_require_dpop"
+}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 39.sql
+	addTLSClientAuthSubjectDNToOIDCConfigs string
+)
+
+type Apps7OIDCConfigsTLSClientAuth struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7OIDCConfigsTLSClientAuth) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addTLSClientAuthSubjectDNToOIDCConfigs)
+	return err
+}
+
+func (mig *Apps7OIDCConfigsTLSClientAuth) String() string {
+	return "39_apps7_oidc_configs_add_tls_client_auth_subject_dn"
+}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 40.sql
+	addDPoPProofsTable string
+)
+
+type AddDPoPProofsTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddDPoPProofsTable) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addDPoPProofsTable)
+	return err
+}
+
+func (mig *AddDPoPProofsTable) String() string {
+	return "40_add_dpop_proofs_table"
+}
@@ -0,0 +1,10 @@
+-- used DPoP proofs are remembered until they expire, so they can't be replayed on any instance of ZITADEL
+CREATE TABLE IF NOT EXISTS auth.dpop_proofs (
+    jti TEXT NOT NULL
+    , htu TEXT NOT NULL
+    , expires_at TIMESTAMPTZ NOT NULL
+
+    , PRIMARY KEY (jti, htu)
+);
+
+CREATE INDEX IF NOT EXISTS dpop_proofs_expires_at ON auth.dpop_proofs (expires_at);
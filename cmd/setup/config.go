@@ -115,6 +115,15 @@ type Steps struct {
 	s29FillFieldsForProjectGrant           *FillFieldsForProjectGrant
 	s30FillFieldsForOrgDomainVerified      *FillFieldsForOrgDomainVerified
 	s31AddAggregateIndexToFields           *AddAggregateIndexToFields
+	s32AddTargetCallLogsTable              *AddTargetCallLogsTable
+	s33AddSnapshotsTable                   *AddSnapshotsTable
+	s34AddPersonalDataKeysTable            *AddPersonalDataKeysTable
+	s35AddArchivesTable                    *AddArchivesTable
+	s36Apps7OIDCConfigsBackChannelLogout   *Apps7OIDCConfigsBackChannelLogout
+	s37Apps7OIDCConfigsPushedAuthRequests  *Apps7OIDCConfigsPushedAuthRequests
+	s38Apps7OIDCConfigsDPoP                *Apps7OIDCConfigsDPoP
+	s39Apps7OIDCConfigsTLSClientAuth       *Apps7OIDCConfigsTLSClientAuth
+	s40AddDPoPProofsTable                  *AddDPoPProofsTable
 }
 
 func MustNewSteps(v *viper.Viper) *Steps {
@@ -107,16 +107,27 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	projectionDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeProjectionSpooler)
 	logging.OnError(err).Fatal("unable to connect to database")
 
+	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey)
+	logging.OnError(err).Fatal("unable to start key storage")
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	logging.OnError(err).Fatal("unable to ensure encryption keys")
+
 	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
 	esV3 := new_es.NewEventstore(esPusherDBClient)
 	config.Eventstore.Pusher = esV3
 	config.Eventstore.Searcher = esV3
+	config.Eventstore.PersonalDataKeys = new_es.NewPersonalDataKeys(queryDBClient, keys.User)
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
 
 	logging.OnError(err).Fatal("unable to start eventstore")
-	eventstoreV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(queryDBClient, &es_v4_pg.Config{
-		MaxRetries: config.Eventstore.MaxRetries,
-	}))
+	eventstoreV4Config := &es_v4_pg.Config{
+		MaxRetries:   config.Eventstore.MaxRetries,
+		PersonalData: eventstore.NewPersonalDataDecrypter(config.Eventstore.PersonalDataKeys),
+	}
+	if config.Eventstore.EncryptPersonalData {
+		eventstoreV4Config.EncryptPersonalData = eventstore.NewPersonalDataEncrypter(config.Eventstore.PersonalDataKeys)
+	}
+	eventstoreV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(queryDBClient, eventstoreV4Config))
 
 	steps.s1ProjectionTable = &ProjectionTable{dbClient: queryDBClient.DB}
 	steps.s2AssetsTable = &AssetTable{dbClient: queryDBClient.DB}
@@ -160,6 +171,15 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	steps.s29FillFieldsForProjectGrant = &FillFieldsForProjectGrant{eventstore: eventstoreClient}
 	steps.s30FillFieldsForOrgDomainVerified = &FillFieldsForOrgDomainVerified{eventstore: eventstoreClient}
 	steps.s31AddAggregateIndexToFields = &AddAggregateIndexToFields{dbClient: esPusherDBClient}
+	steps.s32AddTargetCallLogsTable = &AddTargetCallLogsTable{dbClient: queryDBClient, username: config.Database.Username()}
+	steps.s33AddSnapshotsTable = &AddSnapshotsTable{dbClient: esPusherDBClient}
+	steps.s34AddPersonalDataKeysTable = &AddPersonalDataKeysTable{dbClient: esPusherDBClient}
+	steps.s35AddArchivesTable = &AddArchivesTable{dbClient: esPusherDBClient}
+	steps.s36Apps7OIDCConfigsBackChannelLogout = &Apps7OIDCConfigsBackChannelLogout{dbClient: queryDBClient}
+	steps.s37Apps7OIDCConfigsPushedAuthRequests = &Apps7OIDCConfigsPushedAuthRequests{dbClient: queryDBClient}
+	steps.s38Apps7OIDCConfigsDPoP = &Apps7OIDCConfigsDPoP{dbClient: queryDBClient}
+	steps.s39Apps7OIDCConfigsTLSClientAuth = &Apps7OIDCConfigsTLSClientAuth{dbClient: queryDBClient}
+	steps.s40AddDPoPProofsTable = &AddDPoPProofsTable{dbClient: queryDBClient}
 
 	err = projection.Create(ctx, projectionDBClient, eventstoreClient, config.Projections, nil, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -184,11 +204,16 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 		steps.s2AssetsTable,
 		steps.s28AddFieldTable,
 		steps.s31AddAggregateIndexToFields,
+		steps.s33AddSnapshotsTable,
+		steps.s34AddPersonalDataKeysTable,
+		steps.s35AddArchivesTable,
 		steps.FirstInstance,
 		steps.s5LastFailed,
 		steps.s6OwnerRemoveColumns,
 		steps.s7LogstoreTables,
+		steps.s32AddTargetCallLogsTable,
 		steps.s8AuthTokens,
+		steps.s40AddDPoPProofsTable,
 		steps.s12AddOTPColumns,
 		steps.s13FixQuotaProjection,
 		steps.s15CurrentStates,
@@ -216,6 +241,10 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 		steps.s21AddBlockFieldToLimits,
 		steps.s25User11AddLowerFieldsToVerifiedEmail,
 		steps.s27IDPTemplate6SAMLNameIDFormat,
+		steps.s36Apps7OIDCConfigsBackChannelLogout,
+		steps.s37Apps7OIDCConfigsPushedAuthRequests,
+		steps.s38Apps7OIDCConfigsDPoP,
+		steps.s39Apps7OIDCConfigsTLSClientAuth,
 	} {
 		mustExecuteMigration(ctx, eventstoreClient, step, "migration failed")
 	}
@@ -313,6 +342,7 @@ func initProjections(
 		keys.OTP,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		config.InternalAuthZ.RolePermissionMappings,
 		sessionTokenVerifier,
 		func(q *query.Queries) domain.PermissionCheck {
@@ -350,6 +380,7 @@ func initProjections(
 	}
 	commands, err := command.StartCommands(
 		eventstoreClient,
+		eventstoreV4,
 		config.SystemDefaults,
 		config.InternalAuthZ.RolePermissionMappings,
 		staticStorage,
@@ -368,6 +399,7 @@ func initProjections(
 		keys.DomainVerification,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
@@ -382,6 +414,7 @@ func initProjections(
 		config.Projections.Customizations["notifications"],
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["backchannel_logout"],
 		*config.Telemetry,
 		config.ExternalDomain,
 		config.ExternalPort,
@@ -394,6 +427,7 @@ func initProjections(
 		keys.User,
 		keys.SMTP,
 		keys.SMS,
+		keys.OIDC,
 	)
 	for _, p := range notify_handler.Projections() {
 		err := migration.Migrate(ctx, eventstoreClient, p)
@@ -17,6 +17,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/archive"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/hook"
@@ -25,6 +26,8 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	target_execution "github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/export"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
@@ -69,6 +72,15 @@ type Config struct {
 	LogStore          *logstore.Configs
 	Quotas            *QuotasConfig
 	Telemetry         *handlers.TelemetryPusherConfig
+	Executions        *ExecutionsConfig
+	Export            *export.Config
+	Archive           *archive.Config
+}
+
+type ExecutionsConfig struct {
+	Deliveries *target_execution.DeliveryConfig
+	// MaxParallelTargets is the maximum amount of concurrent calls to the targets of a parallel execution
+	MaxParallelTargets uint16
 }
 
 type QuotasConfig struct {
@@ -76,7 +88,8 @@ type QuotasConfig struct {
 		logstore.EmitterConfig  `mapstructure:",squash"`
 		middleware.AccessConfig `mapstructure:",squash"`
 	}
-	Execution *logstore.EmitterConfig
+	Execution  *logstore.EmitterConfig
+	TargetCall *logstore.EmitterConfig
 }
 
 func MustNewConfig(v *viper.Viper) *Config {
@@ -32,8 +32,10 @@ import (
 	"github.com/zitadel/zitadel/internal/actions"
 	admin_es "github.com/zitadel/zitadel/internal/admin/repository/eventsourcing"
 	"github.com/zitadel/zitadel/internal/api"
+	"github.com/zitadel/zitadel/internal/api/actionsrunner"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/authz/dpopstore"
 	action_v3_alpha "github.com/zitadel/zitadel/internal/api/grpc/action/v3alpha"
 	"github.com/zitadel/zitadel/internal/api/grpc/admin"
 	"github.com/zitadel/zitadel/internal/api/grpc/auth"
@@ -54,6 +56,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/archive"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
 	"github.com/zitadel/zitadel/internal/authz"
 	authz_repo "github.com/zitadel/zitadel/internal/authz/repository"
@@ -67,12 +70,16 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	target_execution "github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/execution/grpctarget"
+	"github.com/zitadel/zitadel/internal/export"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/stdout"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/targetcall"
 	"github.com/zitadel/zitadel/internal/logstore/record"
 	"github.com/zitadel/zitadel/internal/net"
 	"github.com/zitadel/zitadel/internal/notification"
@@ -153,14 +160,26 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	}
 
 	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient)
-	config.Eventstore.Searcher = new_es.NewEventstore(queryDBClient)
+	esV3Query := new_es.NewEventstore(queryDBClient)
+	config.Eventstore.Searcher = esV3Query
+	config.Eventstore.Snapshots = esV3Query
+	config.Eventstore.PersonalDataKeys = new_es.NewPersonalDataKeys(queryDBClient, keys.User)
 	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
-	eventstoreV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(queryDBClient, &es_v4_pg.Config{
-		MaxRetries: config.Eventstore.MaxRetries,
-	}))
+	eventstoreV4Config := &es_v4_pg.Config{
+		MaxRetries:   config.Eventstore.MaxRetries,
+		PersonalData: eventstore.NewPersonalDataDecrypter(config.Eventstore.PersonalDataKeys),
+	}
+	if config.Eventstore.EncryptPersonalData {
+		eventstoreV4Config.EncryptPersonalData = eventstore.NewPersonalDataEncrypter(config.Eventstore.PersonalDataKeys)
+	}
+	eventstoreV4 := es_v4.NewEventstore(
+		es_v4_pg.New(queryDBClient, eventstoreV4Config),
+		es_v4_pg.New(esPusherDBClient, eventstoreV4Config),
+	)
 
 	sessionTokenVerifier := internal_authz.SessionTokenVerifier(keys.OIDC)
+	internal_authz.SetDPoPProofStore(dpopstore.New(queryDBClient))
 
 	queries, err := query.StartQueries(
 		ctx,
@@ -174,6 +193,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		keys.OTP,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		config.InternalAuthZ.RolePermissionMappings,
 		sessionTokenVerifier,
 		func(q *query.Queries) domain.PermissionCheck {
@@ -207,6 +227,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	}
 	commands, err := command.StartCommands(
 		eventstoreClient,
+		eventstoreV4,
 		config.SystemDefaults,
 		config.InternalAuthZ.RolePermissionMappings,
 		storage,
@@ -222,6 +243,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		keys.DomainVerification,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
@@ -248,11 +270,22 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	actionsLogstoreSvc := logstore.New(queries, actionsExecutionDBEmitter, actionsExecutionStdoutEmitter)
 	actions.SetLogstoreService(actionsLogstoreSvc)
 
+	targetCallStdoutEmitter, err := logstore.NewEmitter[*record.TargetCallLog](ctx, clock, &logstore.EmitterConfig{Enabled: config.LogStore.TargetCall.Stdout.Enabled}, stdout.NewStdoutEmitter[*record.TargetCallLog]())
+	if err != nil {
+		return err
+	}
+	targetCallDBEmitter, err := logstore.NewEmitter[*record.TargetCallLog](ctx, clock, config.Quotas.TargetCall, targetcall.NewDatabaseLogStorage(queryDBClient, commands, queries))
+	if err != nil {
+		return err
+	}
+	target_execution.SetLogstoreService(logstore.New(queries, targetCallDBEmitter, targetCallStdoutEmitter))
+
 	notification.Register(
 		ctx,
 		config.Projections.Customizations["notifications"],
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["backchannel_logout"],
 		*config.Telemetry,
 		config.ExternalDomain,
 		config.ExternalPort,
@@ -265,9 +298,43 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		keys.User,
 		keys.SMTP,
 		keys.SMS,
+		keys.OIDC,
 	)
 	notification.Start(ctx)
 
+	target_execution.RegisterGRPCInvoker(grpctarget.New())
+	target_execution.Register(
+		ctx,
+		config.Projections.Customizations["execution_handler"],
+		config.Executions.Deliveries,
+		config.Executions.MaxParallelTargets,
+		eventstoreClient,
+		queries,
+		commands,
+	)
+	target_execution.Start(ctx)
+
+	err = export.Register(
+		ctx,
+		config.Projections.Customizations["export"],
+		config.Export,
+		eventstoreClient,
+	)
+	if err != nil {
+		return err
+	}
+	export.Start(ctx)
+
+	archive.New(
+		config.Archive,
+		esPusherDBClient,
+		eventstoreClient,
+		queries,
+		storage,
+		keys.User,
+		config.AuditLogRetention,
+	).Start(ctx)
+
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
 	if err != nil {
@@ -426,6 +493,7 @@ func startAPIs(
 	apis.RegisterHandlerOnPrefix(assets.HandlerPrefix, assets.NewHandler(commands, verifier, config.InternalAuthZ, id.SonyFlakeGenerator(), store, queries, middleware.CallDurationHandler, instanceInterceptor.Handler, assetsCache.Handler, limitingAccessInterceptor.Handle))
 
 	apis.RegisterHandlerOnPrefix(idp.HandlerPrefix, idp.NewHandler(commands, queries, keys.IDPConfig, config.ExternalSecure, instanceInterceptor.Handler))
+	apis.RegisterHandlerOnPrefix(actionsrunner.HandlerPrefix, actionsrunner.NewHandler(queries, instanceInterceptor.Handler))
 
 	userAgentInterceptor, err := middleware.NewUserAgentHandler(config.UserAgentCookie, keys.UserAgentCookieKey, id.SonyFlakeGenerator(), config.ExternalSecure, login.EndpointResources, login.EndpointExternalLoginCallbackFormPost, login.EndpointSAMLACS)
 	if err != nil {
@@ -11,11 +11,14 @@ import (
 	"github.com/spf13/viper"
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/cmd/actions"
 	"github.com/zitadel/zitadel/cmd/admin"
+	"github.com/zitadel/zitadel/cmd/archive"
 	"github.com/zitadel/zitadel/cmd/build"
 	"github.com/zitadel/zitadel/cmd/initialise"
 	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/cmd/mirror"
+	"github.com/zitadel/zitadel/cmd/projections"
 	"github.com/zitadel/zitadel/cmd/ready"
 	"github.com/zitadel/zitadel/cmd/setup"
 	"github.com/zitadel/zitadel/cmd/start"
@@ -59,6 +62,9 @@ func New(out io.Writer, in io.Reader, args []string, server chan<- *start.Server
 		mirror.New(),
 		key.New(),
 		ready.New(),
+		actions.New(),
+		projections.New(),
+		archive.New(),
 	)
 
 	cmd.InitDefaultVersionFlag()
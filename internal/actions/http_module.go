@@ -18,7 +18,7 @@ import (
 
 func WithHTTP(ctx context.Context) Option {
 	return func(c *runConfig) {
-		c.modules["zitadel/http"] = func(runtime *goja.Runtime, module *goja.Object) {
+		c.modules[ModuleHTTP] = func(runtime *goja.Runtime, module *goja.Object) {
 			requireHTTP(ctx, &http.Client{Transport: new(transport)}, runtime, module)
 		}
 	}
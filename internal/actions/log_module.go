@@ -73,7 +73,7 @@ func withLogger(ctx context.Context) Option {
 	return func(c *runConfig) {
 		c.logger = newLogger(ctx, instanceID)
 		c.instanceID = instanceID
-		c.modules["zitadel/log"] = func(runtime *goja.Runtime, module *goja.Object) {
+		c.modules[ModuleLog] = func(runtime *goja.Runtime, module *goja.Object) {
 			console.RequireWithPrinter(c.logger)(runtime, module)
 		}
 	}
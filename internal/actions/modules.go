@@ -0,0 +1,25 @@
+package actions
+
+import (
+	"regexp"
+	"slices"
+)
+
+const (
+	ModuleHTTP = "zitadel/http"
+	ModuleUUID = "zitadel/uuid"
+	ModuleLog  = "zitadel/log"
+)
+
+var requireRegexp = regexp.MustCompile("require\\(\\s*[\"'`]([^\"'`]+)[\"'`]\\s*\\)")
+
+// RequiredModules returns the modules the script loads with require, in the order of their first occurrence
+func RequiredModules(script string) []string {
+	modules := make([]string, 0)
+	for _, match := range requireRegexp.FindAllStringSubmatch(script, -1) {
+		if !slices.Contains(modules, match[1]) {
+			modules = append(modules, match[1])
+		}
+	}
+	return modules
+}
@@ -0,0 +1,46 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredModules(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "no modules",
+			script: "function test(ctx, api) {}",
+			want:   []string{},
+		},
+		{
+			name: "modules",
+			script: `let http = require('zitadel/http')
+let logger = require("zitadel/log")
+function test(ctx, api) {
+	let uuid = require( ` + "`zitadel/uuid`" + ` )
+	logger.log(uuid.v4())
+}`,
+			want: []string{ModuleHTTP, ModuleLog, ModuleUUID},
+		},
+		{
+			name: "module required twice",
+			script: `function test(ctx, api) {
+	let http = require('zitadel/http')
+}
+function test2(ctx, api) {
+	let http = require('zitadel/http')
+}`,
+			want: []string{ModuleHTTP},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RequiredModules(tt.script))
+		})
+	}
+}
@@ -18,13 +18,17 @@ func AuthRequestField(authRequest *domain.AuthRequest) func(c *actions.FieldConf
 }
 
 func AuthRequestFromDomain(c *actions.FieldConfig, request *domain.AuthRequest) goja.Value {
+	return c.Runtime.ToValue(authRequestFromDomain(request))
+}
+
+func authRequestFromDomain(request *domain.AuthRequest) *authRequest {
 	var maxAuthAge *time.Duration
 	if request.MaxAuthAge != nil {
 		maxAuthAgeCopy := *request.MaxAuthAge
 		maxAuthAge = &maxAuthAgeCopy
 	}
 
-	return c.Runtime.ToValue(&authRequest{
+	return &authRequest{
 		Id:                       request.ID,
 		AgentId:                  request.AgentID,
 		CreationDate:             request.CreationDate,
@@ -56,47 +60,47 @@ func AuthRequestFromDomain(c *actions.FieldConfig, request *domain.AuthRequest)
 		MfasVerified:             request.MFAsVerified,
 		Audience:                 request.Audience,
 		AuthTime:                 request.AuthTime,
-	})
+	}
 }
 
 type authRequest struct {
-	Id            string
-	AgentId       string
-	CreationDate  time.Time
-	ChangeDate    time.Time
-	BrowserInfo   *browserInfo
-	ApplicationId string
-	CallbackUri   string
-	TransferState string
-	Prompt        []domain.Prompt
-	UiLocales     []string
-	LoginHint     string
-	MaxAuthAge    *time.Duration
-	InstanceId    string
-	Request       *request
-	UserId        string
-	UserName      string
-	LoginName     string
-	DisplayName   string
+	Id            string          `json:"id"`
+	AgentId       string          `json:"agent_id"`
+	CreationDate  time.Time       `json:"creation_date"`
+	ChangeDate    time.Time       `json:"change_date"`
+	BrowserInfo   *browserInfo    `json:"browser_info"`
+	ApplicationId string          `json:"application_id"`
+	CallbackUri   string          `json:"callback_uri"`
+	TransferState string          `json:"transfer_state"`
+	Prompt        []domain.Prompt `json:"prompt"`
+	UiLocales     []string        `json:"ui_locales"`
+	LoginHint     string          `json:"login_hint"`
+	MaxAuthAge    *time.Duration  `json:"max_auth_age"`
+	InstanceId    string          `json:"instance_id"`
+	Request       *request        `json:"request"`
+	UserId        string          `json:"user_id"`
+	UserName      string          `json:"user_name"`
+	LoginName     string          `json:"login_name"`
+	DisplayName   string          `json:"display_name"`
 	// UserOrgID string
-	ResourceOwner string
+	ResourceOwner string `json:"resource_owner"`
 	// requested by scope
-	RequestedOrgId string
+	RequestedOrgId string `json:"requested_org_id"`
 	// requested by scope
-	RequestedOrgName string
+	RequestedOrgName string `json:"requested_org_name"`
 	// requested by scope
-	RequestedPrimaryDomain string
+	RequestedPrimaryDomain string `json:"requested_primary_domain"`
 	// requested by scope
-	RequestedOrgDomain bool
+	RequestedOrgDomain bool `json:"requested_org_domain"`
 	// client
-	ApplicationResourceOwner string
-	PrivateLabelingSetting   domain.PrivateLabelingSetting
-	SelectedIdpConfigId      string
-	LinkingUsers             []*externalUser
-	PasswordVerified         bool
-	MfasVerified             []domain.MFAType
-	Audience                 []string
-	AuthTime                 time.Time
+	ApplicationResourceOwner string                        `json:"application_resource_owner"`
+	PrivateLabelingSetting   domain.PrivateLabelingSetting `json:"private_labeling_setting"`
+	SelectedIdpConfigId      string                        `json:"selected_idp_config_id"`
+	LinkingUsers             []*externalUser               `json:"linking_users"`
+	PasswordVerified         bool                          `json:"password_verified"`
+	MfasVerified             []domain.MFAType              `json:"mfas_verified"`
+	Audience                 []string                      `json:"audience"`
+	AuthTime                 time.Time                     `json:"auth_time"`
 }
 
 func browserInfoFromDomain(info *domain.BrowserInfo) *browserInfo {
@@ -121,15 +125,15 @@ func requestFromDomain(req domain.Request) *request {
 }
 
 type request struct {
-	Oidc OIDCRequest
+	Oidc OIDCRequest `json:"oidc"`
 }
 
 type OIDCRequest struct {
-	Scopes []string
+	Scopes []string `json:"scopes"`
 }
 
 type browserInfo struct {
-	UserAgent      string
-	AcceptLanguage string
-	RemoteIp       net.IP
+	UserAgent      string `json:"user_agent"`
+	AcceptLanguage string `json:"accept_language"`
+	RemoteIp       net.IP `json:"remote_ip"`
 }
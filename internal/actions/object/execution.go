@@ -0,0 +1,74 @@
+package object
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// The following functions return the same objects as they are available in the actions,
+// so they can be sent to the targets of function executions.
+
+func AuthRequestForExecution(request *domain.AuthRequest) interface{} {
+	if request == nil {
+		return nil
+	}
+	return authRequestFromDomain(request)
+}
+
+func HTTPRequestForExecution(request *http.Request) interface{} {
+	if request == nil {
+		return nil
+	}
+	return httpRequestFromRequest(request)
+}
+
+func UserFromHumanForExecution(user *domain.Human) interface{} {
+	if user == nil {
+		return nil
+	}
+	return userFromHuman(user)
+}
+
+func UserFromQueryForExecution(user *query.User) interface{} {
+	if user == nil {
+		return nil
+	}
+	return userFromQuery(user)
+}
+
+func UserFromExternalUserForExecution(user *domain.ExternalUser) interface{} {
+	if user == nil {
+		return nil
+	}
+	return externalUserFromDomain(user)
+}
+
+// ExecutionMetadata is the metadata returned by the targets of function executions.
+// The value can be any JSON, like the value passed to appendMetadata in the actions.
+type ExecutionMetadata struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// AppendExecutionMetadata appends the metadata returned by the targets of function executions
+func (md *MetadataList) AppendExecutionMetadata(metadata ...*ExecutionMetadata) {
+	for _, m := range metadata {
+		if m == nil || m.Key == "" || len(m.Value) == 0 {
+			continue
+		}
+		md.metadata = append(md.metadata, &Metadata{
+			Key:   m.Key,
+			value: m.Value,
+		})
+	}
+}
+
+// ExecutionMetadataToDomain maps the metadata returned by the targets of function executions to [domain.Metadata]
+func ExecutionMetadataToDomain(metadata []*ExecutionMetadata) []*domain.Metadata {
+	list := &MetadataList{}
+	list.AppendExecutionMetadata(metadata...)
+	return MetadataListToDomain(list)
+}
@@ -9,30 +9,34 @@ import (
 // HTTPRequestField accepts the http.Request by value, so it's not mutated
 func HTTPRequestField(request *http.Request) func(c *actions.FieldConfig) interface{} {
 	return func(c *actions.FieldConfig) interface{} {
-		return c.Runtime.ToValue(&httpRequest{
-			Method:        request.Method,
-			Url:           request.URL.String(),
-			Proto:         request.Proto,
-			ContentLength: request.ContentLength,
-			Host:          request.Host,
-			Form:          copyMap(request.Form),
-			PostForm:      copyMap(request.PostForm),
-			RemoteAddr:    request.RemoteAddr,
-			Headers:       copyMap(request.Header),
-		})
+		return c.Runtime.ToValue(httpRequestFromRequest(request))
+	}
+}
+
+func httpRequestFromRequest(request *http.Request) *httpRequest {
+	return &httpRequest{
+		Method:        request.Method,
+		Url:           request.URL.String(),
+		Proto:         request.Proto,
+		ContentLength: request.ContentLength,
+		Host:          request.Host,
+		Form:          copyMap(request.Form),
+		PostForm:      copyMap(request.PostForm),
+		RemoteAddr:    request.RemoteAddr,
+		Headers:       copyMap(request.Header),
 	}
 }
 
 type httpRequest struct {
-	Method        string
-	Url           string
-	Proto         string
-	ContentLength int64
-	Host          string
-	Form          map[string][]string
-	PostForm      map[string][]string
-	RemoteAddr    string
-	Headers       map[string][]string
+	Method        string              `json:"method"`
+	Url           string              `json:"url"`
+	Proto         string              `json:"proto"`
+	ContentLength int64               `json:"content_length"`
+	Host          string              `json:"host"`
+	Form          map[string][]string `json:"form"`
+	PostForm      map[string][]string `json:"post_form"`
+	RemoteAddr    string              `json:"remote_addr"`
+	Headers       map[string][]string `json:"headers"`
 }
 
 func copyMap(src map[string][]string) map[string][]string {
@@ -44,6 +44,10 @@ func externalUserFromDomain(user *domain.ExternalUser) *externalUser {
 }
 
 func UserFromHuman(c *actions.FieldConfig, user *domain.Human) goja.Value {
+	return c.Runtime.ToValue(userFromHuman(user))
+}
+
+func userFromHuman(user *domain.Human) *humanUser {
 	u := &humanUser{
 		Id:                 user.AggregateID,
 		CreationDate:       user.CreationDate,
@@ -74,18 +78,22 @@ func UserFromHuman(c *actions.FieldConfig, user *domain.Human) goja.Value {
 		u.Human.IsPhoneVerified = user.Phone.IsPhoneVerified
 	}
 
-	return c.Runtime.ToValue(u)
+	return u
 }
 
 func UserFromQuery(c *actions.FieldConfig, user *query.User) goja.Value {
+	return c.Runtime.ToValue(userFromQuery(user))
+}
+
+func userFromQuery(user *query.User) interface{} {
 	if user.Human != nil {
-		return humanFromQuery(c, user)
+		return humanFromQuery(user)
 	}
-	return machineFromQuery(c, user)
+	return machineFromQuery(user)
 }
 
-func humanFromQuery(c *actions.FieldConfig, user *query.User) goja.Value {
-	return c.Runtime.ToValue(&humanUser{
+func humanFromQuery(user *query.User) *humanUser {
+	return &humanUser{
 		Id:                 user.ID,
 		CreationDate:       user.CreationDate,
 		ChangeDate:         user.ChangeDate,
@@ -108,11 +116,11 @@ func humanFromQuery(c *actions.FieldConfig, user *query.User) goja.Value {
 			Phone:             user.Human.Phone,
 			IsPhoneVerified:   user.Human.IsPhoneVerified,
 		},
-	})
+	}
 }
 
-func machineFromQuery(c *actions.FieldConfig, user *query.User) goja.Value {
-	return c.Runtime.ToValue(&machineUser{
+func machineFromQuery(user *query.User) *machineUser {
+	return &machineUser{
 		Id:                 user.ID,
 		CreationDate:       user.CreationDate,
 		ChangeDate:         user.ChangeDate,
@@ -126,56 +134,56 @@ func machineFromQuery(c *actions.FieldConfig, user *query.User) goja.Value {
 			Name:        user.Machine.Name,
 			Description: user.Machine.Description,
 		},
-	})
+	}
 }
 
 type externalUser struct {
-	ExternalId    string
-	ExternalIdpId string
-	Human         human
+	ExternalId    string `json:"external_id"`
+	ExternalIdpId string `json:"external_idp_id"`
+	Human         human  `json:"human"`
 }
 
 type humanUser struct {
-	Id                 string
-	CreationDate       time.Time
-	ChangeDate         time.Time
-	ResourceOwner      string
-	Sequence           uint64
-	State              domain.UserState
-	Username           string
-	LoginNames         database.TextArray[string]
-	PreferredLoginName string
-	Human              human
+	Id                 string                     `json:"id"`
+	CreationDate       time.Time                  `json:"creation_date"`
+	ChangeDate         time.Time                  `json:"change_date"`
+	ResourceOwner      string                     `json:"resource_owner"`
+	Sequence           uint64                     `json:"sequence"`
+	State              domain.UserState           `json:"state"`
+	Username           string                     `json:"username"`
+	LoginNames         database.TextArray[string] `json:"login_names"`
+	PreferredLoginName string                     `json:"preferred_login_name"`
+	Human              human                      `json:"human"`
 }
 
 type human struct {
-	FirstName         string
-	LastName          string
-	NickName          string
-	DisplayName       string
-	AvatarKey         string
-	PreferredLanguage string
-	Gender            domain.Gender
-	Email             domain.EmailAddress
-	IsEmailVerified   bool
-	Phone             domain.PhoneNumber
-	IsPhoneVerified   bool
+	FirstName         string              `json:"first_name"`
+	LastName          string              `json:"last_name"`
+	NickName          string              `json:"nick_name"`
+	DisplayName       string              `json:"display_name"`
+	AvatarKey         string              `json:"avatar_key"`
+	PreferredLanguage string              `json:"preferred_language"`
+	Gender            domain.Gender       `json:"gender"`
+	Email             domain.EmailAddress `json:"email"`
+	IsEmailVerified   bool                `json:"is_email_verified"`
+	Phone             domain.PhoneNumber  `json:"phone"`
+	IsPhoneVerified   bool                `json:"is_phone_verified"`
 }
 
 type machineUser struct {
-	Id                 string
-	CreationDate       time.Time
-	ChangeDate         time.Time
-	ResourceOwner      string
-	Sequence           uint64
-	State              domain.UserState
-	Username           string
-	LoginNames         database.TextArray[string]
-	PreferredLoginName string
-	Machine            machine
+	Id                 string                     `json:"id"`
+	CreationDate       time.Time                  `json:"creation_date"`
+	ChangeDate         time.Time                  `json:"change_date"`
+	ResourceOwner      string                     `json:"resource_owner"`
+	Sequence           uint64                     `json:"sequence"`
+	State              domain.UserState           `json:"state"`
+	Username           string                     `json:"username"`
+	LoginNames         database.TextArray[string] `json:"login_names"`
+	PreferredLoginName string                     `json:"preferred_login_name"`
+	Machine            machine                    `json:"machine"`
 }
 
 type machine struct {
-	Name        string
-	Description string
+	Name        string `json:"name"`
+	Description string `json:"description"`
 }
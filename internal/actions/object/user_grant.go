@@ -16,9 +16,9 @@ type UserGrants struct {
 }
 
 type UserGrant struct {
-	ProjectID      string
-	ProjectGrantID string
-	Roles          []string
+	ProjectID      string   `json:"project_id"`
+	ProjectGrantID string   `json:"project_grant_id"`
+	Roles          []string `json:"roles"`
 }
 
 type userGrantList struct {
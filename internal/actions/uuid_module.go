@@ -10,7 +10,7 @@ import (
 
 func WithUUID(ctx context.Context) Option {
 	return func(c *runConfig) {
-		c.modules["zitadel/uuid"] = func(runtime *goja.Runtime, module *goja.Object) {
+		c.modules[ModuleUUID] = func(runtime *goja.Runtime, module *goja.Object) {
 			requireUUID(ctx, runtime, module)
 		}
 	}
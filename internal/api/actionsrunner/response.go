@@ -0,0 +1,130 @@
+package actionsrunner
+
+import (
+	"encoding/json"
+
+	"github.com/dop251/goja"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// response contains the changes made by the script with the api,
+// only the parts of the response expected by the function of the flow are applied
+type response struct {
+	SetHuman            *setHuman                   `json:"set_human,omitempty"`
+	AppendMetadata      []*object.ExecutionMetadata `json:"append_metadata,omitempty"`
+	SetUserMetadata     []*object.ExecutionMetadata `json:"set_user_metadata,omitempty"`
+	AppendUserGrants    []object.UserGrant          `json:"append_user_grants,omitempty"`
+	AppendClaims        []*appendClaim              `json:"append_claims,omitempty"`
+	AppendLogClaims     []string                    `json:"append_log_claims,omitempty"`
+	SetCustomAttributes []*customAttribute          `json:"set_custom_attributes,omitempty"`
+}
+
+type setHuman struct {
+	FirstName         *string              `json:"first_name,omitempty"`
+	LastName          *string              `json:"last_name,omitempty"`
+	NickName          *string              `json:"nick_name,omitempty"`
+	DisplayName       *string              `json:"display_name,omitempty"`
+	PreferredLanguage *string              `json:"preferred_language,omitempty"`
+	Gender            *domain.Gender       `json:"gender,omitempty"`
+	Username          *string              `json:"username,omitempty"`
+	PreferredUsername *string              `json:"preferred_username,omitempty"`
+	Email             *domain.EmailAddress `json:"email,omitempty"`
+	EmailVerified     *bool                `json:"email_verified,omitempty"`
+	Phone             *domain.PhoneNumber  `json:"phone,omitempty"`
+	PhoneVerified     *bool                `json:"phone_verified,omitempty"`
+}
+
+type appendClaim struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+type customAttribute struct {
+	Name       string   `json:"name"`
+	NameFormat string   `json:"name_format"`
+	Value      []string `json:"value"`
+}
+
+// apiFields returns the api of the actions v1 available to the scripts
+func (r *response) apiFields(function string) []actions.FieldOption {
+	userGrants := &object.UserGrants{UserGrants: r.AppendUserGrants}
+	return []actions.FieldOption{
+		actions.SetFields("setFirstName", func(firstName string) { r.human().FirstName = &firstName }),
+		actions.SetFields("setLastName", func(lastName string) { r.human().LastName = &lastName }),
+		actions.SetFields("setNickName", func(nickName string) { r.human().NickName = &nickName }),
+		actions.SetFields("setDisplayName", func(displayName string) { r.human().DisplayName = &displayName }),
+		actions.SetFields("setPreferredLanguage", func(preferredLanguage string) { r.human().PreferredLanguage = &preferredLanguage }),
+		actions.SetFields("setGender", func(gender domain.Gender) { r.human().Gender = &gender }),
+		actions.SetFields("setUsername", func(username string) { r.human().Username = &username }),
+		actions.SetFields("setPreferredUsername", func(username string) { r.human().PreferredUsername = &username }),
+		actions.SetFields("setEmail", func(email domain.EmailAddress) { r.human().Email = &email }),
+		actions.SetFields("setEmailVerified", func(verified bool) { r.human().EmailVerified = &verified }),
+		actions.SetFields("setPhone", func(phone domain.PhoneNumber) { r.human().Phone = &phone }),
+		actions.SetFields("setPhoneVerified", func(verified bool) { r.human().PhoneVerified = &verified }),
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("appendMetadata", r.metadataFunc(function)),
+				actions.SetFields("setMetadata", r.metadataFunc(function)),
+			),
+			actions.SetFields("claims",
+				actions.SetFields("setClaim", func(key string, value any) {
+					r.AppendClaims = append(r.AppendClaims, &appendClaim{Key: key, Value: value})
+				}),
+				actions.SetFields("appendLogIntoClaims", func(entry string) {
+					r.AppendLogClaims = append(r.AppendLogClaims, entry)
+				}),
+			),
+			actions.SetFields("attributes",
+				actions.SetFields("setCustomAttribute", func(name string, nameFormat string, attributeValue ...string) {
+					r.SetCustomAttributes = append(r.SetCustomAttributes, &customAttribute{Name: name, NameFormat: nameFormat, Value: attributeValue})
+				}),
+			),
+			actions.SetFields("appendUserGrant", func(c *actions.FieldConfig) interface{} {
+				appendGrant := object.AppendGrantFunc(userGrants)(c)
+				return func(call goja.FunctionCall) goja.Value {
+					appendGrant(call)
+					r.AppendUserGrants = userGrants.UserGrants
+					return nil
+				}
+			}),
+		),
+	}
+}
+
+func (r *response) human() *setHuman {
+	if r.SetHuman == nil {
+		r.SetHuman = new(setHuman)
+	}
+	return r.SetHuman
+}
+
+// metadataFunc returns the function to set metadata on the user,
+// the login flows append the metadata to the user and the other flows set the metadata directly
+func (r *response) metadataFunc(function string) func(key string, value any) {
+	return func(key string, value any) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			panic(err)
+		}
+		metadata := &object.ExecutionMetadata{Key: key, Value: data}
+		if isLoginFunction(function) {
+			r.AppendMetadata = append(r.AppendMetadata, metadata)
+			return
+		}
+		r.SetUserMetadata = append(r.SetUserMetadata, metadata)
+	}
+}
+
+func isLoginFunction(function string) bool {
+	for _, flowType := range []domain.FlowType{domain.FlowTypeExternalAuthentication, domain.FlowTypeInternalAuthentication} {
+		for _, triggerType := range flowType.TriggerTypes() {
+			if function == domain.ActionFunction(flowType, triggerType) {
+				return true
+			}
+		}
+	}
+	return false
+}
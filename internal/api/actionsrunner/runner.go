@@ -0,0 +1,202 @@
+package actionsrunner
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/query"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	HandlerPrefix = "/actions/v1/runner"
+
+	runPath = "/{" + varOrgID + "}/{" + varActionID + "}"
+
+	varOrgID    = "orgID"
+	varActionID = "actionID"
+
+	maxRequestSize = 1 << 20
+)
+
+type Queries interface {
+	GetActionByID(ctx context.Context, id string, orgID string, withOwnerRemoved bool) (*query.Action, error)
+	TargetsByExecutionID(ctx context.Context, ids []string) ([]*query.ExecutionTarget, error)
+}
+
+// Handler runs the scripts of the actions v1 for the targets of the migrated flows.
+// The information sent to the targets is passed to the script and the changes made with the api
+// are returned in the response expected by the function of the flow.
+type Handler struct {
+	queries Queries
+}
+
+// URL returns the instance specific URL of the runner
+func URL(externalSecure bool) func(ctx context.Context) string {
+	return func(ctx context.Context) string {
+		return http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), externalSecure) + HandlerPrefix
+	}
+}
+
+func NewHandler(
+	queries Queries,
+	instanceInterceptor func(next http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		queries: queries,
+	}
+	router := mux.NewRouter()
+	router.Use(instanceInterceptor)
+	router.HandleFunc(runPath, h.handleRun).Methods(http.MethodPost)
+	return router
+}
+
+func (h *Handler) handleRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)[varOrgID]
+	actionID := mux.Vars(r)[varActionID]
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, zerrors.ThrowInvalidArgument(err, "RUNNER-m2w9bq4rfz", "Errors.Action.RunnerRequestInvalid"))
+		return
+	}
+	info := make(map[string]any)
+	if err := json.Unmarshal(body, &info); err != nil {
+		writeError(w, zerrors.ThrowInvalidArgument(err, "RUNNER-x6c0ne3tsa", "Errors.Action.RunnerRequestInvalid"))
+		return
+	}
+	function, _ := info["function"].(string)
+	if err := h.checkSignature(ctx, function, orgID, actionID, body, r.Header.Get(execution.SigningHeader)); err != nil {
+		writeError(w, err)
+		return
+	}
+	action, err := h.queries.GetActionByID(ctx, actionID, orgID, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if action.State != domain.ActionStateActive {
+		writeError(w, zerrors.ThrowPreconditionFailed(nil, "RUNNER-g1p7vk5yxj", "Errors.Action.NotActive"))
+		return
+	}
+	resp, err := run(ctx, action, function, info)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		writeError(w, zerrors.ThrowInternal(err, "RUNNER-r8d3ho6wum", "Errors.Internal"))
+		return
+	}
+	w.Header().Set(http_utils.ContentType, "application/json")
+	_, err = w.Write(data)
+	logging.OnError(err).Debug("unable to write runner response")
+}
+
+// checkSignature checks that the call was made by a target of the execution of the function,
+// which calls the runner for the action of the organization
+func (h *Handler) checkSignature(ctx context.Context, function, orgID, actionID string, body []byte, signature string) error {
+	if function == "" {
+		return zerrors.ThrowInvalidArgument(nil, "RUNNER-c4u1zs8owl", "Errors.Action.RunnerRequestInvalid")
+	}
+	targets, err := h.queries.TargetsByExecutionID(ctx, []string{exec_repo.ID(domain.ExecutionTypeFunction, function)})
+	if err != nil {
+		return err
+	}
+	suffix := HandlerPrefix + "/" + orgID + "/" + actionID
+	for _, target := range targets {
+		if !strings.HasSuffix(target.GetEndpoint(), suffix) {
+			continue
+		}
+		return execution.ValidatePayload(body, signature, target.GetSigningKey(), execution.DefaultSignatureTolerance)
+	}
+	return zerrors.ThrowNotFound(nil, "RUNNER-5kq2jt0bav", "Errors.Target.NotFound")
+}
+
+func run(ctx context.Context, action *query.Action, function string, info map[string]any) (*response, error) {
+	resp := new(response)
+	// the response of the previous targets is passed on, so the changes of all actions of the function are applied
+	if previous, ok := info["response"]; ok {
+		if err := remarshal(previous, resp); err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "RUNNER-0zb5xw2nhe", "Errors.Action.RunnerRequestInvalid")
+		}
+		delete(info, "response")
+	}
+
+	actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+	defer cancel()
+	err := actions.Run(
+		actionCtx,
+		actions.SetContextFields(
+			actions.SetFields("v1", camelCaseKeys(info)),
+		),
+		actions.WithAPIFields(resp.apiFields(function)...),
+		action.Script,
+		action.Name,
+		actions.ActionToOptions(action)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func remarshal(from, to any) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
+
+// camelCaseKeys converts the keys of the information to the camel case used for the objects in the actions v1
+func camelCaseKeys(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		converted := make(map[string]any, len(v))
+		for key, value := range v {
+			converted[camelCase(key)] = camelCaseKeys(value)
+		}
+		return converted
+	case []any:
+		for i := range v {
+			v[i] = camelCaseKeys(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func camelCase(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	statusCode, ok := http_utils.ZitadelErrorToHTTPStatusCode(err)
+	if !ok {
+		statusCode = http.StatusInternalServerError
+	}
+	http.Error(w, err.Error(), statusCode)
+}
@@ -0,0 +1,214 @@
+package actionsrunner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type mockQueries struct {
+	action  *query.Action
+	targets []*query.ExecutionTarget
+}
+
+func (m *mockQueries) GetActionByID(_ context.Context, id string, orgID string, _ bool) (*query.Action, error) {
+	if m.action == nil || m.action.ID != id || m.action.ResourceOwner != orgID {
+		return nil, zerrors.ThrowNotFound(nil, "TEST-ek2o5", "Errors.Action.NotFound")
+	}
+	return m.action, nil
+}
+
+func (m *mockQueries) TargetsByExecutionID(_ context.Context, _ []string) ([]*query.ExecutionTarget, error) {
+	return m.targets, nil
+}
+
+func TestHandler_handleRun(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	function := domain.ActionFunction(domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation)
+	loginFunction := domain.ActionFunction(domain.FlowTypeExternalAuthentication, domain.TriggerTypePostAuthentication)
+	targets := []*query.ExecutionTarget{
+		{
+			TargetID:   "target",
+			Endpoint:   "https://example.com" + HandlerPrefix + "/org/action",
+			SigningKey: "signingKey",
+		},
+	}
+	activeAction := func(script string) *query.Action {
+		return &query.Action{
+			ID:            "action",
+			ResourceOwner: "org",
+			State:         domain.ActionStateActive,
+			Name:          "test",
+			Script:        script,
+		}
+	}
+	type args struct {
+		path       string
+		body       string
+		signingKey string
+	}
+	type res struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name    string
+		queries *mockQueries
+		args    args
+		res     res
+	}{
+		{
+			"invalid body",
+			&mockQueries{
+				action:  activeAction("function test(ctx, api) {}"),
+				targets: targets,
+			},
+			args{
+				path:       "/org/action",
+				body:       `invalid`,
+				signingKey: "signingKey",
+			},
+			res{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			"invalid signature",
+			&mockQueries{
+				action:  activeAction("function test(ctx, api) {}"),
+				targets: targets,
+			},
+			args{
+				path:       "/org/action",
+				body:       `{"function":"` + function + `"}`,
+				signingKey: "otherKey",
+			},
+			res{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			"no target for action",
+			&mockQueries{
+				action:  activeAction("function test(ctx, api) {}"),
+				targets: targets,
+			},
+			args{
+				path:       "/org/other",
+				body:       `{"function":"` + function + `"}`,
+				signingKey: "signingKey",
+			},
+			res{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			"inactive action",
+			&mockQueries{
+				action: &query.Action{
+					ID:            "action",
+					ResourceOwner: "org",
+					State:         domain.ActionStateInactive,
+					Name:          "test",
+					Script:        "function test(ctx, api) {}",
+				},
+				targets: targets,
+			},
+			args{
+				path:       "/org/action",
+				body:       `{"function":"` + function + `"}`,
+				signingKey: "signingKey",
+			},
+			res{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			"script error",
+			&mockQueries{
+				action:  activeAction("function test(ctx, api) { throw 'error' }"),
+				targets: targets,
+			},
+			args{
+				path:       "/org/action",
+				body:       `{"function":"` + function + `"}`,
+				signingKey: "signingKey",
+			},
+			res{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			"claims and metadata, ok",
+			&mockQueries{
+				action: activeAction(`function test(ctx, api) {
+	api.v1.claims.setClaim('org', ctx.v1.org.primaryDomain)
+	api.v1.claims.appendLogIntoClaims('log')
+	api.v1.user.setMetadata('key', 'value')
+}`),
+				targets: targets,
+			},
+			args{
+				path:       "/org/action",
+				body:       `{"function":"` + function + `","org":{"id":"org","primary_domain":"example.com"},"response":{"append_claims":[{"key":"previous","value":true}]}}`,
+				signingKey: "signingKey",
+			},
+			res{
+				statusCode: http.StatusOK,
+				body:       `{"set_user_metadata":[{"key":"key","value":"value"}],"append_claims":[{"key":"previous","value":true},{"key":"org","value":"example.com"}],"append_log_claims":["log"]}`,
+			},
+		},
+		{
+			"login, ok",
+			&mockQueries{
+				action: activeAction(`function test(ctx, api) {
+	api.setFirstName(ctx.v1.externalUser.firstName + '!')
+	api.v1.user.appendMetadata('key', 'value')
+	api.v1.appendUserGrant({projectId: 'project', roles: ['role']})
+}`),
+				targets: targets,
+			},
+			args{
+				path:       "/org/action",
+				body:       `{"function":"` + loginFunction + `","org_id":"org","external_user":{"first_name":"first"}}`,
+				signingKey: "signingKey",
+			},
+			res{
+				statusCode: http.StatusOK,
+				body:       `{"set_human":{"first_name":"first!"},"append_metadata":[{"key":"key","value":"value"}],"append_user_grants":[{"project_id":"project","project_grant_id":"","roles":["role"]}]}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(tt.queries, func(next http.Handler) http.Handler { return next })
+			req := httptest.NewRequest(http.MethodPost, tt.args.path, bytes.NewBufferString(tt.args.body))
+			req.Header.Set(execution.SigningHeader, execution.ComputeSignatureHeader(time.Now(), []byte(tt.args.body), tt.args.signingKey))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			resp := recorder.Result()
+			assert.Equal(t, tt.res.statusCode, resp.StatusCode)
+			if tt.res.body == "" {
+				return
+			}
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.res.body, string(body))
+		})
+	}
+}
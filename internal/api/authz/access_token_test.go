@@ -46,10 +46,18 @@ func Test_extractBearerToken(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "dpop auth header set",
+			args: args{
+				ctx:   context.Background(),
+				token: "DPoP AUTH",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := extractBearerToken(tt.args.token)
+			_, _, err := extractBearerToken(tt.args.token)
 			if tt.wantErr && err == nil {
 				t.Errorf("got wrong result, should get err: actual: %v ", err)
 			}
@@ -22,6 +22,8 @@ const (
 	dataKey               key = 2
 	allPermissionsKey     key = 3
 	instanceKey           key = 4
+	dpopProofKey          key = 5
+	dpopThumbprintKey     key = 6
 )
 
 type CtxData struct {
@@ -99,10 +101,17 @@ func (s SystemTokenVerifierFunc) VerifySystemToken(ctx context.Context, token st
 func VerifyTokenAndCreateCtxData(ctx context.Context, token, orgID, orgDomain string, t APITokenVerifier) (_ CtxData, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
-	tokenWOBearer, err := extractBearerToken(token)
+	tokenWOBearer, dpop, err := extractBearerToken(token)
 	if err != nil {
 		return CtxData{}, err
 	}
+	if dpop {
+		jkt, err := verifyDPoPProofFromCtx(ctx, tokenWOBearer)
+		if err != nil {
+			return CtxData{}, err
+		}
+		ctx = withDPoPThumbprint(ctx, jkt)
+	}
 	userID, clientID, agentID, prefLang, resourceOwner, err := t.VerifyAccessToken(ctx, tokenWOBearer)
 	var sysMemberships Memberships
 	if err != nil && !zerrors.IsUnauthenticated(err) {
@@ -179,10 +188,15 @@ func checkOrigin(ctx context.Context, origins []string) error {
 	return zerrors.ThrowPermissionDenied(nil, "AUTH-DZG21", "Errors.OriginNotAllowed")
 }
 
-func extractBearerToken(token string) (part string, err error) {
+// extractBearerToken returns the token of the auth header
+// and if it's sent using the DPoP scheme, which requires a proof of possession.
+func extractBearerToken(token string) (part string, dpop bool, err error) {
+	if part, ok := CutDPoPPrefix(token); ok && part != "" {
+		return part, true, nil
+	}
 	parts := strings.Split(token, BearerPrefix)
 	if len(parts) != 2 {
-		return "", zerrors.ThrowUnauthenticated(nil, "AUTH-toLo1", "invalid auth header")
+		return "", false, zerrors.ThrowUnauthenticated(nil, "AUTH-toLo1", "invalid auth header")
 	}
-	return parts[1], nil
+	return parts[1], false, nil
 }
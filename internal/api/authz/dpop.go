@@ -0,0 +1,184 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	DPoPPrefix = "DPoP "
+	// DPoPHeader is the name of the header the client sends the proof in (RFC 9449)
+	DPoPHeader = "DPoP"
+
+	dpopProofType = "dpop+jwt"
+	// DPoPProofLifetime defines how long after its creation a proof is accepted,
+	// used proofs are remembered for this time frame, so they can't be replayed
+	DPoPProofLifetime = time.Minute
+	dpopProofMaxSkew  = 5 * time.Second
+)
+
+var DPoPSigningAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// DPoPProofStore remembers the used proofs of all processes until they expire, so they can't be replayed
+type DPoPProofStore interface {
+	// UseDPoPProof marks the proof identified by its jti and htu as used until its expiration.
+	// It returns false if the proof was already used before.
+	UseDPoPProof(ctx context.Context, jti, htu string, expiration time.Time) (bool, error)
+}
+
+// usedDPoPProofs is set by [SetDPoPProofStore]
+var usedDPoPProofs DPoPProofStore
+
+// SetDPoPProofStore sets the store of the used proofs, proofs are rejected until it is set
+func SetDPoPProofStore(store DPoPProofStore) {
+	usedDPoPProofs = store
+}
+
+// CutDPoPPrefix returns the token of the authorization header if it's sent using the DPoP scheme.
+// The scheme is matched case-insensitively.
+func CutDPoPPrefix(header string) (token string, ok bool) {
+	if len(header) < len(DPoPPrefix) || !strings.EqualFold(header[:len(DPoPPrefix)], DPoPPrefix) {
+		return "", false
+	}
+	return header[len(DPoPPrefix):], true
+}
+
+type dpopProof struct {
+	proof  string
+	method string
+	uri    string
+}
+
+type dpopClaims struct {
+	JWTID           string `json:"jti"`
+	Method          string `json:"htm"`
+	URI             string `json:"htu"`
+	IssuedAt        int64  `json:"iat"`
+	AccessTokenHash string `json:"ath,omitempty"`
+}
+
+// WithDPoPProof sets the DPoP proof of the request into the context,
+// so it can be verified with the access token on [VerifyTokenAndCreateCtxData].
+func WithDPoPProof(ctx context.Context, proof, method, uri string) context.Context {
+	if proof == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, dpopProofKey, &dpopProof{proof: proof, method: method, uri: uri})
+}
+
+// DPoPThumbprint returns the thumbprint of the key of the verified DPoP proof.
+// It's empty if the access token was not presented with a DPoP proof.
+func DPoPThumbprint(ctx context.Context) string {
+	jkt, _ := ctx.Value(dpopThumbprintKey).(string)
+	return jkt
+}
+
+func withDPoPThumbprint(ctx context.Context, jkt string) context.Context {
+	return context.WithValue(ctx, dpopThumbprintKey, jkt)
+}
+
+// verifyDPoPProofFromCtx verifies the proof set by [WithDPoPProof] for the provided access token
+func verifyDPoPProofFromCtx(ctx context.Context, accessToken string) (string, error) {
+	proof, ok := ctx.Value(dpopProofKey).(*dpopProof)
+	if !ok {
+		return "", zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p1", "Errors.Token.Invalid")
+	}
+	return VerifyDPoPProof(ctx, proof.proof, proof.method, proof.uri, accessToken, time.Now())
+}
+
+// VerifyDPoPProof verifies the DPoP proof (RFC 9449) of a request to the provided method and uri.
+// If an access token is provided, the proof must contain its hash (ath).
+// A proof is only accepted once, replays within its lifetime are rejected.
+// It returns the base64url encoded SHA-256 thumbprint of the public key of the proof (jkt).
+func VerifyDPoPProof(ctx context.Context, proof, method, uri, accessToken string, now time.Time) (jkt string, err error) {
+	signed, err := jose.ParseSigned(proof, DPoPSigningAlgorithms)
+	if err != nil {
+		return "", zerrors.ThrowUnauthenticated(err, "AUTHZ-Dp0p2", "Errors.Token.Invalid")
+	}
+	if len(signed.Signatures) != 1 {
+		return "", zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p3", "Errors.Token.Invalid")
+	}
+	header := signed.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != dpopProofType {
+		return "", zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p4", "Errors.Token.Invalid")
+	}
+	key := header.JSONWebKey
+	if key == nil || !key.IsPublic() || !key.Valid() {
+		return "", zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p5", "Errors.Token.Invalid")
+	}
+	payload, err := signed.Verify(key)
+	if err != nil {
+		return "", zerrors.ThrowUnauthenticated(err, "AUTHZ-Dp0p6", "Errors.Token.Invalid")
+	}
+	claims := new(dpopClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return "", zerrors.ThrowUnauthenticated(err, "AUTHZ-Dp0p7", "Errors.Token.Invalid")
+	}
+	if err = claims.verify(method, uri, accessToken, now); err != nil {
+		return "", err
+	}
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", zerrors.ThrowUnauthenticated(err, "AUTHZ-Dp0p8", "Errors.Token.Invalid")
+	}
+	if usedDPoPProofs == nil {
+		return "", zerrors.ThrowInternal(nil, "AUTHZ-Dp0pd", "Errors.Internal")
+	}
+	unused, err := usedDPoPProofs.UseDPoPProof(ctx, claims.JWTID, claims.URI, time.Unix(claims.IssuedAt, 0).Add(DPoPProofLifetime))
+	if err != nil {
+		return "", err
+	}
+	if !unused {
+		return "", zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0pc", "Errors.Token.Invalid")
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+func (c *dpopClaims) verify(method, uri, accessToken string, now time.Time) error {
+	if c.JWTID == "" || c.Method != method || !sameHTTPURI(c.URI, uri) {
+		return zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p9", "Errors.Token.Invalid")
+	}
+	issuedAt := time.Unix(c.IssuedAt, 0)
+	if issuedAt.Before(now.Add(-DPoPProofLifetime)) || issuedAt.After(now.Add(dpopProofMaxSkew)) {
+		return zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0pa", "Errors.Token.Invalid")
+	}
+	if accessToken == "" {
+		return nil
+	}
+	hash := sha256.Sum256([]byte(accessToken))
+	if c.AccessTokenHash != base64.RawURLEncoding.EncodeToString(hash[:]) {
+		return zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0pb", "Errors.Token.Invalid")
+	}
+	return nil
+}
+
+// sameHTTPURI compares the uris without query and fragment parts
+// as defined in https://www.rfc-editor.org/rfc/rfc9449#section-4.3
+func sameHTTPURI(proofURI, requestURI string) bool {
+	proof, err := url.Parse(proofURI)
+	if err != nil {
+		return false
+	}
+	request, err := url.Parse(requestURI)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(proof.Scheme, request.Scheme) &&
+		strings.EqualFold(proof.Host, request.Host) &&
+		proof.EscapedPath() == request.EscapedPath()
+}
@@ -0,0 +1,250 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestVerifyDPoPProof(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	thumbprint, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	jkt := base64.RawURLEncoding.EncodeToString(thumbprint)
+	jti := newJTI(t)
+	now := time.Now()
+	useTestDPoPProofStore(t)
+
+	type args struct {
+		proof       string
+		method      string
+		uri         string
+		accessToken string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "invalid proof",
+			args: args{
+				proof:  "invalid",
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p2", "Errors.Token.Invalid"),
+		},
+		{
+			name: "wrong type",
+			args: args{
+				proof:  signDPoPProof(t, key, "jwt", dpopClaims{JWTID: jti, Method: "POST", URI: "https://issuer.com/oauth/v2/token", IssuedAt: now.Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p4", "Errors.Token.Invalid"),
+		},
+		{
+			name: "wrong method",
+			args: args{
+				proof:  signDPoPProof(t, key, dpopProofType, dpopClaims{JWTID: jti, Method: "GET", URI: "https://issuer.com/oauth/v2/token", IssuedAt: now.Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p9", "Errors.Token.Invalid"),
+		},
+		{
+			name: "wrong uri",
+			args: args{
+				proof:  signDPoPProof(t, key, dpopProofType, dpopClaims{JWTID: jti, Method: "POST", URI: "https://other.com/oauth/v2/token", IssuedAt: now.Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p9", "Errors.Token.Invalid"),
+		},
+		{
+			name: "expired proof",
+			args: args{
+				proof:  signDPoPProof(t, key, dpopProofType, dpopClaims{JWTID: jti, Method: "POST", URI: "https://issuer.com/oauth/v2/token", IssuedAt: now.Add(-2 * DPoPProofLifetime).Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0pa", "Errors.Token.Invalid"),
+		},
+		{
+			name: "missing access token hash",
+			args: args{
+				proof:       signDPoPProof(t, key, dpopProofType, dpopClaims{JWTID: jti, Method: "GET", URI: "https://issuer.com/oidc/v1/userinfo", IssuedAt: now.Unix()}),
+				method:      "GET",
+				uri:         "https://issuer.com/oidc/v1/userinfo",
+				accessToken: "accessToken",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0pb", "Errors.Token.Invalid"),
+		},
+		{
+			name: "valid proof",
+			args: args{
+				proof:  signDPoPProof(t, key, dpopProofType, dpopClaims{JWTID: jti, Method: "POST", URI: "https://issuer.com/oauth/v2/token", IssuedAt: now.Unix()}),
+				method: "POST",
+				uri:    "https://ISSUER.com/oauth/v2/token?query=ignored",
+			},
+			want: jkt,
+		},
+		{
+			name: "replayed proof",
+			args: args{
+				proof:  signDPoPProof(t, key, dpopProofType, dpopClaims{JWTID: jti, Method: "POST", URI: "https://issuer.com/oauth/v2/token", IssuedAt: now.Unix()}),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0pc", "Errors.Token.Invalid"),
+		},
+		{
+			name: "valid proof with access token hash",
+			args: args{
+				proof:       signDPoPProof(t, key, dpopProofType, dpopClaims{JWTID: jti, Method: "GET", URI: "https://issuer.com/oidc/v1/userinfo", IssuedAt: now.Unix(), AccessTokenHash: accessTokenHash("accessToken")}),
+				method:      "GET",
+				uri:         "https://issuer.com/oidc/v1/userinfo",
+				accessToken: "accessToken",
+			},
+			want: jkt,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyDPoPProof(context.Background(), tt.args.proof, tt.args.method, tt.args.uri, tt.args.accessToken, now)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVerifyTokenAndCreateCtxData_DPoP(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	useTestDPoPProofStore(t)
+	jti := newJTI(t)
+	proof := signDPoPProof(t, key, dpopProofType, dpopClaims{JWTID: jti, Method: "POST", URI: "https://issuer.com/zitadel.auth.v1.AuthService/GetMyUser", IssuedAt: time.Now().Unix(), AccessTokenHash: accessTokenHash("accessToken")})
+
+	var gotThumbprint string
+	verifier := &testTokenVerifier{
+		verifyAccessToken: func(ctx context.Context, _ string) (string, string, string, string, string, error) {
+			gotThumbprint = DPoPThumbprint(ctx)
+			return "", "", "", "", "", zerrors.ThrowUnauthenticated(nil, "TEST", "test")
+		},
+	}
+
+	_, err = VerifyTokenAndCreateCtxData(context.Background(), "DPoP accessToken", "", "", verifier)
+	assert.ErrorIs(t, err, zerrors.ThrowUnauthenticated(nil, "AUTHZ-Dp0p1", "Errors.Token.Invalid"))
+	assert.Empty(t, gotThumbprint)
+
+	ctx := WithDPoPProof(context.Background(), proof, "POST", "https://issuer.com/zitadel.auth.v1.AuthService/GetMyUser")
+	_, _ = VerifyTokenAndCreateCtxData(ctx, "dpop accessToken", "", "", verifier)
+	assert.NotEmpty(t, gotThumbprint)
+}
+
+type testTokenVerifier struct {
+	APITokenVerifier
+	verifyAccessToken func(context.Context, string) (string, string, string, string, string, error)
+}
+
+func (v *testTokenVerifier) VerifyAccessToken(ctx context.Context, token string) (string, string, string, string, string, error) {
+	return v.verifyAccessToken(ctx, token)
+}
+
+func (v *testTokenVerifier) VerifySystemToken(context.Context, string, string) (Memberships, string, error) {
+	return nil, "", zerrors.ThrowUnauthenticated(nil, "TEST", "test")
+}
+
+func signDPoPProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims dpopClaims) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{EmbedJWK: true}).WithType(jose.ContentType(typ)),
+	)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed, err := signer.Sign(payload)
+	require.NoError(t, err)
+	proof, err := signed.CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
+
+func newJTI(t *testing.T) string {
+	jti := make([]byte, 16)
+	_, err := rand.Read(jti)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(jti)
+}
+
+func accessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func TestCutDPoPPrefix(t *testing.T) {
+	tests := []struct {
+		header    string
+		wantToken string
+		wantOK    bool
+	}{
+		{header: "DPoP accessToken", wantToken: "accessToken", wantOK: true},
+		{header: "dpop accessToken", wantToken: "accessToken", wantOK: true},
+		{header: "DPOP accessToken", wantToken: "accessToken", wantOK: true},
+		{header: "Bearer accessToken"},
+		{header: "DPoP"},
+		{header: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			token, ok := CutDPoPPrefix(tt.header)
+			assert.Equal(t, tt.wantToken, token)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestVerifyDPoPProof_noStore(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	SetDPoPProofStore(nil)
+	now := time.Now()
+	proof := signDPoPProof(t, key, dpopProofType, dpopClaims{JWTID: newJTI(t), Method: "POST", URI: "https://issuer.com/oauth/v2/token", IssuedAt: now.Unix()})
+
+	_, err = VerifyDPoPProof(context.Background(), proof, "POST", "https://issuer.com/oauth/v2/token", "", now)
+	assert.ErrorIs(t, err, zerrors.ThrowInternal(nil, "AUTHZ-Dp0pd", "Errors.Internal"))
+}
+
+// testDPoPProofStore remembers the used proofs in memory
+type testDPoPProofStore struct {
+	used map[[2]string]time.Time
+}
+
+func (s *testDPoPProofStore) UseDPoPProof(_ context.Context, jti, htu string, expiration time.Time) (bool, error) {
+	if _, ok := s.used[[2]string{jti, htu}]; ok {
+		return false, nil
+	}
+	s.used[[2]string{jti, htu}] = expiration
+	return true, nil
+}
+
+func useTestDPoPProofStore(t *testing.T) {
+	SetDPoPProofStore(&testDPoPProofStore{used: make(map[[2]string]time.Time)})
+	t.Cleanup(func() { SetDPoPProofStore(nil) })
+}
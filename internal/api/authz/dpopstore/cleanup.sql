@@ -0,0 +1 @@
+DELETE FROM auth.dpop_proofs WHERE expires_at < $1
@@ -0,0 +1,72 @@
+// Package dpopstore remembers the used DPoP proofs in the database,
+// so a proof can't be replayed on another process of ZITADEL.
+package dpopstore
+
+import (
+	"context"
+	_ "embed"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed use.sql
+	useStmt string
+	//go:embed cleanup.sql
+	cleanupStmt string
+)
+
+var _ authz.DPoPProofStore = (*Store)(nil)
+
+type Store struct {
+	client *database.DB
+	now    func() time.Time
+
+	mutex       sync.Mutex
+	nextCleanup time.Time
+}
+
+func New(client *database.DB) *Store {
+	return &Store{client: client, now: time.Now}
+}
+
+// UseDPoPProof implements [authz.DPoPProofStore].
+// The proof is stored unless the same jti and htu is already stored and not yet expired.
+func (s *Store) UseDPoPProof(ctx context.Context, jti, htu string, expiration time.Time) (_ bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	now := s.now()
+	s.cleanup(ctx, now)
+
+	result, err := s.client.ExecContext(ctx, useStmt, jti, htu, expiration, now)
+	if err != nil {
+		return false, zerrors.ThrowInternal(err, "DPOP-Wq3fz", "Errors.Internal")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, zerrors.ThrowInternal(err, "DPOP-Lr8vn", "Errors.Internal")
+	}
+	return rows == 1, nil
+}
+
+// cleanup removes the expired proofs at most once per proof lifetime of this process
+func (s *Store) cleanup(ctx context.Context, now time.Time) {
+	s.mutex.Lock()
+	if now.Before(s.nextCleanup) {
+		s.mutex.Unlock()
+		return
+	}
+	s.nextCleanup = now.Add(authz.DPoPProofLifetime)
+	s.mutex.Unlock()
+
+	_, err := s.client.ExecContext(ctx, cleanupStmt, now)
+	logging.OnError(err).Warn("unable to remove expired dpop proofs")
+}
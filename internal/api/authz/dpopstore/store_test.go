@@ -0,0 +1,75 @@
+package dpopstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestStore_UseDPoPProof(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiration := now.Add(authz.DPoPProofLifetime)
+	htu := "https://issuer.com/oauth/v2/token"
+
+	tests := []struct {
+		name        string
+		nextCleanup time.Time
+		mock        *mock.SQLMock
+		want        bool
+		wantErr     error
+	}{
+		{
+			name: "unused, cleaned up",
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(cleanupStmt, mock.WithExecArgs(now), mock.WithExecRowsAffected(2)),
+				mock.ExcpectExec(useStmt, mock.WithExecArgs("jti", htu, expiration, now), mock.WithExecRowsAffected(1)),
+			),
+			want: true,
+		},
+		{
+			name: "cleanup failed, unused",
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(cleanupStmt, mock.WithExecArgs(now), mock.WithExecErr(errors.New("cleanup failed"))),
+				mock.ExcpectExec(useStmt, mock.WithExecArgs("jti", htu, expiration, now), mock.WithExecRowsAffected(1)),
+			),
+			want: true,
+		},
+		{
+			name:        "used",
+			nextCleanup: now.Add(time.Second),
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(useStmt, mock.WithExecArgs("jti", htu, expiration, now), mock.WithExecRowsAffected(0)),
+			),
+			want: false,
+		},
+		{
+			name:        "error",
+			nextCleanup: now.Add(time.Second),
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(useStmt, mock.WithExecArgs("jti", htu, expiration, now), mock.WithExecErr(errors.New("db down"))),
+			),
+			wantErr: zerrors.ThrowInternal(nil, "DPOP-Wq3fz", "Errors.Internal"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.mock.Assert(t)
+			s := New(&database.DB{DB: tt.mock.DB})
+			s.now = func() time.Time { return now }
+			s.nextCleanup = tt.nextCleanup
+
+			got, err := s.UseDPoPProof(context.Background(), "jti", htu, expiration)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
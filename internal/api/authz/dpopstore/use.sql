@@ -0,0 +1,4 @@
+-- an expired proof with the same jti and htu is overwritten, so it can't block new proofs until it's cleaned up
+INSERT INTO auth.dpop_proofs (jti, htu, expires_at) VALUES ($1, $2, $3)
+ON CONFLICT (jti, htu) DO UPDATE SET expires_at = EXCLUDED.expires_at
+WHERE auth.dpop_proofs.expires_at < $4
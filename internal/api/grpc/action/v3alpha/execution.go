@@ -46,9 +46,14 @@ func (s *Server) SetExecution(ctx context.Context, req *action.SetExecutionReque
 		case *action.ExecutionTargetType_Target:
 			targets[i] = &execution.Target{Type: domain.ExecutionTargetTypeTarget, Target: t.Target}
 		}
+		// conditions on includes are rejected by the command
+		if targets[i] != nil {
+			targets[i].Condition = targetConditionToDomain(target.TargetCondition)
+		}
 	}
 	set := &command.SetExecution{
 		Targets: targets,
+		Mode:    executionModeToDomain(req.GetMode()),
 	}
 
 	var err error
@@ -83,6 +88,45 @@ func (s *Server) SetExecution(ctx context.Context, req *action.SetExecutionReque
 	}, nil
 }
 
+func targetConditionToDomain(condition *action.TargetCondition) *domain.ExecutionTargetCondition {
+	if condition == nil {
+		return nil
+	}
+	return &domain.ExecutionTargetCondition{
+		Path:     condition.GetPath(),
+		Operator: targetConditionOperatorToDomain(condition.GetOperator()),
+		Value:    condition.GetValue(),
+	}
+}
+
+func targetConditionOperatorToDomain(operator action.TargetConditionOperator) domain.ExecutionTargetConditionOperator {
+	switch operator {
+	case action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_EQUALS:
+		return domain.ExecutionTargetConditionOperatorEquals
+	case action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_NOT_EQUALS:
+		return domain.ExecutionTargetConditionOperatorNotEquals
+	case action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_EXISTS:
+		return domain.ExecutionTargetConditionOperatorExists
+	case action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_NOT_EXISTS:
+		return domain.ExecutionTargetConditionOperatorNotExists
+	case action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_UNSPECIFIED:
+		return domain.ExecutionTargetConditionOperatorUnspecified
+	default:
+		return domain.ExecutionTargetConditionOperatorUnspecified
+	}
+}
+
+func executionModeToDomain(mode action.ExecutionMode) domain.ExecutionMode {
+	switch mode {
+	case action.ExecutionMode_EXECUTION_MODE_PARALLEL:
+		return domain.ExecutionModeParallel
+	case action.ExecutionMode_EXECUTION_MODE_SEQUENTIAL, action.ExecutionMode_EXECUTION_MODE_UNSPECIFIED:
+		return domain.ExecutionModeSequential
+	default:
+		return domain.ExecutionModeSequential
+	}
+}
+
 func conditionToInclude(cond *action.Condition) (string, error) {
 	switch t := cond.GetConditionType().(type) {
 	case *action.Condition_Request:
@@ -0,0 +1,135 @@
+package action
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/server/middleware"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	action "github.com/zitadel/zitadel/pkg/grpc/action/v3alpha"
+)
+
+func (s *Server) SimulateExecution(ctx context.Context, req *action.SimulateExecutionRequest) (*action.SimulateExecutionResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+	method, err := methodDescriptor(req.GetMethod())
+	if err != nil {
+		return nil, err
+	}
+	request, err := structToMessage(req.GetRequest(), method.Input())
+	if err != nil {
+		return nil, err
+	}
+	// the response is passed as untyped nil if not provided, so the targets of the response executions are not called
+	var response interface{}
+	if req.Response != nil {
+		response, err = structToMessage(req.GetResponse(), method.Output())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	simulation := middleware.SimulateExecution(ctx, s.query, req.GetMethod(), request, response)
+	return simulationToPb(simulation)
+}
+
+// methodDescriptor returns the descriptor of a registered method, the full method has the form "/package.Service/Method"
+func methodDescriptor(fullMethod string) (protoreflect.MethodDescriptor, error) {
+	parts := strings.Split(fullMethod, "/")
+	if len(parts) != 3 || parts[0] != "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ACTION-0vt7xq3zk2", "Errors.Execution.ConditionInvalid")
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(parts[1]))
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "ACTION-9kq2c5h1ne", "Errors.Execution.ConditionInvalid")
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ACTION-m3s8wz6d0p", "Errors.Execution.ConditionInvalid")
+	}
+	method := service.Methods().ByName(protoreflect.Name(parts[2]))
+	if method == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ACTION-r5y1fb7o4u", "Errors.Execution.ConditionInvalid")
+	}
+	return method, nil
+}
+
+// structToMessage converts the JSON representation of a message to the message of the descriptor,
+// so the targets receive the same body as during the call of the method
+func structToMessage(value *structpb.Struct, descriptor protoreflect.MessageDescriptor) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(descriptor.FullName())
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ACTION-6hd0e2vjqa", "Errors.Internal")
+	}
+	message := messageType.New().Interface()
+	if value == nil {
+		return message, nil
+	}
+	data, err := protojson.Marshal(value)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "ACTION-t8x4nu1k6g", "Errors.Execution.Invalid")
+	}
+	if err := protojson.Unmarshal(data, message); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "ACTION-c2w9lp5m3r", "Errors.Execution.Invalid")
+	}
+	return message, nil
+}
+
+func simulationToPb(simulation *middleware.ExecutionSimulation) (_ *action.SimulateExecutionResponse, err error) {
+	resp := &action.SimulateExecutionResponse{
+		RequestCalls:  simulatedCallsToPb(simulation.RequestCalls),
+		ResponseCalls: simulatedCallsToPb(simulation.ResponseCalls),
+		Interrupted:   simulation.Interrupted,
+	}
+	if resp.Request, err = contentToStruct(simulation.Request); err != nil {
+		return nil, err
+	}
+	if resp.Response, err = contentToStruct(simulation.Response); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// contentToStruct converts the request or response of the simulation to its JSON representation
+func contentToStruct(content interface{}) (*structpb.Struct, error) {
+	message, ok := content.(proto.Message)
+	if !ok {
+		return nil, nil
+	}
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ACTION-z1n7qa4e8s", "Errors.Internal")
+	}
+	value := new(structpb.Struct)
+	if err := value.UnmarshalJSON(data); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ACTION-j4b6yk0x2d", "Errors.Internal")
+	}
+	return value, nil
+}
+
+func simulatedCallsToPb(calls []*execution.SimulatedCall) []*action.SimulatedTargetCall {
+	result := make([]*action.SimulatedTargetCall, len(calls))
+	for i, call := range calls {
+		result[i] = &action.SimulatedTargetCall{
+			TargetId:     call.TargetID,
+			ExecutionId:  call.ExecutionID,
+			Latency:      durationpb.New(call.Duration),
+			StatusCode:   int32(call.StatusCode),
+			ResponseBody: call.Response,
+			Interrupted:  call.Interrupted,
+		}
+		if call.Err != nil {
+			result[i].Error = call.Err.Error()
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	action "github.com/zitadel/zitadel/pkg/grpc/action/v3alpha"
+)
+
+func Test_methodDescriptor(t *testing.T) {
+	tests := []struct {
+		name       string
+		fullMethod string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "no full method, error",
+			fullMethod: "zitadel.action.v3alpha.ActionService",
+			wantErr:    true,
+		},
+		{
+			name:       "unknown service, error",
+			fullMethod: "/unknown.Service/Method",
+			wantErr:    true,
+		},
+		{
+			name:       "unknown method, error",
+			fullMethod: "/zitadel.action.v3alpha.ActionService/Unknown",
+			wantErr:    true,
+		},
+		{
+			name:       "method, ok",
+			fullMethod: "/zitadel.action.v3alpha.ActionService/GetTargetByID",
+			want:       "zitadel.action.v3alpha.GetTargetByIDRequest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := methodDescriptor(tt.fullMethod)
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got.Input().FullName()))
+		})
+	}
+}
+
+func Test_structToMessage(t *testing.T) {
+	method, err := methodDescriptor("/zitadel.action.v3alpha.ActionService/GetTargetByID")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		value   *structpb.Struct
+		want    *action.GetTargetByIDRequest
+		wantErr bool
+	}{
+		{
+			name: "unknown field, error",
+			value: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"unknown": structpb.NewStringValue("value"),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "empty, ok",
+			want: &action.GetTargetByIDRequest{},
+		},
+		{
+			name: "fields, ok",
+			value: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"targetId": structpb.NewStringValue("target"),
+			}},
+			want: &action.GetTargetByIDRequest{TargetId: "target"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := structToMessage(tt.value, method.Input())
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.GetTargetId(), got.(*action.GetTargetByIDRequest).GetTargetId())
+		})
+	}
+}
+
+func Test_simulatedCallsToPb(t *testing.T) {
+	got := simulatedCallsToPb([]*execution.SimulatedCall{
+		{
+			TargetID:    "target1",
+			ExecutionID: "request/zitadel.action.v3alpha.ActionService/GetTargetByID",
+			Duration:    time.Second,
+			StatusCode:  200,
+			Response:    []byte(`{"targetId":"target"}`),
+		},
+		{
+			TargetID:    "target2",
+			ExecutionID: "request",
+			Duration:    time.Second,
+			StatusCode:  403,
+			Err:         zerrors.ThrowUnknown(nil, "EXEC-dra6yamk98", "Errors.Execution.Failed"),
+			Interrupted: true,
+		},
+	})
+	assert.Equal(t, []*action.SimulatedTargetCall{
+		{
+			TargetId:     "target1",
+			ExecutionId:  "request/zitadel.action.v3alpha.ActionService/GetTargetByID",
+			Latency:      durationpb.New(time.Second),
+			StatusCode:   200,
+			ResponseBody: []byte(`{"targetId":"target"}`),
+		},
+		{
+			TargetId:    "target2",
+			ExecutionId: "request",
+			Latency:     durationpb.New(time.Second),
+			StatusCode:  403,
+			Error:       "ID=EXEC-dra6yamk98 Message=Errors.Execution.Failed",
+			Interrupted: true,
+		},
+	}, got)
+}
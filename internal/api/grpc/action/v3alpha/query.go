@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
 	"github.com/zitadel/zitadel/internal/command"
@@ -134,6 +135,7 @@ func targetToPb(t *query.Target) *action.Target {
 		Name:     t.Name,
 		Timeout:  durationpb.New(t.Timeout),
 		Endpoint: t.Endpoint,
+		AuthType: targetAuthTypeToPb(t.AuthType),
 	}
 
 	switch t.TargetType {
@@ -143,12 +145,223 @@ func targetToPb(t *query.Target) *action.Target {
 		target.TargetType = &action.Target_RestCall{RestCall: &action.SetRESTCall{InterruptOnError: t.InterruptOnError}}
 	case domain.TargetTypeAsync:
 		target.TargetType = &action.Target_RestAsync{RestAsync: &action.SetRESTAsync{}}
+	case domain.TargetTypeGRPC:
+		target.TargetType = &action.Target_Grpc{Grpc: &action.SetGRPC{InterruptOnError: t.InterruptOnError}}
 	default:
 		target.TargetType = nil
 	}
 	return target
 }
 
+func (s *Server) ListTargetDeliveries(ctx context.Context, req *action.ListTargetDeliveriesRequest) (*action.ListTargetDeliveriesResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	queries, err := listTargetDeliveriesRequestToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.query.SearchTargetDeliveries(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &action.ListTargetDeliveriesResponse{
+		Result:  targetDeliveriesToPb(resp.TargetDeliveries),
+		Details: object.ToListDetails(resp.SearchResponse),
+	}, nil
+}
+
+func listTargetDeliveriesRequestToModel(req *action.ListTargetDeliveriesRequest) (*query.TargetDeliverySearchQueries, error) {
+	offset, limit, asc := object.ListQueryToQuery(req.Query)
+	queries, err := targetDeliveryQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.TargetDeliverySearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.TargetDeliveryColumnCreationDate,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func targetDeliveryQueriesToQuery(queries []*action.TargetDeliverySearchQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = targetDeliveryQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func targetDeliveryQueryToQuery(searchQuery *action.TargetDeliverySearchQuery) (query.SearchQuery, error) {
+	switch q := searchQuery.Query.(type) {
+	case *action.TargetDeliverySearchQuery_TargetIdQuery:
+		return query.NewTargetDeliveryTargetIDSearchQuery(q.TargetIdQuery.GetTargetId())
+	case *action.TargetDeliverySearchQuery_StateQuery:
+		return query.NewTargetDeliveryStateSearchQuery(targetDeliveryStateToDomain(q.StateQuery.GetState()))
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "GRPC-uh3u6q3fzb", "List.Query.Invalid")
+	}
+}
+
+func targetDeliveryStateToDomain(state action.TargetDeliveryState) domain.TargetDeliveryState {
+	switch state {
+	case action.TargetDeliveryState_TARGET_DELIVERY_STATE_PENDING:
+		return domain.TargetDeliveryStatePending
+	case action.TargetDeliveryState_TARGET_DELIVERY_STATE_ATTEMPTING:
+		return domain.TargetDeliveryStateAttempting
+	case action.TargetDeliveryState_TARGET_DELIVERY_STATE_FAILED:
+		return domain.TargetDeliveryStateFailed
+	case action.TargetDeliveryState_TARGET_DELIVERY_STATE_UNSPECIFIED:
+		return domain.TargetDeliveryStateUnspecified
+	default:
+		return domain.TargetDeliveryStateUnspecified
+	}
+}
+
+func targetDeliveryStateToPb(state domain.TargetDeliveryState) action.TargetDeliveryState {
+	switch state {
+	case domain.TargetDeliveryStatePending:
+		return action.TargetDeliveryState_TARGET_DELIVERY_STATE_PENDING
+	case domain.TargetDeliveryStateAttempting:
+		return action.TargetDeliveryState_TARGET_DELIVERY_STATE_ATTEMPTING
+	case domain.TargetDeliveryStateFailed:
+		return action.TargetDeliveryState_TARGET_DELIVERY_STATE_FAILED
+	case domain.TargetDeliveryStateUnspecified, domain.TargetDeliveryStateSucceeded:
+		return action.TargetDeliveryState_TARGET_DELIVERY_STATE_UNSPECIFIED
+	default:
+		return action.TargetDeliveryState_TARGET_DELIVERY_STATE_UNSPECIFIED
+	}
+}
+
+func targetDeliveriesToPb(deliveries []*query.TargetDelivery) []*action.TargetDelivery {
+	d := make([]*action.TargetDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		d[i] = targetDeliveryToPb(delivery)
+	}
+	return d
+}
+
+func targetDeliveryToPb(d *query.TargetDelivery) *action.TargetDelivery {
+	return &action.TargetDelivery{
+		DeliveryId:   d.ID,
+		Details:      object.DomainToDetailsPb(&d.ObjectDetails),
+		CreationDate: timestamppb.New(d.CreationDate),
+		TargetId:     d.TargetID,
+		ExecutionId:  d.ExecutionID,
+		State:        targetDeliveryStateToPb(d.State),
+		Attempt:      d.Attempt,
+		FailureCount: d.FailureCount,
+		NextAttempt:  timestamppb.New(d.NextAttempt),
+		LastError:    d.LastError,
+	}
+}
+
+func (s *Server) ListTargetCallLogs(ctx context.Context, req *action.ListTargetCallLogsRequest) (*action.ListTargetCallLogsResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	queries, err := listTargetCallLogsRequestToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.query.SearchTargetCallLogs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &action.ListTargetCallLogsResponse{
+		Result:  targetCallLogsToPb(resp.TargetCallLogs),
+		Details: object.ToListDetails(resp.SearchResponse),
+	}, nil
+}
+
+func listTargetCallLogsRequestToModel(req *action.ListTargetCallLogsRequest) (*query.TargetCallLogSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToQuery(req.Query)
+	queries, err := targetCallLogQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.TargetCallLogSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.TargetCallLogColumnLogDate,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func targetCallLogQueriesToQuery(queries []*action.TargetCallLogSearchQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = targetCallLogQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func targetCallLogQueryToQuery(searchQuery *action.TargetCallLogSearchQuery) (query.SearchQuery, error) {
+	switch q := searchQuery.Query.(type) {
+	case *action.TargetCallLogSearchQuery_TargetIdQuery:
+		return query.NewTargetCallLogTargetIDSearchQuery(q.TargetIdQuery.GetTargetId())
+	case *action.TargetCallLogSearchQuery_ExecutionIdQuery:
+		return query.NewTargetCallLogExecutionIDSearchQuery(q.ExecutionIdQuery.GetExecutionId())
+	case *action.TargetCallLogSearchQuery_LogDateQuery:
+		return query.NewTargetCallLogLogDateSearchQuery(q.LogDateQuery.GetLogDate().AsTime(), object.TimestampMethodToQuery(q.LogDateQuery.GetMethod()))
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "GRPC-p7c2kw9dna", "List.Query.Invalid")
+	}
+}
+
+func targetCallLogsToPb(logs []*query.TargetCallLog) []*action.TargetCallLog {
+	l := make([]*action.TargetCallLog, len(logs))
+	for i, log := range logs {
+		l[i] = targetCallLogToPb(log)
+	}
+	return l
+}
+
+func targetCallLogToPb(l *query.TargetCallLog) *action.TargetCallLog {
+	return &action.TargetCallLog{
+		LogDate:     timestamppb.New(l.LogDate),
+		TargetId:    l.TargetID,
+		ExecutionId: l.ExecutionID,
+		StatusCode:  int32(l.StatusCode),
+		Took:        durationpb.New(l.Took),
+		Error:       l.Error,
+	}
+}
+
+func targetAuthTypeToPb(authType domain.TargetAuthType) action.TargetAuthType {
+	switch authType {
+	case domain.TargetAuthTypeHeaders:
+		return action.TargetAuthType_TARGET_AUTH_TYPE_HEADERS
+	case domain.TargetAuthTypeBasic:
+		return action.TargetAuthType_TARGET_AUTH_TYPE_BASIC
+	case domain.TargetAuthTypeBearer:
+		return action.TargetAuthType_TARGET_AUTH_TYPE_BEARER
+	case domain.TargetAuthTypeClientCredentials:
+		return action.TargetAuthType_TARGET_AUTH_TYPE_CLIENT_CREDENTIALS
+	case domain.TargetAuthTypeClientCertificate:
+		return action.TargetAuthType_TARGET_AUTH_TYPE_CLIENT_CERTIFICATE
+	case domain.TargetAuthTypeNone:
+		return action.TargetAuthType_TARGET_AUTH_TYPE_UNSPECIFIED
+	default:
+		return action.TargetAuthType_TARGET_AUTH_TYPE_UNSPECIFIED
+	}
+}
+
 func (s *Server) ListExecutions(ctx context.Context, req *action.ListExecutionsRequest) (*action.ListExecutionsResponse, error) {
 	if err := checkExecutionEnabled(ctx); err != nil {
 		return nil, err
@@ -288,7 +501,10 @@ func executionToPb(e *query.Execution) *action.Execution {
 		case domain.ExecutionTargetTypeInclude:
 			targets[i] = &action.ExecutionTargetType{Type: &action.ExecutionTargetType_Include{Include: executionIDToCondition(e.Targets[i].Target)}}
 		case domain.ExecutionTargetTypeTarget:
-			targets[i] = &action.ExecutionTargetType{Type: &action.ExecutionTargetType_Target{Target: e.Targets[i].Target}}
+			targets[i] = &action.ExecutionTargetType{
+				Type:            &action.ExecutionTargetType_Target{Target: e.Targets[i].Target},
+				TargetCondition: targetConditionToPb(e.Targets[i].Condition),
+			}
 		case domain.ExecutionTargetTypeUnspecified:
 			continue
 		default:
@@ -300,6 +516,46 @@ func executionToPb(e *query.Execution) *action.Execution {
 		Details:   object.DomainToDetailsPb(&e.ObjectDetails),
 		Condition: executionIDToCondition(e.ID),
 		Targets:   targets,
+		Mode:      executionModeToPb(e.Mode),
+	}
+}
+
+func targetConditionToPb(condition *domain.ExecutionTargetCondition) *action.TargetCondition {
+	if condition == nil {
+		return nil
+	}
+	return &action.TargetCondition{
+		Path:     condition.Path,
+		Operator: targetConditionOperatorToPb(condition.Operator),
+		Value:    condition.Value,
+	}
+}
+
+func targetConditionOperatorToPb(operator domain.ExecutionTargetConditionOperator) action.TargetConditionOperator {
+	switch operator {
+	case domain.ExecutionTargetConditionOperatorEquals:
+		return action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_EQUALS
+	case domain.ExecutionTargetConditionOperatorNotEquals:
+		return action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_NOT_EQUALS
+	case domain.ExecutionTargetConditionOperatorExists:
+		return action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_EXISTS
+	case domain.ExecutionTargetConditionOperatorNotExists:
+		return action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_NOT_EXISTS
+	case domain.ExecutionTargetConditionOperatorUnspecified:
+		return action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_UNSPECIFIED
+	default:
+		return action.TargetConditionOperator_TARGET_CONDITION_OPERATOR_UNSPECIFIED
+	}
+}
+
+func executionModeToPb(mode domain.ExecutionMode) action.ExecutionMode {
+	switch mode {
+	case domain.ExecutionModeSequential:
+		return action.ExecutionMode_EXECUTION_MODE_SEQUENTIAL
+	case domain.ExecutionModeParallel:
+		return action.ExecutionMode_EXECUTION_MODE_PARALLEL
+	default:
+		return action.ExecutionMode_EXECUTION_MODE_UNSPECIFIED
 	}
 }
 
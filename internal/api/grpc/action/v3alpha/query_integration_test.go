@@ -692,12 +692,12 @@ func TestServer_ListExecutions(t *testing.T) {
 					response.Result[0].Targets = targets1
 
 					cond2 := request.Queries[0].GetInConditionsQuery().GetConditions()[1]
-					targets2 := executionTargetsSingleTarget(targetResp.GetId())
-					resp2 := Tester.SetExecution(ctx, t, cond2, targets2)
+					targets3 := executionTargetsSingleTarget(targetResp.GetId())
+					resp2 := Tester.SetExecution(ctx, t, cond2, targets3)
 					response.Result[1].Details.ChangeDate = resp2.GetDetails().GetChangeDate()
 					response.Result[1].Details.Sequence = resp2.GetDetails().GetSequence()
 					response.Result[1].Condition = cond2
-					response.Result[1].Targets = targets2
+					response.Result[1].Targets = targets3
 
 					cond3 := request.Queries[0].GetInConditionsQuery().GetConditions()[2]
 					targets3 := executionTargetsSingleTarget(targetResp.GetId())
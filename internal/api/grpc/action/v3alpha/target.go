@@ -24,8 +24,9 @@ func (s *Server) CreateTarget(ctx context.Context, req *action.CreateTargetReque
 		return nil, err
 	}
 	return &action.CreateTargetResponse{
-		Id:      add.AggregateID,
-		Details: object.DomainToDetailsPb(details),
+		Id:         add.AggregateID,
+		Details:    object.DomainToDetailsPb(details),
+		SigningKey: add.SigningKey,
 	}, nil
 }
 
@@ -57,6 +58,35 @@ func (s *Server) DeleteTarget(ctx context.Context, req *action.DeleteTargetReque
 	}, nil
 }
 
+func (s *Server) RotateTargetSigningKey(ctx context.Context, req *action.RotateTargetSigningKeyRequest) (*action.RotateTargetSigningKeyResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	details, signingKey, err := s.command.RotateTargetSigningKey(ctx, req.GetTargetId(), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &action.RotateTargetSigningKeyResponse{
+		Details:    object.DomainToDetailsPb(details),
+		SigningKey: signingKey,
+	}, nil
+}
+
+func (s *Server) RedriveTargetDelivery(ctx context.Context, req *action.RedriveTargetDeliveryRequest) (*action.RedriveTargetDeliveryResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	details, err := s.command.RedriveTargetDelivery(ctx, req.GetDeliveryId(), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &action.RedriveTargetDeliveryResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
 func createTargetToCommand(req *action.CreateTargetRequest) *command.AddTarget {
 	var (
 		targetType       domain.TargetType
@@ -71,6 +101,9 @@ func createTargetToCommand(req *action.CreateTargetRequest) *command.AddTarget {
 		interruptOnError = t.RestCall.InterruptOnError
 	case *action.CreateTargetRequest_RestAsync:
 		targetType = domain.TargetTypeAsync
+	case *action.CreateTargetRequest_Grpc:
+		targetType = domain.TargetTypeGRPC
+		interruptOnError = t.Grpc.InterruptOnError
 	}
 	return &command.AddTarget{
 		Name:             req.GetName(),
@@ -78,6 +111,7 @@ func createTargetToCommand(req *action.CreateTargetRequest) *command.AddTarget {
 		Endpoint:         req.GetEndpoint(),
 		Timeout:          req.GetTimeout().AsDuration(),
 		InterruptOnError: interruptOnError,
+		Auth:             targetAuthToDomain(req.GetAuth()),
 	}
 }
 
@@ -103,10 +137,58 @@ func updateTargetToCommand(req *action.UpdateTargetRequest) *command.ChangeTarge
 		case *action.UpdateTargetRequest_RestAsync:
 			target.TargetType = gu.Ptr(domain.TargetTypeAsync)
 			target.InterruptOnError = gu.Ptr(false)
+		case *action.UpdateTargetRequest_Grpc:
+			target.TargetType = gu.Ptr(domain.TargetTypeGRPC)
+			target.InterruptOnError = gu.Ptr(t.Grpc.InterruptOnError)
 		}
 	}
 	if req.Timeout != nil {
 		target.Timeout = gu.Ptr(req.GetTimeout().AsDuration())
 	}
+	if req.Auth != nil {
+		target.Auth = targetAuthToDomain(req.Auth)
+	}
 	return target
 }
+
+func targetAuthToDomain(auth *action.TargetAuth) *domain.TargetAuth {
+	if auth == nil {
+		return nil
+	}
+	switch a := auth.GetAuth().(type) {
+	case *action.TargetAuth_Headers:
+		return &domain.TargetAuth{
+			Type:    domain.TargetAuthTypeHeaders,
+			Headers: a.Headers.GetHeaders(),
+		}
+	case *action.TargetAuth_Basic:
+		return &domain.TargetAuth{
+			Type:     domain.TargetAuthTypeBasic,
+			Username: a.Basic.GetUsername(),
+			Password: a.Basic.GetPassword(),
+		}
+	case *action.TargetAuth_Bearer:
+		return &domain.TargetAuth{
+			Type:  domain.TargetAuthTypeBearer,
+			Token: a.Bearer.GetToken(),
+		}
+	case *action.TargetAuth_ClientCredentials:
+		return &domain.TargetAuth{
+			Type:          domain.TargetAuthTypeClientCredentials,
+			TokenEndpoint: a.ClientCredentials.GetTokenEndpoint(),
+			ClientID:      a.ClientCredentials.GetClientId(),
+			ClientSecret:  a.ClientCredentials.GetClientSecret(),
+			Scopes:        a.ClientCredentials.GetScopes(),
+		}
+	case *action.TargetAuth_ClientCertificate:
+		return &domain.TargetAuth{
+			Type:        domain.TargetAuthTypeClientCertificate,
+			Certificate: a.ClientCertificate.GetCertificate(),
+			Key:         a.ClientCertificate.GetKey(),
+		}
+	default:
+		return &domain.TargetAuth{
+			Type: domain.TargetAuthTypeNone,
+		}
+	}
+}
@@ -200,6 +200,7 @@ func TestServer_CreateTarget(t *testing.T) {
 
 			integration.AssertDetails(t, tt.want, got)
 			assert.NotEmpty(t, got.GetId())
+			assert.NotEmpty(t, got.GetSigningKey())
 		})
 	}
 }
@@ -421,3 +422,58 @@ func TestServer_DeleteTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_RotateTargetSigningKey(t *testing.T) {
+	ensureFeatureEnabled(t)
+	target := Tester.CreateTarget(CTX, t, "", "https://example.com", domain.TargetTypeWebhook, false)
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *action.RotateTargetSigningKeyRequest
+		want    *action.RotateTargetSigningKeyResponse
+		wantErr bool
+	}{
+		{
+			name: "missing permission",
+			ctx:  Tester.WithAuthorization(context.Background(), integration.OrgOwner),
+			req: &action.RotateTargetSigningKeyRequest{
+				TargetId: target.GetId(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "not existing",
+			ctx:  CTX,
+			req: &action.RotateTargetSigningKeyRequest{
+				TargetId: "notexisting",
+			},
+			wantErr: true,
+		},
+		{
+			name: "rotate signing key",
+			ctx:  CTX,
+			req: &action.RotateTargetSigningKeyRequest{
+				TargetId: target.GetId(),
+			},
+			want: &action.RotateTargetSigningKeyResponse{
+				Details: &object.Details{
+					ChangeDate:    timestamppb.Now(),
+					ResourceOwner: Tester.Instance.InstanceID(),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Client.RotateTargetSigningKey(tt.ctx, tt.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			integration.AssertDetails(t, tt.want, got)
+			assert.NotEmpty(t, got.GetSigningKey())
+			assert.NotEqual(t, target.GetSigningKey(), got.GetSigningKey())
+		})
+	}
+}
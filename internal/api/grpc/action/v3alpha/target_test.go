@@ -88,6 +88,53 @@ func Test_createTargetToCommand(t *testing.T) {
 				InterruptOnError: true,
 			},
 		},
+		{
+			name: "all fields (grpc)",
+			args: args{&action.CreateTargetRequest{
+				Name:     "target 1",
+				Endpoint: "https://example.com:443",
+				TargetType: &action.CreateTargetRequest_Grpc{
+					Grpc: &action.SetGRPC{
+						InterruptOnError: true,
+					},
+				},
+				Timeout: durationpb.New(10 * time.Second),
+			}},
+			want: &command.AddTarget{
+				Name:             "target 1",
+				TargetType:       domain.TargetTypeGRPC,
+				Endpoint:         "https://example.com:443",
+				Timeout:          10 * time.Second,
+				InterruptOnError: true,
+			},
+		},
+		{
+			name: "auth (bearer)",
+			args: args{&action.CreateTargetRequest{
+				Name:     "target 1",
+				Endpoint: "https://example.com/hooks/1",
+				TargetType: &action.CreateTargetRequest_RestWebhook{
+					RestWebhook: &action.SetRESTWebhook{},
+				},
+				Timeout: durationpb.New(10 * time.Second),
+				Auth: &action.TargetAuth{
+					Auth: &action.TargetAuth_Bearer{
+						Bearer: &action.TargetAuthBearer{Token: "token"},
+					},
+				},
+			}},
+			want: &command.AddTarget{
+				Name:             "target 1",
+				TargetType:       domain.TargetTypeWebhook,
+				Endpoint:         "https://example.com/hooks/1",
+				Timeout:          10 * time.Second,
+				InterruptOnError: false,
+				Auth: &domain.TargetAuth{
+					Type:  domain.TargetAuthTypeBearer,
+					Token: "token",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -219,6 +266,37 @@ func Test_updateTargetToCommand(t *testing.T) {
 				InterruptOnError: gu.Ptr(true),
 			},
 		},
+		{
+			name: "all fields (grpc)",
+			args: args{&action.UpdateTargetRequest{
+				Name:     gu.Ptr("target 1"),
+				Endpoint: gu.Ptr("https://example.com:443"),
+				TargetType: &action.UpdateTargetRequest_Grpc{
+					Grpc: &action.SetGRPC{
+						InterruptOnError: true,
+					},
+				},
+				Timeout: durationpb.New(10 * time.Second),
+			}},
+			want: &command.ChangeTarget{
+				Name:             gu.Ptr("target 1"),
+				TargetType:       gu.Ptr(domain.TargetTypeGRPC),
+				Endpoint:         gu.Ptr("https://example.com:443"),
+				Timeout:          gu.Ptr(10 * time.Second),
+				InterruptOnError: gu.Ptr(true),
+			},
+		},
+		{
+			name: "remove auth",
+			args: args{&action.UpdateTargetRequest{
+				Auth: &action.TargetAuth{},
+			}},
+			want: &command.ChangeTarget{
+				Auth: &domain.TargetAuth{
+					Type: domain.TargetAuthTypeNone,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) MigrateActionsV1(ctx context.Context, req *admin_pb.MigrateActionsV1Request) (*admin_pb.MigrateActionsV1Response, error) {
+	migrated, err := s.command.MigrateActionsV1(ctx, &command.MigrateActionsV1{
+		RunnerURL: s.actionsRunnerURL(ctx),
+		DryRun:    req.GetDryRun(),
+	}, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.MigrateActionsV1Response{
+		Actions: migratedActionsV1ToPb(migrated),
+	}, nil
+}
+
+func migratedActionsV1ToPb(migrated []*command.MigratedActionV1) []*admin_pb.MigratedActionV1 {
+	actions := make([]*admin_pb.MigratedActionV1, len(migrated))
+	for i, action := range migrated {
+		actions[i] = &admin_pb.MigratedActionV1{
+			ActionId:               action.ActionID,
+			OrgId:                  action.ResourceOwner,
+			Name:                   action.Name,
+			TargetId:               action.TargetID,
+			Functions:              action.Functions,
+			ManualAttentionModules: action.Modules,
+			Inactive:               action.Inactive,
+		}
+	}
+	return actions
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	event_grpc "github.com/zitadel/zitadel/internal/api/grpc/event"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
@@ -118,3 +120,29 @@ func aggregateTypesFromEventTypes(eventTypes []eventstore.EventType) []eventstor
 
 	return aggregateTypes
 }
+
+func (s *Server) WatchEvents(in *admin_pb.WatchEventsRequest, stream admin_pb.AdminService_WatchEventsServer) error {
+	ctx := stream.Context()
+	return s.query.WatchEvents(ctx, watchEventsRequestToQuery(ctx, in), func(event *query.WatchedEvent) error {
+		res, err := event_grpc.EventToPb(event.Event)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&admin_pb.WatchEventsResponse{
+			Event:    res,
+			Position: event_grpc.PositionToPb(event.Position),
+		})
+	})
+}
+
+func watchEventsRequestToQuery(ctx context.Context, req *admin_pb.WatchEventsRequest) *query.WatchEventsQuery {
+	return &query.WatchEventsQuery{
+		InstanceIDs:    []string{authz.GetInstance(ctx).InstanceID()},
+		From:           event_grpc.PositionFromPb(req.GetPosition()),
+		AggregateTypes: req.GetAggregateTypes(),
+		AggregateID:    req.GetAggregateId(),
+		EventTypes:     req.GetEventTypes(),
+		EditorUserID:   req.GetEditorUserId(),
+		ResourceOwner:  req.GetResourceOwner(),
+	}
+}
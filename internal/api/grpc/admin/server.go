@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/zitadel/zitadel/internal/admin/repository/eventsourcing"
+	"github.com/zitadel/zitadel/internal/api/actionsrunner"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
@@ -29,6 +30,7 @@ type Server struct {
 	command           *command.Commands
 	query             *query.Queries
 	assetsAPIDomain   func(context.Context) string
+	actionsRunnerURL  func(context.Context) string
 	userCodeAlg       crypto.EncryptionAlgorithm
 	auditLogRetention time.Duration
 }
@@ -51,6 +53,7 @@ func CreateServer(
 		command:           command,
 		query:             query,
 		assetsAPIDomain:   assets.AssetAPI(externalSecure),
+		actionsRunnerURL:  actionsrunner.URL(externalSecure),
 		userCodeAlg:       userCodeAlg,
 		auditLogRetention: auditLogRetention,
 	}
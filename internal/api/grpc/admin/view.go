@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
@@ -22,3 +23,11 @@ func (s *Server) ListViews(ctx context.Context, _ *admin_pb.ListViewsRequest) (*
 	}
 	return &admin_pb.ListViewsResponse{Result: CurrentSequencesToPb(s.database, currentSequences)}, nil
 }
+
+func (s *Server) ListProjectionStates(ctx context.Context, req *admin_pb.ListProjectionStatesRequest) (*admin_pb.ListProjectionStatesResponse, error) {
+	lags, err := projection.Lags(ctx, req.GetProjectionNames()...)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListProjectionStatesResponse{Result: ProjectionLagsToPb(s.database, lags)}, nil
+}
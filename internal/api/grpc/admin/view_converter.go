@@ -1,8 +1,10 @@
 package admin
 
 import (
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
@@ -24,3 +26,25 @@ func CurrentSequenceToPb(database string, currentSequence *query.CurrentState) *
 		EventTimestamp:           timestamppb.New(currentSequence.EventCreatedAt),
 	}
 }
+
+func ProjectionLagsToPb(database string, lags []*handler.Lag) []*admin_pb.ProjectionState {
+	states := make([]*admin_pb.ProjectionState, len(lags))
+	for i, lag := range lags {
+		states[i] = ProjectionLagToPb(database, lag)
+	}
+	return states
+}
+
+func ProjectionLagToPb(database string, lag *handler.Lag) *admin_pb.ProjectionState {
+	return &admin_pb.ProjectionState{
+		Database:       database,
+		ProjectionName: lag.ProjectionName,
+		Position:       lag.Position,
+		EventTimestamp: timestamppb.New(lag.EventTimestamp),
+		LastRun:        timestamppb.New(lag.LastRun),
+		EventLag:       lag.Events,
+		TimeLag:        durationpb.New(lag.Time),
+		BulkDuration:   durationpb.New(lag.BulkDuration),
+		FailedEvents:   lag.FailedEvents,
+	}
+}
@@ -49,6 +49,20 @@ func EventToPb(event *query.Event) (response *eventpb.Event, err error) {
 	}, nil
 }
 
+func PositionToPb(position query.EventPosition) *eventpb.Position {
+	return &eventpb.Position{
+		Position:  position.Position,
+		InTxOrder: position.InTxOrder,
+	}
+}
+
+func PositionFromPb(position *eventpb.Position) query.EventPosition {
+	return query.EventPosition{
+		Position:  position.GetPosition(),
+		InTxOrder: position.GetInTxOrder(),
+	}
+}
+
 func EventTypeToPb(typ string) *eventpb.EventType {
 	return &eventpb.EventType{
 		Type:      typ,
@@ -117,6 +117,10 @@ func improvedPerformanceTypeToPb(typ feature.ImprovedPerformanceType) feature_pb
 		return feature_pb.ImprovedPerformance_IMPROVED_PERFORMANCE_USER_GRANT
 	case feature.ImprovedPerformanceTypeOrgDomainVerified:
 		return feature_pb.ImprovedPerformance_IMPROVED_PERFORMANCE_ORG_DOMAIN_VERIFIED
+	case feature.ImprovedPerformanceTypeUserCommands:
+		return feature_pb.ImprovedPerformance_IMPROVED_PERFORMANCE_USER_COMMANDS
+	case feature.ImprovedPerformanceTypeOrgCommands:
+		return feature_pb.ImprovedPerformance_IMPROVED_PERFORMANCE_ORG_COMMANDS
 	default:
 		return feature_pb.ImprovedPerformance(typ)
 	}
@@ -149,6 +153,10 @@ func improvedPerformanceToDomain(typ feature_pb.ImprovedPerformance) feature.Imp
 		return feature.ImprovedPerformanceTypeUserGrant
 	case feature_pb.ImprovedPerformance_IMPROVED_PERFORMANCE_ORG_DOMAIN_VERIFIED:
 		return feature.ImprovedPerformanceTypeOrgDomainVerified
+	case feature_pb.ImprovedPerformance_IMPROVED_PERFORMANCE_USER_COMMANDS:
+		return feature.ImprovedPerformanceTypeUserCommands
+	case feature_pb.ImprovedPerformance_IMPROVED_PERFORMANCE_ORG_COMMANDS:
+		return feature.ImprovedPerformanceTypeOrgCommands
 	default:
 		return feature.ImprovedPerformanceTypeUnknown
 	}
@@ -2,6 +2,7 @@ package management
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	authn_grpc "github.com/zitadel/zitadel/internal/api/grpc/authn"
@@ -291,3 +292,29 @@ func (s *Server) RemoveAppKey(ctx context.Context, req *mgmt_pb.RemoveAppKeyRequ
 		Details: object_grpc.DomainToChangeDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) AddProjectInitialAccessToken(ctx context.Context, req *mgmt_pb.AddProjectInitialAccessTokenRequest) (*mgmt_pb.AddProjectInitialAccessTokenResponse, error) {
+	var expirationDate time.Time
+	if req.ExpirationDate != nil {
+		expirationDate = req.ExpirationDate.AsTime()
+	}
+	token, err := s.command.AddInitialAccessToken(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, expirationDate)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddProjectInitialAccessTokenResponse{
+		TokenId: token.TokenID,
+		Token:   token.Token,
+		Details: object_grpc.DomainToAddDetailsPb(token.Details),
+	}, nil
+}
+
+func (s *Server) RemoveProjectInitialAccessToken(ctx context.Context, req *mgmt_pb.RemoveProjectInitialAccessTokenRequest) (*mgmt_pb.RemoveProjectInitialAccessTokenResponse, error) {
+	details, err := s.command.RemoveInitialAccessToken(ctx, req.ProjectId, req.TokenId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveProjectInitialAccessTokenResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -41,22 +41,27 @@ func AddOIDCAppRequestToDomain(req *mgmt_pb.AddOIDCAppRequest) *domain.OIDCApp {
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.ProjectId,
 		},
-		AppName:                  req.Name,
-		OIDCVersion:              app_grpc.OIDCVersionToDomain(req.Version),
-		RedirectUris:             req.RedirectUris,
-		ResponseTypes:            app_grpc.OIDCResponseTypesToDomain(req.ResponseTypes),
-		GrantTypes:               app_grpc.OIDCGrantTypesToDomain(req.GrantTypes),
-		ApplicationType:          app_grpc.OIDCApplicationTypeToDomain(req.AppType),
-		AuthMethodType:           app_grpc.OIDCAuthMethodTypeToDomain(req.AuthMethodType),
-		PostLogoutRedirectUris:   req.PostLogoutRedirectUris,
-		DevMode:                  req.DevMode,
-		AccessTokenType:          app_grpc.OIDCTokenTypeToDomain(req.AccessTokenType),
-		AccessTokenRoleAssertion: req.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     req.IdTokenRoleAssertion,
-		IDTokenUserinfoAssertion: req.IdTokenUserinfoAssertion,
-		ClockSkew:                req.ClockSkew.AsDuration(),
-		AdditionalOrigins:        req.AdditionalOrigins,
-		SkipNativeAppSuccessPage: req.SkipNativeAppSuccessPage,
+		AppName:                          req.Name,
+		OIDCVersion:                      app_grpc.OIDCVersionToDomain(req.Version),
+		RedirectUris:                     req.RedirectUris,
+		ResponseTypes:                    app_grpc.OIDCResponseTypesToDomain(req.ResponseTypes),
+		GrantTypes:                       app_grpc.OIDCGrantTypesToDomain(req.GrantTypes),
+		ApplicationType:                  app_grpc.OIDCApplicationTypeToDomain(req.AppType),
+		AuthMethodType:                   app_grpc.OIDCAuthMethodTypeToDomain(req.AuthMethodType),
+		PostLogoutRedirectUris:           req.PostLogoutRedirectUris,
+		DevMode:                          req.DevMode,
+		AccessTokenType:                  app_grpc.OIDCTokenTypeToDomain(req.AccessTokenType),
+		AccessTokenRoleAssertion:         req.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:             req.IdTokenRoleAssertion,
+		IDTokenUserinfoAssertion:         req.IdTokenUserinfoAssertion,
+		ClockSkew:                        req.ClockSkew.AsDuration(),
+		AdditionalOrigins:                req.AdditionalOrigins,
+		SkipNativeAppSuccessPage:         req.SkipNativeAppSuccessPage,
+		BackChannelLogoutURI:             req.BackChannelLogoutUri,
+		BackChannelLogoutSessionRequired: req.BackChannelLogoutSessionRequired,
+		RequirePushedAuthRequests:        req.RequirePushedAuthRequests,
+		RequireDPoP:                      req.RequireDPoP,
+		TLSClientAuthSubjectDN:           req.TlsClientAuthSubjectDn,
 	}
 }
 
@@ -93,21 +98,26 @@ func UpdateOIDCAppConfigRequestToDomain(app *mgmt_pb.UpdateOIDCAppConfigRequest)
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: app.ProjectId,
 		},
-		AppID:                    app.AppId,
-		RedirectUris:             app.RedirectUris,
-		ResponseTypes:            app_grpc.OIDCResponseTypesToDomain(app.ResponseTypes),
-		GrantTypes:               app_grpc.OIDCGrantTypesToDomain(app.GrantTypes),
-		ApplicationType:          app_grpc.OIDCApplicationTypeToDomain(app.AppType),
-		AuthMethodType:           app_grpc.OIDCAuthMethodTypeToDomain(app.AuthMethodType),
-		PostLogoutRedirectUris:   app.PostLogoutRedirectUris,
-		DevMode:                  app.DevMode,
-		AccessTokenType:          app_grpc.OIDCTokenTypeToDomain(app.AccessTokenType),
-		AccessTokenRoleAssertion: app.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     app.IdTokenRoleAssertion,
-		IDTokenUserinfoAssertion: app.IdTokenUserinfoAssertion,
-		ClockSkew:                app.ClockSkew.AsDuration(),
-		AdditionalOrigins:        app.AdditionalOrigins,
-		SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+		AppID:                            app.AppId,
+		RedirectUris:                     app.RedirectUris,
+		ResponseTypes:                    app_grpc.OIDCResponseTypesToDomain(app.ResponseTypes),
+		GrantTypes:                       app_grpc.OIDCGrantTypesToDomain(app.GrantTypes),
+		ApplicationType:                  app_grpc.OIDCApplicationTypeToDomain(app.AppType),
+		AuthMethodType:                   app_grpc.OIDCAuthMethodTypeToDomain(app.AuthMethodType),
+		PostLogoutRedirectUris:           app.PostLogoutRedirectUris,
+		DevMode:                          app.DevMode,
+		AccessTokenType:                  app_grpc.OIDCTokenTypeToDomain(app.AccessTokenType),
+		AccessTokenRoleAssertion:         app.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:             app.IdTokenRoleAssertion,
+		IDTokenUserinfoAssertion:         app.IdTokenUserinfoAssertion,
+		ClockSkew:                        app.ClockSkew.AsDuration(),
+		AdditionalOrigins:                app.AdditionalOrigins,
+		SkipNativeAppSuccessPage:         app.SkipNativeAppSuccessPage,
+		BackChannelLogoutURI:             app.BackChannelLogoutUri,
+		BackChannelLogoutSessionRequired: app.BackChannelLogoutSessionRequired,
+		RequirePushedAuthRequests:        app.RequirePushedAuthRequests,
+		RequireDPoP:                      app.RequireDPoP,
+		TLSClientAuthSubjectDN:           app.TlsClientAuthSubjectDn,
 	}
 }
 
@@ -70,3 +70,20 @@ func TextMethodToQuery(method object.TextQueryMethod) query.TextComparison {
 		return -1
 	}
 }
+
+func TimestampMethodToQuery(method object.TimestampQueryMethod) query.TimestampComparison {
+	switch method {
+	case object.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_EQUALS:
+		return query.TimestampEquals
+	case object.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_GREATER:
+		return query.TimestampGreater
+	case object.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_GREATER_OR_EQUALS:
+		return query.TimestampGreaterOrEquals
+	case object.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_LESS:
+		return query.TimestampLess
+	case object.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_LESS_OR_EQUALS:
+		return query.TimestampLessOrEquals
+	default:
+		return -1
+	}
+}
@@ -42,25 +42,30 @@ func AppConfigToPb(app *query.App) app_pb.AppConfig {
 func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 	return &app_pb.App_OidcConfig{
 		OidcConfig: &app_pb.OIDCConfig{
-			RedirectUris:             app.RedirectURIs,
-			ResponseTypes:            OIDCResponseTypesFromModel(app.ResponseTypes),
-			GrantTypes:               OIDCGrantTypesFromModel(app.GrantTypes),
-			AppType:                  OIDCApplicationTypeToPb(app.AppType),
-			ClientId:                 app.ClientID,
-			AuthMethodType:           OIDCAuthMethodTypeToPb(app.AuthMethodType),
-			PostLogoutRedirectUris:   app.PostLogoutRedirectURIs,
-			Version:                  OIDCVersionToPb(domain.OIDCVersion(app.Version)),
-			NoneCompliant:            len(app.ComplianceProblems) != 0,
-			ComplianceProblems:       ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
-			DevMode:                  app.IsDevMode,
-			AccessTokenType:          oidcTokenTypeToPb(app.AccessTokenType),
-			AccessTokenRoleAssertion: app.AssertAccessTokenRole,
-			IdTokenRoleAssertion:     app.AssertIDTokenRole,
-			IdTokenUserinfoAssertion: app.AssertIDTokenUserinfo,
-			ClockSkew:                durationpb.New(app.ClockSkew),
-			AdditionalOrigins:        app.AdditionalOrigins,
-			AllowedOrigins:           app.AllowedOrigins,
-			SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+			RedirectUris:                     app.RedirectURIs,
+			ResponseTypes:                    OIDCResponseTypesFromModel(app.ResponseTypes),
+			GrantTypes:                       OIDCGrantTypesFromModel(app.GrantTypes),
+			AppType:                          OIDCApplicationTypeToPb(app.AppType),
+			ClientId:                         app.ClientID,
+			AuthMethodType:                   OIDCAuthMethodTypeToPb(app.AuthMethodType),
+			PostLogoutRedirectUris:           app.PostLogoutRedirectURIs,
+			Version:                          OIDCVersionToPb(domain.OIDCVersion(app.Version)),
+			NoneCompliant:                    len(app.ComplianceProblems) != 0,
+			ComplianceProblems:               ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
+			DevMode:                          app.IsDevMode,
+			AccessTokenType:                  oidcTokenTypeToPb(app.AccessTokenType),
+			AccessTokenRoleAssertion:         app.AssertAccessTokenRole,
+			IdTokenRoleAssertion:             app.AssertIDTokenRole,
+			IdTokenUserinfoAssertion:         app.AssertIDTokenUserinfo,
+			ClockSkew:                        durationpb.New(app.ClockSkew),
+			AdditionalOrigins:                app.AdditionalOrigins,
+			AllowedOrigins:                   app.AllowedOrigins,
+			SkipNativeAppSuccessPage:         app.SkipNativeAppSuccessPage,
+			BackChannelLogoutUri:             app.BackChannelLogoutURI,
+			BackChannelLogoutSessionRequired: app.BackChannelLogoutSessionRequired,
+			RequirePushedAuthRequests:        app.RequirePushedAuthRequests,
+			RequireDPoP:                      app.RequireDPoP,
+			TlsClientAuthSubjectDn:           app.TLSClientAuthSubjectDN,
 		},
 	}
 }
@@ -202,6 +207,10 @@ func OIDCAuthMethodTypeToPb(authType domain.OIDCAuthMethodType) app_pb.OIDCAuthM
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_NONE
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_TLS_CLIENT_AUTH
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_SELF_SIGNED_TLS_CLIENT_AUTH
 	default:
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_BASIC
 	}
@@ -217,6 +226,10 @@ func OIDCAuthMethodTypeToDomain(authType app_pb.OIDCAuthMethodType) domain.OIDCA
 		return domain.OIDCAuthMethodTypeNone
 	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT:
 		return domain.OIDCAuthMethodTypePrivateKeyJWT
+	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_TLS_CLIENT_AUTH:
+		return domain.OIDCAuthMethodTypeTLSClientAuth
+	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_SELF_SIGNED_TLS_CLIENT_AUTH:
+		return domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth
 	default:
 		return domain.OIDCAuthMethodTypeBasic
 	}
@@ -33,6 +33,7 @@ const (
 var (
 	customHeaders = []string{
 		"x-zitadel-",
+		http_utils.DPoP,
 	}
 	jsonMarshaler = &runtime.JSONPb{
 		UnmarshalOptions: protojson.UnmarshalOptions{
@@ -201,6 +202,7 @@ func addInterceptors(
 ) http.Handler {
 	handler = http_mw.CallDurationHandler(handler)
 	handler = http1Host(handler, http1HostName)
+	handler = dpopRequest(handler)
 	handler = http_mw.CORSInterceptor(handler)
 	handler = http_mw.RobotsTagHandler(handler)
 	handler = http_mw.DefaultTelemetryHandler(handler)
@@ -227,6 +229,18 @@ func http1Host(next http.Handler, http1HostName string) http.Handler {
 	})
 }
 
+// dpopRequest passes the method and uri of the request to the grpc server,
+// so the DPoP proof can be verified against the called REST endpoint.
+// The gateway key proves to the grpc server that the values were not set by the client.
+func dpopRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set(http_utils.ZitadelDPoPMethod, r.Method)
+		r.Header.Set(http_utils.ZitadelDPoPURI, http_utils.ComposedOrigin(r.Context())+r.RequestURI)
+		r.Header.Set(http_utils.ZitadelDPoPGateway, middleware.DPoPGatewayKey())
+		next.ServeHTTP(w, r)
+	})
+}
+
 func exhaustedCookieInterceptor(
 	next http.Handler,
 	accessInterceptor *http_mw.AccessInterceptor,
@@ -2,7 +2,11 @@ package middleware
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 
+	"github.com/zitadel/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -13,6 +17,22 @@ import (
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
+// dpopGatewayKey is generated on startup and only known to the gateway of this process,
+// so the DPoP request headers of direct grpc calls are not trusted
+var dpopGatewayKey = newDPoPGatewayKey()
+
+func newDPoPGatewayKey() string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	logging.OnError(err).Fatal("unable to generate dpop gateway key")
+	return base64.RawURLEncoding.EncodeToString(key)
+}
+
+// DPoPGatewayKey returns the key the gateway has to send with the DPoP request headers
+func DPoPGatewayKey() string {
+	return dpopGatewayKey
+}
+
 func AuthorizationInterceptor(verifier authz.APITokenVerifier, authConfig authz.Config) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		return authorize(ctx, req, info, handler, verifier, authConfig)
@@ -33,6 +53,8 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 		return nil, status.Error(codes.Unauthenticated, "auth header missing")
 	}
 
+	dpopMethod, dpopURI := dpopRequest(authCtx, info.FullMethod)
+	authCtx = authz.WithDPoPProof(authCtx, grpc_util.GetHeader(authCtx, http.DPoP), dpopMethod, dpopURI)
 	orgID, orgDomain := orgIDAndDomainFromRequest(authCtx, req)
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, req, authToken, orgID, orgDomain, verifier, authConfig, authOpt, info.FullMethod)
 	if err != nil {
@@ -55,6 +77,19 @@ func orgIDAndDomainFromRequest(ctx context.Context, req interface{}) (id, domain
 	return orgID, domain
 }
 
+// dpopRequest returns the http method and uri of the gateway request.
+// The headers are only trusted if they were sent with the key of the gateway,
+// otherwise POST, which is used by grpc for every call, and the uri of the called grpc method are returned.
+func dpopRequest(ctx context.Context, fullMethod string) (method, uri string) {
+	method = grpc_util.GetHeader(ctx, http.ZitadelDPoPMethod)
+	uri = grpc_util.GetHeader(ctx, http.ZitadelDPoPURI)
+	gatewayKey := grpc_util.GetHeader(ctx, http.ZitadelDPoPGateway)
+	if method != "" && uri != "" && subtle.ConstantTimeCompare([]byte(gatewayKey), []byte(dpopGatewayKey)) == 1 {
+		return method, uri
+	}
+	return "POST", http.ComposedOrigin(ctx) + fullMethod
+}
+
 type Organization struct {
 	ID     string
 	Domain string
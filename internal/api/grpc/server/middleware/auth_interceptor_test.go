@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -263,3 +264,87 @@ func Test_authorize(t *testing.T) {
 		})
 	}
 }
+
+func Test_dpopRequest(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		fullMethod string
+	}
+	type res struct {
+		method string
+		uri    string
+	}
+	origin := http.WithComposedOrigin(context.Background(), "https://zitadel.cloud")
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			"grpc call",
+			args{
+				ctx:        metadata.NewIncomingContext(origin, nil),
+				fullMethod: "/zitadel.auth.v1.AuthService/GetMyUser",
+			},
+			res{
+				method: "POST",
+				uri:    "https://zitadel.cloud/zitadel.auth.v1.AuthService/GetMyUser",
+			},
+		},
+		{
+			"grpc call with gateway headers, ignored",
+			args{
+				ctx: metadata.NewIncomingContext(origin, metadata.Pairs(
+					http.ZitadelDPoPMethod, "GET",
+					http.ZitadelDPoPURI, "https://zitadel.cloud/auth/v1/users/me",
+				)),
+				fullMethod: "/zitadel.auth.v1.AuthService/GetMyUser",
+			},
+			res{
+				method: "POST",
+				uri:    "https://zitadel.cloud/zitadel.auth.v1.AuthService/GetMyUser",
+			},
+		},
+		{
+			"grpc call with wrong gateway key, ignored",
+			args{
+				ctx: metadata.NewIncomingContext(origin, metadata.Pairs(
+					http.ZitadelDPoPMethod, "GET",
+					http.ZitadelDPoPURI, "https://zitadel.cloud/auth/v1/users/me",
+					http.ZitadelDPoPGateway, "wrong",
+				)),
+				fullMethod: "/zitadel.auth.v1.AuthService/GetMyUser",
+			},
+			res{
+				method: "POST",
+				uri:    "https://zitadel.cloud/zitadel.auth.v1.AuthService/GetMyUser",
+			},
+		},
+		{
+			"gateway call",
+			args{
+				ctx: metadata.NewIncomingContext(origin, metadata.Pairs(
+					http.ZitadelDPoPMethod, "GET",
+					http.ZitadelDPoPURI, "https://zitadel.cloud/auth/v1/users/me",
+					http.ZitadelDPoPGateway, DPoPGatewayKey(),
+				)),
+				fullMethod: "/zitadel.auth.v1.AuthService/GetMyUser",
+			},
+			res{
+				method: "GET",
+				uri:    "https://zitadel.cloud/auth/v1/users/me",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, uri := dpopRequest(tt.args.ctx, tt.args.fullMethod)
+			if method != tt.res.method {
+				t.Errorf("dpopRequest() method = %v, want %v", method, tt.res.method)
+			}
+			if uri != tt.res.uri {
+				t.Errorf("dpopRequest() uri = %v, want %v", uri, tt.res.uri)
+			}
+		})
+	}
+}
@@ -26,6 +26,8 @@ type mockExecutionTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	SigningKey       string
+	Auth             *domain.TargetAuth
 }
 
 func (e *mockExecutionTarget) SetEndpoint(endpoint string) {
@@ -49,6 +51,12 @@ func (e *mockExecutionTarget) GetTargetID() string {
 func (e *mockExecutionTarget) GetExecutionID() string {
 	return e.ExecutionID
 }
+func (e *mockExecutionTarget) GetSigningKey() string {
+	return e.SigningKey
+}
+func (e *mockExecutionTarget) GetAuth() *domain.TargetAuth {
+	return e.Auth
+}
 
 type mockContentRequest struct {
 	Content string
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ExecutionSimulation is the result of [SimulateExecution]
+type ExecutionSimulation struct {
+	RequestCalls  []*execution.SimulatedCall
+	Request       interface{}
+	ResponseCalls []*execution.SimulatedCall
+	Response      interface{}
+	Interrupted   bool
+}
+
+// SimulateExecution calls the targets of the executions of the full method with the same information as the [ExecutionHandler],
+// but the API call itself is not handled.
+// The targets of the response executions are only called if a response is provided and the request executions are not interrupted.
+func SimulateExecution(ctx context.Context, queries ExecutionQueries, fullMethod string, req, resp interface{}) *ExecutionSimulation {
+	ctx, span := tracing.NewSpan(ctx)
+	defer span.End()
+
+	requestTargets, responseTargets := queryTargets(ctx, queries, fullMethod)
+	ctxData := authz.GetCtxData(ctx)
+
+	simulation := new(ExecutionSimulation)
+	simulation.RequestCalls, simulation.Request, simulation.Interrupted = execution.SimulateTargets(ctx, requestTargets, &ContextInfoRequest{
+		FullMethod: fullMethod,
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+		ProjectID:  ctxData.ProjectID,
+		OrgID:      ctxData.OrgID,
+		UserID:     ctxData.UserID,
+		Request:    req,
+	})
+	if simulation.Interrupted || resp == nil {
+		return simulation
+	}
+
+	simulation.ResponseCalls, simulation.Response, simulation.Interrupted = execution.SimulateTargets(ctx, responseTargets, &ContextInfoResponse{
+		FullMethod: fullMethod,
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+		ProjectID:  ctxData.ProjectID,
+		OrgID:      ctxData.OrgID,
+		UserID:     ctxData.UserID,
+		Request:    simulation.Request,
+		Response:   resp,
+	})
+	return simulation
+}
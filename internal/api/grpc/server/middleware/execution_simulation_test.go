@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockExecutionQueries struct {
+	targets []*query.ExecutionTarget
+}
+
+func (m *mockExecutionQueries) TargetsByExecutionIDs(context.Context, []string, []string) ([]*query.ExecutionTarget, error) {
+	return m.targets, nil
+}
+
+func TestSimulateExecution(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+		switch r.URL.Path {
+		case "/request":
+			_, _ = io.WriteString(w, `{"Content":"changed request"}`)
+		case "/response":
+			_, _ = io.WriteString(w, `{"Content":"changed response"}`)
+		default:
+			http.Error(w, "error", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	type args struct {
+		targets []*query.ExecutionTarget
+		resp    interface{}
+	}
+	type res struct {
+		bodies        []string
+		requestCalls  int
+		request       interface{}
+		responseCalls int
+		response      interface{}
+		interrupted   bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			"request and response",
+			args{
+				targets: []*query.ExecutionTarget{
+					{ExecutionID: "request/service/method", TargetID: "request", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/request", Timeout: time.Second},
+					{ExecutionID: "response/service/method", TargetID: "response", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/response", Timeout: time.Second},
+				},
+				resp: newMockContentRequest("response"),
+			},
+			res{
+				bodies: []string{
+					`{"fullMethod":"/service/method","request":{"Content":"request"}}`,
+					`{"fullMethod":"/service/method","request":{"Content":"changed request"},"response":{"Content":"response"}}`,
+				},
+				requestCalls:  1,
+				request:       newMockContentRequest("changed request"),
+				responseCalls: 1,
+				response:      newMockContentRequest("changed response"),
+			},
+		},
+		{
+			"no response, response targets not called",
+			args{
+				targets: []*query.ExecutionTarget{
+					{ExecutionID: "request/service/method", TargetID: "request", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/request", Timeout: time.Second},
+					{ExecutionID: "response/service/method", TargetID: "response", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/response", Timeout: time.Second},
+				},
+			},
+			res{
+				bodies: []string{
+					`{"fullMethod":"/service/method","request":{"Content":"request"}}`,
+				},
+				requestCalls: 1,
+				request:      newMockContentRequest("changed request"),
+			},
+		},
+		{
+			"request interrupted, response targets not called",
+			args{
+				targets: []*query.ExecutionTarget{
+					{ExecutionID: "request/service/method", TargetID: "request", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/error", Timeout: time.Second, InterruptOnError: true},
+					{ExecutionID: "response/service/method", TargetID: "response", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/response", Timeout: time.Second},
+				},
+				resp: newMockContentRequest("response"),
+			},
+			res{
+				bodies: []string{
+					`{"fullMethod":"/service/method","request":{"Content":"request"}}`,
+				},
+				requestCalls: 1,
+				interrupted:  true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodies = nil
+			got := SimulateExecution(context.Background(), &mockExecutionQueries{targets: tt.args.targets}, "/service/method", newMockContentRequest("request"), tt.args.resp)
+			assert.Equal(t, tt.res.bodies, bodies)
+			assert.Len(t, got.RequestCalls, tt.res.requestCalls)
+			assert.Equal(t, tt.res.request, got.Request)
+			assert.Len(t, got.ResponseCalls, tt.res.responseCalls)
+			assert.Equal(t, tt.res.response, got.Response)
+			assert.Equal(t, tt.res.interrupted, got.Interrupted)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamInterceptor runs the unary interceptor before a server streaming handler.
+// The interceptor is called without request,
+// the context it passes to its handler is used for the stream.
+// Client streaming methods are not supported.
+func StreamInterceptor(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.IsClientStream {
+			return status.Error(codes.Unimplemented, "client streaming is not supported")
+		}
+		_, err := interceptor(
+			stream.Context(),
+			nil,
+			&grpc.UnaryServerInfo{Server: srv, FullMethod: info.FullMethod},
+			func(ctx context.Context, _ interface{}) (interface{}, error) {
+				wrapped := grpc_middleware.WrapServerStream(stream)
+				wrapped.WrappedContext = ctx
+				return nil, handler(srv, wrapped)
+			},
+		)
+		return err
+	}
+}
+
+// StreamInterceptors maps the unary interceptors to stream interceptors using [StreamInterceptor].
+func StreamInterceptors(interceptors ...grpc.UnaryServerInterceptor) []grpc.StreamServerInterceptor {
+	streamInterceptors := make([]grpc.StreamServerInterceptor, len(interceptors))
+	for i, interceptor := range interceptors {
+		streamInterceptors[i] = StreamInterceptor(interceptor)
+	}
+	return streamInterceptors
+}
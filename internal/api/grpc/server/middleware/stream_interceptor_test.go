@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ctxKey struct{}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	setValue := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(context.WithValue(ctx, ctxKey{}, info.FullMethod), req)
+	}
+	deny := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	}
+
+	tests := []struct {
+		name        string
+		interceptor grpc.UnaryServerInterceptor
+		info        *grpc.StreamServerInfo
+		wantValue   interface{}
+		wantCode    codes.Code
+	}{
+		{
+			name:        "context passed to stream",
+			interceptor: setValue,
+			info:        &grpc.StreamServerInfo{FullMethod: "/service/Watch", IsServerStream: true},
+			wantValue:   "/service/Watch",
+		},
+		{
+			name:        "interceptor error",
+			interceptor: deny,
+			info:        &grpc.StreamServerInfo{FullMethod: "/service/Watch", IsServerStream: true},
+			wantCode:    codes.PermissionDenied,
+		},
+		{
+			name:        "client stream",
+			interceptor: setValue,
+			info:        &grpc.StreamServerInfo{FullMethod: "/service/Upload", IsClientStream: true},
+			wantCode:    codes.Unimplemented,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotValue interface{}
+			err := StreamInterceptor(tt.interceptor)(nil, &mockServerStream{ctx: context.Background()}, tt.info, func(_ interface{}, stream grpc.ServerStream) error {
+				gotValue = stream.Context().Value(ctxKey{})
+				return nil
+			})
+			if tt.wantCode != codes.OK {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValue, gotValue)
+		})
+	}
+}
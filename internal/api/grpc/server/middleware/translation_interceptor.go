@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/zitadel/logging"
 	"google.golang.org/grpc"
 
@@ -36,6 +37,33 @@ func TranslationHandler() func(ctx context.Context, req interface{}, info *grpc.
 	}
 }
 
+// TranslationStreamHandler translates the messages sent by a server stream
+// errors are translated by the [TranslationHandler] passed to [StreamInterceptor]
+func TranslationStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &translatedServerStream{WrappedServerStream: grpc_middleware.WrapServerStream(stream)})
+	}
+}
+
+type translatedServerStream struct {
+	*grpc_middleware.WrappedServerStream
+	translator *i18n.Translator
+}
+
+func (s *translatedServerStream) SendMsg(m interface{}) error {
+	if loc, ok := m.(localizers); ok && m != nil {
+		if s.translator == nil {
+			translator, err := getTranslator(s.Context())
+			if err != nil {
+				return s.WrappedServerStream.SendMsg(m)
+			}
+			s.translator = translator
+		}
+		translateFields(s.Context(), loc, s.translator)
+	}
+	return s.WrappedServerStream.SendMsg(m)
+}
+
 func getTranslator(ctx context.Context) (*i18n.Translator, error) {
 	translator, err := i18n.NewZitadelTranslator(authz.GetInstance(ctx).DefaultLanguage())
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	//import to make sure go.mod does not lose it
 	//because dependency is only needed for generated code
 	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -17,6 +18,31 @@ func ValidationHandler() grpc.UnaryServerInterceptor {
 	}
 }
 
+// ValidationStreamHandler validates the messages received by a stream
+func ValidationStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatedServerStream{WrappedServerStream: grpc_middleware.WrapServerStream(stream)})
+	}
+}
+
+type validatedServerStream struct {
+	*grpc_middleware.WrappedServerStream
+}
+
+func (s *validatedServerStream) RecvMsg(m interface{}) error {
+	if err := s.WrappedServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	validate, ok := m.(validator)
+	if !ok {
+		return nil
+	}
+	if err := validate.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
 // validator interface needed for github.com/envoyproxy/protoc-gen-validate
 // (it does not expose an interface itself)
 type validator interface {
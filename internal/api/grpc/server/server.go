@@ -64,6 +64,23 @@ func CreateServer(
 				middleware.ActivityInterceptor(),
 			),
 		),
+		grpc.StreamInterceptor(
+			grpc_middleware.ChainStreamServer(
+				append(
+					middleware.StreamInterceptors(
+						middleware.DefaultTracingServer(),
+						middleware.InstanceInterceptor(queries, hostHeaderName, externalDomain, system_pb.SystemService_ServiceDesc.ServiceName, healthpb.Health_ServiceDesc.ServiceName),
+						middleware.ErrorHandler(),
+						middleware.LimitsInterceptor(system_pb.SystemService_ServiceDesc.ServiceName),
+						middleware.AuthorizationInterceptor(verifier, authConfig),
+						middleware.TranslationHandler(),
+						middleware.ServiceHandler(),
+					),
+					middleware.TranslationStreamHandler(),
+					middleware.ValidationStreamHandler(),
+				)...,
+			),
+		),
 	}
 	if tlsConfig != nil {
 		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
@@ -0,0 +1,33 @@
+package system
+
+import (
+	event_grpc "github.com/zitadel/zitadel/internal/api/grpc/event"
+	"github.com/zitadel/zitadel/internal/query"
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func (s *Server) WatchEvents(in *system_pb.WatchEventsRequest, stream system_pb.SystemService_WatchEventsServer) error {
+	return s.query.WatchEvents(stream.Context(), watchEventsRequestToQuery(in), func(event *query.WatchedEvent) error {
+		res, err := event_grpc.EventToPb(event.Event)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&system_pb.WatchEventsResponse{
+			InstanceId: event.Aggregate.InstanceID,
+			Event:      res,
+			Position:   event_grpc.PositionToPb(event.Position),
+		})
+	})
+}
+
+func watchEventsRequestToQuery(req *system_pb.WatchEventsRequest) *query.WatchEventsQuery {
+	return &query.WatchEventsQuery{
+		InstanceIDs:    req.GetInstanceIds(),
+		From:           event_grpc.PositionFromPb(req.GetPosition()),
+		AggregateTypes: req.GetAggregateTypes(),
+		AggregateID:    req.GetAggregateId(),
+		EventTypes:     req.GetEventTypes(),
+		EditorUserID:   req.GetEditorUserId(),
+		ResourceOwner:  req.GetResourceOwner(),
+	}
+}
@@ -34,6 +34,8 @@ func instanceQuotaUnitPbToCommand(unit quota.Unit) command.QuotaUnit {
 		return command.QuotaRequestsAllAuthenticated
 	case quota.Unit_UNIT_ACTIONS_ALL_RUN_SECONDS:
 		return command.QuotaActionsAllRunsSeconds
+	case quota.Unit_UNIT_ACTIONS_TARGET_CALLS:
+		return command.QuotaActionsTargetCalls
 	case quota.Unit_UNIT_UNIMPLEMENTED:
 		fallthrough
 	default:
@@ -3,7 +3,11 @@ package system
 import (
 	"context"
 
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
 )
 
@@ -22,3 +26,26 @@ func (s *Server) ClearView(ctx context.Context, req *system_pb.ClearViewRequest)
 	}
 	return &system_pb.ClearViewResponse{}, nil
 }
+
+func (s *Server) RebuildView(ctx context.Context, req *system_pb.RebuildViewRequest) (*system_pb.RebuildViewResponse, error) {
+	if err := projection.CheckProjectionNames(req.ViewName); err != nil {
+		return nil, err
+	}
+	go func() {
+		err := projection.Rebuild(context.WithoutCancel(ctx), req.ViewName)
+		logging.WithFields("view", req.ViewName).OnError(err).Error("rebuild of view failed")
+	}()
+	return &system_pb.RebuildViewResponse{}, nil
+}
+
+func (s *Server) ListProjectionStates(ctx context.Context, req *system_pb.ListProjectionStatesRequest) (*system_pb.ListProjectionStatesResponse, error) {
+	result := make([]*system_pb.ProjectionState, 0, len(req.GetInstanceIds()))
+	for _, instanceID := range req.GetInstanceIds() {
+		lags, err := projection.Lags(authz.WithInstanceID(ctx, instanceID), req.GetProjectionNames()...)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ProjectionLagsToPb(s.database, lags)...)
+	}
+	return &system_pb.ListProjectionStatesResponse{Result: result}, nil
+}
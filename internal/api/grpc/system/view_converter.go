@@ -1,8 +1,10 @@
 package system
 
 import (
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
 )
@@ -23,3 +25,26 @@ func CurrentSequenceToPb(database string, currentSequence *query.CurrentState) *
 		LastSuccessfulSpoolerRun: timestamppb.New(currentSequence.LastRun),
 	}
 }
+
+func ProjectionLagsToPb(database string, lags []*handler.Lag) []*system_pb.ProjectionState {
+	states := make([]*system_pb.ProjectionState, len(lags))
+	for i, lag := range lags {
+		states[i] = ProjectionLagToPb(database, lag)
+	}
+	return states
+}
+
+func ProjectionLagToPb(database string, lag *handler.Lag) *system_pb.ProjectionState {
+	return &system_pb.ProjectionState{
+		Database:       database,
+		ProjectionName: lag.ProjectionName,
+		InstanceId:     lag.InstanceID,
+		Position:       lag.Position,
+		EventTimestamp: timestamppb.New(lag.EventTimestamp),
+		LastRun:        timestamppb.New(lag.LastRun),
+		EventLag:       lag.Events,
+		TimeLag:        durationpb.New(lag.Time),
+		BulkDuration:   durationpb.New(lag.BulkDuration),
+		FailedEvents:   lag.FailedEvents,
+	}
+}
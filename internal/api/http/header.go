@@ -27,6 +27,7 @@ const (
 	IfNoneMatch     = "If-None-Match"
 	LastModified    = "Last-Modified"
 	Etag            = "Etag"
+	DPoP            = "dpop"
 
 	ContentSecurityPolicy   = "content-security-policy"
 	XXSSProtection          = "x-xss-protection"
@@ -38,6 +39,12 @@ const (
 	PermissionsPolicy       = "permissions-policy"
 
 	ZitadelOrgID = "x-zitadel-orgid"
+	// ZitadelDPoPMethod and ZitadelDPoPURI pass the http method and uri of a gateway request to the grpc server,
+	// which are needed to verify the DPoP proof of the request
+	ZitadelDPoPMethod = "x-zitadel-dpop-htm"
+	ZitadelDPoPURI    = "x-zitadel-dpop-htu"
+	// ZitadelDPoPGateway proves that ZitadelDPoPMethod and ZitadelDPoPURI were set by the gateway and not by the client
+	ZitadelDPoPGateway = "x-zitadel-dpop-gateway"
 )
 
 type key int
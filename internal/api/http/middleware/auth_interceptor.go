@@ -62,6 +62,7 @@ func authorize(r *http.Request, verifier authz.APITokenVerifier, authConfig auth
 		return nil, errors.New("auth header missing")
 	}
 
+	authCtx = authz.WithDPoPProof(authCtx, r.Header.Get(http_util.DPoP), r.Method, http_util.ComposedOrigin(ctx)+r.RequestURI)
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, &httpReq{}, authToken, http_util.GetOrgID(r), "", verifier, authConfig, authOpt, r.RequestURI)
 	if err != nil {
 		return nil, err
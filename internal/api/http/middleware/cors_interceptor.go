@@ -21,6 +21,7 @@ var (
 			http_utils.XUserAgent,
 			http_utils.XGrpcWeb,
 			http_utils.XRequestedWith,
+			http_utils.DPoP,
 		},
 		AllowedMethods: []string{
 			http.MethodOptions,
@@ -35,6 +35,9 @@ type accessToken struct {
 	tokenExpiration   time.Time
 	isPAT             bool
 	actor             *domain.TokenActor
+	dpopJKT           string
+	// certificateThumbprint is the x5t#S256 of the client certificate the token is bound to
+	certificateThumbprint string
 }
 
 var ErrInvalidTokenFormat = errors.New("invalid token format")
@@ -98,19 +101,21 @@ func accessTokenV1(tokenID, subject string, token *model.TokenView) *accessToken
 
 func accessTokenV2(tokenID, subject string, token *query.OIDCSessionAccessTokenReadModel) *accessToken {
 	return &accessToken{
-		tokenID:           tokenID,
-		userID:            token.UserID,
-		resourceOwner:     token.ResourceOwner,
-		subject:           subject,
-		preferredLanguage: token.PreferredLanguage,
-		clientID:          token.ClientID,
-		audience:          token.Audience,
-		scope:             token.Scope,
-		authMethods:       token.AuthMethods,
-		authTime:          token.AuthTime,
-		tokenCreation:     token.AccessTokenCreation,
-		tokenExpiration:   token.AccessTokenExpiration,
-		actor:             token.Actor,
+		tokenID:               tokenID,
+		userID:                token.UserID,
+		resourceOwner:         token.ResourceOwner,
+		subject:               subject,
+		preferredLanguage:     token.PreferredLanguage,
+		clientID:              token.ClientID,
+		audience:              token.Audience,
+		scope:                 token.Scope,
+		authMethods:           token.AuthMethods,
+		authTime:              token.AuthTime,
+		tokenCreation:         token.AccessTokenCreation,
+		tokenExpiration:       token.AccessTokenExpiration,
+		actor:                 token.Actor,
+		dpopJKT:               token.DPoPJKT,
+		certificateThumbprint: token.CertificateThumbprint,
 	}
 }
 
@@ -468,6 +468,8 @@ func (s *Server) CreateTokenCallbackURL(ctx context.Context, req op.AuthRequest)
 		req.GetID(),
 		implicitFlowComplianceChecker(),
 		slices.Contains(client.GrantTypes(), oidc.GrantTypeRefreshToken),
+		"",
+		"",
 	)
 	if err != nil {
 		return "", err
@@ -564,6 +566,8 @@ func (s *Server) authResponseToken(authReq *AuthRequest, authorizer op.Authorize
 		domain.TokenReasonAuthRequest,
 		nil,
 		slices.Contains(scope, oidc.ScopeOfflineAccess),
+		"",
+		"",
 	)
 	if err != nil {
 		op.AuthRequestError(w, r, authReq, err, authorizer)
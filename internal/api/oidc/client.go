@@ -384,7 +384,10 @@ func (o *OPStorage) setUserinfo(ctx context.Context, userInfo *oidc.UserInfo, us
 	}
 	o.setUserInfoRoleClaims(userInfo, projectRoles)
 
-	return o.userinfoFlows(ctx, user, userGrants, userInfo)
+	if err = o.userinfoFlows(ctx, user, userGrants, userInfo); err != nil {
+		return err
+	}
+	return o.userinfoExecutions(ctx, user, userGrants, userInfo)
 }
 
 func (o *OPStorage) setUserInfoProfile(ctx context.Context, userInfo *oidc.UserInfo, user *query.User) {
@@ -591,6 +594,38 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, user *query.User, userGra
 	return nil
 }
 
+func (o *OPStorage) userinfoExecutions(ctx context.Context, user *query.User, userGrants *query.UserGrants, userInfo *oidc.UserInfo) error {
+	claims, err := userInfoToClaims(userInfo)
+	if err != nil {
+		return err
+	}
+	getUserInfo := func() (*query.OIDCUserInfo, error) {
+		return o.executionUserInfo(ctx, user, userGrants)
+	}
+	return userinfoExecutions(ctx, o.query, o.command, domain.TriggerTypePreUserinfoCreation, getUserInfo, claims, userInfo.AppendClaims)
+}
+
+// executionUserInfo collects the information about the user sent to the targets of the executions
+func (o *OPStorage) executionUserInfo(ctx context.Context, user *query.User, userGrants *query.UserGrants) (*query.OIDCUserInfo, error) {
+	resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(user.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := o.query.SearchUserMetadata(ctx, true, user.ID, &query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	userMetadata := make([]query.UserMetadata, len(metadata.Metadata))
+	for i, md := range metadata.Metadata {
+		userMetadata[i] = *md
+	}
+	return &query.OIDCUserInfo{
+		User:       user,
+		Metadata:   userMetadata,
+		UserGrants: userGrantsToSlice(userGrants),
+	}, nil
+}
+
 func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clientID string, scopes []string) (claims map[string]interface{}, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() {
@@ -786,6 +821,15 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 		}
 	}
 
+	getUserInfo := func() (*query.OIDCUserInfo, error) {
+		return o.executionUserInfo(ctx, user, userGrants)
+	}
+	err = userinfoExecutions(ctx, o.query, o.command, domain.TriggerTypePreAccessTokenCreation, getUserInfo, claims, func(key string, value any) {
+		claims = appendClaim(claims, key, value)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return claims, nil
 }
 
@@ -979,7 +1023,8 @@ func (s *Server) VerifyClient(ctx context.Context, r *op.Request[op.ClientCreden
 	if err != nil {
 		return nil, err
 	}
-	client, err := s.query.GetOIDCClientByID(ctx, clientID, assertion)
+	// the keys are also needed to verify self-signed client certificates
+	client, err := s.query.GetOIDCClientByID(ctx, clientID, assertion || s.hasClientCertificate(r.Header))
 	if zerrors.IsNotFound(err) {
 		return nil, oidc.ErrInvalidClient().WithParent(err).WithDescription("client not found")
 	}
@@ -1001,6 +1046,8 @@ func (s *Server) VerifyClient(ctx context.Context, r *op.Request[op.ClientCreden
 		err = s.verifyClientSecret(ctx, client, r.Data.ClientSecret)
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		err = s.verifyClientAssertion(ctx, client, r.Data.ClientAssertion)
+	case domain.OIDCAuthMethodTypeTLSClientAuth, domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		err = s.verifyClientCertificate(ctx, client, r.Header)
 	case domain.OIDCAuthMethodTypeNone:
 	}
 	if err != nil {
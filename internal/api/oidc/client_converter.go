@@ -150,6 +150,10 @@ func authMethodToOIDC(authType domain.OIDCAuthMethodType) oidc.AuthMethod {
 		return oidc.AuthMethodNone
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		return oidc.AuthMethodPrivateKeyJWT
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		return authMethodTLSClientAuth
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		return authMethodSelfSignedTLSClientAuth
 	default:
 		return oidc.AuthMethodBasic
 	}
@@ -0,0 +1,478 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	clientIDParam = "client_id"
+
+	// error codes defined in https://www.rfc-editor.org/rfc/rfc7591#section-3.2.2
+	errorInvalidRedirectURI    = "invalid_redirect_uri"
+	errorInvalidClientMetadata = "invalid_client_metadata"
+	// error code defined in https://www.rfc-editor.org/rfc/rfc6750#section-3.1
+	errorInvalidToken = "invalid_token"
+
+	applicationTypeWeb    = "web"
+	applicationTypeNative = "native"
+)
+
+// clientMetadata contains the client metadata of https://www.rfc-editor.org/rfc/rfc7591#section-2
+// and of the OpenID Connect extensions supported by ZITADEL.
+// Metadata which is not supported is ignored as allowed by the RFC.
+type clientMetadata struct {
+	RedirectURIs                       []string            `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs             []string            `json:"post_logout_redirect_uris,omitempty"`
+	TokenEndpointAuthMethod            oidc.AuthMethod     `json:"token_endpoint_auth_method,omitempty"`
+	GrantTypes                         []oidc.GrantType    `json:"grant_types,omitempty"`
+	ResponseTypes                      []oidc.ResponseType `json:"response_types,omitempty"`
+	ApplicationType                    string              `json:"application_type,omitempty"`
+	ClientName                         string              `json:"client_name,omitempty"`
+	BackChannelLogoutURI               string              `json:"backchannel_logout_uri,omitempty"`
+	BackChannelLogoutSessionRequired   bool                `json:"backchannel_logout_session_required,omitempty"`
+	RequirePushedAuthorizationRequests bool                `json:"require_pushed_authorization_requests,omitempty"`
+	DPoPBoundAccessTokens              bool                `json:"dpop_bound_access_tokens,omitempty"`
+	TLSClientAuthSubjectDN             string              `json:"tls_client_auth_subject_dn,omitempty"`
+}
+
+// clientInformationResponse is defined in https://www.rfc-editor.org/rfc/rfc7591#section-3.2.1
+// and https://www.rfc-editor.org/rfc/rfc7592#section-3
+type clientInformationResponse struct {
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientSecretExpiresAt   *int64 `json:"client_secret_expires_at,omitempty"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+	clientMetadata
+}
+
+type clientUpdateRequest struct {
+	ClientID string `json:"client_id"`
+	clientMetadata
+}
+
+// clientRegistrationHandler implements the client registration endpoint (RFC 7591).
+// The application is added to the project the initial access token was issued for.
+func (s *Server) clientRegistrationHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.registerClient(r.Context(), r)
+	if err != nil {
+		op.WriteError(w, r, err, s.getLogger(r.Context()))
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, resp, http.StatusCreated)
+}
+
+// clientConfigurationHandler returns the registered metadata of the client (RFC 7592).
+func (s *Server) clientConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.clientConfiguration(r.Context(), r)
+	if err != nil {
+		op.WriteError(w, r, err, s.getLogger(r.Context()))
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, resp, http.StatusOK)
+}
+
+// clientUpdateHandler replaces the registered metadata of the client (RFC 7592).
+func (s *Server) clientUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.updateClient(r.Context(), r)
+	if err != nil {
+		op.WriteError(w, r, err, s.getLogger(r.Context()))
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, resp, http.StatusOK)
+}
+
+// clientDeleteHandler removes the application of the client (RFC 7592).
+func (s *Server) clientDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	if err := s.deleteClient(r.Context(), r); err != nil {
+		op.WriteError(w, r, err, s.getLogger(r.Context()))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) registerClient(ctx context.Context, r *http.Request) (_ *clientInformationResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		err = oidcError(err)
+		span.EndWithError(err)
+	}()
+
+	initialAccessToken, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+	metadata := new(clientMetadata)
+	if err = json.NewDecoder(r.Body).Decode(metadata); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding client metadata").WithParent(err)
+	}
+	app := &domain.OIDCApp{
+		OIDCVersion:     domain.OIDCVersionV1,
+		AccessTokenType: domain.OIDCTokenTypeBearer,
+	}
+	if err = metadata.applyTo(app); err != nil {
+		return nil, err
+	}
+	app, registrationAccessToken, err := s.command.RegisterOIDCApplication(ctx, initialAccessToken, app)
+	if zerrors.IsPermissionDenied(err) {
+		return nil, invalidTokenError(err)
+	}
+	if zerrors.IsErrorInvalidArgument(err) {
+		return nil, &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "invalid client metadata", Parent: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := s.clientInformation(ctx, app)
+	resp.RegistrationAccessToken = registrationAccessToken
+	if app.ClientSecretString != "" {
+		resp.ClientSecret = app.ClientSecretString
+		resp.ClientSecretExpiresAt = new(int64)
+	}
+	return resp, nil
+}
+
+func (s *Server) clientConfiguration(ctx context.Context, r *http.Request) (_ *clientInformationResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		err = oidcError(err)
+		span.EndWithError(err)
+	}()
+
+	app, _, err := s.registeredClient(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return s.clientInformation(ctx, oidcAppFromQuery(app)), nil
+}
+
+func (s *Server) updateClient(ctx context.Context, r *http.Request) (_ *clientInformationResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		err = oidcError(err)
+		span.EndWithError(err)
+	}()
+
+	app, resourceOwner, err := s.registeredClient(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	update := new(clientUpdateRequest)
+	if err = json.NewDecoder(r.Body).Decode(update); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding client metadata").WithParent(err)
+	}
+	if update.ClientID != app.OIDCConfig.ClientID {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id does not match")
+	}
+	changed := oidcAppFromQuery(app)
+	if err = update.applyTo(changed); err != nil {
+		return nil, err
+	}
+	if changed.AppName != app.Name {
+		_, err = s.command.ChangeApplication(ctx, app.ProjectID, &domain.ChangeApp{AppID: app.ID, AppName: changed.AppName}, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err = s.command.ChangeOIDCApplication(ctx, changed, resourceOwner)
+	if zerrors.IsErrorInvalidArgument(err) {
+		return nil, &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "invalid client metadata", Parent: err}
+	}
+	// the metadata is replaced as a whole, unchanged metadata is not an error
+	if err != nil && !zerrors.IsPreconditionFailed(err) {
+		return nil, err
+	}
+	resp := s.clientInformation(ctx, changed)
+	// clients switching to a secret based authentication method need a secret
+	if requiresClientSecret(changed.AuthMethodType) && !requiresClientSecret(app.OIDCConfig.AuthMethodType) {
+		secret, err := s.command.ChangeOIDCApplicationSecret(ctx, app.ProjectID, app.ID, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		resp.ClientSecret = secret.ClientSecretString
+		resp.ClientSecretExpiresAt = new(int64)
+	}
+	return resp, nil
+}
+
+func (s *Server) deleteClient(ctx context.Context, r *http.Request) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		err = oidcError(err)
+		span.EndWithError(err)
+	}()
+
+	app, resourceOwner, err := s.registeredClient(ctx, r)
+	if err != nil {
+		return err
+	}
+	_, err = s.command.RemoveApplication(ctx, app.ProjectID, app.ID, resourceOwner)
+	return err
+}
+
+// registeredClient returns the application of the client in the path,
+// if the registration access token is valid for it.
+// As defined by RFC 7592, an unknown client is treated as an invalid token.
+func (s *Server) registeredClient(ctx context.Context, r *http.Request) (_ *query.App, resourceOwner string, err error) {
+	registrationAccessToken, err := bearerToken(r)
+	if err != nil {
+		return nil, "", err
+	}
+	app, err := s.query.AppByOIDCClientID(ctx, chi.URLParam(r, clientIDParam))
+	if zerrors.IsNotFound(err) {
+		return nil, "", invalidTokenError(err)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	resourceOwner, err = s.command.VerifyRegistrationAccessToken(ctx, app.ProjectID, app.ID, registrationAccessToken)
+	if err != nil {
+		return nil, "", invalidTokenError(err)
+	}
+	return app, resourceOwner, nil
+}
+
+func (s *Server) clientInformation(ctx context.Context, app *domain.OIDCApp) *clientInformationResponse {
+	return &clientInformationResponse{
+		ClientID:              app.ClientID,
+		RegistrationClientURI: s.registrationEndpoint.Absolute(op.IssuerFromContext(ctx)) + "/" + app.ClientID,
+		clientMetadata:        clientMetadataFromOIDCApp(app),
+	}
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), oidc.PrefixBearer)
+	if !ok || token == "" {
+		return "", invalidTokenError(nil)
+	}
+	return token, nil
+}
+
+func invalidTokenError(parent error) error {
+	return op.NewStatusError(&oidc.Error{ErrorType: errorInvalidToken, Description: "invalid or missing access token", Parent: parent}, http.StatusUnauthorized)
+}
+
+// applyTo sets the metadata on the app, defaults are set as defined in RFC 7591.
+// Properties of the app without corresponding metadata are kept.
+func (m *clientMetadata) applyTo(app *domain.OIDCApp) (err error) {
+	app.AppName = m.ClientName
+	if app.AppName == "" {
+		return &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "client_name is required"}
+	}
+	app.RedirectUris = m.RedirectURIs
+	app.PostLogoutRedirectUris = m.PostLogoutRedirectURIs
+	if app.AuthMethodType, err = authMethodFromOIDC(m.TokenEndpointAuthMethod); err != nil {
+		return err
+	}
+	if app.GrantTypes, err = grantTypesFromOIDC(m.GrantTypes); err != nil {
+		return err
+	}
+	if app.ResponseTypes, err = responseTypesFromOIDC(m.ResponseTypes); err != nil {
+		return err
+	}
+	if app.ApplicationType, err = applicationTypeFromOIDC(m.ApplicationType, app.AuthMethodType); err != nil {
+		return err
+	}
+	if err = validateBackChannelLogoutURI(m.BackChannelLogoutURI); err != nil {
+		return err
+	}
+	app.BackChannelLogoutURI = m.BackChannelLogoutURI
+	app.BackChannelLogoutSessionRequired = m.BackChannelLogoutSessionRequired
+	app.RequirePushedAuthRequests = m.RequirePushedAuthorizationRequests
+	app.RequireDPoP = m.DPoPBoundAccessTokens
+	app.TLSClientAuthSubjectDN = m.TLSClientAuthSubjectDN
+	// dynamically registered clients are not reviewed by anyone,
+	// so they must be compliant
+	compliance := domain.GetOIDCCompliance(app.OIDCVersion, app.ApplicationType, app.GrantTypes, app.ResponseTypes, app.AuthMethodType, app.RedirectUris)
+	if compliance.NoneCompliant && !app.DevMode {
+		return complianceError(compliance)
+	}
+	return nil
+}
+
+// validateBackChannelLogoutURI ensures ZITADEL only sends logout tokens of dynamically registered clients
+// to public https endpoints, so the registration can't be used to reach internal services.
+func validateBackChannelLogoutURI(uri string) error {
+	if uri == "" {
+		return nil
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" || parsed.Fragment != "" {
+		return &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "backchannel_logout_uri must be an absolute https uri without fragment"}
+	}
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "backchannel_logout_uri must not be a loopback address"}
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		ip = ip.Unmap()
+		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() || ip.IsMulticast() {
+			return &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "backchannel_logout_uri must not be a loopback or private address"}
+		}
+	}
+	return nil
+}
+
+func complianceError(compliance *domain.Compliance) error {
+	description := strings.Join(compliance.Problems, ", ")
+	if strings.Contains(description, "RedirectUris") {
+		return &oidc.Error{ErrorType: errorInvalidRedirectURI, Description: description}
+	}
+	return &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: description}
+}
+
+func clientMetadataFromOIDCApp(app *domain.OIDCApp) clientMetadata {
+	return clientMetadata{
+		RedirectURIs:                       app.RedirectUris,
+		PostLogoutRedirectURIs:             app.PostLogoutRedirectUris,
+		TokenEndpointAuthMethod:            authMethodToOIDC(app.AuthMethodType),
+		GrantTypes:                         grantTypesToOIDC(app.GrantTypes),
+		ResponseTypes:                      responseTypesToOIDC(app.ResponseTypes),
+		ApplicationType:                    applicationTypeToOIDC(app.ApplicationType),
+		ClientName:                         app.AppName,
+		BackChannelLogoutURI:               app.BackChannelLogoutURI,
+		BackChannelLogoutSessionRequired:   app.BackChannelLogoutSessionRequired,
+		RequirePushedAuthorizationRequests: app.RequirePushedAuthRequests,
+		DPoPBoundAccessTokens:              app.RequireDPoP,
+		TLSClientAuthSubjectDN:             app.TLSClientAuthSubjectDN,
+	}
+}
+
+func oidcAppFromQuery(app *query.App) *domain.OIDCApp {
+	return &domain.OIDCApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   app.ProjectID,
+			ResourceOwner: app.ResourceOwner,
+		},
+		AppID:                            app.ID,
+		AppName:                          app.Name,
+		ClientID:                         app.OIDCConfig.ClientID,
+		RedirectUris:                     app.OIDCConfig.RedirectURIs,
+		ResponseTypes:                    app.OIDCConfig.ResponseTypes,
+		GrantTypes:                       app.OIDCConfig.GrantTypes,
+		ApplicationType:                  app.OIDCConfig.AppType,
+		AuthMethodType:                   app.OIDCConfig.AuthMethodType,
+		PostLogoutRedirectUris:           app.OIDCConfig.PostLogoutRedirectURIs,
+		OIDCVersion:                      app.OIDCConfig.Version,
+		DevMode:                          app.OIDCConfig.IsDevMode,
+		AccessTokenType:                  app.OIDCConfig.AccessTokenType,
+		AccessTokenRoleAssertion:         app.OIDCConfig.AssertAccessTokenRole,
+		IDTokenRoleAssertion:             app.OIDCConfig.AssertIDTokenRole,
+		IDTokenUserinfoAssertion:         app.OIDCConfig.AssertIDTokenUserinfo,
+		ClockSkew:                        app.OIDCConfig.ClockSkew,
+		AdditionalOrigins:                app.OIDCConfig.AdditionalOrigins,
+		SkipNativeAppSuccessPage:         app.OIDCConfig.SkipNativeAppSuccessPage,
+		BackChannelLogoutURI:             app.OIDCConfig.BackChannelLogoutURI,
+		BackChannelLogoutSessionRequired: app.OIDCConfig.BackChannelLogoutSessionRequired,
+		RequirePushedAuthRequests:        app.OIDCConfig.RequirePushedAuthRequests,
+		RequireDPoP:                      app.OIDCConfig.RequireDPoP,
+		TLSClientAuthSubjectDN:           app.OIDCConfig.TLSClientAuthSubjectDN,
+		State:                            app.State,
+	}
+}
+
+func requiresClientSecret(authMethod domain.OIDCAuthMethodType) bool {
+	return authMethod == domain.OIDCAuthMethodTypeBasic || authMethod == domain.OIDCAuthMethodTypePost
+}
+
+func authMethodFromOIDC(authMethod oidc.AuthMethod) (domain.OIDCAuthMethodType, error) {
+	switch authMethod {
+	case "", oidc.AuthMethodBasic:
+		return domain.OIDCAuthMethodTypeBasic, nil
+	case oidc.AuthMethodPost:
+		return domain.OIDCAuthMethodTypePost, nil
+	case oidc.AuthMethodNone:
+		return domain.OIDCAuthMethodTypeNone, nil
+	case oidc.AuthMethodPrivateKeyJWT:
+		return domain.OIDCAuthMethodTypePrivateKeyJWT, nil
+	case authMethodTLSClientAuth:
+		return domain.OIDCAuthMethodTypeTLSClientAuth, nil
+	case authMethodSelfSignedTLSClientAuth:
+		return domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth, nil
+	default:
+		return 0, &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "unsupported token_endpoint_auth_method " + string(authMethod)}
+	}
+}
+
+func grantTypesFromOIDC(grantTypes []oidc.GrantType) ([]domain.OIDCGrantType, error) {
+	if len(grantTypes) == 0 {
+		return []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode}, nil
+	}
+	domainTypes := make([]domain.OIDCGrantType, len(grantTypes))
+	for i, grantType := range grantTypes {
+		switch grantType {
+		case oidc.GrantTypeCode:
+			domainTypes[i] = domain.OIDCGrantTypeAuthorizationCode
+		case oidc.GrantTypeImplicit:
+			domainTypes[i] = domain.OIDCGrantTypeImplicit
+		case oidc.GrantTypeRefreshToken:
+			domainTypes[i] = domain.OIDCGrantTypeRefreshToken
+		case oidc.GrantTypeDeviceCode:
+			domainTypes[i] = domain.OIDCGrantTypeDeviceCode
+		case oidc.GrantTypeTokenExchange:
+			domainTypes[i] = domain.OIDCGrantTypeTokenExchange
+		default:
+			return nil, &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "unsupported grant_type " + string(grantType)}
+		}
+	}
+	return domainTypes, nil
+}
+
+func responseTypesFromOIDC(responseTypes []oidc.ResponseType) ([]domain.OIDCResponseType, error) {
+	if len(responseTypes) == 0 {
+		return []domain.OIDCResponseType{domain.OIDCResponseTypeCode}, nil
+	}
+	domainTypes := make([]domain.OIDCResponseType, len(responseTypes))
+	for i, responseType := range responseTypes {
+		switch responseType {
+		case oidc.ResponseTypeCode:
+			domainTypes[i] = domain.OIDCResponseTypeCode
+		case oidc.ResponseTypeIDToken:
+			domainTypes[i] = domain.OIDCResponseTypeIDTokenToken
+		case oidc.ResponseTypeIDTokenOnly:
+			domainTypes[i] = domain.OIDCResponseTypeIDToken
+		default:
+			return nil, &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "unsupported response_type " + string(responseType)}
+		}
+	}
+	return domainTypes, nil
+}
+
+// applicationTypeFromOIDC maps the application type of OpenID Connect Dynamic Client Registration.
+// Web clients without authentication are browser based apps and therefore user agent apps in ZITADEL.
+func applicationTypeFromOIDC(applicationType string, authMethod domain.OIDCAuthMethodType) (domain.OIDCApplicationType, error) {
+	switch applicationType {
+	case "", applicationTypeWeb:
+		if authMethod == domain.OIDCAuthMethodTypeNone {
+			return domain.OIDCApplicationTypeUserAgent, nil
+		}
+		return domain.OIDCApplicationTypeWeb, nil
+	case applicationTypeNative:
+		return domain.OIDCApplicationTypeNative, nil
+	default:
+		return 0, &oidc.Error{ErrorType: errorInvalidClientMetadata, Description: "unsupported application_type " + applicationType}
+	}
+}
+
+func applicationTypeToOIDC(applicationType domain.OIDCApplicationType) string {
+	if applicationType == domain.OIDCApplicationTypeNative {
+		return applicationTypeNative
+	}
+	return applicationTypeWeb
+}
@@ -0,0 +1,245 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func Test_clientMetadata_applyTo(t *testing.T) {
+	tests := []struct {
+		name          string
+		metadata      *clientMetadata
+		want          *domain.OIDCApp
+		wantErrorType string
+	}{
+		{
+			name: "defaults",
+			metadata: &clientMetadata{
+				ClientName:   "app",
+				RedirectURIs: []string{"https://example.com/callback"},
+			},
+			want: &domain.OIDCApp{
+				AppName:         "app",
+				OIDCVersion:     domain.OIDCVersionV1,
+				RedirectUris:    []string{"https://example.com/callback"},
+				ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				ApplicationType: domain.OIDCApplicationTypeWeb,
+				AuthMethodType:  domain.OIDCAuthMethodTypeBasic,
+			},
+		},
+		{
+			name: "public web client",
+			metadata: &clientMetadata{
+				ClientName:              "spa",
+				RedirectURIs:            []string{"https://example.com/callback"},
+				TokenEndpointAuthMethod: oidc.AuthMethodNone,
+				GrantTypes:              []oidc.GrantType{oidc.GrantTypeCode, oidc.GrantTypeRefreshToken},
+				DPoPBoundAccessTokens:   true,
+			},
+			want: &domain.OIDCApp{
+				AppName:         "spa",
+				OIDCVersion:     domain.OIDCVersionV1,
+				RedirectUris:    []string{"https://example.com/callback"},
+				ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+				ApplicationType: domain.OIDCApplicationTypeUserAgent,
+				AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+				RequireDPoP:     true,
+			},
+		},
+		{
+			name: "native client",
+			metadata: &clientMetadata{
+				ClientName:              "native",
+				RedirectURIs:            []string{"http://localhost:8080/callback", "com.example.app:/callback"},
+				TokenEndpointAuthMethod: oidc.AuthMethodNone,
+				ApplicationType:         applicationTypeNative,
+			},
+			want: &domain.OIDCApp{
+				AppName:         "native",
+				OIDCVersion:     domain.OIDCVersionV1,
+				RedirectUris:    []string{"http://localhost:8080/callback", "com.example.app:/callback"},
+				ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				ApplicationType: domain.OIDCApplicationTypeNative,
+				AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+			},
+		},
+		{
+			name: "back-channel logout",
+			metadata: &clientMetadata{
+				ClientName:                       "app",
+				RedirectURIs:                     []string{"https://example.com/callback"},
+				BackChannelLogoutURI:             "https://example.com/backchannel",
+				BackChannelLogoutSessionRequired: true,
+			},
+			want: &domain.OIDCApp{
+				AppName:                          "app",
+				OIDCVersion:                      domain.OIDCVersionV1,
+				RedirectUris:                     []string{"https://example.com/callback"},
+				ResponseTypes:                    []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:                       []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				ApplicationType:                  domain.OIDCApplicationTypeWeb,
+				AuthMethodType:                   domain.OIDCAuthMethodTypeBasic,
+				BackChannelLogoutURI:             "https://example.com/backchannel",
+				BackChannelLogoutSessionRequired: true,
+			},
+		},
+		{
+			name: "missing client name",
+			metadata: &clientMetadata{
+				RedirectURIs: []string{"https://example.com/callback"},
+			},
+			wantErrorType: errorInvalidClientMetadata,
+		},
+		{
+			name: "unsupported grant type",
+			metadata: &clientMetadata{
+				ClientName: "app",
+				GrantTypes: []oidc.GrantType{oidc.GrantTypeClientCredentials},
+			},
+			wantErrorType: errorInvalidClientMetadata,
+		},
+		{
+			name: "unsupported application type",
+			metadata: &clientMetadata{
+				ClientName:      "app",
+				RedirectURIs:    []string{"https://example.com/callback"},
+				ApplicationType: "desktop",
+			},
+			wantErrorType: errorInvalidClientMetadata,
+		},
+		{
+			name: "custom scheme for web client",
+			metadata: &clientMetadata{
+				ClientName:   "app",
+				RedirectURIs: []string{"com.example.app:/callback"},
+			},
+			wantErrorType: errorInvalidRedirectURI,
+		},
+		{
+			name: "native client with secret",
+			metadata: &clientMetadata{
+				ClientName:      "native",
+				RedirectURIs:    []string{"http://localhost:8080/callback"},
+				ApplicationType: applicationTypeNative,
+			},
+			wantErrorType: errorInvalidClientMetadata,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &domain.OIDCApp{OIDCVersion: domain.OIDCVersionV1}
+			err := tt.metadata.applyTo(app)
+			if tt.wantErrorType != "" {
+				var oidcErr *oidc.Error
+				require.ErrorAs(t, err, &oidcErr)
+				assert.EqualValues(t, tt.wantErrorType, oidcErr.ErrorType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, app)
+		})
+	}
+}
+
+func Test_validateBackChannelLogoutURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		wantErr bool
+	}{
+		{uri: ""},
+		{uri: "https://example.com/backchannel"},
+		{uri: "https://93.184.215.14:8443/backchannel"},
+		{uri: "http://example.com/backchannel", wantErr: true},
+		{uri: "https://example.com/backchannel#fragment", wantErr: true},
+		{uri: "/backchannel", wantErr: true},
+		{uri: "https://localhost/backchannel", wantErr: true},
+		{uri: "https://app.localhost./backchannel", wantErr: true},
+		{uri: "https://127.0.0.1/backchannel", wantErr: true},
+		{uri: "https://[::1]/backchannel", wantErr: true},
+		{uri: "https://[::ffff:10.0.0.1]/backchannel", wantErr: true},
+		{uri: "https://10.0.0.1/backchannel", wantErr: true},
+		{uri: "https://192.168.1.1:8443/backchannel", wantErr: true},
+		{uri: "https://169.254.169.254/latest/meta-data", wantErr: true},
+		{uri: "https://0.0.0.0/backchannel", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			err := validateBackChannelLogoutURI(tt.uri)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var oidcErr *oidc.Error
+			require.ErrorAs(t, err, &oidcErr)
+			assert.EqualValues(t, errorInvalidClientMetadata, oidcErr.ErrorType)
+		})
+	}
+}
+
+func Test_clientMetadataFromOIDCApp(t *testing.T) {
+	metadata := &clientMetadata{
+		RedirectURIs:                       []string{"https://example.com/callback"},
+		PostLogoutRedirectURIs:             []string{"https://example.com/logout"},
+		TokenEndpointAuthMethod:            authMethodTLSClientAuth,
+		GrantTypes:                         []oidc.GrantType{oidc.GrantTypeCode, oidc.GrantTypeRefreshToken},
+		ResponseTypes:                      []oidc.ResponseType{oidc.ResponseTypeCode},
+		ApplicationType:                    applicationTypeWeb,
+		ClientName:                         "app",
+		BackChannelLogoutURI:               "https://example.com/backchannel",
+		BackChannelLogoutSessionRequired:   true,
+		RequirePushedAuthorizationRequests: true,
+		TLSClientAuthSubjectDN:             "CN=client,O=ZITADEL",
+	}
+	app := &domain.OIDCApp{OIDCVersion: domain.OIDCVersionV1}
+	require.NoError(t, metadata.applyTo(app))
+	assert.Equal(t, *metadata, clientMetadataFromOIDCApp(app))
+}
+
+func Test_bearerToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:          "bearer token",
+			authorization: "Bearer token",
+			want:          "token",
+		},
+		{
+			name:    "missing",
+			wantErr: true,
+		},
+		{
+			name:          "basic auth",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/oauth/v2/register", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			got, err := bearerToken(r)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+)
+
+const (
+	// invalidDPoPProof is the error code defined in https://www.rfc-editor.org/rfc/rfc9449#section-12.2
+	invalidDPoPProof = "invalid_dpop_proof"
+	dpopTokenType    = "DPoP"
+)
+
+func errInvalidDPoPProof() *oidc.Error {
+	return &oidc.Error{ErrorType: invalidDPoPProof}
+}
+
+// tokenDPoPThumbprint verifies the DPoP proof sent to the token endpoint (RFC 9449)
+// and returns the thumbprint of its key, which the issued tokens are bound to.
+// Requests without proof are only allowed, if the client does not require DPoP.
+func (s *Server) tokenDPoPThumbprint(ctx context.Context, method string, header http.Header, requireDPoP bool) (string, error) {
+	proofs := header.Values(authz.DPoPHeader)
+	if len(proofs) == 0 {
+		if requireDPoP {
+			return "", errInvalidDPoPProof().WithDescription("DPoP proof required")
+		}
+		return "", nil
+	}
+	if len(proofs) > 1 {
+		return "", errInvalidDPoPProof().WithDescription("multiple DPoP proofs")
+	}
+	jkt, err := authz.VerifyDPoPProof(ctx, proofs[0], method, s.Endpoints().Token.Absolute(op.IssuerFromContext(ctx)), "", time.Now())
+	if err != nil {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof invalid").WithParent(err)
+	}
+	return jkt, nil
+}
+
+// verifyUserInfoDPoP verifies the DPoP proof of a userinfo request,
+// if the access token is bound to a key or is sent with the DPoP scheme.
+func (s *Server) verifyUserInfoDPoP(ctx context.Context, r *op.Request[oidc.UserInfoRequest], token *accessToken) error {
+	dpop := dpopSchemeFromContext(ctx)
+	if !dpop && token.dpopJKT == "" {
+		return nil
+	}
+	if !dpop {
+		return errInvalidDPoPProof().WithDescription("DPoP bound access token must be sent with the DPoP scheme")
+	}
+	jkt, err := authz.VerifyDPoPProof(ctx, r.Header.Get(authz.DPoPHeader), r.Method, s.Endpoints().Userinfo.Absolute(op.IssuerFromContext(ctx)), r.Data.AccessToken, time.Now())
+	if err != nil {
+		return errInvalidDPoPProof().WithDescription("DPoP proof invalid").WithParent(err)
+	}
+	if jkt != token.dpopJKT {
+		return errInvalidDPoPProof().WithDescription("DPoP proof does not match the access token")
+	}
+	return nil
+}
+
+// dpopTokenTypeOrBearer returns the token_type for access tokens which are bound to the jkt or not
+func dpopTokenTypeOrBearer(jkt string) string {
+	if jkt != "" {
+		return dpopTokenType
+	}
+	return oidc.BearerToken
+}
+
+func dpopSigningAlgorithms() []string {
+	algs := make([]string, len(authz.DPoPSigningAlgorithms))
+	for i, alg := range authz.DPoPSigningAlgorithms {
+		algs[i] = string(alg)
+	}
+	return algs
+}
+
+type dpopSchemeKey struct{}
+
+// dpopAuthorizationHandler rewrites the DPoP authorization scheme to bearer,
+// which is the only one the OIDC library parses (e.g. on the userinfo endpoint).
+// The used scheme is kept in the context, so it can be verified with the proof.
+func dpopAuthorizationHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := authz.CutDPoPPrefix(r.Header.Get("Authorization")); ok {
+			r.Header.Set("Authorization", authz.BearerPrefix+token)
+			r = r.WithContext(context.WithValue(r.Context(), dpopSchemeKey{}, true))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func dpopSchemeFromContext(ctx context.Context) bool {
+	dpop, _ := ctx.Value(dpopSchemeKey{}).(bool)
+	return dpop
+}
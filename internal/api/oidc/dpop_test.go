@@ -0,0 +1,61 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dpopAuthorizationHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		want          string
+		wantDPoP      bool
+	}{
+		{
+			name:          "bearer scheme",
+			authorization: "Bearer token",
+			want:          "Bearer token",
+			wantDPoP:      false,
+		},
+		{
+			name:          "dpop scheme",
+			authorization: "DPoP token",
+			want:          "Bearer token",
+			wantDPoP:      true,
+		},
+		{
+			name:          "no authorization",
+			authorization: "",
+			want:          "",
+			wantDPoP:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				gotAuthorization string
+				gotDPoP          bool
+			)
+			handler := dpopAuthorizationHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				gotAuthorization = r.Header.Get("Authorization")
+				gotDPoP = dpopSchemeFromContext(r.Context())
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/oidc/v1/userinfo", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tt.want, gotAuthorization)
+			assert.Equal(t, tt.wantDPoP, gotDPoP)
+		})
+	}
+}
+
+func Test_dpopTokenTypeOrBearer(t *testing.T) {
+	assert.Equal(t, "Bearer", dpopTokenTypeOrBearer(""))
+	assert.Equal(t, "DPoP", dpopTokenTypeOrBearer("jkt"))
+}
@@ -120,7 +120,7 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 		Active:                          true,
 		Scope:                           token.scope,
 		ClientID:                        token.clientID,
-		TokenType:                       oidc.BearerToken,
+		TokenType:                       dpopTokenTypeOrBearer(token.dpopJKT),
 		Expiration:                      oidc.FromTime(token.tokenExpiration),
 		IssuedAt:                        oidc.FromTime(token.tokenCreation),
 		AuthTime:                        oidc.FromTime(token.authTime),
@@ -132,6 +132,9 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 		Actor:                           actorDomainToClaims(token.actor),
 	}
 	introspectionResp.SetUserInfo(userInfo)
+	// the resource server must verify the DPoP proof or the client certificate of the request
+	// with the key of the confirmation claim
+	introspectionResp.Claims = withConfirmationClaims(introspectionResp.Claims, token.dpopJKT, token.certificateThumbprint)
 	return op.NewResponse(introspectionResp), nil
 }
 
@@ -229,10 +229,9 @@ const (
 	signingKey = "signing_key"
 	oidcUser   = "OIDC"
 
-	retryBackoff   = 500 * time.Millisecond
-	retryCount     = 3
-	lockDuration   = retryCount * retryBackoff * 5
-	gracefulPeriod = 10 * time.Minute
+	retryBackoff = 500 * time.Millisecond
+	retryCount   = 3
+	lockDuration = retryCount * retryBackoff * 5
 )
 
 // SigningKey wraps the query.PrivateKey to implement the op.SigningKey interface
@@ -319,12 +318,12 @@ func (o *OPStorage) SigningKey(ctx context.Context) (key op.SigningKey, err erro
 }
 
 func (o *OPStorage) getSigningKey(ctx context.Context) (op.SigningKey, error) {
-	keys, err := o.query.ActivePrivateSigningKey(ctx, time.Now().Add(gracefulPeriod))
+	keys, err := o.query.ActivePrivateSigningKey(ctx, time.Now().Add(query.SigningKeyGracefulPeriod))
 	if err != nil {
 		return nil, err
 	}
-	if len(keys.Keys) > 0 {
-		return o.privateKeyToSigningKey(selectSigningKey(keys.Keys))
+	if key := keys.ActiveSigningKey(); key != nil {
+		return o.privateKeyToSigningKey(key)
 	}
 	var position float64
 	if keys.State != nil {
@@ -406,10 +405,6 @@ func (o *OPStorage) getMaxKeySequence(ctx context.Context) (float64, error) {
 	)
 }
 
-func selectSigningKey(keys []query.PrivateKey) query.PrivateKey {
-	return keys[len(keys)-1]
-}
-
 func setOIDCCtx(ctx context.Context) context.Context {
 	return authz.SetCtxData(ctx, authz.CtxData{UserID: oidcUser, OrgID: authz.GetInstance(ctx).InstanceID()})
 }
@@ -0,0 +1,151 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// auth methods and confirmation method defined in https://www.rfc-editor.org/rfc/rfc8705
+const (
+	authMethodTLSClientAuth           oidc.AuthMethod = "tls_client_auth"
+	authMethodSelfSignedTLSClientAuth oidc.AuthMethod = "self_signed_tls_client_auth"
+
+	certificateThumbprintConfirmation = "x5t#S256"
+)
+
+// clientCertificate returns the client certificate of the mutual TLS connection.
+// TLS is terminated by the ingress, which has to forward the certificate
+// as URL encoded PEM (or base64 encoded DER) in the configured header.
+// The ingress is also responsible for the proof of possession of the private key
+// and must validate the certificate chain for clients using tls_client_auth.
+// If no header is configured or the client did not present a certificate, nil is returned.
+func (s *Server) clientCertificate(header http.Header) (*x509.Certificate, error) {
+	if s.clientCertificateHeader == "" {
+		return nil, nil
+	}
+	value := header.Get(s.clientCertificateHeader)
+	if value == "" {
+		return nil, nil
+	}
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		value = unescaped
+	}
+	der, err := base64.StdEncoding.DecodeString(value)
+	if block, _ := pem.Decode([]byte(value)); block != nil {
+		der, err = block.Bytes, nil
+	}
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithDescription("invalid client certificate").WithParent(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithDescription("invalid client certificate").WithParent(err)
+	}
+	return cert, nil
+}
+
+func (s *Server) hasClientCertificate(header http.Header) bool {
+	return s.clientCertificateHeader != "" && header.Get(s.clientCertificateHeader) != ""
+}
+
+// verifyClientCertificate authenticates the client with its certificate (RFC 8705).
+// Using tls_client_auth, the subject of the certificate must match the registered subject distinguished name.
+// Using self_signed_tls_client_auth, the public key of the certificate must be registered as a key of the client.
+func (s *Server) verifyClientCertificate(ctx context.Context, client *query.OIDCClient, header http.Header) (err error) {
+	_, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	cert, err := s.clientCertificate(header)
+	if err != nil {
+		return err
+	}
+	if cert == nil {
+		return oidc.ErrInvalidClient().WithDescription("client certificate required")
+	}
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return oidc.ErrInvalidClient().WithDescription("client certificate expired")
+	}
+	switch client.AuthMethodType {
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		if cert.Subject.String() != client.TLSClientAuthSubjectDN {
+			return oidc.ErrInvalidClient().WithDescription("client certificate subject does not match")
+		}
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		if !keySetMap(client.PublicKeys).containsKey(cert.PublicKey) {
+			return oidc.ErrInvalidClient().WithDescription("client certificate not registered")
+		}
+	}
+	return nil
+}
+
+// tokenCertificateThumbprint returns the thumbprint of the certificate the client authenticated with,
+// which the issued tokens are bound to.
+// For clients not using mutual TLS client authentication, the thumbprint is empty.
+func (s *Server) tokenCertificateThumbprint(client *Client, header http.Header) (string, error) {
+	if !isTLSClientAuth(client.client.AuthMethodType) {
+		return "", nil
+	}
+	cert, err := s.clientCertificate(header)
+	if err != nil || cert == nil {
+		return "", oidc.ErrInvalidClient().WithDescription("client certificate required").WithParent(err)
+	}
+	return certificateThumbprint(cert), nil
+}
+
+// verifyUserInfoCertificate verifies that an access token bound to a certificate
+// is sent on a connection with the same client certificate.
+func (s *Server) verifyUserInfoCertificate(header http.Header, token *accessToken) error {
+	if token.certificateThumbprint == "" {
+		return nil
+	}
+	cert, err := s.clientCertificate(header)
+	if err != nil || cert == nil || certificateThumbprint(cert) != token.certificateThumbprint {
+		return oidc.ErrAccessDenied().WithDescription("access token is bound to another client certificate").WithParent(err)
+	}
+	return nil
+}
+
+// tlsClientAuthMethods adds the mutual TLS auth methods to the supported methods,
+// if the client certificate is forwarded to ZITADEL.
+func (s *Server) tlsClientAuthMethods(methods []oidc.AuthMethod) []oidc.AuthMethod {
+	if s.clientCertificateHeader == "" {
+		return methods
+	}
+	return append(slices.Clone(methods), authMethodTLSClientAuth, authMethodSelfSignedTLSClientAuth)
+}
+
+func isTLSClientAuth(authMethod domain.OIDCAuthMethodType) bool {
+	return authMethod == domain.OIDCAuthMethodTypeTLSClientAuth || authMethod == domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth
+}
+
+// certificateThumbprint returns the base64url encoded SHA-256 hash of the DER encoded certificate
+func certificateThumbprint(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// containsKey checks if the public key is part of the key set
+func (k keySetMap) containsKey(publicKey any) bool {
+	for _, data := range k {
+		registered, err := crypto.BytesToPublicKey(data)
+		if err == nil && registered != nil && registered.Equal(publicKey) {
+			return true
+		}
+	}
+	return false
+}
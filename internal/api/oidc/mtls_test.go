@@ -0,0 +1,206 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const testClientCertificateHeader = "X-Client-Cert"
+
+func testClientCertificate(t *testing.T, notAfter time.Time) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client", Organization: []string{"ZITADEL"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func testClientCertificateRequestHeader(value string) http.Header {
+	header := make(http.Header)
+	if value != "" {
+		header.Set(testClientCertificateHeader, value)
+	}
+	return header
+}
+
+func TestServer_clientCertificate(t *testing.T) {
+	cert, _ := testClientCertificate(t, time.Now().Add(time.Hour))
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+
+	tests := []struct {
+		name         string
+		headerConfig string
+		value        string
+		want         *x509.Certificate
+		wantErr      bool
+	}{
+		{
+			name:         "header not configured",
+			headerConfig: "",
+			value:        url.PathEscape(string(certPEM)),
+		},
+		{
+			name:         "no certificate",
+			headerConfig: testClientCertificateHeader,
+		},
+		{
+			name:         "url encoded pem",
+			headerConfig: testClientCertificateHeader,
+			value:        url.PathEscape(string(certPEM)),
+			want:         cert,
+		},
+		{
+			name:         "base64 der",
+			headerConfig: testClientCertificateHeader,
+			value:        base64.StdEncoding.EncodeToString(cert.Raw),
+			want:         cert,
+		},
+		{
+			name:         "invalid certificate",
+			headerConfig: testClientCertificateHeader,
+			value:        "invalid",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{clientCertificateHeader: tt.headerConfig}
+			got, err := s.clientCertificate(testClientCertificateRequestHeader(tt.value))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.True(t, tt.want.Equal(got))
+		})
+	}
+}
+
+func TestServer_verifyClientCertificate(t *testing.T) {
+	cert, key := testClientCertificate(t, time.Now().Add(time.Hour))
+	expired, _ := testClientCertificate(t, time.Now().Add(-time.Minute))
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKey, err := crypto.PublicKeyToBytes(&key.PublicKey)
+	require.NoError(t, err)
+	otherPublicKey, err := crypto.PublicKeyToBytes(&otherKey.PublicKey)
+	require.NoError(t, err)
+	encode := func(cert *x509.Certificate) string {
+		return base64.StdEncoding.EncodeToString(cert.Raw)
+	}
+
+	tests := []struct {
+		name    string
+		client  *query.OIDCClient
+		value   string
+		wantErr bool
+	}{
+		{
+			name: "no certificate",
+			client: &query.OIDCClient{
+				AuthMethodType:         domain.OIDCAuthMethodTypeTLSClientAuth,
+				TLSClientAuthSubjectDN: "CN=client,O=ZITADEL",
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired certificate",
+			client: &query.OIDCClient{
+				AuthMethodType:         domain.OIDCAuthMethodTypeTLSClientAuth,
+				TLSClientAuthSubjectDN: "CN=client,O=ZITADEL",
+			},
+			value:   encode(expired),
+			wantErr: true,
+		},
+		{
+			name: "subject dn mismatch",
+			client: &query.OIDCClient{
+				AuthMethodType:         domain.OIDCAuthMethodTypeTLSClientAuth,
+				TLSClientAuthSubjectDN: "CN=other,O=ZITADEL",
+			},
+			value:   encode(cert),
+			wantErr: true,
+		},
+		{
+			name: "subject dn match",
+			client: &query.OIDCClient{
+				AuthMethodType:         domain.OIDCAuthMethodTypeTLSClientAuth,
+				TLSClientAuthSubjectDN: "CN=client,O=ZITADEL",
+			},
+			value: encode(cert),
+		},
+		{
+			name: "self signed key not registered",
+			client: &query.OIDCClient{
+				AuthMethodType: domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth,
+				PublicKeys:     map[string][]byte{"key1": otherPublicKey},
+			},
+			value:   encode(cert),
+			wantErr: true,
+		},
+		{
+			name: "self signed key registered",
+			client: &query.OIDCClient{
+				AuthMethodType: domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth,
+				PublicKeys:     map[string][]byte{"key1": otherPublicKey, "key2": publicKey},
+			},
+			value: encode(cert),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{clientCertificateHeader: testClientCertificateHeader}
+			err := s.verifyClientCertificate(context.Background(), tt.client, testClientCertificateRequestHeader(tt.value))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_confirmationClaims(t *testing.T) {
+	assert.Nil(t, confirmationClaims("", ""))
+	assert.Equal(t, map[string]any{"cnf": map[string]any{"jkt": "jkt"}}, confirmationClaims("jkt", ""))
+	assert.Equal(t, map[string]any{"cnf": map[string]any{"x5t#S256": "thumbprint"}}, confirmationClaims("", "thumbprint"))
+}
+
+func Test_withConfirmationClaims(t *testing.T) {
+	assert.Nil(t, withConfirmationClaims(nil, "", ""))
+	assert.Equal(t, map[string]any{"custom": "value"}, withConfirmationClaims(map[string]any{"custom": "value"}, "", ""))
+	assert.Equal(t, map[string]any{"cnf": map[string]any{"jkt": "jkt"}}, withConfirmationClaims(nil, "jkt", ""))
+	assert.Equal(t,
+		map[string]any{"custom": "value", "cnf": map[string]any{"jkt": "jkt"}},
+		withConfirmationClaims(map[string]any{"custom": "value", "cnf": map[string]any{"jkt": "other"}}, "jkt", ""),
+	)
+}
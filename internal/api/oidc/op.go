@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/oidc/v3/pkg/op"
 
@@ -43,6 +44,9 @@ type Config struct {
 	DefaultLoginURLV2                 string
 	DefaultLogoutURLV2                string
 	PublicKeyCacheMaxAge              time.Duration
+	PushedAuthRequestLifetime         time.Duration
+	// ClientCertificateHeader is the header the ingress forwards the client certificate of mutual TLS connections in
+	ClientCertificateHeader string
 }
 
 type EndpointConfig struct {
@@ -54,6 +58,10 @@ type EndpointConfig struct {
 	EndSession    *Endpoint
 	Keys          *Endpoint
 	DeviceAuth    *Endpoint
+	// PushedAuthorization is the endpoint of RFC 9126
+	PushedAuthorization *Endpoint
+	// Registration is the endpoint of RFC 7591
+	Registration *Endpoint
 }
 
 type Endpoint struct {
@@ -137,12 +145,16 @@ func NewServer(
 		defaultLogoutURLV2:         config.DefaultLogoutURLV2,
 		defaultAccessTokenLifetime: config.DefaultAccessTokenLifetime,
 		defaultIdTokenLifetime:     config.DefaultIdTokenLifetime,
+		pushedAuthRequestLifetime:  config.PushedAuthRequestLifetime,
 		fallbackLogger:             fallbackLogger,
 		hasher:                     hasher,
 		signingKeyAlgorithm:        config.SigningKeyAlgorithm,
 		encAlg:                     encryptionAlg,
 		opCrypto:                   op.NewAESCrypto(opConfig.CryptoKey),
 		assetAPIPrefix:             assets.AssetAPI(externalSecure),
+		parEndpoint:                pushedAuthorizationEndpoint(config.CustomEndpoints),
+		registrationEndpoint:       registrationEndpoint(config.CustomEndpoints),
+		clientCertificateHeader:    config.ClientCertificateHeader,
 	}
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 	server.Handler = op.RegisterLegacyServer(server,
@@ -157,7 +169,19 @@ func NewServer(
 			http_utils.CopyHeadersToContext,
 			accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(config.CustomEndpoints)),
 			middleware.ActivityHandler,
-		))
+			dpopAuthorizationHandler,
+		),
+		// the DPoP header must be allowed for the browser based clients
+		op.WithServerCORSOptions(&middleware.DefaultCORSOptions),
+		// the router does not allow to add middlewares after the routes
+		op.WithSetRouter(func(router chi.Router) {
+			router.Post(server.parEndpoint.Relative(), server.pushedAuthorizationHandler)
+			router.Post(server.registrationEndpoint.Relative(), server.clientRegistrationHandler)
+			router.Get(server.registrationEndpoint.Relative()+"/{"+clientIDParam+"}", server.clientConfigurationHandler)
+			router.Put(server.registrationEndpoint.Relative()+"/{"+clientIDParam+"}", server.clientUpdateHandler)
+			router.Delete(server.registrationEndpoint.Relative()+"/{"+clientIDParam+"}", server.clientDeleteHandler)
+		}),
+	)
 
 	return server, nil
 }
@@ -0,0 +1,203 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// requestURIPrefix is prepended to the id of the pushed authorization request
+// as recommended by https://www.rfc-editor.org/rfc/rfc9126#section-2.2
+const requestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+type pushedAuthorizationResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int64  `json:"expires_in"`
+}
+
+// pushedAuthorizationHandler implements the pushed authorization request endpoint (RFC 9126).
+// The client is authenticated the same way as on the token endpoint
+// and the verified authorization request is stored until it's referenced by the returned request_uri.
+func (s *Server) pushedAuthorizationHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.pushedAuthorization(r.Context(), r)
+	if err != nil {
+		op.WriteError(w, r, err, s.getLogger(r.Context()))
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, resp, http.StatusCreated)
+}
+
+func (s *Server) pushedAuthorization(ctx context.Context, r *http.Request) (_ *pushedAuthorizationResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		err = oidcError(err)
+		span.EndWithError(err)
+	}()
+
+	if err = r.ParseForm(); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error parsing form").WithParent(err)
+	}
+	credentials, err := s.clientCredentials(r)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.VerifyClient(ctx, &op.Request[op.ClientCredentials]{
+		Method: r.Method,
+		URL:    r.URL,
+		Header: r.Header,
+		Form:   r.Form,
+		Data:   credentials,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if r.PostForm.Has("request_uri") {
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri must not be pushed")
+	}
+	authReq := new(oidc.AuthRequest)
+	if err = s.Provider().Decoder().Decode(authReq, r.PostForm); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	if authReq.ClientID == "" {
+		authReq.ClientID = client.GetID()
+	}
+	if authReq.RequestParam != "" {
+		if !s.Provider().RequestObjectSupported() {
+			return nil, oidc.ErrRequestNotSupported()
+		}
+		// the signature of the request object is verified with the keys of the client
+		if err = op.ParseRequestObject(ctx, authReq, s.Provider().Storage(), op.IssuerFromContext(ctx)); err != nil {
+			return nil, err
+		}
+		authReq.RequestParam = ""
+	}
+	if authReq.ClientID != client.GetID() {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id does not match the authenticated client")
+	}
+	if err = validatePushedAuthRequest(client, authReq); err != nil {
+		return nil, err
+	}
+	pushed, err := s.command.AddPushedAuthRequest(ctx, client.GetID(), pushedAuthRequestParameters(authReq), time.Now().Add(s.pushedAuthRequestLifetime))
+	if err != nil {
+		return nil, err
+	}
+	return &pushedAuthorizationResponse{
+		RequestURI: requestURIPrefix + pushed.ID,
+		ExpiresIn:  int64(s.pushedAuthRequestLifetime / time.Second),
+	}, nil
+}
+
+// validatePushedAuthRequest checks the parameters, which the authorization endpoint would check as well,
+// so the client is informed about an invalid request before the user is redirected.
+func validatePushedAuthRequest(client op.Client, authReq *oidc.AuthRequest) (err error) {
+	if authReq.MaxAge, err = op.ValidateAuthReqPrompt(authReq.Prompt, authReq.MaxAge); err != nil {
+		return err
+	}
+	if authReq.Scopes, err = op.ValidateAuthReqScopes(client, authReq.Scopes); err != nil {
+		return err
+	}
+	if err = op.ValidateAuthReqRedirectURI(client, authReq.RedirectURI, authReq.ResponseType); err != nil {
+		return err
+	}
+	return op.ValidateAuthReqResponseType(client, authReq.ResponseType)
+}
+
+// pushedAuthRequestParameters returns the form parameters of the verified authorization request,
+// which are decoded again on the authorization endpoint.
+func pushedAuthRequestParameters(authReq *oidc.AuthRequest) url.Values {
+	parameters := make(url.Values)
+	setParameter := func(key, value string) {
+		if value != "" {
+			parameters.Set(key, value)
+		}
+	}
+	setParameter("scope", authReq.Scopes.String())
+	setParameter("response_type", string(authReq.ResponseType))
+	setParameter("client_id", authReq.ClientID)
+	setParameter("redirect_uri", authReq.RedirectURI)
+	setParameter("state", authReq.State)
+	setParameter("nonce", authReq.Nonce)
+	setParameter("response_mode", string(authReq.ResponseMode))
+	setParameter("display", string(authReq.Display))
+	setParameter("prompt", authReq.Prompt.String())
+	if authReq.MaxAge != nil {
+		setParameter("max_age", strconv.FormatUint(uint64(*authReq.MaxAge), 10))
+	}
+	uiLocales := make([]string, len(authReq.UILocales))
+	for i, locale := range authReq.UILocales {
+		uiLocales[i] = locale.String()
+	}
+	setParameter("ui_locales", strings.Join(uiLocales, " "))
+	setParameter("id_token_hint", authReq.IDTokenHint)
+	setParameter("login_hint", authReq.LoginHint)
+	setParameter("acr_values", authReq.ACRValues.String())
+	setParameter("code_challenge", authReq.CodeChallenge)
+	setParameter("code_challenge_method", string(authReq.CodeChallengeMethod))
+	return parameters
+}
+
+// clientCredentials parses the credentials from the form, basic auth takes precedence.
+func (s *Server) clientCredentials(r *http.Request) (_ *op.ClientCredentials, err error) {
+	credentials := new(op.ClientCredentials)
+	if err = s.Provider().Decoder().Decode(credentials, r.Form); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	if clientID, clientSecret, ok := r.BasicAuth(); ok {
+		credentials.ClientID, err = url.QueryUnescape(clientID)
+		if err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+		credentials.ClientSecret, err = url.QueryUnescape(clientSecret)
+		if err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+	}
+	if credentials.ClientID == "" && credentials.ClientAssertion == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id or client_assertion must be provided")
+	}
+	if credentials.ClientAssertion != "" && credentials.ClientAssertionType != oidc.ClientAssertionTypeJWTAssertion {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid client_assertion_type %s", credentials.ClientAssertionType)
+	}
+	return credentials, nil
+}
+
+// pushedAuthRequest replaces the parameters of the authorization request
+// with the ones pushed by the client and referenced by the request_uri.
+func (s *Server) pushedAuthRequest(ctx context.Context, r *op.Request[oidc.AuthRequest], requestURI string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		err = oidcError(err)
+		span.EndWithError(err)
+	}()
+
+	id, ok := strings.CutPrefix(requestURI, requestURIPrefix)
+	if !ok {
+		return oidc.ErrInvalidRequest().WithDescription("invalid request_uri")
+	}
+	if r.Data.ClientID == "" {
+		return oidc.ErrInvalidRequest().WithParent(op.ErrAuthReqMissingClientID).WithDescription(op.ErrAuthReqMissingClientID.Error())
+	}
+	parameters, err := s.command.UsePushedAuthRequest(ctx, id, r.Data.ClientID)
+	if zerrors.IsNotFound(err) || zerrors.IsPreconditionFailed(err) {
+		return oidc.ErrInvalidRequest().WithParent(err).WithDescription("request_uri is invalid or expired")
+	}
+	if err != nil {
+		return err
+	}
+	authReq := new(oidc.AuthRequest)
+	if err = s.Provider().Decoder().Decode(authReq, parameters); err != nil {
+		return zerrors.ThrowInternal(err, "OIDC-Par2d", "Errors.Internal")
+	}
+	r.Data = authReq
+	return nil
+}
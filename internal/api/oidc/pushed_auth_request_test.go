@@ -0,0 +1,132 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+	"golang.org/x/text/language"
+)
+
+func testPushedAuthRequestServer(t *testing.T) *Server {
+	provider, err := op.NewForwardedOpenIDProvider("path",
+		&op.Config{
+			CodeMethodS256:         true,
+			RequestObjectSupported: true,
+		},
+		nil,
+	)
+	require.NoError(t, err)
+	return &Server{
+		LegacyServer: op.NewLegacyServer(provider, op.Endpoints{}),
+	}
+}
+
+func TestServer_clientCredentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		form      url.Values
+		basicAuth []string
+		want      *op.ClientCredentials
+		wantErr   bool
+	}{
+		{
+			name:    "missing client",
+			form:    url.Values{"scope": {"openid"}},
+			wantErr: true,
+		},
+		{
+			name: "invalid assertion type",
+			form: url.Values{
+				"client_assertion":      {"assertion"},
+				"client_assertion_type": {"type"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "post",
+			form: url.Values{
+				"client_id":     {"clientID"},
+				"client_secret": {"secret"},
+				"scope":         {"openid"},
+			},
+			want: &op.ClientCredentials{
+				ClientID:     "clientID",
+				ClientSecret: "secret",
+			},
+		},
+		{
+			name: "basic auth takes precedence",
+			form: url.Values{
+				"client_id":     {"clientID"},
+				"client_secret": {"secret"},
+			},
+			basicAuth: []string{"basic%3AclientID", "basic%20secret"},
+			want: &op.ClientCredentials{
+				ClientID:     "basic:clientID",
+				ClientSecret: "basic secret",
+			},
+		},
+		{
+			name: "assertion",
+			form: url.Values{
+				"client_assertion":      {"assertion"},
+				"client_assertion_type": {oidc.ClientAssertionTypeJWTAssertion},
+			},
+			want: &op.ClientCredentials{
+				ClientAssertion:     "assertion",
+				ClientAssertionType: oidc.ClientAssertionTypeJWTAssertion,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/oauth/v2/par", strings.NewReader(tt.form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.basicAuth != nil {
+				r.SetBasicAuth(tt.basicAuth[0], tt.basicAuth[1])
+			}
+			require.NoError(t, r.ParseForm())
+
+			got, err := testPushedAuthRequestServer(t).clientCredentials(r)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// Test_pushedAuthRequestParameters ensures the parameters stored for a pushed authorization request
+// result in the same authorization request on the authorization endpoint.
+func Test_pushedAuthRequestParameters(t *testing.T) {
+	maxAge := uint(300)
+	authReq := &oidc.AuthRequest{
+		Scopes:              oidc.SpaceDelimitedArray{oidc.ScopeOpenID, oidc.ScopeProfile},
+		ResponseType:        oidc.ResponseTypeCode,
+		ClientID:            "clientID",
+		RedirectURI:         "https://example.com/callback",
+		State:               "state",
+		Nonce:               "nonce",
+		ResponseMode:        oidc.ResponseModeFormPost,
+		Prompt:              oidc.SpaceDelimitedArray{oidc.PromptLogin, oidc.PromptConsent},
+		MaxAge:              &maxAge,
+		LoginHint:           "user@example.com",
+		CodeChallenge:       "challenge",
+		CodeChallengeMethod: oidc.CodeChallengeMethodS256,
+		Display:             oidc.DisplayPage,
+		UILocales:           oidc.Locales{language.German, language.English},
+		ACRValues:           oidc.SpaceDelimitedArray{"acr"},
+	}
+	got := new(oidc.AuthRequest)
+	require.NoError(t, testPushedAuthRequestServer(t).Provider().Decoder().Decode(got, pushedAuthRequestParameters(authReq)))
+	assert.Equal(t, authReq, got)
+}
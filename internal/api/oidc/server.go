@@ -33,6 +33,7 @@ type Server struct {
 	defaultLogoutURLV2         string
 	defaultAccessTokenLifetime time.Duration
 	defaultIdTokenLifetime     time.Duration
+	pushedAuthRequestLifetime  time.Duration
 
 	fallbackLogger      *slog.Logger
 	hasher              *crypto.Hasher
@@ -40,6 +41,11 @@ type Server struct {
 	encAlg              crypto.EncryptionAlgorithm
 	opCrypto            op.Crypto
 
+	parEndpoint          *op.Endpoint
+	registrationEndpoint *op.Endpoint
+	// clientCertificateHeader contains the client certificate forwarded by the ingress (RFC 8705)
+	clientCertificateHeader string
+
 	assetAPIPrefix func(ctx context.Context) string
 }
 
@@ -86,6 +92,20 @@ func endpoints(endpointConfig *EndpointConfig) op.Endpoints {
 	return endpoints
 }
 
+func pushedAuthorizationEndpoint(endpointConfig *EndpointConfig) *op.Endpoint {
+	if endpointConfig == nil || endpointConfig.PushedAuthorization == nil {
+		return op.NewEndpoint("/oauth/v2/par")
+	}
+	return op.NewEndpointWithURL(endpointConfig.PushedAuthorization.Path, endpointConfig.PushedAuthorization.URL)
+}
+
+func registrationEndpoint(endpointConfig *EndpointConfig) *op.Endpoint {
+	if endpointConfig == nil || endpointConfig.Registration == nil {
+		return op.NewEndpoint("/oauth/v2/register")
+	}
+	return op.NewEndpointWithURL(endpointConfig.Registration.Path, endpointConfig.Registration.URL)
+}
+
 func (s *Server) getLogger(ctx context.Context) *slog.Logger {
 	if logger, ok := logging.FromContext(ctx); ok {
 		return logger
@@ -139,7 +159,29 @@ func (s *Server) VerifyAuthRequest(ctx context.Context, r *op.Request[oidc.AuthR
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.VerifyAuthRequest(ctx, r)
+	requestURI := r.Form.Get("request_uri")
+	if requestURI != "" {
+		if err = s.pushedAuthRequest(ctx, r, requestURI); err != nil {
+			return nil, err
+		}
+	}
+	clientRequest, err := s.LegacyServer.VerifyAuthRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	client, ok := clientRequest.Client.(*Client)
+	if !ok {
+		return clientRequest, nil
+	}
+	if client.client.RequirePushedAuthRequests && requestURI == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("pushed authorization request required")
+	}
+	// access tokens of the implicit flow are returned on the authorization endpoint,
+	// where no DPoP proof can be sent
+	if client.client.RequireDPoP && clientRequest.Data.ResponseType == oidc.ResponseTypeIDToken {
+		return nil, oidc.ErrInvalidRequest().WithDescription("DPoP required, access tokens cannot be returned from the authorization endpoint")
+	}
+	return clientRequest, nil
 }
 
 func (s *Server) Authorize(ctx context.Context, r *op.ClientRequest[oidc.AuthRequest]) (_ *op.Redirect, err error) {
@@ -170,8 +212,35 @@ func (s *Server) EndSession(ctx context.Context, r *op.Request[oidc.EndSessionRe
 	return s.LegacyServer.EndSession(ctx, r)
 }
 
-func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales oidc.Locales) *oidc.DiscoveryConfiguration {
+// discoveryConfiguration extends the discovery document of the oidc library
+// with the metadata of https://openid.net/specs/openid-connect-backchannel-1_0.html#BCSupport
+// and https://www.rfc-editor.org/rfc/rfc9126#section-5
+// and https://www.rfc-editor.org/rfc/rfc9449#section-5.1
+// and https://www.rfc-editor.org/rfc/rfc8705#section-3.3
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	BackChannelLogoutSupported         bool     `json:"backchannel_logout_supported,omitempty"`
+	BackChannelLogoutSessionSupported  bool     `json:"backchannel_logout_session_supported,omitempty"`
+	PushedAuthorizationRequestEndpoint string   `json:"pushed_authorization_request_endpoint,omitempty"`
+	DPoPSigningAlgValuesSupported      []string `json:"dpop_signing_alg_values_supported,omitempty"`
+	TLSClientCertificateBoundTokens    bool     `json:"tls_client_certificate_bound_access_tokens,omitempty"`
+}
+
+func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales oidc.Locales) *discoveryConfiguration {
 	issuer := op.IssuerFromContext(ctx)
+	return &discoveryConfiguration{
+		DiscoveryConfiguration: s.createOIDCDiscoveryConfig(issuer, supportedUILocales),
+		// logout tokens are sent by the back-channel logout notifier
+		// and always contain the sid claim
+		BackChannelLogoutSupported:         true,
+		BackChannelLogoutSessionSupported:  true,
+		PushedAuthorizationRequestEndpoint: s.parEndpoint.Absolute(issuer),
+		DPoPSigningAlgValuesSupported:      dpopSigningAlgorithms(),
+		TLSClientCertificateBoundTokens:    s.clientCertificateHeader != "",
+	}
+}
+
+func (s *Server) createOIDCDiscoveryConfig(issuer string, supportedUILocales oidc.Locales) *oidc.DiscoveryConfiguration {
 	return &oidc.DiscoveryConfiguration{
 		Issuer:                      issuer,
 		AuthorizationEndpoint:       s.Endpoints().Authorization.Absolute(issuer),
@@ -181,6 +250,7 @@ func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales o
 		RevocationEndpoint:          s.Endpoints().Revocation.Absolute(issuer),
 		EndSessionEndpoint:          s.Endpoints().EndSession.Absolute(issuer),
 		JwksURI:                     s.Endpoints().JwksURI.Absolute(issuer),
+		RegistrationEndpoint:        s.registrationEndpoint.Absolute(issuer),
 		DeviceAuthorizationEndpoint: s.Endpoints().DeviceAuthorization.Absolute(issuer),
 		ScopesSupported:             op.Scopes(s.Provider()),
 		ResponseTypesSupported:      op.ResponseTypes(s.Provider()),
@@ -193,12 +263,12 @@ func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales o
 		SubjectTypesSupported:                              op.SubjectTypes(s.Provider()),
 		IDTokenSigningAlgValuesSupported:                   []string{s.signingKeyAlgorithm},
 		RequestObjectSigningAlgValuesSupported:             op.RequestObjectSigAlgorithms(s.Provider()),
-		TokenEndpointAuthMethodsSupported:                  op.AuthMethodsTokenEndpoint(s.Provider()),
+		TokenEndpointAuthMethodsSupported:                  s.tlsClientAuthMethods(op.AuthMethodsTokenEndpoint(s.Provider())),
 		TokenEndpointAuthSigningAlgValuesSupported:         op.TokenSigAlgorithms(s.Provider()),
 		IntrospectionEndpointAuthSigningAlgValuesSupported: op.IntrospectionSigAlgorithms(s.Provider()),
 		IntrospectionEndpointAuthMethodsSupported:          op.AuthMethodsIntrospectionEndpoint(s.Provider()),
 		RevocationEndpointAuthSigningAlgValuesSupported:    op.RevocationSigAlgorithms(s.Provider()),
-		RevocationEndpointAuthMethodsSupported:             op.AuthMethodsRevocationEndpoint(s.Provider()),
+		RevocationEndpointAuthMethodsSupported:             s.tlsClientAuthMethods(op.AuthMethodsRevocationEndpoint(s.Provider())),
 		ClaimsSupported:                                    op.SupportedClaims(s.Provider()),
 		CodeChallengeMethodsSupported:                      op.CodeChallengeMethods(s.Provider()),
 		UILocalesSupported:                                 supportedUILocales,
@@ -3,6 +3,7 @@ package oidc
 import (
 	"context"
 	"encoding/base64"
+	"maps"
 	"slices"
 	"sync"
 	"time"
@@ -34,7 +35,7 @@ func (s *Server) accessTokenResponseFromSession(ctx context.Context, client op.C
 	getSigner := s.getSignerOnce()
 
 	resp := &oidc.AccessTokenResponse{
-		TokenType:    oidc.BearerToken,
+		TokenType:    dpopTokenTypeOrBearer(session.DPoPJKT),
 		RefreshToken: session.RefreshToken,
 		ExpiresIn:    timeToOIDCExpiresIn(session.Expiration),
 		State:        state,
@@ -171,11 +172,41 @@ func (s *Server) createJWT(ctx context.Context, client op.Client, session *comma
 		client.ClockSkew(),
 	)
 	claims.Actor = actorDomainToClaims(session.Actor)
-	claims.Claims = userInfo.Claims
+	claims.Claims = withConfirmationClaims(userInfo.Claims, session.DPoPJKT, session.CertificateThumbprint)
 
 	return crypto.Sign(claims, signer)
 }
 
+// confirmationClaims returns the confirmation claim of an access token bound to a DPoP key
+// (https://www.rfc-editor.org/rfc/rfc9449#section-6) or a client certificate (https://www.rfc-editor.org/rfc/rfc8705#section-3.1).
+// Bearer tokens have no confirmation claim.
+func confirmationClaims(dpopJKT, certificateThumbprint string) map[string]any {
+	cnf := make(map[string]any, 2)
+	if dpopJKT != "" {
+		cnf["jkt"] = dpopJKT
+	}
+	if certificateThumbprint != "" {
+		cnf[certificateThumbprintConfirmation] = certificateThumbprint
+	}
+	if len(cnf) == 0 {
+		return nil
+	}
+	return map[string]any{"cnf": cnf}
+}
+
+// withConfirmationClaims returns the claims with the confirmation claim of a bound token.
+// The confirmation claim is set last, so it can't be overwritten by a custom claim (e.g. of an action).
+func withConfirmationClaims(claims map[string]any, dpopJKT, certificateThumbprint string) map[string]any {
+	cnf := confirmationClaims(dpopJKT, certificateThumbprint)
+	if cnf == nil {
+		return claims
+	}
+	merged := make(map[string]any, len(claims)+len(cnf))
+	maps.Copy(merged, claims)
+	maps.Copy(merged, cnf)
+	return merged
+}
+
 // decryptCode decrypts a code or refresh_token
 func (s *Server) decryptCode(ctx context.Context, code string) (_ string, err error) {
 	_, span := tracing.NewSpan(ctx)
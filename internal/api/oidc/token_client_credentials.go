@@ -22,6 +22,10 @@ func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequ
 	if !ok {
 		return nil, zerrors.ThrowInternal(nil, "OIDC-ga0EP", "Error.Internal")
 	}
+	dpopJKT, err := s.tokenDPoPThumbprint(ctx, r.Method, r.Header, false)
+	if err != nil {
+		return nil, err
+	}
 	scope, err := op.ValidateAuthReqScopes(client, r.Data.Scope)
 	if err != nil {
 		return nil, err
@@ -45,6 +49,8 @@ func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequ
 		domain.TokenReasonClientCredentials,
 		nil,
 		false,
+		dpopJKT,
+		"",
 	)
 	if err != nil {
 		return nil, err
@@ -21,6 +21,10 @@ func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGr
 		err = oidcError(err)
 	}()
 
+	dpopJKT, err := s.tokenDPoPThumbprint(ctx, r.Method, r.Header, false)
+	if err != nil {
+		return nil, err
+	}
 	user, jwtReq, err := s.verifyJWTProfile(ctx, r.Data)
 	if err != nil {
 		return nil, err
@@ -53,6 +57,8 @@ func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGr
 		domain.TokenReasonJWTProfile,
 		nil,
 		false,
+		dpopJKT,
+		"",
 	)
 	if err != nil {
 		return nil, err
@@ -25,13 +25,21 @@ func (s *Server) RefreshToken(ctx context.Context, r *op.ClientRequest[oidc.Refr
 	if !ok {
 		return nil, zerrors.ThrowInternal(nil, "OIDC-ga0EP", "Error.Internal")
 	}
+	dpopJKT, err := s.tokenDPoPThumbprint(ctx, r.Method, r.Header, client.client.RequireDPoP)
+	if err != nil {
+		return nil, err
+	}
+	certificateThumbprint, err := s.tokenCertificateThumbprint(client, r.Header)
+	if err != nil {
+		return nil, err
+	}
 
-	session, err := s.command.ExchangeOIDCSessionRefreshAndAccessToken(ctx, r.Data.RefreshToken, r.Data.Scopes, refreshTokenComplianceChecker())
+	session, err := s.command.ExchangeOIDCSessionRefreshAndAccessToken(ctx, r.Data.RefreshToken, r.Data.Scopes, refreshTokenComplianceChecker(), dpopJKT, certificateThumbprint)
 	if err == nil {
 		return response(s.accessTokenResponseFromSession(ctx, client, session, "", client.client.ProjectID, client.client.ProjectRoleAssertion, client.client.AccessTokenRoleAssertion, client.client.IDTokenRoleAssertion, client.client.IDTokenUserinfoAssertion))
 	} else if errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "OIDCS-JOI23", "Errors.OIDCSession.RefreshTokenInvalid")) {
 		// We try again for v1 tokens when we encountered specific parsing error
-		return s.refreshTokenV1(ctx, client, r)
+		return s.refreshTokenV1(ctx, client, r, dpopJKT, certificateThumbprint)
 	}
 	return nil, err
 }
@@ -41,7 +49,7 @@ func (s *Server) RefreshToken(ctx context.Context, r *op.ClientRequest[oidc.Refr
 // This "upgrades" existing v1 sessions to v2 session without requiring users to re-login.
 //
 // This function can be removed when we retire the v1 token repo.
-func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.ClientRequest[oidc.RefreshTokenRequest]) (_ *op.Response, err error) {
+func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.ClientRequest[oidc.RefreshTokenRequest], dpopJKT, certificateThumbprint string) (_ *op.Response, err error) {
 	refreshToken, err := s.repo.RefreshTokenByToken(ctx, r.Data.RefreshToken)
 	if err != nil {
 		return nil, err
@@ -67,6 +75,8 @@ func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.Clien
 		domain.TokenReasonRefresh,
 		refreshToken.Actor,
 		true,
+		dpopJKT,
+		certificateThumbprint,
 	)
 	if err != nil {
 		return nil, err
@@ -44,6 +44,12 @@ func (s *Server) UserInfo(ctx context.Context, r *op.Request[oidc.UserInfoReques
 	if err != nil {
 		return nil, op.NewStatusError(oidc.ErrAccessDenied().WithDescription("access token invalid").WithParent(err), http.StatusUnauthorized)
 	}
+	if err = s.verifyUserInfoDPoP(ctx, r, token); err != nil {
+		return nil, op.NewStatusError(err, http.StatusUnauthorized)
+	}
+	if err = s.verifyUserInfoCertificate(r.Header, token); err != nil {
+		return nil, op.NewStatusError(err, http.StatusUnauthorized)
+	}
 
 	var (
 		projectID string
@@ -124,7 +130,15 @@ func (s *Server) userInfo(
 			Claims:          maps.Clone(rawUserInfo.Claims),
 		}
 		assertRoles(projectID, qu, roleAudience, requestedRoles, roleAssertion, userInfo)
-		return userInfo, s.userinfoFlows(ctx, qu, userInfo, triggerType)
+		if err = s.userinfoFlows(ctx, qu, userInfo, triggerType); err != nil {
+			return nil, err
+		}
+		claims, err := userInfoToClaims(userInfo)
+		if err != nil {
+			return nil, err
+		}
+		getUserInfo := func() (*query.OIDCUserInfo, error) { return qu, nil }
+		return userInfo, userinfoExecutions(ctx, s.query, s.command, triggerType, getUserInfo, claims, userInfo.AppendClaims)
 	}
 }
 
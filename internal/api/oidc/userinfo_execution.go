@@ -0,0 +1,161 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// userinfoExecutions calls the targets of the function execution for the trigger type of the customise token flow.
+// The returned claims are added with appendClaim, reserved and already existing claims are not overwritten.
+// The information about the user is only collected with getUserInfo if any target has to be called.
+// The returned metadata is set on the user.
+func userinfoExecutions(
+	ctx context.Context,
+	queries execution.FunctionQueries,
+	commands *command.Commands,
+	triggerType domain.TriggerType,
+	getUserInfo func() (*query.OIDCUserInfo, error),
+	claims map[string]any,
+	appendClaim func(key string, value any),
+) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	targets, err := execution.QueryExecutionTargetsForFunction(ctx, queries, execution.FunctionID(domain.FlowTypeCustomiseToken, triggerType))
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+
+	qu, err := getUserInfo()
+	if err != nil {
+		return err
+	}
+	if claims == nil {
+		claims = make(map[string]any)
+	}
+	function := domain.ActionFunction(domain.FlowTypeCustomiseToken, triggerType)
+	info := &ContextInfo{
+		Function:     function,
+		Claims:       claims,
+		User:         qu.User,
+		UserMetadata: qu.Metadata,
+		Org:          qu.Org,
+		UserGrants:   qu.UserGrants,
+	}
+	resp, err := execution.CallTargets(ctx, targets, info)
+	if err != nil {
+		return err
+	}
+	contextInfoResponse, ok := resp.(*ContextInfoResponse)
+	if !ok || contextInfoResponse == nil {
+		return nil
+	}
+
+	claimLogs := contextInfoResponse.AppendLogClaims
+	for _, claim := range contextInfoResponse.AppendClaims {
+		if strings.HasPrefix(claim.Key, ClaimPrefix) {
+			continue
+		}
+		if claims[claim.Key] == nil {
+			claims[claim.Key] = claim.Value
+			appendClaim(claim.Key, claim.Value)
+			continue
+		}
+		claimLogs = append(claimLogs, fmt.Sprintf("key %q already exists", claim.Key))
+	}
+	if len(claimLogs) > 0 {
+		appendClaim(fmt.Sprintf(ClaimActionLogFormat, function), claimLogs)
+	}
+
+	for _, metadata := range object.ExecutionMetadataToDomain(contextInfoResponse.SetUserMetadata) {
+		if _, err = commands.SetUserMetadata(ctx, metadata, qu.User.ID, qu.User.ResourceOwner); err != nil {
+			logging.WithError(err).Info("unable to set md in execution")
+			return err
+		}
+	}
+	return nil
+}
+
+// ContextInfo is sent to the targets of the function executions of the customise token flow.
+type ContextInfo struct {
+	Function     string               `json:"function,omitempty"`
+	Claims       map[string]any       `json:"claims,omitempty"`
+	User         *query.User          `json:"user,omitempty"`
+	UserMetadata []query.UserMetadata `json:"user_metadata,omitempty"`
+	Org          *query.UserInfoOrg   `json:"org,omitempty"`
+	UserGrants   []query.UserGrant    `json:"user_grants,omitempty"`
+	Response     *ContextInfoResponse `json:"response,omitempty"`
+}
+
+// ContextInfoResponse is the expected response of the targets of the function executions of the customise token flow.
+type ContextInfoResponse struct {
+	SetUserMetadata []*object.ExecutionMetadata `json:"set_user_metadata,omitempty"`
+	AppendClaims    []*AppendClaim              `json:"append_claims,omitempty"`
+	AppendLogClaims []string                    `json:"append_log_claims,omitempty"`
+}
+
+type AppendClaim struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+func (c *ContextInfo) GetHTTPRequestBody() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (c *ContextInfo) SetHTTPResponseBody(resp []byte) error {
+	if !json.Valid(resp) {
+		return zerrors.ThrowPreconditionFailed(nil, "ACTION-4m9s2", "Errors.Execution.ResponseIsNotValidJSON")
+	}
+	if c.Response == nil {
+		c.Response = &ContextInfoResponse{}
+	}
+	return json.Unmarshal(resp, c.Response)
+}
+
+func (c *ContextInfo) GetContent() interface{} {
+	return c.Response
+}
+
+// userInfoToClaims returns all claims of the userinfo, including the standard claims,
+// as they are sent to the targets of the executions.
+func userInfoToClaims(userInfo *oidc.UserInfo) (map[string]any, error) {
+	marshalled, err := json.Marshal(userInfo)
+	if err != nil {
+		return nil, err
+	}
+	claims := make(map[string]any, len(userInfo.Claims)+10)
+	if err = json.Unmarshal(marshalled, &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// userGrantsToSlice maps the user grants of the legacy implementation to the slice used in [query.OIDCUserInfo]
+func userGrantsToSlice(userGrants *query.UserGrants) []query.UserGrant {
+	if userGrants == nil {
+		return nil
+	}
+	grants := make([]query.UserGrant, len(userGrants.UserGrants))
+	for i, grant := range userGrants.UserGrants {
+		grants[i] = *grant
+	}
+	return grants
+}
@@ -313,7 +313,7 @@ func (p *Storage) getCustomAttributes(ctx context.Context, user *query.User, use
 			return nil, err
 		}
 	}
-	return customAttributes, nil
+	return p.customAttributesExecutions(ctx, user, userGrants, customAttributes)
 }
 
 func (p *Storage) getGrants(ctx context.Context, userID, applicationID string) (*query.UserGrants, error) {
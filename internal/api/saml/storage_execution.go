@@ -0,0 +1,115 @@
+package saml
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// customAttributesExecutions calls the targets of the function execution of the customize SAML response flow.
+// The returned custom attributes are added if they don't exist yet and the returned metadata is set on the user.
+func (p *Storage) customAttributesExecutions(
+	ctx context.Context,
+	user *query.User,
+	userGrants *query.UserGrants,
+	customAttributes map[string]*customAttribute,
+) (_ map[string]*customAttribute, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	function := domain.ActionFunction(domain.FlowTypeCustomizeSAMLResponse, domain.TriggerTypePreSAMLResponseCreation)
+	targets, err := execution.QueryExecutionTargetsForFunction(ctx, p.query, execution.FunctionID(domain.FlowTypeCustomizeSAMLResponse, domain.TriggerTypePreSAMLResponseCreation))
+	if err != nil || len(targets) == 0 {
+		return customAttributes, err
+	}
+
+	resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(user.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := p.query.SearchUserMetadata(ctx, true, user.ID, &query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	info := &ContextInfo{
+		Function:     function,
+		User:         user,
+		UserMetadata: metadata.Metadata,
+	}
+	if userGrants != nil {
+		info.UserGrants = userGrants.UserGrants
+	}
+	resp, err := execution.CallTargets(ctx, targets, info)
+	if err != nil {
+		return nil, err
+	}
+	contextInfoResponse, ok := resp.(*ContextInfoResponse)
+	if !ok || contextInfoResponse == nil {
+		return customAttributes, nil
+	}
+
+	for _, attribute := range contextInfoResponse.SetCustomAttributes {
+		if _, ok := customAttributes[attribute.Name]; ok {
+			continue
+		}
+		customAttributes = appendCustomAttribute(customAttributes, attribute.Name, attribute.NameFormat, attribute.Value)
+	}
+	for _, metadata := range object.ExecutionMetadataToDomain(contextInfoResponse.SetUserMetadata) {
+		if _, err = p.command.SetUserMetadata(ctx, metadata, user.ID, user.ResourceOwner); err != nil {
+			logging.WithError(err).Info("unable to set md in execution")
+			return nil, err
+		}
+	}
+	return customAttributes, nil
+}
+
+// ContextInfo is sent to the targets of the function executions of the customize SAML response flow.
+type ContextInfo struct {
+	Function     string                `json:"function,omitempty"`
+	User         *query.User           `json:"user,omitempty"`
+	UserGrants   []*query.UserGrant    `json:"user_grants,omitempty"`
+	UserMetadata []*query.UserMetadata `json:"user_metadata,omitempty"`
+	Response     *ContextInfoResponse  `json:"response,omitempty"`
+}
+
+// ContextInfoResponse is the expected response of the targets of the function executions of the customize SAML response flow.
+type ContextInfoResponse struct {
+	SetCustomAttributes []*CustomAttribute          `json:"set_custom_attributes,omitempty"`
+	SetUserMetadata     []*object.ExecutionMetadata `json:"set_user_metadata,omitempty"`
+}
+
+type CustomAttribute struct {
+	Name       string   `json:"name"`
+	NameFormat string   `json:"name_format"`
+	Value      []string `json:"value"`
+}
+
+func (c *ContextInfo) GetHTTPRequestBody() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (c *ContextInfo) SetHTTPResponseBody(resp []byte) error {
+	if !json.Valid(resp) {
+		return zerrors.ThrowPreconditionFailed(nil, "SAML-4m0fs", "Errors.Execution.ResponseIsNotValidJSON")
+	}
+	if c.Response == nil {
+		c.Response = &ContextInfoResponse{}
+	}
+	return json.Unmarshal(resp, c.Response)
+}
+
+func (c *ContextInfo) GetContent() interface{} {
+	return c.Response
+}
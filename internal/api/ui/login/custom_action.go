@@ -158,6 +158,26 @@ func (l *Login) runPostExternalAuthenticationActions(
 			return nil, false, err
 		}
 	}
+
+	resp, err := l.runFunctionExecution(ctx, domain.FlowTypeExternalAuthentication, domain.TriggerTypePostAuthentication, func() (*ContextInfo, error) {
+		return &ContextInfo{
+			AuthRequest:  object.AuthRequestForExecution(authRequest),
+			HTTPRequest:  object.HTTPRequestForExecution(httpRequest),
+			AuthError:    authErrStr,
+			ExternalUser: object.UserFromExternalUserForExecution(user),
+			ProviderInfo: idpUser,
+			OrgID:        resourceOwner,
+		}, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if resp != nil {
+		if resp.SetHuman.applyToExternalUser(user) {
+			userChanged = true
+		}
+		metadataList.AppendExecutionMetadata(resp.AppendMetadata...)
+	}
 	user.Metadatas = object.MetadataListToDomain(metadataList)
 	return user, userChanged, err
 }
@@ -202,13 +222,13 @@ func (l *Login) runPostInternalAuthenticationActions(
 			),
 		),
 	)
+	authErrStr := "none"
+	if authenticationError != nil {
+		authErrStr = authenticationError.Error()
+	}
 	for _, a := range triggerActions {
 		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
 
-		authErrStr := "none"
-		if authenticationError != nil {
-			authErrStr = authenticationError.Error()
-		}
 		ctxFields := actions.SetContextFields(
 			actions.SetFields("v1",
 				actions.SetFields("authMethod", authMethod),
@@ -231,6 +251,22 @@ func (l *Login) runPostInternalAuthenticationActions(
 			return nil, err
 		}
 	}
+
+	resp, err := l.runFunctionExecution(ctx, domain.FlowTypeInternalAuthentication, domain.TriggerTypePostAuthentication, func() (*ContextInfo, error) {
+		return &ContextInfo{
+			AuthRequest: object.AuthRequestForExecution(authRequest),
+			HTTPRequest: object.HTTPRequestForExecution(httpRequest),
+			AuthMethod:  authMethod,
+			AuthError:   authErrStr,
+			OrgID:       resourceOwner,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil {
+		metadataList.AppendExecutionMetadata(resp.AppendMetadata...)
+	}
 	return object.MetadataListToDomain(metadataList), err
 }
 
@@ -350,6 +386,22 @@ func (l *Login) runPreCreationActions(
 			return nil, nil, err
 		}
 	}
+
+	resp, err := l.runFunctionExecution(ctx, flowType, domain.TriggerTypePreCreation, func() (*ContextInfo, error) {
+		return &ContextInfo{
+			AuthRequest: object.AuthRequestForExecution(authRequest),
+			HTTPRequest: object.HTTPRequestForExecution(httpRequest),
+			User:        object.UserFromHumanForExecution(user),
+			OrgID:       resourceOwner,
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp != nil {
+		resp.SetHuman.applyToHuman(user)
+		metadataList.AppendExecutionMetadata(resp.AppendMetadata...)
+	}
 	return user, object.MetadataListToDomain(metadataList), err
 }
 
@@ -426,6 +478,25 @@ func (l *Login) runPostCreationActions(
 			return nil, err
 		}
 	}
+
+	resp, err := l.runFunctionExecution(ctx, flowType, domain.TriggerTypePostCreation, func() (*ContextInfo, error) {
+		user, err := l.query.GetUserByID(ctx, true, userID)
+		if err != nil {
+			return nil, err
+		}
+		return &ContextInfo{
+			AuthRequest: object.AuthRequestForExecution(authRequest),
+			HTTPRequest: object.HTTPRequestForExecution(httpRequest),
+			User:        object.UserFromQueryForExecution(user),
+			OrgID:       resourceOwner,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil {
+		mutableUserGrants.UserGrants = append(mutableUserGrants.UserGrants, resp.AppendUserGrants...)
+	}
 	return object.UserGrantsToDomain(userID, mutableUserGrants.UserGrants), err
 }
 
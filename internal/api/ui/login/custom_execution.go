@@ -0,0 +1,191 @@
+package login
+
+import (
+	"context"
+	"encoding/json"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// runFunctionExecution calls the targets of the function execution for the trigger type of the flow.
+// The information sent to the targets is only collected with getInfo if any target has to be called.
+// If no target was called or no target responded, the returned response is nil.
+func (l *Login) runFunctionExecution(
+	ctx context.Context,
+	flowType domain.FlowType,
+	triggerType domain.TriggerType,
+	getInfo func() (*ContextInfo, error),
+) (_ *ContextInfoResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	targets, err := execution.QueryExecutionTargetsForFunction(ctx, l.query, execution.FunctionID(flowType, triggerType))
+	if err != nil || len(targets) == 0 {
+		return nil, err
+	}
+	info, err := getInfo()
+	if err != nil {
+		return nil, err
+	}
+	info.Function = domain.ActionFunction(flowType, triggerType)
+	resp, err := execution.CallTargets(ctx, targets, info)
+	if err != nil {
+		return nil, err
+	}
+	response, _ := resp.(*ContextInfoResponse)
+	return response, nil
+}
+
+// ContextInfo is sent to the targets of the function executions of the login flows.
+// The objects are the same as the ones available in the actions of the flows.
+type ContextInfo struct {
+	Function     string               `json:"function,omitempty"`
+	AuthRequest  interface{}          `json:"auth_request,omitempty"`
+	HTTPRequest  interface{}          `json:"http_request,omitempty"`
+	AuthMethod   authMethod           `json:"auth_method,omitempty"`
+	AuthError    string               `json:"auth_error,omitempty"`
+	ExternalUser interface{}          `json:"external_user,omitempty"`
+	ProviderInfo interface{}          `json:"provider_info,omitempty"`
+	User         interface{}          `json:"user,omitempty"`
+	OrgID        string               `json:"org_id,omitempty"`
+	Response     *ContextInfoResponse `json:"response,omitempty"`
+}
+
+// ContextInfoResponse is the expected response of the targets of the function executions of the login flows.
+// Which parts of the response are applied depends on the flow and trigger type.
+type ContextInfoResponse struct {
+	SetHuman         *SetHuman                   `json:"set_human,omitempty"`
+	AppendMetadata   []*object.ExecutionMetadata `json:"append_metadata,omitempty"`
+	AppendUserGrants []object.UserGrant          `json:"append_user_grants,omitempty"`
+}
+
+// SetHuman contains the fields of the user which are changed,
+// the same as the set functions available in the actions.
+type SetHuman struct {
+	FirstName         *string              `json:"first_name,omitempty"`
+	LastName          *string              `json:"last_name,omitempty"`
+	NickName          *string              `json:"nick_name,omitempty"`
+	DisplayName       *string              `json:"display_name,omitempty"`
+	PreferredLanguage *string              `json:"preferred_language,omitempty"`
+	Gender            *domain.Gender       `json:"gender,omitempty"`
+	Username          *string              `json:"username,omitempty"`
+	PreferredUsername *string              `json:"preferred_username,omitempty"`
+	Email             *domain.EmailAddress `json:"email,omitempty"`
+	EmailVerified     *bool                `json:"email_verified,omitempty"`
+	Phone             *domain.PhoneNumber  `json:"phone,omitempty"`
+	PhoneVerified     *bool                `json:"phone_verified,omitempty"`
+}
+
+func (c *ContextInfo) GetHTTPRequestBody() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (c *ContextInfo) SetHTTPResponseBody(resp []byte) error {
+	if !json.Valid(resp) {
+		return zerrors.ThrowPreconditionFailed(nil, "LOGIN-3n9fs", "Errors.Execution.ResponseIsNotValidJSON")
+	}
+	if c.Response == nil {
+		c.Response = &ContextInfoResponse{}
+	}
+	return json.Unmarshal(resp, c.Response)
+}
+
+func (c *ContextInfo) GetContent() interface{} {
+	return c.Response
+}
+
+// applyToExternalUser sets the changed fields on the external user,
+// gender and username are ignored, as the external user has no such fields.
+func (s *SetHuman) applyToExternalUser(user *domain.ExternalUser) (changed bool) {
+	if s == nil {
+		return false
+	}
+	set := func(field *string, value *string) {
+		if value != nil {
+			*field = *value
+			changed = true
+		}
+	}
+	set(&user.FirstName, s.FirstName)
+	set(&user.LastName, s.LastName)
+	set(&user.NickName, s.NickName)
+	set(&user.DisplayName, s.DisplayName)
+	set(&user.PreferredUsername, s.PreferredUsername)
+	if s.PreferredLanguage != nil {
+		user.PreferredLanguage = language.Make(*s.PreferredLanguage)
+		changed = true
+	}
+	if s.Email != nil {
+		user.Email = *s.Email
+		changed = true
+	}
+	if s.EmailVerified != nil {
+		user.IsEmailVerified = *s.EmailVerified
+		changed = true
+	}
+	if s.Phone != nil {
+		user.Phone = *s.Phone
+		changed = true
+	}
+	if s.PhoneVerified != nil {
+		user.IsPhoneVerified = *s.PhoneVerified
+		changed = true
+	}
+	return changed
+}
+
+// applyToHuman sets the changed fields on the human which will be created,
+// the preferred username is ignored, as it only exists on external users.
+func (s *SetHuman) applyToHuman(user *domain.Human) {
+	if s == nil {
+		return
+	}
+	if user.Profile == nil {
+		user.Profile = &domain.Profile{}
+	}
+	setString(&user.FirstName, s.FirstName)
+	setString(&user.LastName, s.LastName)
+	setString(&user.NickName, s.NickName)
+	setString(&user.DisplayName, s.DisplayName)
+	setString(&user.Username, s.Username)
+	if s.PreferredLanguage != nil {
+		user.PreferredLanguage = language.Make(*s.PreferredLanguage)
+	}
+	if s.Gender != nil {
+		user.Gender = *s.Gender
+	}
+	if s.Email != nil {
+		if user.Email == nil {
+			user.Email = &domain.Email{}
+		}
+		user.Email.EmailAddress = *s.Email
+	}
+	if s.EmailVerified != nil && user.Email != nil {
+		user.Email.IsEmailVerified = *s.EmailVerified
+	}
+	if s.Phone != nil {
+		if user.Phone == nil {
+			user.Phone = &domain.Phone{}
+		}
+		user.Phone.PhoneNumber = *s.Phone
+	}
+	if s.PhoneVerified != nil && user.Phone != nil {
+		user.Phone.IsPhoneVerified = *s.PhoneVerified
+	}
+}
+
+func setString(field *string, value *string) {
+	if value != nil {
+		*field = *value
+	}
+}
@@ -0,0 +1,357 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed ended_aggregates.sql
+	endedAggregatesStmt string
+	//go:embed events.sql
+	eventsStmt string
+	//go:embed events_delete.sql
+	deleteEventsStmt string
+	//go:embed archive_add.sql
+	addArchiveStmt string
+)
+
+const archiveContentType = "application/octet-stream"
+
+type Config struct {
+	// Enabled starts the archival of events in the background
+	Enabled bool
+	// Interval in which the events of all instances are archived
+	Interval time.Duration
+	// BatchSize is the maximum amount of aggregates stored in a single archive
+	BatchSize uint16
+}
+
+// Archive describes an archive file containing the events of multiple aggregates
+type Archive struct {
+	ID              string
+	InstanceID      string
+	ObjectName      string
+	KeyID           string
+	EventCount      uint64
+	OldestCreatedAt time.Time
+	NewestCreatedAt time.Time
+	ArchivedAt      time.Time
+}
+
+type EventStore interface {
+	InstanceIDs(ctx context.Context, maxAge time.Duration, forceLoad bool, query *eventstore.SearchQueryBuilder) ([]string, error)
+}
+
+type Queries interface {
+	InstanceByID(ctx context.Context) (authz.Instance, error)
+}
+
+// Archiver moves events older than the audit log retention of the instance
+// from the eventstore into compressed and encrypted archives on the asset storage.
+//
+// Only the events of aggregates which reached their end of life (see [eventstore.RegisterEndOfLife])
+// are archived, as the events of all other aggregates are still needed by the write models.
+type Archiver struct {
+	config           *Config
+	client           *database.DB
+	es               EventStore
+	queries          Queries
+	storage          static.Storage
+	keyAlgorithm     crypto.EncryptionAlgorithm
+	defaultRetention time.Duration
+	idGenerator      id.Generator
+	now              func() time.Time
+}
+
+// New creates an archiver, es and queries are only required for the archival in the background
+func New(
+	config *Config,
+	client *database.DB,
+	es EventStore,
+	queries Queries,
+	storage static.Storage,
+	keyAlgorithm crypto.EncryptionAlgorithm,
+	defaultRetention time.Duration,
+) *Archiver {
+	return &Archiver{
+		config:           config,
+		client:           client,
+		es:               es,
+		queries:          queries,
+		storage:          storage,
+		keyAlgorithm:     keyAlgorithm,
+		defaultRetention: defaultRetention,
+		idGenerator:      id.SonyFlakeGenerator(),
+		now:              time.Now,
+	}
+}
+
+// Start archives the events of all instances in the configured interval until the context is done
+func (a *Archiver) Start(ctx context.Context) {
+	if a.config == nil || !a.config.Enabled {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(a.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.archiveInstances(ctx)
+			}
+		}
+	}()
+}
+
+func (a *Archiver) archiveInstances(ctx context.Context) {
+	instanceIDs, err := a.es.InstanceIDs(ctx, 0, true, eventstore.NewSearchQueryBuilder(eventstore.ColumnsInstanceIDs).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(instance.InstanceAddedEventType).
+		Builder(),
+	)
+	if err != nil {
+		logging.WithError(err).Warn("unable to query instances to archive")
+		return
+	}
+	for _, instanceID := range instanceIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		retention, err := a.retention(ctx, instanceID)
+		if err != nil {
+			logging.WithFields("instance", instanceID).WithError(err).Debug("unable to query audit log retention")
+			continue
+		}
+		if retention == 0 {
+			continue
+		}
+		before := a.now().Add(-retention)
+		for {
+			archive, err := a.ArchiveInstance(ctx, instanceID, before)
+			if err != nil {
+				logging.WithFields("instance", instanceID).WithError(err).Warn("unable to archive events")
+				break
+			}
+			if archive == nil {
+				break
+			}
+			logging.WithFields("instance", instanceID, "archive", archive.ID, "events", archive.EventCount).Info("events archived")
+		}
+	}
+}
+
+// retention returns the audit log retention of the instance, the default is used if the limit is not set
+func (a *Archiver) retention(ctx context.Context, instanceID string) (time.Duration, error) {
+	instance, err := a.queries.InstanceByID(authz.WithInstanceID(ctx, instanceID))
+	if err != nil {
+		return 0, err
+	}
+	if retention := instance.AuditLogRetention(); retention != nil {
+		return *retention, nil
+	}
+	return a.defaultRetention, nil
+}
+
+type endedAggregate struct {
+	aggregateType string
+	aggregateID   string
+	sequence      uint64
+}
+
+// ArchiveInstance archives the events of the next batch of aggregates of the instance
+// which reached their end of life before the given time.
+// The events are removed from the eventstore in the same transaction as the archive is registered.
+// It returns nil if no events are left to archive.
+func (a *Archiver) ArchiveInstance(ctx context.Context, instanceID string, before time.Time) (_ *Archive, err error) {
+	tx, err := a.client.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Tx4mq9", "unable to begin transaction")
+	}
+	var archive *Archive
+	defer func() {
+		if err != nil || archive == nil {
+			rollbackErr := tx.Rollback()
+			logging.OnError(rollbackErr).Debug("unable to rollback archival")
+		}
+		if err != nil && archive != nil {
+			removeErr := a.storage.RemoveObject(ctx, instanceID, instanceID, archive.ObjectName)
+			logging.WithFields("instance", instanceID, "object", archive.ObjectName).OnError(removeErr).Warn("unable to remove unused archive")
+		}
+	}()
+
+	aggregates, err := a.endedAggregates(ctx, tx, instanceID, before)
+	if err != nil || len(aggregates) == 0 {
+		return nil, err
+	}
+	condition, args := aggregatesCondition(instanceID, aggregates)
+	events, err := queryEvents(ctx, tx, instanceID, condition, args)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+
+	archive, err = a.store(ctx, instanceID, events)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.QueryRowContext(ctx, addArchiveStmt,
+		archive.InstanceID,
+		archive.ID,
+		archive.ObjectName,
+		archive.KeyID,
+		archive.EventCount,
+		archive.OldestCreatedAt,
+		archive.NewestCreatedAt,
+	).Scan(&archive.ArchivedAt)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Add2vn", "unable to add archive")
+	}
+	result, err := tx.ExecContext(ctx, fmt.Sprintf(deleteEventsStmt, condition), args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Del5rx", "unable to delete archived events")
+	}
+	// the events were changed concurrently, e.g. by another archiver
+	if deleted, err := result.RowsAffected(); err != nil || uint64(deleted) != archive.EventCount {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Del8wc", "archived events changed")
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Cmt3nd", "unable to commit archival")
+	}
+	return archive, nil
+}
+
+func (a *Archiver) endedAggregates(ctx context.Context, tx *sql.Tx, instanceID string, before time.Time) ([]*endedAggregate, error) {
+	args := []any{instanceID, before, a.config.BatchSize}
+	conditions := make([]string, 0, len(eventstore.EndOfLifeEventTypes()))
+	for aggregateType, eventTypes := range eventstore.EndOfLifeEventTypes() {
+		conditions = append(conditions, fmt.Sprintf("(e.aggregate_type = $%d AND e.event_type = ANY($%d))", len(args)+1, len(args)+2))
+		args = append(args, aggregateType, database.TextArray[eventstore.EventType](eventTypes))
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(endedAggregatesStmt, strings.Join(conditions, " OR ")), args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Agg6ty", "unable to query ended aggregates")
+	}
+	defer rows.Close()
+	var aggregates []*endedAggregate
+	for rows.Next() {
+		aggregate := new(endedAggregate)
+		if err = rows.Scan(&aggregate.aggregateType, &aggregate.aggregateID, &aggregate.sequence); err != nil {
+			return nil, zerrors.ThrowInternal(err, "ARCHIVE-Agg1pd", "unable to scan ended aggregate")
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Agg9ws", "unable to query ended aggregates")
+	}
+	return aggregates, nil
+}
+
+// aggregatesCondition returns the condition for the events of the aggregates up to their end of life,
+// the first argument is the instance id
+func aggregatesCondition(instanceID string, aggregates []*endedAggregate) (string, []any) {
+	args := make([]any, 1, len(aggregates)*3+1)
+	args[0] = instanceID
+	conditions := make([]string, len(aggregates))
+	for i, aggregate := range aggregates {
+		conditions[i] = fmt.Sprintf(`(aggregate_type = $%d AND aggregate_id = $%d AND "sequence" <= $%d)`, len(args)+1, len(args)+2, len(args)+3)
+		args = append(args, aggregate.aggregateType, aggregate.aggregateID, aggregate.sequence)
+	}
+	return strings.Join(conditions, " OR "), args
+}
+
+func queryEvents(ctx context.Context, tx *sql.Tx, instanceID, condition string, args []any) ([]*Event, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(eventsStmt, condition), args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Evt4kz", "unable to query events")
+	}
+	defer rows.Close()
+	var events []*Event
+	for rows.Next() {
+		event := &Event{InstanceID: instanceID}
+		var payload []byte
+		err = rows.Scan(
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.Revision,
+			&event.ResourceOwner,
+			&event.Creator,
+			&event.Type,
+			&payload,
+			&event.Sequence,
+			&event.CreatedAt,
+			&event.Position,
+			&event.InTxOrder,
+		)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "ARCHIVE-Evt7hb", "unable to scan event")
+		}
+		if len(payload) > 0 {
+			event.Payload = payload
+		}
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Evt2mf", "unable to query events")
+	}
+	return events, nil
+}
+
+// store writes the encrypted archive of the events to the asset storage
+func (a *Archiver) store(ctx context.Context, instanceID string, events []*Event) (*Archive, error) {
+	data, err := encodeEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := crypto.Encrypt(data, a.keyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	archiveID, err := a.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	archive := &Archive{
+		ID:              archiveID,
+		InstanceID:      instanceID,
+		ObjectName:      "eventstore/archives/" + archiveID,
+		KeyID:           encrypted.KeyID,
+		EventCount:      uint64(len(events)),
+		OldestCreatedAt: events[0].CreatedAt,
+		NewestCreatedAt: events[0].CreatedAt,
+	}
+	for _, event := range events {
+		if event.CreatedAt.Before(archive.OldestCreatedAt) {
+			archive.OldestCreatedAt = event.CreatedAt
+		}
+		if event.CreatedAt.After(archive.NewestCreatedAt) {
+			archive.NewestCreatedAt = event.CreatedAt
+		}
+	}
+	_, err = a.storage.PutObject(ctx, instanceID, "", instanceID, archive.ObjectName, archiveContentType, static.ObjectTypeEventArchive, bytes.NewReader(encrypted.Crypted), int64(len(encrypted.Crypted)))
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
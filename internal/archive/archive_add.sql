@@ -0,0 +1,17 @@
+INSERT INTO eventstore.archives (
+    instance_id
+    , id
+    , object_name
+    , key_id
+    , event_count
+    , oldest_created_at
+    , newest_created_at
+) VALUES (
+    $1
+    , $2
+    , $3
+    , $4
+    , $5
+    , $6
+    , $7
+) RETURNING archived_at;
@@ -0,0 +1 @@
+DELETE FROM eventstore.archives WHERE instance_id = $1 AND id = $2;
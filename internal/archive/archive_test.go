@@ -0,0 +1,172 @@
+package archive
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/static"
+	static_mock "github.com/zitadel/zitadel/internal/static/mock"
+)
+
+func TestArchiver_ArchiveInstance(t *testing.T) {
+	eventstore.RegisterEndOfLife("archive.aggregate", "archive.removed")
+
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	endedAggregatesQuery := fmt.Sprintf(endedAggregatesStmt, "(e.aggregate_type = $4 AND e.event_type = ANY($5))")
+	condition := `(aggregate_type = $2 AND aggregate_id = $3 AND "sequence" <= $4)`
+	endedAggregates := mock.ExpectQuery(endedAggregatesQuery,
+		mock.WithQueryArgs("instance", before, uint16(10), eventstore.AggregateType("archive.aggregate"), database.TextArray[eventstore.EventType]{"archive.removed"}),
+		mock.WithQueryResult(
+			[]string{"aggregate_type", "aggregate_id", "sequence"},
+			[][]driver.Value{{"archive.aggregate", "agg1", int64(2)}},
+		),
+	)
+	events := mock.ExpectQuery(fmt.Sprintf(eventsStmt, condition),
+		mock.WithQueryArgs("instance", "archive.aggregate", "agg1", uint64(2)),
+		mock.WithQueryResult(
+			[]string{"aggregate_type", "aggregate_id", "revision", "owner", "creator", "event_type", "payload", "sequence", "created_at", "position", "in_tx_order"},
+			[][]driver.Value{
+				{"archive.aggregate", "agg1", int64(1), "ro", "creator", "archive.added", []byte(`{"name":"name"}`), int64(1), createdAt, "1.1", int64(0)},
+				{"archive.aggregate", "agg1", int64(1), "ro", "creator", "archive.removed", nil, int64(2), createdAt.Add(time.Hour), "2.1", int64(0)},
+			},
+		),
+	)
+	addArchive := mock.ExpectQuery(addArchiveStmt,
+		mock.WithQueryArgs("instance", "archive1", "eventstore/archives/archive1", "id", uint64(2), createdAt, createdAt.Add(time.Hour)),
+		mock.WithQueryResult([]string{"archived_at"}, [][]driver.Value{{before}}),
+	)
+
+	tests := []struct {
+		name     string
+		mock     *mock.SQLMock
+		storage  func(*static_mock.MockStorage)
+		ids      []string
+		want     *Archive
+		wantErr  bool
+		wantNone bool
+	}{
+		{
+			name: "nothing to archive",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(endedAggregatesQuery,
+					mock.WithQueryArgs("instance", before, uint16(10), eventstore.AggregateType("archive.aggregate"), database.TextArray[eventstore.EventType]{"archive.removed"}),
+					mock.WithQueryResult([]string{"aggregate_type", "aggregate_id", "sequence"}, nil),
+				),
+				mock.ExpectRollback(nil),
+			),
+			storage:  func(*static_mock.MockStorage) {},
+			wantNone: true,
+		},
+		{
+			name: "archived",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				endedAggregates,
+				events,
+				addArchive,
+				mock.ExcpectExec(fmt.Sprintf(deleteEventsStmt, condition),
+					mock.WithExecArgs("instance", "archive.aggregate", "agg1", uint64(2)),
+					mock.WithExecRowsAffected(2),
+				),
+				mock.ExpectCommit(nil),
+			),
+			storage: func(storage *static_mock.MockStorage) {
+				storage.EXPECT().
+					PutObject(gomock.Any(), "instance", "", "instance", "eventstore/archives/archive1", archiveContentType, static.ObjectTypeEventArchive, gomock.Any(), gomock.Any()).
+					Return(&static.Asset{}, nil)
+			},
+			ids: []string{"archive1"},
+			want: &Archive{
+				ID:              "archive1",
+				InstanceID:      "instance",
+				ObjectName:      "eventstore/archives/archive1",
+				KeyID:           "id",
+				EventCount:      2,
+				OldestCreatedAt: createdAt,
+				NewestCreatedAt: createdAt.Add(time.Hour),
+				ArchivedAt:      before,
+			},
+		},
+		{
+			name: "events changed concurrently",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				endedAggregates,
+				events,
+				addArchive,
+				mock.ExcpectExec(fmt.Sprintf(deleteEventsStmt, condition),
+					mock.WithExecArgs("instance", "archive.aggregate", "agg1", uint64(2)),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExpectRollback(nil),
+			),
+			storage: func(storage *static_mock.MockStorage) {
+				storage.EXPECT().
+					PutObject(gomock.Any(), "instance", "", "instance", "eventstore/archives/archive1", archiveContentType, static.ObjectTypeEventArchive, gomock.Any(), gomock.Any()).
+					Return(&static.Asset{}, nil)
+				storage.EXPECT().
+					RemoveObject(gomock.Any(), "instance", "instance", "eventstore/archives/archive1").
+					Return(nil)
+			},
+			ids:     []string{"archive1"},
+			wantErr: true,
+		},
+		{
+			name: "storage fails",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				endedAggregates,
+				events,
+				mock.ExpectRollback(nil),
+			),
+			storage: func(storage *static_mock.MockStorage) {
+				storage.EXPECT().
+					PutObject(gomock.Any(), "instance", "", "instance", "eventstore/archives/archive1", archiveContentType, static.ObjectTypeEventArchive, gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("storage unavailable"))
+			},
+			ids:     []string{"archive1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := static_mock.NewMockStorage(ctrl)
+			tt.storage(storage)
+			a := &Archiver{
+				config:       &Config{BatchSize: 10},
+				client:       &database.DB{DB: tt.mock.DB},
+				storage:      storage,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(ctrl),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, tt.ids...),
+				now:          time.Now,
+			}
+
+			got, err := a.ArchiveInstance(context.Background(), "instance", before)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if !tt.wantNone && !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+			tt.mock.Assert(t)
+		})
+	}
+}
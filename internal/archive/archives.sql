@@ -0,0 +1,16 @@
+SELECT
+    id
+    , object_name
+    , key_id
+    , event_count
+    , oldest_created_at
+    , newest_created_at
+    , archived_at
+FROM
+    eventstore.archives
+WHERE
+    instance_id = $1
+    AND newest_created_at >= $2
+    AND oldest_created_at <= $3
+ORDER BY
+    oldest_created_at;
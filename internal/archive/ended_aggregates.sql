@@ -0,0 +1,25 @@
+SELECT
+    e.aggregate_type
+    , e.aggregate_id
+    , e."sequence"
+FROM
+    eventstore.events2 e
+WHERE
+    e.instance_id = $1
+    AND e.created_at < $2
+    AND (%s)
+    -- the end of life event must be the last event of the aggregate
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            eventstore.events2 n
+        WHERE
+            n.instance_id = e.instance_id
+            AND n.aggregate_type = e.aggregate_type
+            AND n.aggregate_id = e.aggregate_id
+            AND n."sequence" > e."sequence"
+    )
+ORDER BY
+    e.created_at
+LIMIT $3;
@@ -0,0 +1,67 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Event is the representation of an archived event.
+// The archives contain the events as gzip compressed newline-delimited JSON.
+// The position is kept as text to restore the exact value.
+type Event struct {
+	InstanceID    string          `json:"instanceId"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	ResourceOwner string          `json:"resourceOwner"`
+	Revision      uint16          `json:"revision"`
+	Sequence      uint64          `json:"sequence"`
+	Position      string          `json:"position"`
+	InTxOrder     uint32          `json:"inTxOrder"`
+	Type          string          `json:"type"`
+	Creator       string          `json:"creator"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+func encodeEvents(events []*Event) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	writer := gzip.NewWriter(buf)
+	encoder := json.NewEncoder(writer)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return nil, zerrors.ThrowInternal(err, "ARCHIVE-Enc3qs", "unable to encode event")
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Enc8vw", "unable to compress events")
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeEvents(data []byte) ([]*Event, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Dec2xk", "unable to decompress events")
+	}
+	defer reader.Close()
+	decoder := json.NewDecoder(bufio.NewReader(reader))
+	var events []*Event
+	for {
+		event := new(Event)
+		err := decoder.Decode(event)
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "ARCHIVE-Dec7pa", "unable to decode event")
+		}
+		events = append(events, event)
+	}
+}
@@ -0,0 +1,52 @@
+package archive
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_encodeEvents(t *testing.T) {
+	events := []*Event{
+		{
+			InstanceID:    "instance",
+			AggregateType: "user",
+			AggregateID:   "user1",
+			ResourceOwner: "org1",
+			Revision:      1,
+			Sequence:      1,
+			Position:      "1712324584.1234567890123456789",
+			Type:          "user.human.added",
+			Creator:       "creator",
+			CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Payload:       json.RawMessage(`{"userName":"gigi"}`),
+		},
+		{
+			InstanceID:    "instance",
+			AggregateType: "user",
+			AggregateID:   "user1",
+			ResourceOwner: "org1",
+			Revision:      1,
+			Sequence:      2,
+			Position:      "1712324590.1",
+			InTxOrder:     1,
+			Type:          "user.removed",
+			Creator:       "creator",
+			CreatedAt:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	data, err := encodeEvents(events)
+	require.NoError(t, err)
+	decoded, err := decodeEvents(data)
+	require.NoError(t, err)
+	assert.Equal(t, events, decoded)
+}
+
+func Test_decodeEvents_invalid(t *testing.T) {
+	_, err := decodeEvents([]byte(`{"type":"user.removed"}`))
+	require.Error(t, err)
+}
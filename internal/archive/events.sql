@@ -0,0 +1,21 @@
+SELECT
+    aggregate_type
+    , aggregate_id
+    , revision
+    , "owner"
+    , creator
+    , event_type
+    , payload
+    , "sequence"
+    , created_at
+    , "position"::TEXT
+    , in_tx_order
+FROM
+    eventstore.events2
+WHERE
+    instance_id = $1
+    AND (%s)
+ORDER BY
+    aggregate_type
+    , aggregate_id
+    , "sequence";
@@ -0,0 +1 @@
+DELETE FROM eventstore.events2 WHERE instance_id = $1 AND (%s);
@@ -0,0 +1,136 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed archives.sql
+	archivesStmt string
+	//go:embed archive_remove.sql
+	removeArchiveStmt string
+	//go:embed events_restore.sql
+	restoreEventsStmt string
+)
+
+// restoreBatchSize is the amount of events inserted per statement during a restore
+const restoreBatchSize = 1000
+
+// Archives returns the archives of the instance containing events created in the given time range.
+// If to is zero, the range is not limited.
+func (a *Archiver) Archives(ctx context.Context, instanceID string, from, to time.Time) ([]*Archive, error) {
+	if to.IsZero() {
+		to = a.now()
+	}
+	var archives []*Archive
+	err := a.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			archive := &Archive{InstanceID: instanceID}
+			err := rows.Scan(
+				&archive.ID,
+				&archive.ObjectName,
+				&archive.KeyID,
+				&archive.EventCount,
+				&archive.OldestCreatedAt,
+				&archive.NewestCreatedAt,
+				&archive.ArchivedAt,
+			)
+			if err != nil {
+				return err
+			}
+			archives = append(archives, archive)
+		}
+		return rows.Err()
+	}, archivesStmt, instanceID, from, to)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Lst5gq", "unable to query archives")
+	}
+	return archives, nil
+}
+
+// Events reads the events of the archive from the asset storage
+func (a *Archiver) Events(ctx context.Context, archive *Archive) ([]*Event, error) {
+	data, _, err := a.storage.GetObject(ctx, archive.InstanceID, archive.InstanceID, archive.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := a.keyAlgorithm.Decrypt(data, archive.KeyID)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHIVE-Rd4vtx", "unable to decrypt archive")
+	}
+	return decodeEvents(decrypted)
+}
+
+// Restore inserts the events of the archive back into the eventstore and removes the archive
+func (a *Archiver) Restore(ctx context.Context, archive *Archive) (err error) {
+	events, err := a.Events(ctx, archive)
+	if err != nil {
+		return err
+	}
+	tx, err := a.client.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "ARCHIVE-Rst1xq", "unable to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			logging.OnError(rollbackErr).Debug("unable to rollback restore")
+		}
+	}()
+	for start := 0; start < len(events); start += restoreBatchSize {
+		if err = restoreEvents(ctx, tx, events[start:min(start+restoreBatchSize, len(events))]); err != nil {
+			return err
+		}
+	}
+	if _, err = tx.ExecContext(ctx, removeArchiveStmt, archive.InstanceID, archive.ID); err != nil {
+		return zerrors.ThrowInternal(err, "ARCHIVE-Rst6mb", "unable to remove archive")
+	}
+	if err = tx.Commit(); err != nil {
+		return zerrors.ThrowInternal(err, "ARCHIVE-Rst9kd", "unable to commit restore")
+	}
+	err = a.storage.RemoveObject(ctx, archive.InstanceID, archive.InstanceID, archive.ObjectName)
+	logging.WithFields("instance", archive.InstanceID, "object", archive.ObjectName).OnError(err).Warn("unable to remove restored archive")
+	return nil
+}
+
+func restoreEvents(ctx context.Context, tx *sql.Tx, events []*Event) error {
+	placeholders := make([]string, len(events))
+	args := make([]any, 0, len(events)*12)
+	for i, event := range events {
+		placeholders[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d::DECIMAL, $%d)",
+			len(args)+1, len(args)+2, len(args)+3, len(args)+4, len(args)+5, len(args)+6,
+			len(args)+7, len(args)+8, len(args)+9, len(args)+10, len(args)+11, len(args)+12,
+		)
+		var payload []byte
+		if len(event.Payload) > 0 {
+			payload = event.Payload
+		}
+		args = append(args,
+			event.InstanceID,
+			event.ResourceOwner,
+			event.AggregateType,
+			event.AggregateID,
+			event.Revision,
+			event.Creator,
+			event.Type,
+			payload,
+			event.Sequence,
+			event.CreatedAt,
+			event.Position,
+			event.InTxOrder,
+		)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(restoreEventsStmt, strings.Join(placeholders, ", ")), args...); err != nil {
+		return zerrors.ThrowInternal(err, "ARCHIVE-Rst3wp", "unable to restore events")
+	}
+	return nil
+}
@@ -99,6 +99,10 @@ func (repo *TokenVerifierRepo) VerifyAccessToken(ctx context.Context, tokenStrin
 	if strings.HasPrefix(tokenID, command.IDPrefixV2) {
 		return repo.verifyAccessTokenV2(ctx, tokenID, verifierClientID, projectID)
 	}
+	// only tokens of OIDC sessions can be bound to a DPoP key
+	if authz.DPoPThumbprint(ctx) != "" {
+		return "", "", "", "", "", zerrors.ThrowUnauthenticated(nil, "APP-Dp0p1", "invalid token")
+	}
 	if sessionID, ok := strings.CutPrefix(tokenID, authz.SessionTokenPrefix); ok {
 		userID, clientID, resourceOwner, err = repo.verifySessionToken(ctx, sessionID, tokenString)
 		return
@@ -142,6 +146,11 @@ func (repo *TokenVerifierRepo) verifyAccessTokenV2(ctx context.Context, token, v
 	if activeToken.Actor != nil {
 		return "", "", "", "", "", zerrors.ThrowPermissionDenied(nil, "APP-Shi0J", "Errors.TokenExchange.Token.NotForAPI")
 	}
+	// a DPoP bound token must be presented with a proof of the same key
+	// and an unbound token must not be presented as DPoP token
+	if activeToken.DPoPJKT != authz.DPoPThumbprint(ctx) {
+		return "", "", "", "", "", zerrors.ThrowUnauthenticated(nil, "APP-Dp0p2", "invalid token")
+	}
 	if err = verifyAudience(activeToken.Audience, verifierClientID, projectID); err != nil {
 		return "", "", "", "", "", err
 	}
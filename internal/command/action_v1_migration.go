@@ -0,0 +1,238 @@
+package command
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// actionV1MaxTimeout is the timeout the actions v1 are limited to when they are run in the flows
+	actionV1MaxTimeout       = 20 * time.Second
+	actionV1TargetNamePrefix = "actions_v1_"
+)
+
+type MigrateActionsV1 struct {
+	// RunnerURL is the URL of the runner hosting the scripts of the actions v1,
+	// the created targets call <RunnerURL>/<organization id>/<action id>
+	RunnerURL string
+	// DryRun only reports the migration without creating the targets and executions
+	DryRun bool
+}
+
+func (m *MigrateActionsV1) IsValid() error {
+	u, err := url.Parse(m.RunnerURL)
+	if err != nil || m.RunnerURL == "" || !u.IsAbs() {
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-q3x8wd0vnk", "Errors.Action.InvalidRunnerURL")
+	}
+	return nil
+}
+
+// MigratedActionV1 reports the migration of an action v1 to a target
+type MigratedActionV1 struct {
+	ActionID      string
+	ResourceOwner string
+	Name          string
+	// TargetID is the ID of the target calling the runner with the script of the action, empty if the action was not migrated
+	TargetID string
+	// Functions are the functions of the executions the target is set on
+	Functions []string
+	// Modules are the modules required by the script, which are not provided by the runner and need manual attention
+	Modules []string
+	// Inactive actions are not migrated, as they are not run in the flows
+	Inactive bool
+}
+
+// actionV1Trigger is an action v1 which is triggered in the function of a flow of an organization
+type actionV1Trigger struct {
+	function string
+	orgPath  string
+	action   *ActionV1
+}
+
+// MigrateActionsV1 creates targets and executions for all actions v1 which are triggered in a flow of an organization.
+// The targets call the runner hosting the script of the action, the ID of the action is reused as ID of the target,
+// so repeated migrations don't create the targets twice and only add the missing targets to the executions.
+// As the executions are set for the whole instance, the targets are conditioned on the organization of the flow.
+func (c *Commands) MigrateActionsV1(ctx context.Context, migrate *MigrateActionsV1, resourceOwner string) (_ []*MigratedActionV1, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-j4n1ydm5ev", "Errors.IDMissing")
+	}
+	if err := migrate.IsValid(); err != nil {
+		return nil, err
+	}
+	wm := NewActionsV1WriteModel(resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+
+	triggers := wm.triggeredActions()
+	migrated := make([]*MigratedActionV1, 0)
+	migratedByID := make(map[string]*MigratedActionV1)
+	for _, trigger := range triggers {
+		m, ok := migratedByID[trigger.action.ID]
+		if !ok {
+			m = &MigratedActionV1{
+				ActionID:      trigger.action.ID,
+				ResourceOwner: trigger.action.ResourceOwner,
+				Name:          trigger.action.Name,
+				Functions:     make([]string, 0),
+				Modules:       actions.RequiredModules(trigger.action.Script),
+				Inactive:      trigger.action.State != domain.ActionStateActive,
+			}
+			if !m.Inactive {
+				m.TargetID = trigger.action.ID
+			}
+			migratedByID[trigger.action.ID] = m
+			migrated = append(migrated, m)
+		}
+		if !m.Inactive && !slices.Contains(m.Functions, trigger.function) {
+			m.Functions = append(m.Functions, trigger.function)
+		}
+	}
+	if migrate.DryRun {
+		return migrated, nil
+	}
+
+	for _, m := range migrated {
+		if m.Inactive {
+			continue
+		}
+		if err := c.addActionV1Target(ctx, wm.Actions[m.ActionID], migrate.RunnerURL, resourceOwner); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.setActionV1Executions(ctx, triggers, resourceOwner); err != nil {
+		return nil, err
+	}
+	return migrated, nil
+}
+
+// triggeredActions returns the triggered actions ordered by the functions of the flows,
+// the organizations and the order of the actions in the trigger
+func (wm *ActionsV1WriteModel) triggeredActions() []*actionV1Trigger {
+	orgIDs := make([]string, 0, len(wm.Flows))
+	for orgID := range wm.Flows {
+		orgIDs = append(orgIDs, orgID)
+	}
+	slices.Sort(orgIDs)
+
+	triggers := make([]*actionV1Trigger, 0)
+	for _, flowType := range domain.AllFlowTypes() {
+		for _, triggerType := range flowType.TriggerTypes() {
+			for _, orgID := range orgIDs {
+				for _, actionID := range wm.Flows[orgID][flowType][triggerType] {
+					a, ok := wm.Actions[actionID]
+					if !ok {
+						continue
+					}
+					triggers = append(triggers, &actionV1Trigger{
+						function: domain.ActionFunction(flowType, triggerType),
+						orgPath:  actionV1OrganizationPath(flowType),
+						action:   a,
+					})
+				}
+			}
+		}
+	}
+	return triggers
+}
+
+// actionV1OrganizationPath returns the path to the ID of the organization in the information sent to the targets of the flow
+func actionV1OrganizationPath(flowType domain.FlowType) string {
+	switch flowType {
+	case domain.FlowTypeCustomiseToken:
+		return "$.org.id"
+	case domain.FlowTypeCustomizeSAMLResponse:
+		return "$.user.resource_owner"
+	case domain.FlowTypeExternalAuthentication,
+		domain.FlowTypeInternalAuthentication,
+		domain.FlowTypeUnspecified:
+		fallthrough
+	default:
+		return "$.org_id"
+	}
+}
+
+func (c *Commands) addActionV1Target(ctx context.Context, a *ActionV1, runnerURL, resourceOwner string) error {
+	wm, err := c.getTargetWriteModelByID(ctx, a.ID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if wm.State.Exists() {
+		return nil
+	}
+	timeout := a.Timeout
+	if timeout <= 0 || timeout > actionV1MaxTimeout {
+		timeout = actionV1MaxTimeout
+	}
+	_, err = c.AddTarget(ctx, &AddTarget{
+		ObjectRoot:       models.ObjectRoot{AggregateID: a.ID},
+		Name:             actionV1TargetNamePrefix + a.ResourceOwner + "_" + a.Name,
+		TargetType:       domain.TargetTypeCall,
+		Endpoint:         strings.TrimSuffix(runnerURL, "/") + "/" + a.ResourceOwner + "/" + a.ID,
+		Timeout:          timeout,
+		InterruptOnError: !a.AllowedToFail,
+	}, resourceOwner)
+	return err
+}
+
+func (c *Commands) setActionV1Executions(ctx context.Context, triggers []*actionV1Trigger, resourceOwner string) error {
+	functions := make([]string, 0)
+	targets := make(map[string][]*execution.Target)
+	for _, trigger := range triggers {
+		if trigger.action.State != domain.ActionStateActive {
+			continue
+		}
+		if _, ok := targets[trigger.function]; !ok {
+			functions = append(functions, trigger.function)
+		}
+		targets[trigger.function] = append(targets[trigger.function], &execution.Target{
+			Type:   domain.ExecutionTargetTypeTarget,
+			Target: trigger.action.ID,
+			Condition: &domain.ExecutionTargetCondition{
+				Path:     trigger.orgPath,
+				Operator: domain.ExecutionTargetConditionOperatorEquals,
+				Value:    trigger.action.ResourceOwner,
+			},
+		})
+	}
+
+	for _, function := range functions {
+		cond := ExecutionFunctionCondition(function)
+		wm, err := c.getExecutionWriteModelByID(ctx, cond.ID(), resourceOwner)
+		if err != nil {
+			return err
+		}
+		existing := wm.executionTargets()
+		added := false
+		for _, target := range targets[function] {
+			if slices.ContainsFunc(existing, func(t *execution.Target) bool {
+				return t.Type == target.Type && t.Target == target.Target
+			}) {
+				continue
+			}
+			existing = append(existing, target)
+			added = true
+		}
+		if !added {
+			continue
+		}
+		if _, err := c.SetExecutionFunction(ctx, cond, &SetExecution{Targets: existing}, resourceOwner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// ActionsV1WriteModel contains the actions and flows of all organizations of the instance
+type ActionsV1WriteModel struct {
+	eventstore.WriteModel
+
+	Actions map[string]*ActionV1
+	// Flows contains the IDs of the actions per trigger type and flow type of each organization
+	Flows map[string]map[domain.FlowType]map[domain.TriggerType][]string
+}
+
+type ActionV1 struct {
+	ID            string
+	ResourceOwner string
+	Name          string
+	Script        string
+	Timeout       time.Duration
+	AllowedToFail bool
+	State         domain.ActionState
+}
+
+func NewActionsV1WriteModel(instanceID string) *ActionsV1WriteModel {
+	return &ActionsV1WriteModel{
+		WriteModel: eventstore.WriteModel{
+			InstanceID: instanceID,
+		},
+		Actions: make(map[string]*ActionV1),
+		Flows:   make(map[string]map[domain.FlowType]map[domain.TriggerType][]string),
+	}
+}
+
+func (wm *ActionsV1WriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *action.AddedEvent:
+			wm.Actions[e.Aggregate().ID] = &ActionV1{
+				ID:            e.Aggregate().ID,
+				ResourceOwner: e.Aggregate().ResourceOwner,
+				Name:          e.Name,
+				Script:        e.Script,
+				Timeout:       e.Timeout,
+				AllowedToFail: e.AllowedToFail,
+				State:         domain.ActionStateActive,
+			}
+		case *action.ChangedEvent:
+			a, ok := wm.Actions[e.Aggregate().ID]
+			if !ok {
+				continue
+			}
+			if e.Name != nil {
+				a.Name = *e.Name
+			}
+			if e.Script != nil {
+				a.Script = *e.Script
+			}
+			if e.Timeout != nil {
+				a.Timeout = *e.Timeout
+			}
+			if e.AllowedToFail != nil {
+				a.AllowedToFail = *e.AllowedToFail
+			}
+		case *action.DeactivatedEvent:
+			wm.setActionState(e.Aggregate().ID, domain.ActionStateInactive)
+		case *action.ReactivatedEvent:
+			wm.setActionState(e.Aggregate().ID, domain.ActionStateActive)
+		case *action.RemovedEvent:
+			delete(wm.Actions, e.Aggregate().ID)
+		case *org.TriggerActionsSetEvent:
+			wm.flow(e.Aggregate().ResourceOwner, e.FlowType)[e.TriggerType] = slices.Clone(e.ActionIDs)
+		case *org.TriggerActionsCascadeRemovedEvent:
+			triggers := wm.flow(e.Aggregate().ResourceOwner, e.FlowType)
+			triggers[e.TriggerType] = slices.DeleteFunc(triggers[e.TriggerType], func(id string) bool {
+				return id == e.ActionID
+			})
+		case *org.FlowClearedEvent:
+			delete(wm.Flows[e.Aggregate().ResourceOwner], e.FlowType)
+		case *org.OrgRemovedEvent:
+			delete(wm.Flows, e.Aggregate().ResourceOwner)
+			for id, a := range wm.Actions {
+				if a.ResourceOwner == e.Aggregate().ResourceOwner {
+					delete(wm.Actions, id)
+				}
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ActionsV1WriteModel) setActionState(id string, state domain.ActionState) {
+	if a, ok := wm.Actions[id]; ok {
+		a.State = state
+	}
+}
+
+func (wm *ActionsV1WriteModel) flow(resourceOwner string, flowType domain.FlowType) map[domain.TriggerType][]string {
+	flows, ok := wm.Flows[resourceOwner]
+	if !ok {
+		flows = make(map[domain.FlowType]map[domain.TriggerType][]string)
+		wm.Flows[resourceOwner] = flows
+	}
+	triggers, ok := flows[flowType]
+	if !ok {
+		triggers = make(map[domain.TriggerType][]string)
+		flows[flowType] = triggers
+	}
+	return triggers
+}
+
+func (wm *ActionsV1WriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(wm.InstanceID).
+		AddQuery().
+		AggregateTypes(action.AggregateType).
+		EventTypes(action.AddedEventType,
+			action.ChangedEventType,
+			action.DeactivatedEventType,
+			action.ReactivatedEventType,
+			action.RemovedEventType).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(org.TriggerActionsSetEventType,
+			org.TriggerActionsCascadeRemovedEventType,
+			org.FlowClearedEventType,
+			org.OrgRemovedEventType).
+		Builder()
+}
@@ -0,0 +1,284 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/target"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_MigrateActionsV1(t *testing.T) {
+	actionAddedEvent := func(id, resourceOwner, name, script string) *action.AddedEvent {
+		return action.NewAddedEvent(context.Background(),
+			&action.NewAggregate(id, resourceOwner).Aggregate,
+			name,
+			script,
+			5*time.Second,
+			false,
+		)
+	}
+	triggerActionsSetEvent := func(orgID string, flowType domain.FlowType, triggerType domain.TriggerType, actionIDs ...string) *org.TriggerActionsSetEvent {
+		return org.NewTriggerActionsSetEvent(context.Background(),
+			&org.NewAggregate(orgID).Aggregate,
+			flowType,
+			triggerType,
+			actionIDs,
+		)
+	}
+	migratedTargetAddedEvent := func(id, resourceOwner, name string) *target.AddedEvent {
+		return target.NewAddedEvent(context.Background(),
+			target.NewAggregate(id, "instance"),
+			"actions_v1_"+resourceOwner+"_"+name,
+			domain.TargetTypeCall,
+			"https://runner.example.com/"+resourceOwner+"/"+id,
+			5*time.Second,
+			true,
+			targetSigningKey("12345678"),
+			domain.TargetAuthTypeNone,
+			nil,
+		)
+	}
+	preUserinfo := domain.ActionFunction(domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation)
+	preAccessToken := domain.ActionFunction(domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation)
+	orgCondition := func(orgID string) *domain.ExecutionTargetCondition {
+		return &domain.ExecutionTargetCondition{
+			Path:     "$.org.id",
+			Operator: domain.ExecutionTargetConditionOperatorEquals,
+			Value:    orgID,
+		}
+	}
+
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		migrate       *MigrateActionsV1
+		resourceOwner string
+	}
+	type res struct {
+		migrated []*MigratedActionV1
+		err      func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no resourceowner, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				migrate:       &MigrateActionsV1{RunnerURL: "https://runner.example.com"},
+				resourceOwner: "",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"relative runner url, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				migrate:       &MigrateActionsV1{RunnerURL: "/actions/v1/runner"},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no flows, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(actionAddedEvent("action1", "org1", "name", "function name(ctx, api) {}")),
+					),
+				),
+			},
+			args{
+				migrate:       &MigrateActionsV1{RunnerURL: "https://runner.example.com"},
+				resourceOwner: "instance",
+			},
+			res{
+				migrated: []*MigratedActionV1{},
+			},
+		},
+		{
+			"dry run, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(actionAddedEvent("action1", "org1", "name", "let http = require('zitadel/http')")),
+						eventFromEventPusher(actionAddedEvent("action2", "org2", "inactive", "function inactive(ctx, api) {}")),
+						eventFromEventPusher(action.NewDeactivatedEvent(context.Background(), &action.NewAggregate("action2", "org2").Aggregate)),
+						eventFromEventPusher(actionAddedEvent("action3", "org1", "removed", "function removed(ctx, api) {}")),
+						eventFromEventPusher(action.NewRemovedEvent(context.Background(), &action.NewAggregate("action3", "org1").Aggregate, "removed")),
+						eventFromEventPusher(triggerActionsSetEvent("org1", domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, "action1", "action3")),
+						eventFromEventPusher(triggerActionsSetEvent("org1", domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, "action1")),
+						eventFromEventPusher(triggerActionsSetEvent("org2", domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, "action2")),
+					),
+				),
+			},
+			args{
+				migrate:       &MigrateActionsV1{RunnerURL: "https://runner.example.com", DryRun: true},
+				resourceOwner: "instance",
+			},
+			res{
+				migrated: []*MigratedActionV1{
+					{
+						ActionID:      "action1",
+						ResourceOwner: "org1",
+						Name:          "name",
+						TargetID:      "action1",
+						Functions:     []string{preUserinfo, preAccessToken},
+						Modules:       []string{"zitadel/http"},
+					},
+					{
+						ActionID:      "action2",
+						ResourceOwner: "org2",
+						Name:          "inactive",
+						Functions:     []string{},
+						Modules:       []string{},
+						Inactive:      true,
+					},
+				},
+			},
+		},
+		{
+			"migrate, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(actionAddedEvent("action1", "org1", "name", "function name(ctx, api) {}")),
+						eventFromEventPusher(triggerActionsSetEvent("org1", domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, "action1")),
+					),
+					// target of the action
+					expectFilter(),
+					expectFilter(),
+					expectPush(
+						migratedTargetAddedEvent("action1", "org1", "name"),
+					),
+					// execution of the function
+					expectFilter(
+						eventFromEventPusher(
+							execution.NewSetEventV2(context.Background(),
+								execution.NewAggregate("function/"+preUserinfo, "instance"),
+								[]*execution.Target{
+									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+								},
+								domain.ExecutionModeSequential,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(targetAddEvent("target", "instance")),
+						eventFromEventPusher(migratedTargetAddedEvent("action1", "org1", "name")),
+					),
+					expectPush(
+						execution.NewSetEventV2(context.Background(),
+							execution.NewAggregate("function/"+preUserinfo, "instance"),
+							[]*execution.Target{
+								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+								{Type: domain.ExecutionTargetTypeTarget, Target: "action1", Condition: orgCondition("org1")},
+							},
+							domain.ExecutionModeSequential,
+						),
+					),
+				),
+			},
+			args{
+				migrate:       &MigrateActionsV1{RunnerURL: "https://runner.example.com/"},
+				resourceOwner: "instance",
+			},
+			res{
+				migrated: []*MigratedActionV1{
+					{
+						ActionID:      "action1",
+						ResourceOwner: "org1",
+						Name:          "name",
+						TargetID:      "action1",
+						Functions:     []string{preUserinfo},
+						Modules:       []string{},
+					},
+				},
+			},
+		},
+		{
+			"already migrated, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(actionAddedEvent("action1", "org1", "name", "function name(ctx, api) {}")),
+						eventFromEventPusher(triggerActionsSetEvent("org1", domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, "action1")),
+					),
+					expectFilter(
+						eventFromEventPusher(migratedTargetAddedEvent("action1", "org1", "name")),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							execution.NewSetEventV2(context.Background(),
+								execution.NewAggregate("function/"+preUserinfo, "instance"),
+								[]*execution.Target{
+									{Type: domain.ExecutionTargetTypeTarget, Target: "action1", Condition: orgCondition("org1")},
+								},
+								domain.ExecutionModeSequential,
+							),
+						),
+					),
+				),
+			},
+			args{
+				migrate:       &MigrateActionsV1{RunnerURL: "https://runner.example.com"},
+				resourceOwner: "instance",
+			},
+			res{
+				migrated: []*MigratedActionV1{
+					{
+						ActionID:      "action1",
+						ResourceOwner: "org1",
+						Name:          "name",
+						TargetID:      "action1",
+						Functions:     []string{preUserinfo},
+						Modules:       []string{},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                  tt.fields.eventstore(t),
+				newEncryptedCodeWithDefault: mockEncryptedCodeWithDefault("12345678", 0),
+				targetEncryption:            crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				ActionFunctionExisting:      existsMock(true),
+			}
+			migrated, err := c.MigrateActionsV1(context.Background(), tt.args.migrate, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.migrated, migrated)
+			}
+		})
+	}
+}
@@ -63,6 +63,9 @@ func (c *Commands) SetExecutionRequest(ctx context.Context, cond *ExecutionAPICo
 	if err := cond.Existing(c); err != nil {
 		return nil, err
 	}
+	if err := set.sequentialOnly(); err != nil {
+		return nil, err
+	}
 	if set.AggregateID == "" {
 		set.AggregateID = cond.ID(domain.ExecutionTypeRequest)
 	}
@@ -109,6 +112,9 @@ func (c *Commands) SetExecutionFunction(ctx context.Context, cond ExecutionFunct
 	if err := cond.Existing(c); err != nil {
 		return nil, err
 	}
+	if err := set.sequentialOnly(); err != nil {
+		return nil, err
+	}
 	if set.AggregateID == "" {
 		set.AggregateID = cond.ID()
 	}
@@ -178,6 +184,7 @@ type SetExecution struct {
 	models.ObjectRoot
 
 	Targets []*execution.Target
+	Mode    domain.ExecutionMode
 }
 
 func (t SetExecution) GetIncludes() []string {
@@ -204,6 +211,30 @@ func (e *SetExecution) IsValid() error {
 	if len(e.Targets) == 0 {
 		return zerrors.ThrowInvalidArgument(nil, "COMMAND-56bteot2uj", "Errors.Execution.NoTargets")
 	}
+	if !e.Mode.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-u2dm8kq0vz", "Errors.Execution.Invalid")
+	}
+	for _, target := range e.Targets {
+		if target.Condition == nil {
+			continue
+		}
+		// includes are called with the conditions of the included execution
+		if target.Type != domain.ExecutionTargetTypeTarget {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-6yv1rj3mxo", "Errors.Execution.TargetConditionInvalid")
+		}
+		if err := target.Condition.IsValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sequentialOnly returns an error if the targets should be called in parallel,
+// which is not possible for executions where the response of a target is passed to the next target
+func (e *SetExecution) sequentialOnly() error {
+	if e.Mode == domain.ExecutionModeParallel {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-c9hw4n7tge", "Errors.Execution.ParallelModeInvalid")
+	}
 	return nil
 }
 
@@ -239,6 +270,7 @@ func (c *Commands) setExecution(ctx context.Context, set *SetExecution, resource
 		ctx,
 		ExecutionAggregateFromWriteModel(&wm.WriteModel),
 		set.Targets,
+		set.Mode,
 	)); err != nil {
 		return nil, err
 	}
@@ -26,6 +26,22 @@ func (e *ExecutionWriteModel) IncludeList() []string {
 	return includes
 }
 
+// executionTargets returns the targets and includes of the execution,
+// targets and includes set before the execution targets were introduced are converted
+func (e *ExecutionWriteModel) executionTargets() []*execution.Target {
+	if len(e.ExecutionTargets) > 0 {
+		return slices.Clone(e.ExecutionTargets)
+	}
+	targets := make([]*execution.Target, 0, len(e.Includes)+len(e.Targets))
+	for _, include := range e.Includes {
+		targets = append(targets, &execution.Target{Type: domain.ExecutionTargetTypeInclude, Target: include})
+	}
+	for _, target := range e.Targets {
+		targets = append(targets, &execution.Target{Type: domain.ExecutionTargetTypeTarget, Target: target})
+	}
+	return targets
+}
+
 func (e *ExecutionWriteModel) Exists() bool {
 	return len(e.ExecutionTargets) > 0 || len(e.Includes) > 0 || len(e.Targets) > 0
 }
@@ -39,6 +39,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -62,6 +63,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -76,6 +78,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -104,6 +107,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -127,6 +131,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -155,6 +160,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -164,6 +170,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -173,6 +180,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -197,6 +205,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -206,6 +215,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -220,6 +230,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -243,6 +254,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -252,6 +264,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -266,6 +279,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -289,6 +303,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -298,6 +313,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -307,6 +323,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -340,6 +357,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -349,6 +367,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -358,6 +377,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -381,6 +401,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -395,6 +416,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -404,6 +426,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -438,6 +461,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -452,6 +476,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -461,6 +486,7 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 						eventFromEventPusher(
@@ -178,6 +178,31 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 				err: zerrors.IsNotFound,
 			},
 		},
+		{
+			"parallel mode, error",
+			fields{
+				eventstore:       expectEventstore(),
+				grpcMethodExists: existsMock(true),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					"valid",
+					"",
+					false,
+				},
+				set: &SetExecution{
+					Targets: []*execution.Target{
+						{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+					},
+					Mode: domain.ExecutionModeParallel,
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			"push ok, method target",
 			fields{
@@ -191,6 +216,9 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								"https://example.com",
 								time.Second,
 								true,
+								nil,
+								domain.TargetAuthTypeNone,
+								nil,
 							),
 						),
 					),
@@ -200,6 +228,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -238,6 +267,9 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								"https://example.com",
 								time.Second,
 								true,
+								nil,
+								domain.TargetAuthTypeNone,
+								nil,
 							),
 						),
 					),
@@ -247,6 +279,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -285,6 +318,9 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								"https://example.com",
 								time.Second,
 								true,
+								nil,
+								domain.TargetAuthTypeNone,
+								nil,
 							),
 						),
 					),
@@ -294,6 +330,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -355,6 +392,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -365,6 +403,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -374,6 +413,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeInclude, Target: "request/include"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -436,6 +476,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -446,6 +487,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -455,6 +497,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeInclude, Target: "request/include"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -516,6 +559,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -526,6 +570,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -535,6 +580,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeInclude, Target: "request/include"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -704,6 +750,9 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							"https://example.com",
 							time.Second,
 							true,
+							nil,
+							domain.TargetAuthTypeNone,
+							nil,
 						),
 					),
 					expectPushFailed(
@@ -713,6 +762,7 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -784,6 +834,147 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 				err: zerrors.IsNotFound,
 			},
 		},
+		{
+			"invalid mode, error",
+			fields{
+				eventstore:       expectEventstore(),
+				grpcMethodExists: existsMock(true),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					"valid",
+					"",
+					false,
+				},
+				set: &SetExecution{
+					Targets: []*execution.Target{
+						{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+					},
+					Mode: 99,
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"condition on include, error",
+			fields{
+				eventstore:       expectEventstore(),
+				grpcMethodExists: existsMock(true),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					"valid",
+					"",
+					false,
+				},
+				set: &SetExecution{
+					Targets: []*execution.Target{
+						{
+							Type:      domain.ExecutionTargetTypeInclude,
+							Target:    "response/include",
+							Condition: &domain.ExecutionTargetCondition{Path: "$.userId", Operator: domain.ExecutionTargetConditionOperatorExists},
+						},
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"invalid condition, error",
+			fields{
+				eventstore:       expectEventstore(),
+				grpcMethodExists: existsMock(true),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					"valid",
+					"",
+					false,
+				},
+				set: &SetExecution{
+					Targets: []*execution.Target{
+						{
+							Type:      domain.ExecutionTargetTypeTarget,
+							Target:    "target",
+							Condition: &domain.ExecutionTargetCondition{Path: "userId", Operator: domain.ExecutionTargetConditionOperatorExists},
+						},
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"push ok, parallel with condition",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							target.NewAddedEvent(context.Background(),
+								target.NewAggregate("target", "instance"),
+								"name",
+								domain.TargetTypeWebhook,
+								"https://example.com",
+								time.Second,
+								true,
+								nil,
+								domain.TargetAuthTypeNone,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						execution.NewSetEventV2(context.Background(),
+							execution.NewAggregate("response/valid", "instance"),
+							[]*execution.Target{
+								{
+									Type:      domain.ExecutionTargetTypeTarget,
+									Target:    "target",
+									Condition: &domain.ExecutionTargetCondition{Path: "$.request.organization.orgId", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "org"},
+								},
+							},
+							domain.ExecutionModeParallel,
+						),
+					),
+				),
+				grpcMethodExists: existsMock(true),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					"valid",
+					"",
+					false,
+				},
+				set: &SetExecution{
+					Targets: []*execution.Target{
+						{
+							Type:      domain.ExecutionTargetTypeTarget,
+							Target:    "target",
+							Condition: &domain.ExecutionTargetCondition{Path: "$.request.organization.orgId", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "org"},
+						},
+					},
+					Mode: domain.ExecutionModeParallel,
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
 		{
 			"push ok, method target",
 			fields{
@@ -797,6 +988,9 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 								"https://example.com",
 								time.Second,
 								true,
+								nil,
+								domain.TargetAuthTypeNone,
+								nil,
 							),
 						),
 					),
@@ -806,6 +1000,7 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -844,6 +1039,7 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -882,6 +1078,7 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -1053,6 +1250,7 @@ func TestCommands_SetExecutionEvent(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -1137,6 +1335,7 @@ func TestCommands_SetExecutionEvent(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -1175,6 +1374,7 @@ func TestCommands_SetExecutionEvent(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -1213,6 +1413,7 @@ func TestCommands_SetExecutionEvent(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -1357,6 +1558,7 @@ func TestCommands_SetExecutionFunction(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -1430,6 +1632,7 @@ func TestCommands_SetExecutionFunction(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							domain.ExecutionModeSequential,
 						),
 					),
 				),
@@ -1548,6 +1751,7 @@ func TestCommands_DeleteExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -1603,6 +1807,7 @@ func TestCommands_DeleteExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -1639,6 +1844,7 @@ func TestCommands_DeleteExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -1675,6 +1881,7 @@ func TestCommands_DeleteExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -1796,6 +2003,7 @@ func TestCommands_DeleteExecutionResponse(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -1851,6 +2059,7 @@ func TestCommands_DeleteExecutionResponse(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -1887,6 +2096,7 @@ func TestCommands_DeleteExecutionResponse(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -1923,6 +2133,7 @@ func TestCommands_DeleteExecutionResponse(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2026,6 +2237,7 @@ func TestCommands_DeleteExecutionEvent(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2101,6 +2313,7 @@ func TestCommands_DeleteExecutionEvent(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2157,6 +2370,7 @@ func TestCommands_DeleteExecutionEvent(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2213,6 +2427,7 @@ func TestCommands_DeleteExecutionEvent(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2316,6 +2531,7 @@ func TestCommands_DeleteExecutionFunction(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2363,6 +2579,7 @@ func TestCommands_DeleteExecutionFunction(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2679,6 +2896,7 @@ func TestCommands_getExecutionIncludes(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2706,6 +2924,7 @@ func TestCommands_getExecutionIncludes(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2789,6 +3008,7 @@ func TestCommands_getExecutionIncludes(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2825,6 +3045,7 @@ func TestCommands_getExecutionIncludes(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								domain.ExecutionModeSequential,
 							),
 						),
 					),
@@ -2,9 +2,12 @@ package command
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"net/url"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/repository/target"
@@ -19,6 +22,11 @@ type AddTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	// Auth optionally contains the credentials used to authenticate the calls to the target
+	Auth *domain.TargetAuth
+
+	// SigningKey is set to the generated key used to sign the calls to the target
+	SigningKey string
 }
 
 func (a *AddTarget) IsValid() error {
@@ -32,8 +40,11 @@ func (a *AddTarget) IsValid() error {
 	if err != nil || a.Endpoint == "" {
 		return zerrors.ThrowInvalidArgument(err, "COMMAND-1r2k6qo6wg", "Errors.Target.InvalidURL")
 	}
+	if err := validateTargetEndpoint(a.TargetType, a.Endpoint); err != nil {
+		return err
+	}
 
-	return nil
+	return validateTargetAuth(a.Auth)
 }
 
 func (c *Commands) AddTarget(ctx context.Context, add *AddTarget, resourceOwner string) (_ *domain.ObjectDetails, err error) {
@@ -58,6 +69,14 @@ func (c *Commands) AddTarget(ctx context.Context, add *AddTarget, resourceOwner
 	if wm.State.Exists() {
 		return nil, zerrors.ThrowAlreadyExists(nil, "INSTANCE-9axkz0jvzm", "Errors.Target.AlreadyExists")
 	}
+	signingKey, err := c.newTargetSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	authType, auth, err := c.encryptTargetAuth(add.Auth)
+	if err != nil {
+		return nil, err
+	}
 
 	pushedEvents, err := c.eventstore.Push(ctx, target.NewAddedEvent(
 		ctx,
@@ -67,6 +86,9 @@ func (c *Commands) AddTarget(ctx context.Context, add *AddTarget, resourceOwner
 		add.Endpoint,
 		add.Timeout,
 		add.InterruptOnError,
+		signingKey.Crypted,
+		authType,
+		auth,
 	))
 	if err != nil {
 		return nil, err
@@ -74,6 +96,7 @@ func (c *Commands) AddTarget(ctx context.Context, add *AddTarget, resourceOwner
 	if err := AppendAndReduce(wm, pushedEvents...); err != nil {
 		return nil, err
 	}
+	add.SigningKey = signingKey.Plain
 	return writeModelToObjectDetails(&wm.WriteModel), nil
 }
 
@@ -85,6 +108,8 @@ type ChangeTarget struct {
 	Endpoint         *string
 	Timeout          *time.Duration
 	InterruptOnError *bool
+	// Auth replaces the credentials of the target, [domain.TargetAuthTypeNone] removes them
+	Auth *domain.TargetAuth
 }
 
 func (a *ChangeTarget) IsValid() error {
@@ -103,7 +128,7 @@ func (a *ChangeTarget) IsValid() error {
 			return zerrors.ThrowInvalidArgument(err, "COMMAND-jsbaera7b6", "Errors.Target.InvalidURL")
 		}
 	}
-	return nil
+	return validateTargetAuth(a.Auth)
 }
 
 func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resourceOwner string) (*domain.ObjectDetails, error) {
@@ -121,6 +146,28 @@ func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resou
 	if !existing.State.Exists() {
 		return nil, zerrors.ThrowNotFound(nil, "COMMAND-xj14f2cccn", "Errors.Target.NotFound")
 	}
+	targetType, endpoint := existing.TargetType, existing.Endpoint
+	if change.TargetType != nil {
+		targetType = *change.TargetType
+	}
+	if change.Endpoint != nil {
+		endpoint = *change.Endpoint
+	}
+	if err := validateTargetEndpoint(targetType, endpoint); err != nil {
+		return nil, err
+	}
+
+	var (
+		authType *domain.TargetAuthType
+		auth     *crypto.CryptoValue
+	)
+	if change.Auth != nil {
+		changedType, crypted, err := c.encryptTargetAuth(change.Auth)
+		if err != nil {
+			return nil, err
+		}
+		authType, auth = &changedType, crypted
+	}
 
 	changedEvent := existing.NewChangedEvent(
 		ctx,
@@ -129,7 +176,10 @@ func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resou
 		change.TargetType,
 		change.Endpoint,
 		change.Timeout,
-		change.InterruptOnError)
+		change.InterruptOnError,
+		authType,
+		auth,
+	)
 	if changedEvent == nil {
 		return writeModelToObjectDetails(&existing.WriteModel), nil
 	}
@@ -169,6 +219,125 @@ func (c *Commands) DeleteTarget(ctx context.Context, id, resourceOwner string) (
 	return writeModelToObjectDetails(&existing.WriteModel), nil
 }
 
+// RotateTargetSigningKey generates a new key used to sign the calls to the target, the old key is not valid anymore.
+func (c *Commands) RotateTargetSigningKey(ctx context.Context, id, resourceOwner string) (_ *domain.ObjectDetails, signingKey string, err error) {
+	if id == "" || resourceOwner == "" {
+		return nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-8wqn3dkr2l", "Errors.IDMissing")
+	}
+
+	existing, err := c.getTargetWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, "", err
+	}
+	if !existing.State.Exists() {
+		return nil, "", zerrors.ThrowNotFound(nil, "COMMAND-5m2jq7xv0c", "Errors.Target.NotFound")
+	}
+	code, err := c.newTargetSigningKey(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		target.NewChangedEvent(ctx,
+			TargetAggregateFromWriteModel(&existing.WriteModel),
+			[]target.Changes{target.ChangeSigningKey(code.Crypted)},
+		),
+	); err != nil {
+		return nil, "", err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), code.Plain, nil
+}
+
+func (c *Commands) newTargetSigningKey(ctx context.Context) (*EncryptedCode, error) {
+	var defaultConfig *crypto.GeneratorConfig
+	if c.defaultSecretGenerators != nil {
+		defaultConfig = c.defaultSecretGenerators.SigningKey
+	}
+	if defaultConfig == nil {
+		defaultConfig = defaultSigningKeyConfig
+	}
+	return c.newEncryptedCodeWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeSigningKey, c.targetEncryption, defaultConfig)
+}
+
+// defaultSigningKeyConfig is used if no default config for signing keys is provided
+var defaultSigningKeyConfig = &crypto.GeneratorConfig{
+	Length:              32,
+	IncludeLowerLetters: true,
+	IncludeUpperLetters: true,
+	IncludeDigits:       true,
+}
+
+// validateTargetEndpoint checks that the endpoints of gRPC targets contain the host to connect to
+// and the scheme which defines if TLS is used (https) or not (http)
+func validateTargetEndpoint(targetType domain.TargetType, endpoint string) error {
+	if targetType != domain.TargetTypeGRPC {
+		return nil
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-k4w0q7z9ns", "Errors.Target.InvalidURL")
+	}
+	return nil
+}
+
+func validateTargetAuth(auth *domain.TargetAuth) error {
+	if auth == nil {
+		return nil
+	}
+	switch auth.Type {
+	case domain.TargetAuthTypeNone:
+		return nil
+	case domain.TargetAuthTypeHeaders:
+		if len(auth.Headers) == 0 {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-n5d2vjz3ql", "Errors.Target.InvalidAuth")
+		}
+		for name := range auth.Headers {
+			if name == "" {
+				return zerrors.ThrowInvalidArgument(nil, "COMMAND-w2p7tkx8gs", "Errors.Target.InvalidAuth")
+			}
+		}
+	case domain.TargetAuthTypeBasic:
+		if auth.Username == "" {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-3y9hs0cmpq", "Errors.Target.InvalidAuth")
+		}
+	case domain.TargetAuthTypeBearer:
+		if auth.Token == "" {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-e8mqz1vb4d", "Errors.Target.InvalidAuth")
+		}
+	case domain.TargetAuthTypeClientCredentials:
+		if auth.ClientID == "" {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-q6c0rj5lue", "Errors.Target.InvalidAuth")
+		}
+		if _, err := url.ParseRequestURI(auth.TokenEndpoint); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "COMMAND-7f4kwhn2ia", "Errors.Target.InvalidAuth")
+		}
+	case domain.TargetAuthTypeClientCertificate:
+		if _, err := tls.X509KeyPair(auth.Certificate, auth.Key); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "COMMAND-k1xu8bg6oz", "Errors.Target.InvalidAuth")
+		}
+	default:
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-9lr3ycm7pf", "Errors.Target.InvalidAuth")
+	}
+	return nil
+}
+
+// encryptTargetAuth returns the type and the encrypted credentials of the target, which are empty if no authentication is used
+func (c *Commands) encryptTargetAuth(auth *domain.TargetAuth) (domain.TargetAuthType, *crypto.CryptoValue, error) {
+	if auth == nil || auth.Type == domain.TargetAuthTypeNone {
+		return domain.TargetAuthTypeNone, nil, nil
+	}
+	value, err := json.Marshal(auth)
+	if err != nil {
+		return domain.TargetAuthTypeNone, nil, zerrors.ThrowInternal(err, "COMMAND-s4vbn8x2ke", "Errors.Internal")
+	}
+	crypted, err := crypto.Encrypt(value, c.targetEncryption)
+	if err != nil {
+		return domain.TargetAuthTypeNone, nil, err
+	}
+	return auth.Type, crypted, nil
+}
+
 func (c *Commands) existsTargetsByIDs(ctx context.Context, ids []string, resourceOwner string) bool {
 	wm := NewTargetsExistsWriteModel(ids, resourceOwner)
 	err := c.eventstore.FilterToQueryReducer(ctx, wm)
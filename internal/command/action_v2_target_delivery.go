@@ -0,0 +1,210 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/delivery"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AddTargetDeliveries persists calls to async targets, which are delivered and retried until they succeed or fail on all attempts.
+// Either all or none of the deliveries are added.
+func (c *Commands) AddTargetDeliveries(ctx context.Context, resourceOwner string, deliveries []*domain.TargetDelivery) (ids []string, err error) {
+	ids, cmds, err := c.targetDeliveryAddedEvents(ctx, resourceOwner, deliveries)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.eventstore.Push(ctx, cmds...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (c *Commands) targetDeliveryAddedEvents(ctx context.Context, resourceOwner string, deliveries []*domain.TargetDelivery) (ids []string, cmds []eventstore.Command, err error) {
+	if resourceOwner == "" {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-d4lw0ktbfz", "Errors.IDMissing")
+	}
+	ids = make([]string, len(deliveries))
+	cmds = make([]eventstore.Command, len(deliveries))
+	for i, d := range deliveries {
+		if d.TargetID == "" {
+			return nil, nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ly6x5bjb5d", "Errors.IDMissing")
+		}
+		ids[i], err = c.idGenerator.Next()
+		if err != nil {
+			return nil, nil, err
+		}
+		body, err := crypto.Encrypt(d.Body, c.targetEncryption)
+		if err != nil {
+			return nil, nil, err
+		}
+		cmds[i] = delivery.NewAddedEvent(ctx,
+			delivery.NewAggregate(ids[i], resourceOwner),
+			d.TargetID,
+			d.ExecutionID,
+			d.Deliverer,
+			body,
+		)
+	}
+	return ids, cmds, nil
+}
+
+// StartTargetDeliveryAttempt claims the next attempt of a delivery, which is either due or of which the last attempt was started longer than staleAfter ago.
+// Only one caller is able to start an attempt, all others receive an error.
+func (c *Commands) StartTargetDeliveryAttempt(ctx context.Context, id, resourceOwner string, staleAfter time.Duration) (attempt uint32, err error) {
+	if id == "" || resourceOwner == "" {
+		return 0, zerrors.ThrowInvalidArgument(nil, "COMMAND-xzap7ve0cs", "Errors.IDMissing")
+	}
+	existing, err := c.getTargetDeliveryWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return 0, err
+	}
+	var staleAttempt uint32
+	switch existing.State {
+	case domain.TargetDeliveryStatePending:
+		if existing.NextAttempt.After(time.Now()) {
+			return 0, zerrors.ThrowPreconditionFailed(nil, "COMMAND-y1cshbch4k", "Errors.Target.Delivery.NotDue")
+		}
+	case domain.TargetDeliveryStateAttempting:
+		if existing.AttemptStarted.Add(staleAfter).After(time.Now()) {
+			return 0, zerrors.ThrowPreconditionFailed(nil, "COMMAND-f7ajdc4nq4", "Errors.Target.Delivery.AlreadyAttempted")
+		}
+		staleAttempt = existing.Attempt
+	case domain.TargetDeliveryStateUnspecified:
+		return 0, zerrors.ThrowNotFound(nil, "COMMAND-kb4edeils5", "Errors.Target.Delivery.NotFound")
+	case domain.TargetDeliveryStateSucceeded, domain.TargetDeliveryStateFailed:
+		return 0, zerrors.ThrowPreconditionFailed(nil, "COMMAND-yn1kghzl77", "Errors.Target.Delivery.NotDue")
+	}
+
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		delivery.NewAttemptStartedEvent(ctx,
+			TargetDeliveryAggregateFromWriteModel(&existing.WriteModel),
+			existing.Attempt+1,
+			staleAttempt,
+		),
+	); err != nil {
+		return 0, err
+	}
+	return existing.Attempt, nil
+}
+
+// SucceedTargetDelivery finishes the delivery after a successful attempt.
+func (c *Commands) SucceedTargetDelivery(ctx context.Context, id, resourceOwner string, attempt uint32) (*domain.ObjectDetails, error) {
+	existing, err := c.attemptingTargetDelivery(ctx, id, resourceOwner, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		delivery.NewSucceededEvent(ctx,
+			TargetDeliveryAggregateFromWriteModel(&existing.WriteModel),
+			attempt,
+		),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// FailTargetDeliveryAttempt records the failed attempt, the delivery is retried at nextAttempt.
+func (c *Commands) FailTargetDeliveryAttempt(ctx context.Context, id, resourceOwner string, attempt uint32, reason error, nextAttempt time.Time) (*domain.ObjectDetails, error) {
+	existing, err := c.attemptingTargetDelivery(ctx, id, resourceOwner, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		delivery.NewAttemptFailedEvent(ctx,
+			TargetDeliveryAggregateFromWriteModel(&existing.WriteModel),
+			attempt,
+			deliveryErrorMessage(reason),
+			nextAttempt,
+		),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// FailTargetDelivery records the last failed attempt, the delivery is not retried until it is redriven.
+func (c *Commands) FailTargetDelivery(ctx context.Context, id, resourceOwner string, attempt uint32, reason error) (*domain.ObjectDetails, error) {
+	existing, err := c.attemptingTargetDelivery(ctx, id, resourceOwner, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		delivery.NewFailedEvent(ctx,
+			TargetDeliveryAggregateFromWriteModel(&existing.WriteModel),
+			attempt,
+			deliveryErrorMessage(reason),
+		),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// RedriveTargetDelivery schedules a failed delivery to be delivered again with all attempts.
+func (c *Commands) RedriveTargetDelivery(ctx context.Context, id, resourceOwner string) (*domain.ObjectDetails, error) {
+	if id == "" || resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-mgkcao20vf", "Errors.IDMissing")
+	}
+	existing, err := c.getTargetDeliveryWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-3sqayjzqis", "Errors.Target.Delivery.NotFound")
+	}
+	if existing.State != domain.TargetDeliveryStateFailed {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieas0xdvso", "Errors.Target.Delivery.NotFailed")
+	}
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		delivery.NewRedrivenEvent(ctx,
+			TargetDeliveryAggregateFromWriteModel(&existing.WriteModel),
+		),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) attemptingTargetDelivery(ctx context.Context, id, resourceOwner string, attempt uint32) (*TargetDeliveryWriteModel, error) {
+	if id == "" || resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-9favfnfzar", "Errors.IDMissing")
+	}
+	existing, err := c.getTargetDeliveryWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-73318nav94", "Errors.Target.Delivery.NotFound")
+	}
+	if !existing.isAttempting(attempt) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-7m6we0z7o1", "Errors.Target.Delivery.AlreadyAttempted")
+	}
+	return existing, nil
+}
+
+func deliveryErrorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func (c *Commands) getTargetDeliveryWriteModelByID(ctx context.Context, id string, resourceOwner string) (*TargetDeliveryWriteModel, error) {
+	wm := NewTargetDeliveryWriteModel(id, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, wm)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
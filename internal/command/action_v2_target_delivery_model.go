@@ -0,0 +1,89 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/delivery"
+)
+
+type TargetDeliveryWriteModel struct {
+	eventstore.WriteModel
+
+	TargetID       string
+	ExecutionID    string
+	Attempt        uint32
+	AttemptStarted time.Time
+	NextAttempt    time.Time
+
+	State domain.TargetDeliveryState
+}
+
+func NewTargetDeliveryWriteModel(id string, resourceOwner string) *TargetDeliveryWriteModel {
+	return &TargetDeliveryWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+			InstanceID:    resourceOwner,
+		},
+	}
+}
+
+func (wm *TargetDeliveryWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *delivery.AddedEvent:
+			wm.TargetID = e.TargetID
+			wm.ExecutionID = e.ExecutionID
+			wm.NextAttempt = e.CreationDate()
+			wm.State = domain.TargetDeliveryStatePending
+		case *delivery.AttemptStartedEvent:
+			wm.Attempt = e.Attempt
+			wm.AttemptStarted = e.CreationDate()
+			wm.State = domain.TargetDeliveryStateAttempting
+		case *delivery.AttemptFailedEvent:
+			wm.NextAttempt = e.NextAttempt
+			wm.State = domain.TargetDeliveryStatePending
+		case *delivery.SucceededEvent:
+			wm.State = domain.TargetDeliveryStateSucceeded
+		case *delivery.FailedEvent:
+			wm.State = domain.TargetDeliveryStateFailed
+		case *delivery.RedrivenEvent:
+			wm.NextAttempt = e.CreationDate()
+			wm.State = domain.TargetDeliveryStatePending
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *TargetDeliveryWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(delivery.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(delivery.AddedEventType,
+			delivery.AttemptStartedEventType,
+			delivery.AttemptFailedEventType,
+			delivery.SucceededEventType,
+			delivery.FailedEventType,
+			delivery.RedrivenEventType).
+		Builder()
+}
+
+// isAttempting checks if the attempt is the current attempt of the delivery,
+// if not the attempt was taken over by another worker
+func (wm *TargetDeliveryWriteModel) isAttempting(attempt uint32) bool {
+	return wm.State == domain.TargetDeliveryStateAttempting && wm.Attempt == attempt
+}
+
+func TargetDeliveryAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            wm.AggregateID,
+		Type:          delivery.AggregateType,
+		ResourceOwner: wm.ResourceOwner,
+		InstanceID:    wm.InstanceID,
+		Version:       delivery.AggregateVersion,
+	}
+}
@@ -0,0 +1,945 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/delivery"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func targetDeliveryAddEvent(id, resourceOwner string) *delivery.AddedEvent {
+	return delivery.NewAddedEvent(context.Background(),
+		delivery.NewAggregate(id, resourceOwner),
+		"target",
+		"event",
+		"",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte(`{"key":"value"}`),
+		},
+	)
+}
+
+func targetDelivery() *domain.TargetDelivery {
+	return &domain.TargetDelivery{
+		TargetID:    "target",
+		ExecutionID: "event",
+		Body:        []byte(`{"key":"value"}`),
+	}
+}
+
+func TestCommands_AddTargetDeliveries(t *testing.T) {
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		deliveries    []*domain.TargetDelivery
+	}
+	type res struct {
+		ids []string
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no resourceowner, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "",
+				deliveries:    []*domain.TargetDelivery{{TargetID: "target"}},
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no target, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "instance",
+				deliveries:    []*domain.TargetDelivery{{}},
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"push failed, error",
+			fields{
+				eventstore: expectEventstore(
+					expectPushFailed(
+						zerrors.ThrowPreconditionFailed(nil, "id", "name already exists"),
+						targetDeliveryAddEvent("id1", "instance"),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "instance",
+				deliveries:    []*domain.TargetDelivery{targetDelivery()},
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"add ok",
+			fields{
+				eventstore: expectEventstore(
+					expectPush(
+						targetDeliveryAddEvent("id1", "instance"),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "instance",
+				deliveries:    []*domain.TargetDelivery{targetDelivery()},
+			},
+			res{
+				ids: []string{"id1"},
+			},
+		},
+		{
+			"add multiple ok",
+			fields{
+				eventstore: expectEventstore(
+					expectPush(
+						targetDeliveryAddEvent("id1", "instance"),
+						targetDeliveryAddEvent("id2", "instance"),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id1", "id2"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "instance",
+				deliveries:    []*domain.TargetDelivery{targetDelivery(), targetDelivery()},
+			},
+			res{
+				ids: []string{"id1", "id2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:       tt.fields.eventstore(t),
+				idGenerator:      tt.fields.idGenerator,
+				targetEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			ids, err := c.AddTargetDeliveries(tt.args.ctx, tt.args.resourceOwner, tt.args.deliveries)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.ids, ids)
+			}
+		})
+	}
+}
+
+func TestCommands_StartTargetDeliveryAttempt(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+		staleAfter    time.Duration
+	}
+	type res struct {
+		attempt uint32
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"not due, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptFailedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								"failed",
+								time.Now().Add(time.Hour),
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"already attempting, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusherWithCreationDateNow(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+				staleAfter:    time.Minute,
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"already failed, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+						eventFromEventPusher(
+							delivery.NewFailedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								"failed",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"attempt started by other, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+					),
+					expectPushFailed(
+						zerrors.ThrowAlreadyExists(nil, "id", "Errors.Target.Delivery.AlreadyAttempted"),
+						delivery.NewAttemptStartedEvent(context.Background(),
+							delivery.NewAggregate("id1", "instance"),
+							1,
+							0,
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			"first attempt, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+					),
+					expectPush(
+						delivery.NewAttemptStartedEvent(context.Background(),
+							delivery.NewAggregate("id1", "instance"),
+							1,
+							0,
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				attempt: 1,
+			},
+		},
+		{
+			"retry due, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptFailedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								"failed",
+								time.Now().Add(-time.Minute),
+							),
+						),
+					),
+					expectPush(
+						delivery.NewAttemptStartedEvent(context.Background(),
+							delivery.NewAggregate("id1", "instance"),
+							2,
+							0,
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				attempt: 2,
+			},
+		},
+		{
+			"stale attempt, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+					),
+					expectPush(
+						delivery.NewAttemptStartedEvent(context.Background(),
+							delivery.NewAggregate("id1", "instance"),
+							2,
+							1,
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+				staleAfter:    time.Minute,
+			},
+			res{
+				attempt: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			attempt, err := c.StartTargetDeliveryAttempt(tt.args.ctx, tt.args.id, tt.args.resourceOwner, tt.args.staleAfter)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.attempt, attempt)
+			}
+		})
+	}
+}
+
+func TestCommands_FailTargetDeliveryAttempt(t *testing.T) {
+	nextAttempt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+		attempt       uint32
+		reason        error
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "",
+				resourceOwner: "instance",
+				attempt:       1,
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+				attempt:       1,
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"attempt taken over, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								2,
+								1,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+				attempt:       1,
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"fail attempt, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+					),
+					expectPush(
+						delivery.NewAttemptFailedEvent(context.Background(),
+							delivery.NewAggregate("id1", "instance"),
+							1,
+							"connection refused",
+							nextAttempt,
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+				attempt:       1,
+				reason:        errors.New("connection refused"),
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.FailTargetDeliveryAttempt(tt.args.ctx, tt.args.id, tt.args.resourceOwner, tt.args.attempt, tt.args.reason, nextAttempt)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_SucceedTargetDelivery(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+		attempt       uint32
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"not attempting, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+				attempt:       1,
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"succeed, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+					),
+					expectPush(
+						delivery.NewSucceededEvent(context.Background(),
+							delivery.NewAggregate("id1", "instance"),
+							1,
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+				attempt:       1,
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.SucceedTargetDelivery(tt.args.ctx, tt.args.id, tt.args.resourceOwner, tt.args.attempt)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_FailTargetDelivery(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+		attempt       uint32
+		reason        error
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"not attempting, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+				attempt:       1,
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"fail, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+					),
+					expectPush(
+						delivery.NewFailedEvent(context.Background(),
+							delivery.NewAggregate("id1", "instance"),
+							1,
+							"connection refused",
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+				attempt:       1,
+				reason:        errors.New("connection refused"),
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.FailTargetDelivery(tt.args.ctx, tt.args.id, tt.args.resourceOwner, tt.args.attempt, tt.args.reason)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RedriveTargetDelivery(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"not failed, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"redrive, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetDeliveryAddEvent("id1", "instance"),
+						),
+						eventFromEventPusher(
+							delivery.NewAttemptStartedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								0,
+							),
+						),
+						eventFromEventPusher(
+							delivery.NewFailedEvent(context.Background(),
+								delivery.NewAggregate("id1", "instance"),
+								1,
+								"failed",
+							),
+						),
+					),
+					expectPush(
+						delivery.NewRedrivenEvent(context.Background(),
+							delivery.NewAggregate("id1", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.RedriveTargetDelivery(tt.args.ctx, tt.args.id, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
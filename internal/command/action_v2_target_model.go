@@ -5,6 +5,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/target"
@@ -18,6 +19,9 @@ type TargetWriteModel struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	SigningKey       *crypto.CryptoValue
+	AuthType         domain.TargetAuthType
+	Auth             *crypto.CryptoValue
 
 	State domain.TargetState
 }
@@ -40,6 +44,10 @@ func (wm *TargetWriteModel) Reduce() error {
 			wm.TargetType = e.TargetType
 			wm.Endpoint = e.Endpoint
 			wm.Timeout = e.Timeout
+			wm.InterruptOnError = e.InterruptOnError
+			wm.SigningKey = e.SigningKey
+			wm.AuthType = e.AuthType
+			wm.Auth = e.Auth
 			wm.State = domain.TargetActive
 		case *target.ChangedEvent:
 			if e.Name != nil {
@@ -57,6 +65,13 @@ func (wm *TargetWriteModel) Reduce() error {
 			if e.InterruptOnError != nil {
 				wm.InterruptOnError = *e.InterruptOnError
 			}
+			if e.SigningKey != nil {
+				wm.SigningKey = e.SigningKey
+			}
+			if e.AuthType != nil {
+				wm.AuthType = *e.AuthType
+				wm.Auth = e.Auth
+			}
 		case *target.RemovedEvent:
 			wm.State = domain.TargetRemoved
 		}
@@ -84,6 +99,8 @@ func (wm *TargetWriteModel) NewChangedEvent(
 	endpoint *string,
 	timeout *time.Duration,
 	interruptOnError *bool,
+	authType *domain.TargetAuthType,
+	auth *crypto.CryptoValue,
 ) *target.ChangedEvent {
 	changes := make([]target.Changes, 0)
 	if name != nil && wm.Name != *name {
@@ -101,6 +118,11 @@ func (wm *TargetWriteModel) NewChangedEvent(
 	if interruptOnError != nil && wm.InterruptOnError != *interruptOnError {
 		changes = append(changes, target.ChangeInterruptOnError(*interruptOnError))
 	}
+	// the encrypted credentials can not be compared, so they are always changed if provided,
+	// except if an already unauthenticated target stays unauthenticated
+	if authType != nil && (*authType != domain.TargetAuthTypeNone || wm.AuthType != domain.TargetAuthTypeNone) {
+		changes = append(changes, target.ChangeAuth(*authType, auth))
+	}
 	if len(changes) == 0 {
 		return nil
 	}
@@ -2,11 +2,13 @@ package command
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/target"
@@ -20,9 +22,31 @@ func targetAddEvent(aggID, resourceOwner string) *target.AddedEvent {
 		"https://example.com",
 		time.Second,
 		false,
+		targetSigningKey("12345678"),
+		domain.TargetAuthTypeNone,
+		nil,
 	)
 }
 
+func targetSigningKey(key string) *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte(key),
+	}
+}
+
+func targetAuth(auth *domain.TargetAuth) *crypto.CryptoValue {
+	value, _ := json.Marshal(auth)
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    value,
+	}
+}
+
 func targetRemoveEvent(aggID, resourceOwner string) *target.RemovedEvent {
 	return target.NewRemovedEvent(context.Background(),
 		target.NewAggregate(aggID, resourceOwner),
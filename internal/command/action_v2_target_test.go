@@ -7,7 +7,9 @@ import (
 
 	"github.com/muhlemmer/gu"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -28,9 +30,10 @@ func TestCommands_AddTarget(t *testing.T) {
 		resourceOwner string
 	}
 	type res struct {
-		id      string
-		details *domain.ObjectDetails
-		err     func(error) bool
+		id         string
+		details    *domain.ObjectDetails
+		signingKey string
+		err        func(error) bool
 	}
 	tests := []struct {
 		name   string
@@ -118,6 +121,25 @@ func TestCommands_AddTarget(t *testing.T) {
 				err: zerrors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			"grpc Endpoint without scheme, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:       "name",
+					TargetType: domain.TargetTypeGRPC,
+					Timeout:    time.Second,
+					Endpoint:   "dns:///example.com:443",
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			"unique constraint failed, error",
 			fields{
@@ -132,6 +154,9 @@ func TestCommands_AddTarget(t *testing.T) {
 							"https://example.com",
 							time.Second,
 							false,
+							targetSigningKey("12345678"),
+							domain.TargetAuthTypeNone,
+							nil,
 						),
 					),
 				),
@@ -203,6 +228,7 @@ func TestCommands_AddTarget(t *testing.T) {
 				details: &domain.ObjectDetails{
 					ResourceOwner: "instance",
 				},
+				signingKey: "12345678",
 			},
 		},
 		{
@@ -236,14 +262,77 @@ func TestCommands_AddTarget(t *testing.T) {
 				details: &domain.ObjectDetails{
 					ResourceOwner: "instance",
 				},
+				signingKey: "12345678",
+			},
+		},
+		{
+			"invalid auth, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:       "name",
+					TargetType: domain.TargetTypeWebhook,
+					Timeout:    time.Second,
+					Endpoint:   "https://example.com",
+					Auth: &domain.TargetAuth{
+						Type: domain.TargetAuthTypeBearer,
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"push auth ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						func() eventstore.Command {
+							event := targetAddEvent("id1", "instance")
+							event.AuthType = domain.TargetAuthTypeBearer
+							event.Auth = targetAuth(&domain.TargetAuth{Type: domain.TargetAuthTypeBearer, Token: "token"})
+							return event
+						}(),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:       "name",
+					TargetType: domain.TargetTypeWebhook,
+					Endpoint:   "https://example.com",
+					Timeout:    time.Second,
+					Auth: &domain.TargetAuth{
+						Type:  domain.TargetAuthTypeBearer,
+						Token: "token",
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				id: "id1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+				signingKey: "12345678",
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
-				eventstore:  tt.fields.eventstore(t),
-				idGenerator: tt.fields.idGenerator,
+				eventstore:                  tt.fields.eventstore(t),
+				idGenerator:                 tt.fields.idGenerator,
+				newEncryptedCodeWithDefault: mockEncryptedCodeWithDefault("12345678", 0),
+				targetEncryption:            crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			}
 			details, err := c.AddTarget(tt.args.ctx, tt.args.add, tt.args.resourceOwner)
 			if tt.res.err == nil {
@@ -255,6 +344,7 @@ func TestCommands_AddTarget(t *testing.T) {
 			if tt.res.err == nil {
 				assert.Equal(t, tt.res.id, tt.args.add.AggregateID)
 				assert.Equal(t, tt.res.details, details)
+				assert.Equal(t, tt.res.signingKey, tt.args.add.SigningKey)
 			}
 		})
 	}
@@ -419,6 +509,32 @@ func TestCommands_ChangeTarget(t *testing.T) {
 				},
 			},
 		},
+		{
+			"grpc Endpoint without host, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("target", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeTarget{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					TargetType: gu.Ptr(domain.TargetTypeGRPC),
+					Endpoint:   gu.Ptr("example.com"),
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			"unique constraint failed, error",
 			fields{
@@ -531,11 +647,125 @@ func TestCommands_ChangeTarget(t *testing.T) {
 				},
 			},
 		},
+		{
+			"push auth ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("id1", "instance"),
+						),
+					),
+					expectPush(
+						target.NewChangedEvent(context.Background(),
+							target.NewAggregate("id1", "instance"),
+							[]target.Changes{
+								target.ChangeAuth(
+									domain.TargetAuthTypeBasic,
+									targetAuth(&domain.TargetAuth{Type: domain.TargetAuthTypeBasic, Username: "user", Password: "password"}),
+								),
+							},
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeTarget{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Auth: &domain.TargetAuth{
+						Type:     domain.TargetAuthTypeBasic,
+						Username: "user",
+						Password: "password",
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+		{
+			"remove auth ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							func() eventstore.Command {
+								event := targetAddEvent("id1", "instance")
+								event.AuthType = domain.TargetAuthTypeBearer
+								event.Auth = targetAuth(&domain.TargetAuth{Type: domain.TargetAuthTypeBearer, Token: "token"})
+								return event
+							}(),
+						),
+					),
+					expectPush(
+						target.NewChangedEvent(context.Background(),
+							target.NewAggregate("id1", "instance"),
+							[]target.Changes{
+								target.ChangeAuth(domain.TargetAuthTypeNone, nil),
+							},
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeTarget{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Auth: &domain.TargetAuth{
+						Type: domain.TargetAuthTypeNone,
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+		{
+			"remove auth without auth, no change",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("id1", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeTarget{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Auth: &domain.TargetAuth{
+						Type: domain.TargetAuthTypeNone,
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
-				eventstore: tt.fields.eventstore(t),
+				eventstore:       tt.fields.eventstore(t),
+				targetEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			}
 			details, err := c.ChangeTarget(tt.args.ctx, tt.args.change, tt.args.resourceOwner)
 			if tt.res.err == nil {
@@ -647,3 +877,106 @@ func TestCommands_DeleteTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_RotateTargetSigningKey(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+	}
+	type res struct {
+		details    *domain.ObjectDetails
+		signingKey string
+		err        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"rotate ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("id1", "instance"),
+						),
+					),
+					expectPush(
+						target.NewChangedEvent(context.Background(),
+							target.NewAggregate("id1", "instance"),
+							[]target.Changes{
+								target.ChangeSigningKey(targetSigningKey("87654321")),
+							},
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+				signingKey: "87654321",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                  tt.fields.eventstore(t),
+				newEncryptedCodeWithDefault: mockEncryptedCodeWithDefault("87654321", 0),
+			}
+			details, signingKey, err := c.RotateTargetSigningKey(tt.args.ctx, tt.args.id, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+				assert.Equal(t, tt.res.signingKey, signingKey)
+			}
+		})
+	}
+}
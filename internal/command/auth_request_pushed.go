@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// PushedAuthRequest is an authorization request pushed by a client (RFC 9126),
+// which can be referenced by its ID on the authorization endpoint until it expires.
+type PushedAuthRequest struct {
+	ID         string
+	ClientID   string
+	Parameters map[string][]string
+	Expiration time.Time
+}
+
+func (c *Commands) AddPushedAuthRequest(ctx context.Context, clientID string, parameters map[string][]string, expiration time.Time) (_ *PushedAuthRequest, err error) {
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	writeModel := NewPushedAuthRequestWriteModel(ctx, IDPrefixV2+id)
+	err = c.pushAppendAndReduce(ctx, writeModel, authrequest.NewPushedAddedEvent(
+		ctx,
+		writeModel.aggregate,
+		clientID,
+		parameters,
+		expiration,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &PushedAuthRequest{
+		ID:         writeModel.AggregateID,
+		ClientID:   writeModel.ClientID,
+		Parameters: writeModel.Parameters,
+		Expiration: writeModel.Expiration,
+	}, nil
+}
+
+// UsePushedAuthRequest returns the parameters of the pushed authorization request
+// and marks it as used, so it can't be used a second time.
+// A concurrent second use fails on the unique constraint of the used event.
+func (c *Commands) UsePushedAuthRequest(ctx context.Context, id, clientID string) (_ map[string][]string, err error) {
+	writeModel := NewPushedAuthRequestWriteModel(ctx, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.exists() || writeModel.ClientID != clientID || writeModel.Expiration.Before(time.Now()) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Par2n", "Errors.AuthRequest.NotExisting")
+	}
+	if writeModel.Used {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Par3u", "Errors.AuthRequest.AlreadyHandled")
+	}
+	if err = c.pushAppendAndReduce(ctx, writeModel, authrequest.NewPushedUsedEvent(ctx, writeModel.aggregate)); err != nil {
+		if zerrors.IsErrorAlreadyExists(err) {
+			return nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-Par4u", "Errors.AuthRequest.AlreadyHandled")
+		}
+		return nil, err
+	}
+	return writeModel.Parameters, nil
+}
@@ -0,0 +1,240 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_AddPushedAuthRequest(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "clientID")
+	expiration := time.Now().Add(time.Minute).UTC().Truncate(time.Second)
+	parameters := map[string][]string{
+		"client_id":     {"clientID"},
+		"redirect_uri":  {"redirectURI"},
+		"response_type": {"code"},
+		"scope":         {"openid"},
+	}
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx        context.Context
+		clientID   string
+		parameters map[string][]string
+		expiration time.Time
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *PushedAuthRequest
+		wantErr error
+	}{
+		{
+			"added",
+			fields{
+				eventstore: expectEventstore(
+					expectPush(
+						authrequest.NewPushedAddedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+							"clientID",
+							parameters,
+							expiration,
+						),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id"),
+			},
+			args{
+				ctx:        mockCtx,
+				clientID:   "clientID",
+				parameters: parameters,
+				expiration: expiration,
+			},
+			&PushedAuthRequest{
+				ID:         "V2_id",
+				ClientID:   "clientID",
+				Parameters: parameters,
+				Expiration: expiration,
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.AddPushedAuthRequest(tt.args.ctx, tt.args.clientID, tt.args.parameters, tt.args.expiration)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_UsePushedAuthRequest(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "clientID")
+	parameters := map[string][]string{
+		"client_id":     {"clientID"},
+		"redirect_uri":  {"redirectURI"},
+		"response_type": {"code"},
+		"scope":         {"openid"},
+	}
+	pushedAddedEvent := func(expiration time.Time) eventstore.Event {
+		return eventFromEventPusher(
+			authrequest.NewPushedAddedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+				"clientID",
+				parameters,
+				expiration,
+			),
+		)
+	}
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		id       string
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    map[string][]string
+		wantErr error
+	}{
+		{
+			"not existing",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowNotFound(nil, "COMMAND-Par2n", "Errors.AuthRequest.NotExisting"),
+		},
+		{
+			"other client",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						pushedAddedEvent(time.Now().Add(time.Minute)),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "otherClientID",
+			},
+			nil,
+			zerrors.ThrowNotFound(nil, "COMMAND-Par2n", "Errors.AuthRequest.NotExisting"),
+		},
+		{
+			"expired",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						pushedAddedEvent(time.Now().Add(-time.Minute)),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowNotFound(nil, "COMMAND-Par2n", "Errors.AuthRequest.NotExisting"),
+		},
+		{
+			"already used",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						pushedAddedEvent(time.Now().Add(time.Minute)),
+						eventFromEventPusher(
+							authrequest.NewPushedUsedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate),
+						),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowPreconditionFailed(nil, "COMMAND-Par3u", "Errors.AuthRequest.AlreadyHandled"),
+		},
+		{
+			"used concurrently",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						pushedAddedEvent(time.Now().Add(time.Minute)),
+					),
+					expectPushFailed(
+						zerrors.ThrowAlreadyExists(nil, "V3-DKcYh", "Errors.AuthRequest.AlreadyHandled"),
+						authrequest.NewPushedUsedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowPreconditionFailed(nil, "COMMAND-Par4u", "Errors.AuthRequest.AlreadyHandled"),
+		},
+		{
+			"used",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						pushedAddedEvent(time.Now().Add(time.Minute)),
+					),
+					expectPush(
+						authrequest.NewPushedUsedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			parameters,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.UsePushedAuthRequest(tt.args.ctx, tt.args.id, tt.args.clientID)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -29,8 +29,6 @@ func (wm *InstanceSecurityPolicyWriteModel) Reduce() error {
 
 			if e.EnableIframeEmbedding != nil {
 				wm.EnableIframeEmbedding = *e.EnableIframeEmbedding
-			} else if e.Enabled != nil {
-				wm.EnableIframeEmbedding = *e.Enabled
 			}
 			if e.AllowedOrigins != nil {
 				wm.AllowedOrigins = *e.AllowedOrigins
@@ -125,6 +125,11 @@ func oidcAppEvents(ctx context.Context, orgID, projectID, id, name, clientID str
 			0,
 			nil,
 			false,
+			"",
+			false,
+			false,
+			false,
+			"",
 		),
 	}
 }
@@ -430,6 +435,11 @@ func generatedDomainFilters(instanceID, orgID, projectID, appID, generatedDomain
 				0,
 				nil,
 				false,
+				"",
+				false,
+				false,
+				false,
+				"",
 			),
 		),
 		expectFilter(
@@ -47,6 +47,9 @@ type OIDCSession struct {
 	Reason            domain.TokenReason
 	Actor             *domain.TokenActor
 	RefreshToken      string
+	DPoPJKT           string
+	// CertificateThumbprint is the x5t#S256 of the client certificate the tokens are bound to
+	CertificateThumbprint string
 }
 
 type AuthRequestComplianceChecker func(context.Context, *AuthRequestWriteModel) error
@@ -54,7 +57,9 @@ type AuthRequestComplianceChecker func(context.Context, *AuthRequestWriteModel)
 // CreateOIDCSessionFromAuthRequest creates a new OIDC Session, creates an access token and refresh token.
 // It returns the access token id, expiration and the refresh token.
 // If the underlying [AuthRequest] is a OIDC Auth Code Flow, it will set the code as exchanged.
-func (c *Commands) CreateOIDCSessionFromAuthRequest(ctx context.Context, authReqId string, complianceCheck AuthRequestComplianceChecker, needRefreshToken bool) (session *OIDCSession, state string, err error) {
+// If a dpopJKT is passed, the tokens are bound to the key with the thumbprint.
+// If a certificateThumbprint is passed, the tokens are bound to the client certificate with the thumbprint.
+func (c *Commands) CreateOIDCSessionFromAuthRequest(ctx context.Context, authReqId string, complianceCheck AuthRequestComplianceChecker, needRefreshToken bool, dpopJKT, certificateThumbprint string) (session *OIDCSession, state string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -105,6 +110,8 @@ func (c *Commands) CreateOIDCSessionFromAuthRequest(ctx context.Context, authReq
 		authReqModel.Nonce,
 		sessionModel.PreferredLanguage,
 		sessionModel.UserAgent,
+		dpopJKT,
+		certificateThumbprint,
 	)
 
 	if authReqModel.ResponseType != domain.OIDCResponseTypeIDToken {
@@ -136,6 +143,8 @@ func (c *Commands) CreateOIDCSession(ctx context.Context,
 	reason domain.TokenReason,
 	actor *domain.TokenActor,
 	needRefreshToken bool,
+	dpopJKT,
+	certificateThumbprint string,
 ) (session *OIDCSession, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -151,7 +160,7 @@ func (c *Commands) CreateOIDCSession(ctx context.Context,
 		cmd.UserImpersonated(ctx, userID, resourceOwner, clientID, actor)
 	}
 
-	cmd.AddSession(ctx, userID, resourceOwner, "", clientID, audience, scope, authMethods, authTime, nonce, preferredLanguage, userAgent)
+	cmd.AddSession(ctx, userID, resourceOwner, "", clientID, audience, scope, authMethods, authTime, nonce, preferredLanguage, userAgent, dpopJKT, certificateThumbprint)
 	if err = cmd.AddAccessToken(ctx, scope, userID, resourceOwner, reason, actor); err != nil {
 		return nil, err
 	}
@@ -167,7 +176,9 @@ type RefreshTokenComplianceChecker func(ctx context.Context, wm *OIDCSessionWrit
 
 // ExchangeOIDCSessionRefreshAndAccessToken updates an existing OIDC Session, creates a new access and refresh token.
 // It returns the access token id and expiration and the new refresh token.
-func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, refreshToken string, scope []string, complianceCheck RefreshTokenComplianceChecker) (_ *OIDCSession, err error) {
+// A refresh token bound to a DPoP key can only be used with a proof of the same key (dpopJKT),
+// a refresh token bound to a client certificate only with the same certificate (certificateThumbprint).
+func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, refreshToken string, scope []string, complianceCheck RefreshTokenComplianceChecker, dpopJKT, certificateThumbprint string) (_ *OIDCSession, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -175,6 +186,12 @@ func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if cmd.oidcSessionWriteModel.DPoPJKT != dpopJKT {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "OIDCS-Dp0pk", "Errors.OIDCSession.RefreshTokenInvalid")
+	}
+	if cmd.oidcSessionWriteModel.CertificateThumbprint != certificateThumbprint {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "OIDCS-Mtls1", "Errors.OIDCSession.RefreshTokenInvalid")
+	}
 	scope, err = complianceCheck(ctx, cmd.oidcSessionWriteModel, scope)
 	if err != nil {
 		return nil, err
@@ -264,6 +281,24 @@ func (c *Commands) RevokeOIDCSessionToken(ctx context.Context, token, clientID s
 	return c.pushAppendAndReduce(ctx, writeModel, oidcsession.NewAccessTokenRevokedEvent(ctx, writeModel.aggregate))
 }
 
+// AddBackChannelLogoutDelivery persists the delivery of the logout token to the back-channel logout uri of the client
+// and marks the back-channel logout as scheduled for all oidc sessions the client received tokens in.
+// Both are pushed at once, so the client is notified once, even if the end of the sessions is reduced again.
+func (c *Commands) AddBackChannelLogoutDelivery(ctx context.Context, sessionID, userID, clientID string, oidcSessions []*eventstore.Aggregate, logout *domain.TargetDelivery) error {
+	if userID == "" || clientID == "" || len(oidcSessions) == 0 || logout == nil {
+		return zerrors.ThrowInvalidArgument(nil, "OIDCS-Ehe2k", "Errors.IDMissing")
+	}
+	_, cmds, err := c.targetDeliveryAddedEvents(ctx, authz.GetInstance(ctx).InstanceID(), []*domain.TargetDelivery{logout})
+	if err != nil {
+		return err
+	}
+	for _, oidcSession := range oidcSessions {
+		cmds = append(cmds, oidcsession.NewBackChannelLogoutScheduledEvent(ctx, oidcSession, sessionID, userID, clientID))
+	}
+	_, err = c.eventstore.Push(ctx, cmds...)
+	return err
+}
+
 func (c *Commands) newOIDCSessionAddEvents(ctx context.Context, resourceOwner string, pending ...eventstore.Command) (*OIDCSessionEvents, error) {
 	accessTokenLifetime, refreshTokenLifeTime, refreshTokenIdleLifetime, err := c.tokenTokenLifetimes(ctx)
 	if err != nil {
@@ -367,6 +402,8 @@ func (c *OIDCSessionEvents) AddSession(
 	nonce string,
 	preferredLanguage *language.Tag,
 	userAgent *domain.UserAgent,
+	dpopJKT,
+	certificateThumbprint string,
 ) {
 	c.events = append(c.events, oidcsession.NewAddedEvent(
 		ctx,
@@ -382,6 +419,8 @@ func (c *OIDCSessionEvents) AddSession(
 		nonce,
 		preferredLanguage,
 		userAgent,
+		dpopJKT,
+		certificateThumbprint,
 	))
 }
 
@@ -459,20 +498,22 @@ func (c *OIDCSessionEvents) PushEvents(ctx context.Context) (*OIDCSession, error
 		return nil, err
 	}
 	session := &OIDCSession{
-		SessionID:         c.oidcSessionWriteModel.SessionID,
-		ClientID:          c.oidcSessionWriteModel.ClientID,
-		UserID:            c.oidcSessionWriteModel.UserID,
-		Audience:          c.oidcSessionWriteModel.Audience,
-		Expiration:        c.oidcSessionWriteModel.AccessTokenExpiration,
-		Scope:             c.oidcSessionWriteModel.Scope,
-		AuthMethods:       c.oidcSessionWriteModel.AuthMethods,
-		AuthTime:          c.oidcSessionWriteModel.AuthTime,
-		Nonce:             c.oidcSessionWriteModel.Nonce,
-		PreferredLanguage: c.oidcSessionWriteModel.PreferredLanguage,
-		UserAgent:         c.oidcSessionWriteModel.UserAgent,
-		Reason:            c.oidcSessionWriteModel.AccessTokenReason,
-		Actor:             c.oidcSessionWriteModel.AccessTokenActor,
-		RefreshToken:      c.refreshToken,
+		SessionID:             c.oidcSessionWriteModel.SessionID,
+		ClientID:              c.oidcSessionWriteModel.ClientID,
+		UserID:                c.oidcSessionWriteModel.UserID,
+		Audience:              c.oidcSessionWriteModel.Audience,
+		Expiration:            c.oidcSessionWriteModel.AccessTokenExpiration,
+		Scope:                 c.oidcSessionWriteModel.Scope,
+		AuthMethods:           c.oidcSessionWriteModel.AuthMethods,
+		AuthTime:              c.oidcSessionWriteModel.AuthTime,
+		Nonce:                 c.oidcSessionWriteModel.Nonce,
+		PreferredLanguage:     c.oidcSessionWriteModel.PreferredLanguage,
+		UserAgent:             c.oidcSessionWriteModel.UserAgent,
+		Reason:                c.oidcSessionWriteModel.AccessTokenReason,
+		Actor:                 c.oidcSessionWriteModel.AccessTokenActor,
+		RefreshToken:          c.refreshToken,
+		DPoPJKT:               c.oidcSessionWriteModel.DPoPJKT,
+		CertificateThumbprint: c.oidcSessionWriteModel.CertificateThumbprint,
 	}
 	if c.accessTokenID != "" {
 		// prefix the returned id with the oidcSessionID so that we can retrieve it later on
@@ -25,6 +25,8 @@ type OIDCSessionWriteModel struct {
 	AuthTime                   time.Time
 	Nonce                      string
 	UserAgent                  *domain.UserAgent
+	DPoPJKT                    string
+	CertificateThumbprint      string
 	State                      domain.OIDCSessionState
 	AccessTokenID              string
 	AccessTokenCreation        time.Time
@@ -101,6 +103,8 @@ func (wm *OIDCSessionWriteModel) reduceAdded(e *oidcsession.AddedEvent) {
 	wm.Nonce = e.Nonce
 	wm.PreferredLanguage = e.PreferredLanguage
 	wm.UserAgent = e.UserAgent
+	wm.DPoPJKT = e.DPoPJKT
+	wm.CertificateThumbprint = e.CertificateThumbprint
 	wm.State = domain.OIDCSessionStateActive
 	// the write model might be initialized without resource owner,
 	// so update the aggregate
@@ -8,10 +8,12 @@ import (
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/feature"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	org_v2 "github.com/zitadel/zitadel/internal/v2/org"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -371,15 +373,15 @@ func (c *Commands) ChangeOrg(ctx context.Context, orgID, name string) (*domain.O
 }
 
 func (c *Commands) DeactivateOrg(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if authz.GetFeatures(ctx).ShouldUseImprovedPerformance(feature.ImprovedPerformanceTypeOrgCommands) {
+		return c.changeOrgStateV2(ctx, orgID, checkOrgDeactivatable, org_v2.NewDeactivatedCommand(ctx, orgID))
+	}
 	orgWriteModel, err := c.getOrgWriteModelByID(ctx, orgID)
 	if err != nil {
 		return nil, err
 	}
-	if !isOrgStateExists(orgWriteModel.State) {
-		return nil, zerrors.ThrowNotFound(nil, "ORG-oL9nT", "Errors.Org.NotFound")
-	}
-	if orgWriteModel.State == domain.OrgStateInactive {
-		return nil, zerrors.ThrowPreconditionFailed(nil, "EVENT-Dbs2g", "Errors.Org.AlreadyDeactivated")
+	if err = checkOrgDeactivatable(orgWriteModel.State); err != nil {
+		return nil, err
 	}
 	orgAgg := OrgAggregateFromWriteModel(&orgWriteModel.WriteModel)
 	pushedEvents, err := c.eventstore.Push(ctx, org.NewOrgDeactivatedEvent(ctx, orgAgg))
@@ -393,16 +395,26 @@ func (c *Commands) DeactivateOrg(ctx context.Context, orgID string) (*domain.Obj
 	return writeModelToObjectDetails(&orgWriteModel.WriteModel), nil
 }
 
+func checkOrgDeactivatable(state domain.OrgState) error {
+	if !isOrgStateExists(state) {
+		return zerrors.ThrowNotFound(nil, "ORG-oL9nT", "Errors.Org.NotFound")
+	}
+	if state == domain.OrgStateInactive {
+		return zerrors.ThrowPreconditionFailed(nil, "EVENT-Dbs2g", "Errors.Org.AlreadyDeactivated")
+	}
+	return nil
+}
+
 func (c *Commands) ReactivateOrg(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if authz.GetFeatures(ctx).ShouldUseImprovedPerformance(feature.ImprovedPerformanceTypeOrgCommands) {
+		return c.changeOrgStateV2(ctx, orgID, checkOrgReactivatable, org_v2.NewReactivatedCommand(ctx, orgID))
+	}
 	orgWriteModel, err := c.getOrgWriteModelByID(ctx, orgID)
 	if err != nil {
 		return nil, err
 	}
-	if !isOrgStateExists(orgWriteModel.State) {
-		return nil, zerrors.ThrowNotFound(nil, "ORG-Dgf3g", "Errors.Org.NotFound")
-	}
-	if orgWriteModel.State == domain.OrgStateActive {
-		return nil, zerrors.ThrowPreconditionFailed(nil, "EVENT-bfnrh", "Errors.Org.AlreadyActive")
+	if err = checkOrgReactivatable(orgWriteModel.State); err != nil {
+		return nil, err
 	}
 	orgAgg := OrgAggregateFromWriteModel(&orgWriteModel.WriteModel)
 	pushedEvents, err := c.eventstore.Push(ctx, org.NewOrgReactivatedEvent(ctx, orgAgg))
@@ -416,6 +428,16 @@ func (c *Commands) ReactivateOrg(ctx context.Context, orgID string) (*domain.Obj
 	return writeModelToObjectDetails(&orgWriteModel.WriteModel), nil
 }
 
+func checkOrgReactivatable(state domain.OrgState) error {
+	if !isOrgStateExists(state) {
+		return zerrors.ThrowNotFound(nil, "ORG-Dgf3g", "Errors.Org.NotFound")
+	}
+	if state == domain.OrgStateActive {
+		return zerrors.ThrowPreconditionFailed(nil, "EVENT-bfnrh", "Errors.Org.AlreadyActive")
+	}
+	return nil
+}
+
 func (c *Commands) RemoveOrg(ctx context.Context, id string) (*domain.ObjectDetails, error) {
 	orgAgg := org.NewAggregate(id)
 
@@ -65,3 +65,13 @@ func (wm *OrgMemberWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.MemberCascadeRemovedEventType).
 		Builder()
 }
+
+// SnapshotKey implements [eventstore.Snapshotter]
+func (wm *OrgMemberWriteModel) SnapshotKey() string {
+	return wm.MemberWriteModel.AggregateID + ":" + wm.MemberWriteModel.UserID
+}
+
+// SnapshotVersion implements [eventstore.Snapshotter]
+func (wm *OrgMemberWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
+	org_v2 "github.com/zitadel/zitadel/internal/v2/org"
+	"github.com/zitadel/zitadel/internal/v2/readmodel"
+	user_v2 "github.com/zitadel/zitadel/internal/v2/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// addOrgMemberV2 adds the member using the eventstore v2
+func (c *Commands) addOrgMemberV2(ctx context.Context, orgID, userID string, roles []string) (*domain.Member, error) {
+	if err := c.checkOrgMemberToAdd(userID, roles); err != nil {
+		return nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	user := readmodel.NewUserState(userID, "")
+	if _, err := c.eventstoreV4.Query(ctx, es_v4.NewQuery(instanceID, user, es_v4.AppendFilters(user.Filter()...))); err != nil {
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Mb8qe", "Errors.Internal")
+	}
+	if !user.State.State.IsValid() || user.State.State.Is(user_v2.RemovedState) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "ORG-GoXOn", "Errors.User.NotFound")
+	}
+	member, err := c.orgMemberV2(ctx, instanceID, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.IsMember {
+		return nil, zerrors.ThrowAlreadyExists(nil, "ORG-poWwe", "Errors.Org.Member.AlreadyExists")
+	}
+	if err = c.pushOrgMemberV2(ctx, instanceID, member, org_v2.NewMemberAddedCommand(ctx, orgID, userID, roles...)); err != nil {
+		return nil, err
+	}
+	return orgMemberV2ToMember(instanceID, member), nil
+}
+
+// changeOrgMemberV2 changes the roles of the member using the eventstore v2
+func (c *Commands) changeOrgMemberV2(ctx context.Context, change *domain.Member) (*domain.Member, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	member, err := c.orgMemberV2(ctx, instanceID, change.AggregateID, change.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.IsMember {
+		return nil, zerrors.ThrowNotFound(nil, "Org-D8JxR", "Errors.NotFound")
+	}
+	if slices.Equal(member.Roles, change.Roles) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "Org-LiaZi", "Errors.Org.Member.RolesNotChanged")
+	}
+	if err = c.pushOrgMemberV2(ctx, instanceID, member, org_v2.NewMemberChangedCommand(ctx, change.UserID, change.Roles...)); err != nil {
+		return nil, err
+	}
+	return orgMemberV2ToMember(instanceID, member), nil
+}
+
+// removeOrgMemberV2 removes the member using the eventstore v2
+func (c *Commands) removeOrgMemberV2(ctx context.Context, orgID, userID string) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	member, err := c.orgMemberV2(ctx, instanceID, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.IsMember {
+		// empty response because we have no data that match the request
+		return &domain.ObjectDetails{}, nil
+	}
+	if err = c.pushOrgMemberV2(ctx, instanceID, member, org_v2.NewMemberRemovedCommand(ctx, orgID, userID)); err != nil {
+		return nil, err
+	}
+	return &domain.ObjectDetails{
+		Sequence:      uint64(member.Sequence),
+		EventDate:     member.ChangeDate,
+		ResourceOwner: member.Owner,
+	}, nil
+}
+
+func (c *Commands) orgMemberV2(ctx context.Context, instanceID, orgID, userID string) (*readmodel.OrgMember, error) {
+	member := readmodel.NewOrgMember(orgID, userID)
+	_, err := c.eventstoreV4.Query(ctx, es_v4.NewQuery(instanceID, member, es_v4.AppendFilters(member.Filter()...)))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Mx2lo", "Errors.Internal")
+	}
+	return member, nil
+}
+
+func (c *Commands) pushOrgMemberV2(ctx context.Context, instanceID string, member *readmodel.OrgMember, command *es_v4.Command) error {
+	return c.eventstoreV4.Push(ctx, es_v4.NewPushIntent(
+		instanceID,
+		es_v4.AppendAggregate(
+			member.Owner,
+			org_v2.AggregateType,
+			member.OrgID,
+			// the read model only contains the member events of the org,
+			// concurrent adds of the same member are prevented by the unique constraint
+			es_v4.CurrentSequenceAtLeast(member.Sequence),
+			es_v4.AppendCommands(command),
+		),
+		es_v4.PushReducer(member),
+	))
+}
+
+func orgMemberV2ToMember(instanceID string, member *readmodel.OrgMember) *domain.Member {
+	return &domain.Member{
+		ObjectRoot: models.ObjectRoot{
+			InstanceID:    instanceID,
+			AggregateID:   member.OrgID,
+			ChangeDate:    member.ChangeDate,
+			ResourceOwner: member.Owner,
+			Sequence:      uint64(member.Sequence),
+		},
+		Roles:  member.Roles,
+		UserID: member.UserID,
+	}
+}
@@ -0,0 +1,213 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/feature"
+	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
+	org_v2 "github.com/zitadel/zitadel/internal/v2/org"
+	user_v2 "github.com/zitadel/zitadel/internal/v2/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_orgMemberV2(t *testing.T) {
+	ctx := authz.WithFeatures(
+		authz.WithInstanceID(context.Background(), "instance1"),
+		feature.Features{ImprovedPerformance: []feature.ImprovedPerformanceType{feature.ImprovedPerformanceTypeOrgCommands}},
+	)
+	orgAggregate := es_v4.Aggregate{ID: "org1", Type: org_v2.AggregateType, Owner: "org1", Instance: "instance1"}
+	userAggregate := es_v4.Aggregate{ID: "user1", Type: user_v2.AggregateType, Owner: "org2", Instance: "instance1"}
+	type res struct {
+		want   any
+		pushed []*es_v4.Command
+		err    func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore *testEventstoreV4
+		change     func(c *Commands) (any, error)
+		res        res
+	}{
+		{
+			name:       "add member, invalid roles",
+			eventstore: &testEventstoreV4{},
+			change: func(c *Commands) (any, error) {
+				return c.AddOrgMember(ctx, "org1", "user1", "IAM_OWNER")
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add member, user removed",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, "{}"),
+					testStorageEventV4(userAggregate, user_v2.RemovedType, 2, "{}"),
+				},
+			},
+			change: func(c *Commands) (any, error) {
+				return c.AddOrgMember(ctx, "org1", "user1", "ORG_OWNER")
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "add member, already member",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, "{}"),
+					testStorageEventV4(orgAggregate, org_v2.MemberAddedType, 2, `{"userId":"user1","roles":["ORG_OWNER"]}`),
+				},
+			},
+			change: func(c *Commands) (any, error) {
+				return c.AddOrgMember(ctx, "org1", "user1", "ORG_OWNER")
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add member, ok",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, "{}"),
+					testStorageEventV4(orgAggregate, org_v2.MemberAddedType, 2, `{"userId":"user1","roles":["ORG_OWNER"]}`),
+					testStorageEventV4(orgAggregate, org_v2.MemberCascadeRemovedType, 3, `{"userId":"user1"}`),
+					testStorageEventV4(orgAggregate, org_v2.MemberAddedType, 4, `{"userId":"user2","roles":["ORG_OWNER"]}`),
+				},
+			},
+			change: func(c *Commands) (any, error) {
+				return c.AddOrgMember(ctx, "org1", "user1", "ORG_OWNER")
+			},
+			res: res{
+				want: &domain.Member{
+					ObjectRoot: models.ObjectRoot{
+						InstanceID:    "instance1",
+						AggregateID:   "org1",
+						ChangeDate:    time.Date(2024, 1, 1, 0, 0, 5, 0, time.UTC),
+						ResourceOwner: "org1",
+						Sequence:      5,
+					},
+					Roles:  []string{"ORG_OWNER"},
+					UserID: "user1",
+				},
+				pushed: []*es_v4.Command{
+					org_v2.NewMemberAddedCommand(ctx, "org1", "user1", "ORG_OWNER"),
+				},
+			},
+		},
+		{
+			name:       "change member, not found",
+			eventstore: &testEventstoreV4{},
+			change: func(c *Commands) (any, error) {
+				return c.ChangeOrgMember(ctx, &domain.Member{ObjectRoot: models.ObjectRoot{AggregateID: "org1"}, UserID: "user1", Roles: []string{"ORG_OWNER"}})
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "change member, roles not changed",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(orgAggregate, org_v2.MemberAddedType, 1, `{"userId":"user1","roles":["ORG_OWNER"]}`),
+				},
+			},
+			change: func(c *Commands) (any, error) {
+				return c.ChangeOrgMember(ctx, &domain.Member{ObjectRoot: models.ObjectRoot{AggregateID: "org1"}, UserID: "user1", Roles: []string{"ORG_OWNER"}})
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change member, ok",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(orgAggregate, org_v2.MemberAddedType, 1, `{"userId":"user1","roles":["ORG_OWNER"]}`),
+				},
+			},
+			change: func(c *Commands) (any, error) {
+				return c.ChangeOrgMember(ctx, &domain.Member{ObjectRoot: models.ObjectRoot{AggregateID: "org1"}, UserID: "user1", Roles: []string{"ORG_OWNER", "ORG_USER_MANAGER"}})
+			},
+			res: res{
+				want: &domain.Member{
+					ObjectRoot: models.ObjectRoot{
+						InstanceID:    "instance1",
+						AggregateID:   "org1",
+						ChangeDate:    time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC),
+						ResourceOwner: "org1",
+						Sequence:      2,
+					},
+					Roles:  []string{"ORG_OWNER", "ORG_USER_MANAGER"},
+					UserID: "user1",
+				},
+				pushed: []*es_v4.Command{
+					org_v2.NewMemberChangedCommand(ctx, "user1", "ORG_OWNER", "ORG_USER_MANAGER"),
+				},
+			},
+		},
+		{
+			name:       "remove member, not member",
+			eventstore: &testEventstoreV4{},
+			change: func(c *Commands) (any, error) {
+				return c.RemoveOrgMember(ctx, "org1", "user1")
+			},
+			res: res{
+				want: &domain.ObjectDetails{},
+			},
+		},
+		{
+			name: "remove member, ok",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(orgAggregate, org_v2.MemberAddedType, 1, `{"userId":"user1","roles":["ORG_OWNER"]}`),
+				},
+			},
+			change: func(c *Commands) (any, error) {
+				return c.RemoveOrgMember(ctx, "org1", "user1")
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					Sequence:      2,
+					EventDate:     time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC),
+					ResourceOwner: "org1",
+				},
+				pushed: []*es_v4.Command{
+					org_v2.NewMemberRemovedCommand(ctx, "org1", "user1"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstoreV4: es_v4.NewEventstoreFromOne(tt.eventstore),
+				zitadelRoles: []authz.RoleMapping{{Role: "ORG_OWNER"}, {Role: "ORG_USER_MANAGER"}, {Role: "IAM_OWNER"}},
+			}
+			got, err := tt.change(c)
+			if tt.res.err != nil {
+				require.True(t, tt.res.err(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.want, got)
+			if tt.res.pushed == nil {
+				assert.Empty(t, tt.eventstore.pushed)
+				return
+			}
+			require.Len(t, tt.eventstore.pushed, 1)
+			assert.Equal(t, tt.res.pushed, tt.eventstore.pushed[0].Commands())
+		})
+	}
+}
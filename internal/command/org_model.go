@@ -60,6 +60,16 @@ func (wm *OrgWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+// SnapshotKey implements [eventstore.Snapshotter]
+func (wm *OrgWriteModel) SnapshotKey() string {
+	return wm.AggregateID
+}
+
+// SnapshotVersion implements [eventstore.Snapshotter]
+func (wm *OrgWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func OrgAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, org.AggregateType, org.AggregateVersion)
 }
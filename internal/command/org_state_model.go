@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
+	org_v2 "github.com/zitadel/zitadel/internal/v2/org"
+	"github.com/zitadel/zitadel/internal/v2/readmodel"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// changeOrgStateV2 queries the org and pushes the command using the eventstore v2
+// check validates if the state of the org allows the command
+func (c *Commands) changeOrgStateV2(ctx context.Context, orgID string, check func(domain.OrgState) error, command *es_v4.Command) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	org := readmodel.NewOrg(orgID)
+	_, err := c.eventstoreV4.Query(ctx, es_v4.NewQuery(instanceID, org, es_v4.AppendFilters(org.Filter()...)))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Jx3nf", "Errors.Internal")
+	}
+	if err = check(domain.OrgState(org.State.State)); err != nil {
+		return nil, err
+	}
+
+	err = c.eventstoreV4.Push(ctx, es_v4.NewPushIntent(
+		instanceID,
+		es_v4.AppendAggregate(
+			org.Owner,
+			org_v2.AggregateType,
+			org.ID,
+			es_v4.CurrentSequenceMatches(org.Sequence),
+			es_v4.AppendCommands(command),
+		),
+		es_v4.PushReducer(org),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ObjectDetails{
+		Sequence:      uint64(org.Sequence),
+		EventDate:     org.ChangeDate,
+		ResourceOwner: org.Owner,
+	}, nil
+}
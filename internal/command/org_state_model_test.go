@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/feature"
+	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
+	org_v2 "github.com/zitadel/zitadel/internal/v2/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_changeOrgStateV2(t *testing.T) {
+	ctx := authz.WithFeatures(
+		authz.WithInstanceID(context.Background(), "instance1"),
+		feature.Features{ImprovedPerformance: []feature.ImprovedPerformanceType{feature.ImprovedPerformanceTypeOrgCommands}},
+	)
+	orgAggregate := es_v4.Aggregate{ID: "org1", Type: org_v2.AggregateType, Owner: "org1", Instance: "instance1"}
+	type res struct {
+		want   *domain.ObjectDetails
+		pushed []*es_v4.Command
+		err    func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore *testEventstoreV4
+		change     func(c *Commands) (*domain.ObjectDetails, error)
+		res        res
+	}{
+		{
+			name:       "org not existing, not found error",
+			eventstore: &testEventstoreV4{},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.DeactivateOrg(ctx, "org1")
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "org already active, precondition error",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(orgAggregate, org_v2.AddedType, 1, `{"name":"org"}`),
+				},
+			},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.ReactivateOrg(ctx, "org1")
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "deactivate org, ok",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(orgAggregate, org_v2.AddedType, 1, `{"name":"org"}`),
+				},
+			},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.DeactivateOrg(ctx, "org1")
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					Sequence:      2,
+					EventDate:     time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC),
+					ResourceOwner: "org1",
+				},
+				pushed: []*es_v4.Command{
+					org_v2.NewDeactivatedCommand(ctx, "org1"),
+				},
+			},
+		},
+		{
+			name: "reactivate org, ok",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(orgAggregate, org_v2.AddedType, 1, `{"name":"org"}`),
+					testStorageEventV4(orgAggregate, org_v2.DeactivatedType, 2, "{}"),
+				},
+			},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.ReactivateOrg(ctx, "org1")
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					Sequence:      3,
+					EventDate:     time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC),
+					ResourceOwner: "org1",
+				},
+				pushed: []*es_v4.Command{
+					org_v2.NewReactivatedCommand(ctx, "org1"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstoreV4: es_v4.NewEventstoreFromOne(tt.eventstore),
+			}
+			got, err := tt.change(c)
+			if tt.res.err != nil {
+				require.True(t, tt.res.err(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.want, got)
+			require.Len(t, tt.eventstore.pushed, 1)
+			assert.Equal(t, tt.res.pushed, tt.eventstore.pushed[0].Commands())
+		})
+	}
+}
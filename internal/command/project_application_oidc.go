@@ -16,21 +16,26 @@ import (
 
 type addOIDCApp struct {
 	AddApp
-	Version                     domain.OIDCVersion
-	RedirectUris                []string
-	ResponseTypes               []domain.OIDCResponseType
-	GrantTypes                  []domain.OIDCGrantType
-	ApplicationType             domain.OIDCApplicationType
-	AuthMethodType              domain.OIDCAuthMethodType
-	PostLogoutRedirectUris      []string
-	DevMode                     bool
-	AccessTokenType             domain.OIDCTokenType
-	AccessTokenRoleAssertion    bool
-	IDTokenRoleAssertion        bool
-	IDTokenUserinfoAssertion    bool
-	ClockSkew                   time.Duration
-	AdditionalOrigins           []string
-	SkipSuccessPageForNativeApp bool
+	Version                          domain.OIDCVersion
+	RedirectUris                     []string
+	ResponseTypes                    []domain.OIDCResponseType
+	GrantTypes                       []domain.OIDCGrantType
+	ApplicationType                  domain.OIDCApplicationType
+	AuthMethodType                   domain.OIDCAuthMethodType
+	PostLogoutRedirectUris           []string
+	DevMode                          bool
+	AccessTokenType                  domain.OIDCTokenType
+	AccessTokenRoleAssertion         bool
+	IDTokenRoleAssertion             bool
+	IDTokenUserinfoAssertion         bool
+	ClockSkew                        time.Duration
+	AdditionalOrigins                []string
+	SkipSuccessPageForNativeApp      bool
+	BackChannelLogoutURI             string
+	BackChannelLogoutSessionRequired bool
+	RequirePushedAuthRequests        bool
+	RequireDPoP                      bool
+	TLSClientAuthSubjectDN           string
 
 	ClientID          string
 	ClientSecret      string
@@ -58,6 +63,14 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp) preparation.Validation {
 			}
 		}
 
+		if !domain.IsValidBackChannelLogoutURI(app.BackChannelLogoutURI) {
+			return nil, zerrors.ThrowInvalidArgument(nil, "V2-Bcl2u", "Errors.Invalid.Argument")
+		}
+
+		if !domain.IsValidTLSClientAuth(app.AuthMethodType, app.TLSClientAuthSubjectDN) {
+			return nil, zerrors.ThrowInvalidArgument(nil, "V2-Tls1d", "Errors.Invalid.Argument")
+		}
+
 		if !domain.ContainsRequiredGrantTypes(app.ResponseTypes, app.GrantTypes) {
 			return nil, zerrors.ThrowInvalidArgument(nil, "V2-sLpW1", "Errors.Invalid.Argument")
 		}
@@ -108,6 +121,11 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp) preparation.Validation {
 					app.ClockSkew,
 					trimStringSliceWhiteSpaces(app.AdditionalOrigins),
 					app.SkipSuccessPageForNativeApp,
+					strings.TrimSpace(app.BackChannelLogoutURI),
+					app.BackChannelLogoutSessionRequired,
+					app.RequirePushedAuthRequests,
+					app.RequireDPoP,
+					strings.TrimSpace(app.TLSClientAuthSubjectDN),
 				),
 			}, nil
 		}, nil
@@ -155,7 +173,7 @@ func (c *Commands) AddOIDCApplication(ctx context.Context, oidcApp *domain.OIDCA
 	return c.addOIDCApplicationWithID(ctx, oidcApp, resourceOwner, appID)
 }
 
-func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string, appID string) (_ *domain.OIDCApp, err error) {
+func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string, appID string, additionalEvents ...eventstore.Command) (_ *domain.OIDCApp, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -199,7 +217,13 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		oidcApp.ClockSkew,
 		trimStringSliceWhiteSpaces(oidcApp.AdditionalOrigins),
 		oidcApp.SkipNativeAppSuccessPage,
+		strings.TrimSpace(oidcApp.BackChannelLogoutURI),
+		oidcApp.BackChannelLogoutSessionRequired,
+		oidcApp.RequirePushedAuthRequests,
+		oidcApp.RequireDPoP,
+		strings.TrimSpace(oidcApp.TLSClientAuthSubjectDN),
 	))
+	events = append(events, additionalEvents...)
 
 	addedApplication.AppID = oidcApp.AppID
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
@@ -251,6 +275,11 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.ClockSkew,
 		trimStringSliceWhiteSpaces(oidc.AdditionalOrigins),
 		oidc.SkipNativeAppSuccessPage,
+		strings.TrimSpace(oidc.BackChannelLogoutURI),
+		oidc.BackChannelLogoutSessionRequired,
+		oidc.RequirePushedAuthRequests,
+		oidc.RequireDPoP,
+		strings.TrimSpace(oidc.TLSClientAuthSubjectDN),
 	)
 	if err != nil {
 		return nil, err
@@ -14,29 +14,34 @@ import (
 type OIDCApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	HashedSecret             string
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []domain.OIDCResponseType
-	GrantTypes               []domain.OIDCGrantType
-	ApplicationType          domain.OIDCApplicationType
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              domain.OIDCVersion
-	Compliance               *domain.Compliance
-	DevMode                  bool
-	AccessTokenType          domain.OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	State                    domain.AppState
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
-	oidc                     bool
+	AppID                            string
+	AppName                          string
+	ClientID                         string
+	HashedSecret                     string
+	ClientSecretString               string
+	RedirectUris                     []string
+	ResponseTypes                    []domain.OIDCResponseType
+	GrantTypes                       []domain.OIDCGrantType
+	ApplicationType                  domain.OIDCApplicationType
+	AuthMethodType                   domain.OIDCAuthMethodType
+	PostLogoutRedirectUris           []string
+	OIDCVersion                      domain.OIDCVersion
+	Compliance                       *domain.Compliance
+	DevMode                          bool
+	AccessTokenType                  domain.OIDCTokenType
+	AccessTokenRoleAssertion         bool
+	IDTokenRoleAssertion             bool
+	IDTokenUserinfoAssertion         bool
+	ClockSkew                        time.Duration
+	State                            domain.AppState
+	AdditionalOrigins                []string
+	SkipNativeAppSuccessPage         bool
+	BackChannelLogoutURI             string
+	BackChannelLogoutSessionRequired bool
+	RequirePushedAuthRequests        bool
+	RequireDPoP                      bool
+	TLSClientAuthSubjectDN           string
+	oidc                             bool
 }
 
 func NewOIDCApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *OIDCApplicationWriteModel {
@@ -165,6 +170,11 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
 	wm.SkipNativeAppSuccessPage = e.SkipNativeAppSuccessPage
+	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
+	wm.BackChannelLogoutSessionRequired = e.BackChannelLogoutSessionRequired
+	wm.RequirePushedAuthRequests = e.RequirePushedAuthRequests
+	wm.RequireDPoP = e.RequireDPoP
+	wm.TLSClientAuthSubjectDN = e.TLSClientAuthSubjectDN
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -213,6 +223,21 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.SkipNativeAppSuccessPage != nil {
 		wm.SkipNativeAppSuccessPage = *e.SkipNativeAppSuccessPage
 	}
+	if e.BackChannelLogoutURI != nil {
+		wm.BackChannelLogoutURI = *e.BackChannelLogoutURI
+	}
+	if e.BackChannelLogoutSessionRequired != nil {
+		wm.BackChannelLogoutSessionRequired = *e.BackChannelLogoutSessionRequired
+	}
+	if e.RequirePushedAuthRequests != nil {
+		wm.RequirePushedAuthRequests = *e.RequirePushedAuthRequests
+	}
+	if e.RequireDPoP != nil {
+		wm.RequireDPoP = *e.RequireDPoP
+	}
+	if e.TLSClientAuthSubjectDN != nil {
+		wm.TLSClientAuthSubjectDN = *e.TLSClientAuthSubjectDN
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -254,6 +279,11 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	backChannelLogoutURI string,
+	backChannelLogoutSessionRequired bool,
+	requirePushedAuthRequests bool,
+	requireDPoP bool,
+	tlsClientAuthSubjectDN string,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -303,6 +333,21 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.SkipNativeAppSuccessPage != skipNativeAppSuccessPage {
 		changes = append(changes, project.ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage))
 	}
+	if wm.BackChannelLogoutURI != backChannelLogoutURI {
+		changes = append(changes, project.ChangeBackChannelLogoutURI(backChannelLogoutURI))
+	}
+	if wm.BackChannelLogoutSessionRequired != backChannelLogoutSessionRequired {
+		changes = append(changes, project.ChangeBackChannelLogoutSessionRequired(backChannelLogoutSessionRequired))
+	}
+	if wm.RequirePushedAuthRequests != requirePushedAuthRequests {
+		changes = append(changes, project.ChangeRequirePushedAuthRequests(requirePushedAuthRequests))
+	}
+	if wm.RequireDPoP != requireDPoP {
+		changes = append(changes, project.ChangeRequireDPoP(requireDPoP))
+	}
+	if wm.TLSClientAuthSubjectDN != tlsClientAuthSubjectDN {
+		changes = append(changes, project.ChangeTLSClientAuthSubjectDN(tlsClientAuthSubjectDN))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
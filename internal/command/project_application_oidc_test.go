@@ -84,6 +84,51 @@ func TestAddOIDCApp(t *testing.T) {
 				ValidationErr: zerrors.ThrowInvalidArgument(nil, "PROJE-Fef31", "Errors.Invalid.Argument"),
 			},
 		},
+		{
+			name:   "invalid back-channel logout uri",
+			fields: fields{},
+			args: args{
+				app: &addOIDCApp{
+					AddApp: AddApp{
+						Aggregate: *agg,
+						ID:        "id",
+						Name:      "name",
+					},
+					GrantTypes:           []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ResponseTypes:        []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					Version:              domain.OIDCVersionV1,
+					ApplicationType:      domain.OIDCApplicationTypeWeb,
+					AuthMethodType:       domain.OIDCAuthMethodTypeNone,
+					AccessTokenType:      domain.OIDCTokenTypeBearer,
+					BackChannelLogoutURI: "/logout",
+				},
+			},
+			want: Want{
+				ValidationErr: zerrors.ThrowInvalidArgument(nil, "V2-Bcl2u", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			name:   "tls client auth without subject dn",
+			fields: fields{},
+			args: args{
+				app: &addOIDCApp{
+					AddApp: AddApp{
+						Aggregate: *agg,
+						ID:        "id",
+						Name:      "name",
+					},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					Version:         domain.OIDCVersionV1,
+					ApplicationType: domain.OIDCApplicationTypeWeb,
+					AuthMethodType:  domain.OIDCAuthMethodTypeTLSClientAuth,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+				},
+			},
+			want: Want{
+				ValidationErr: zerrors.ThrowInvalidArgument(nil, "V2-Tls1d", "Errors.Invalid.Argument"),
+			},
+		},
 		{
 			name:   "project doesn't exist",
 			fields: fields{},
@@ -174,6 +219,11 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						[]string{"https://sub.test.ch"},
 						false,
+						"",
+						false,
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -239,6 +289,11 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						"",
+						false,
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -304,6 +359,11 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						"",
+						false,
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -369,6 +429,11 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						"",
+						false,
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -515,6 +580,11 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							time.Second*1,
 							[]string{"https://sub.test.ch"},
 							true,
+							"",
+							false,
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -613,6 +683,11 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							time.Second*1,
 							[]string{"https://sub.test.ch"},
 							true,
+							"",
+							false,
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -845,6 +920,11 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								"",
+								false,
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -914,6 +994,11 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								"",
+								false,
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -983,6 +1068,11 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								"",
+								false,
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -1000,23 +1090,24 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 					ObjectRoot: models.ObjectRoot{
 						AggregateID: "project1",
 					},
-					AppID:                    "app1",
-					AppName:                  "app",
-					AuthMethodType:           domain.OIDCAuthMethodTypePost,
-					OIDCVersion:              domain.OIDCVersionV1,
-					RedirectUris:             []string{" https://test-change.ch "},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          domain.OIDCApplicationTypeWeb,
-					PostLogoutRedirectUris:   []string{" https://test-change.ch/logout "},
-					DevMode:                  true,
-					AccessTokenType:          domain.OIDCTokenTypeJWT,
-					AccessTokenRoleAssertion: false,
-					IDTokenRoleAssertion:     false,
-					IDTokenUserinfoAssertion: false,
-					ClockSkew:                time.Second * 2,
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					SkipNativeAppSuccessPage: true,
+					AppID:                     "app1",
+					AppName:                   "app",
+					AuthMethodType:            domain.OIDCAuthMethodTypePost,
+					OIDCVersion:               domain.OIDCVersionV1,
+					RedirectUris:              []string{" https://test-change.ch "},
+					ResponseTypes:             []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:           domain.OIDCApplicationTypeWeb,
+					PostLogoutRedirectUris:    []string{" https://test-change.ch/logout "},
+					DevMode:                   true,
+					AccessTokenType:           domain.OIDCTokenTypeJWT,
+					AccessTokenRoleAssertion:  false,
+					IDTokenRoleAssertion:      false,
+					IDTokenUserinfoAssertion:  false,
+					ClockSkew:                 time.Second * 2,
+					AdditionalOrigins:         []string{"https://sub.test.ch"},
+					SkipNativeAppSuccessPage:  true,
+					RequirePushedAuthRequests: true,
 				},
 				resourceOwner: "org1",
 			},
@@ -1026,26 +1117,27 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:                    "app1",
-					ClientID:                 "client1@project",
-					AppName:                  "app",
-					AuthMethodType:           domain.OIDCAuthMethodTypePost,
-					OIDCVersion:              domain.OIDCVersionV1,
-					RedirectUris:             []string{"https://test-change.ch"},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          domain.OIDCApplicationTypeWeb,
-					PostLogoutRedirectUris:   []string{"https://test-change.ch/logout"},
-					DevMode:                  true,
-					AccessTokenType:          domain.OIDCTokenTypeJWT,
-					AccessTokenRoleAssertion: false,
-					IDTokenRoleAssertion:     false,
-					IDTokenUserinfoAssertion: false,
-					ClockSkew:                time.Second * 2,
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					SkipNativeAppSuccessPage: true,
-					Compliance:               &domain.Compliance{},
-					State:                    domain.AppStateActive,
+					AppID:                     "app1",
+					ClientID:                  "client1@project",
+					AppName:                   "app",
+					AuthMethodType:            domain.OIDCAuthMethodTypePost,
+					OIDCVersion:               domain.OIDCVersionV1,
+					RedirectUris:              []string{"https://test-change.ch"},
+					ResponseTypes:             []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:           domain.OIDCApplicationTypeWeb,
+					PostLogoutRedirectUris:    []string{"https://test-change.ch/logout"},
+					DevMode:                   true,
+					AccessTokenType:           domain.OIDCTokenTypeJWT,
+					AccessTokenRoleAssertion:  false,
+					IDTokenRoleAssertion:      false,
+					IDTokenUserinfoAssertion:  false,
+					ClockSkew:                 time.Second * 2,
+					AdditionalOrigins:         []string{"https://sub.test.ch"},
+					SkipNativeAppSuccessPage:  true,
+					RequirePushedAuthRequests: true,
+					Compliance:                &domain.Compliance{},
+					State:                     domain.AppStateActive,
 				},
 			},
 		},
@@ -1168,6 +1260,11 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								"",
+								false,
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -1249,6 +1346,7 @@ func newOIDCAppChangedEvent(ctx context.Context, appID, projectID, resourceOwner
 		project.ChangeIDTokenRoleAssertion(false),
 		project.ChangeIDTokenUserinfoAssertion(false),
 		project.ChangeClockSkew(time.Second * 2),
+		project.ChangeRequirePushedAuthRequests(true),
 	}
 	event, _ := project.NewOIDCConfigChangedEvent(ctx,
 		&project.NewAggregate(projectID, resourceOwner).Aggregate,
@@ -1325,6 +1423,11 @@ func TestCommands_VerifyOIDCClientSecret(t *testing.T) {
 							time.Second*1,
 							[]string{"https://sub.test.ch"},
 							false,
+							"",
+							false,
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -1360,6 +1463,11 @@ func TestCommands_VerifyOIDCClientSecret(t *testing.T) {
 							time.Second*1,
 							[]string{"https://sub.test.ch"},
 							false,
+							"",
+							false,
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -1397,6 +1505,11 @@ func TestCommands_VerifyOIDCClientSecret(t *testing.T) {
 							time.Second*1,
 							[]string{"https://sub.test.ch"},
 							false,
+							"",
+							false,
+							false,
+							false,
+							"",
 						),
 					),
 				),
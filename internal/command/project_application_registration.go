@@ -0,0 +1,178 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	project_repo "github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type InitialAccessToken struct {
+	TokenID        string
+	Token          string
+	ExpirationDate time.Time
+	Details        *domain.ObjectDetails
+}
+
+// AddInitialAccessToken issues a token, which allows to register OIDC applications
+// in the project using dynamic client registration (RFC 7591).
+func (c *Commands) AddInitialAccessToken(ctx context.Context, projectID, resourceOwner string, expirationDate time.Time) (_ *InitialAccessToken, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Iat1p", "Errors.Project.ProjectIDMissing")
+	}
+	expirationDate, err = domain.ValidateExpirationDate(expirationDate)
+	if err != nil {
+		return nil, err
+	}
+	projectWriteModel, err := c.getProjectWriteModelByID(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if projectWriteModel.State == domain.ProjectStateUnspecified || projectWriteModel.State == domain.ProjectStateRemoved {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Iat2p", "Errors.Project.NotFound")
+	}
+	tokenID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	token, err := createToken(c.keyAlgorithm, tokenID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project_repo.NewInitialAccessTokenAddedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&projectWriteModel.WriteModel),
+		tokenID,
+		expirationDate,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &InitialAccessToken{
+		TokenID:        tokenID,
+		Token:          token,
+		ExpirationDate: expirationDate,
+		Details:        pushedEventsToObjectDetails(pushedEvents),
+	}, nil
+}
+
+func (c *Commands) RemoveInitialAccessToken(ctx context.Context, projectID, tokenID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" || tokenID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Iat3r", "Errors.IDMissing")
+	}
+	writeModel := NewInitialAccessTokenWriteModel(projectID, tokenID, resourceOwner)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Iat4r", "Errors.Token.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project_repo.NewInitialAccessTokenRemovedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		tokenID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RegisterOIDCApplication adds the application to the project the initial access token was issued for (RFC 7591).
+// The returned registration access token allows to read, update and delete the application (RFC 7592).
+func (c *Commands) RegisterOIDCApplication(ctx context.Context, initialAccessToken string, oidcApp *domain.OIDCApp) (_ *domain.OIDCApp, registrationAccessToken string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	tokenID, projectID, err := c.parseToken(initialAccessToken)
+	if err != nil {
+		return nil, "", err
+	}
+	tokenWriteModel := NewInitialAccessTokenWriteModel(projectID, tokenID, "")
+	if err = c.eventstore.FilterToQueryReducer(ctx, tokenWriteModel); err != nil {
+		return nil, "", err
+	}
+	if !tokenWriteModel.Valid() {
+		return nil, "", zerrors.ThrowPermissionDenied(nil, "COMMAND-Reg1t", "Errors.Token.Invalid")
+	}
+	resourceOwner := tokenWriteModel.ResourceOwner
+	oidcApp.AggregateID = projectID
+	if oidcApp.AppName = strings.TrimSpace(oidcApp.AppName); oidcApp.AppName == "" || !oidcApp.IsValid() {
+		return nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-Reg2a", "Errors.Project.App.Invalid")
+	}
+	if _, err = c.getProjectByID(ctx, projectID, resourceOwner); err != nil {
+		return nil, "", zerrors.ThrowPreconditionFailed(err, "COMMAND-Reg3p", "Errors.Project.NotFound")
+	}
+	appID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	registrationTokenID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	registrationAccessToken, err = createToken(c.keyAlgorithm, registrationTokenID, appID)
+	if err != nil {
+		return nil, "", err
+	}
+	projectAgg := ProjectAggregateFromWriteModel(&NewOIDCApplicationWriteModel(projectID, resourceOwner).WriteModel)
+	app, err := c.addOIDCApplicationWithID(ctx, oidcApp, resourceOwner, appID,
+		project_repo.NewApplicationRegistrationTokenAddedEvent(ctx, projectAgg, appID, registrationTokenID),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return app, registrationAccessToken, nil
+}
+
+// VerifyRegistrationAccessToken checks the registration access token of a dynamically registered application
+// and returns the resource owner of the application.
+func (c *Commands) VerifyRegistrationAccessToken(ctx context.Context, projectID, appID, registrationAccessToken string) (resourceOwner string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	tokenID, tokenAppID, err := c.parseToken(registrationAccessToken)
+	if err != nil {
+		return "", err
+	}
+	writeModel := NewOIDCApplicationRegistrationWriteModel(projectID, appID, "")
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return "", err
+	}
+	if tokenAppID != appID || writeModel.State != domain.AppStateActive || writeModel.TokenID == "" || writeModel.TokenID != tokenID {
+		return "", zerrors.ThrowPermissionDenied(nil, "COMMAND-Reg4t", "Errors.Token.Invalid")
+	}
+	return writeModel.ResourceOwner, nil
+}
+
+// parseToken returns the token id and subject of a token created by [createToken]
+func (c *Commands) parseToken(token string) (tokenID, subject string, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", zerrors.ThrowPermissionDenied(err, "COMMAND-Reg5t", "Errors.Token.Invalid")
+	}
+	tokenIDSubject, err := c.keyAlgorithm.DecryptString(decoded, c.keyAlgorithm.EncryptionKeyID())
+	if err != nil {
+		return "", "", zerrors.ThrowPermissionDenied(err, "COMMAND-Reg6t", "Errors.Token.Invalid")
+	}
+	tokenID, subject, ok := strings.Cut(tokenIDSubject, ":")
+	if !ok || tokenID == "" || subject == "" {
+		return "", "", zerrors.ThrowPermissionDenied(nil, "COMMAND-Reg7t", "Errors.Token.Invalid")
+	}
+	return tokenID, subject, nil
+}
@@ -0,0 +1,159 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type InitialAccessTokenWriteModel struct {
+	eventstore.WriteModel
+
+	TokenID        string
+	ExpirationDate time.Time
+	active         bool
+}
+
+func NewInitialAccessTokenWriteModel(projectID, tokenID, resourceOwner string) *InitialAccessTokenWriteModel {
+	return &InitialAccessTokenWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		TokenID: tokenID,
+	}
+}
+
+func (wm *InitialAccessTokenWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.InitialAccessTokenAddedEvent:
+			if e.TokenID != wm.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.InitialAccessTokenRemovedEvent:
+			if e.TokenID != wm.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InitialAccessTokenWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.InitialAccessTokenAddedEvent:
+			wm.ExpirationDate = e.ExpirationDate
+			wm.active = true
+		case *project.InitialAccessTokenRemovedEvent:
+			wm.active = false
+		case *project.ProjectRemovedEvent:
+			wm.active = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InitialAccessTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.InitialAccessTokenAddedType,
+			project.InitialAccessTokenRemovedType,
+			project.ProjectRemovedType,
+		).Builder()
+}
+
+func (wm *InitialAccessTokenWriteModel) Exists() bool {
+	return wm.active
+}
+
+// Valid returns if the token exists and is not yet expired
+func (wm *InitialAccessTokenWriteModel) Valid() bool {
+	return wm.active && time.Now().Before(wm.ExpirationDate)
+}
+
+// OIDCApplicationRegistrationWriteModel contains the registration access token
+// of a dynamically registered application
+type OIDCApplicationRegistrationWriteModel struct {
+	eventstore.WriteModel
+
+	AppID   string
+	TokenID string
+	State   domain.AppState
+}
+
+func NewOIDCApplicationRegistrationWriteModel(projectID, appID, resourceOwner string) *OIDCApplicationRegistrationWriteModel {
+	return &OIDCApplicationRegistrationWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppID: appID,
+	}
+}
+
+func (wm *OIDCApplicationRegistrationWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRegistrationTokenAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OIDCApplicationRegistrationWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			wm.State = domain.AppStateActive
+		case *project.ApplicationRemovedEvent:
+			wm.State = domain.AppStateRemoved
+			wm.TokenID = ""
+		case *project.ApplicationRegistrationTokenAddedEvent:
+			wm.TokenID = e.TokenID
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.AppStateRemoved
+			wm.TokenID = ""
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OIDCApplicationRegistrationWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ApplicationAddedType,
+			project.ApplicationRemovedType,
+			project.ApplicationRegistrationTokenAddedType,
+			project.ProjectRemovedType,
+		).Builder()
+}
@@ -0,0 +1,596 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func testToken(tokenID, subject string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(tokenID + ":" + subject))
+}
+
+func TestCommandSide_AddInitialAccessToken(t *testing.T) {
+	expirationDate := time.Now().Add(time.Hour)
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		projectID      string
+		resourceOwner  string
+		expirationDate time.Time
+	}
+	type res struct {
+		want *InitialAccessToken
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no project id, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "expiration date in the past, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				projectID:      "project1",
+				resourceOwner:  "org1",
+				expirationDate: time.Now().Add(-time.Hour),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "token added, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						project.NewInitialAccessTokenAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"token1",
+							expirationDate,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "token1"),
+			},
+			args: args{
+				projectID:      "project1",
+				resourceOwner:  "org1",
+				expirationDate: expirationDate,
+			},
+			res: res{
+				want: &InitialAccessToken{
+					TokenID:        "token1",
+					Token:          testToken("token1", "project1"),
+					ExpirationDate: expirationDate,
+					Details: &domain.ObjectDetails{
+						ResourceOwner: "org1",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := r.AddInitialAccessToken(context.Background(), tt.args.projectID, tt.args.resourceOwner, tt.args.expirationDate)
+			if tt.res.err != nil {
+				assert.True(t, tt.res.err(err), "got wrong err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCommandSide_RemoveInitialAccessToken(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		projectID     string
+		tokenID       string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no token id, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "token removed before, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(time.Hour),
+							),
+						),
+						eventFromEventPusher(
+							project.NewInitialAccessTokenRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "token removed, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(time.Hour),
+							),
+						),
+					),
+					expectPush(
+						project.NewInitialAccessTokenRemovedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"token1",
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.RemoveInitialAccessToken(context.Background(), tt.args.projectID, tt.args.tokenID, tt.args.resourceOwner)
+			if tt.res.err != nil {
+				assert.True(t, tt.res.err(err), "got wrong err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCommandSide_RegisterOIDCApplication(t *testing.T) {
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		initialAccessToken string
+		oidcApp            *domain.OIDCApp
+	}
+	type res struct {
+		want                    *domain.OIDCApp
+		registrationAccessToken string
+		err                     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "malformed token, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				initialAccessToken: base64.RawURLEncoding.EncodeToString([]byte("token1")),
+				oidcApp:            &domain.OIDCApp{},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "expired token, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(-time.Hour),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				initialAccessToken: testToken("token1", "project1"),
+				oidcApp:            &domain.OIDCApp{},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "invalid app, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(time.Hour),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				initialAccessToken: testToken("token1", "project1"),
+				oidcApp: &domain.OIDCApp{
+					AppName: "app",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "register app, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(time.Hour),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						project.NewApplicationAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+						),
+						project.NewOIDCConfigAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							domain.OIDCVersionV1,
+							"app1",
+							"client1",
+							"",
+							[]string{"https://test.ch"},
+							[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+							[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+							domain.OIDCApplicationTypeWeb,
+							domain.OIDCAuthMethodTypeNone,
+							nil,
+							false,
+							domain.OIDCTokenTypeBearer,
+							false,
+							false,
+							false,
+							0,
+							nil,
+							false,
+							"",
+							false,
+							false,
+							false,
+							"",
+						),
+						project.NewApplicationRegistrationTokenAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"registration1",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "app1", "registration1", "client1"),
+			},
+			args: args{
+				initialAccessToken: testToken("token1", "project1"),
+				oidcApp: &domain.OIDCApp{
+					AppName:         " app ",
+					OIDCVersion:     domain.OIDCVersionV1,
+					RedirectUris:    []string{"https://test.ch"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType: domain.OIDCApplicationTypeWeb,
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+				},
+			},
+			res: res{
+				want: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:           "app1",
+					AppName:         "app",
+					ClientID:        "client1",
+					OIDCVersion:     domain.OIDCVersionV1,
+					RedirectUris:    []string{"https://test.ch"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType: domain.OIDCApplicationTypeWeb,
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+					State:           domain.AppStateActive,
+					Compliance:      &domain.Compliance{},
+				},
+				registrationAccessToken: testToken("registration1", "app1"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, registrationAccessToken, err := r.RegisterOIDCApplication(context.Background(), tt.args.initialAccessToken, tt.args.oidcApp)
+			if tt.res.err != nil {
+				assert.True(t, tt.res.err(err), "got wrong err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.want, got)
+			assert.Equal(t, tt.res.registrationAccessToken, registrationAccessToken)
+		})
+	}
+}
+
+func TestCommandSide_VerifyRegistrationAccessToken(t *testing.T) {
+	type args struct {
+		appID                   string
+		registrationAccessToken string
+	}
+	type res struct {
+		resourceOwner string
+		err           func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name:       "token of other app, permission denied error",
+			eventstore: expectEventstore(expectFilter()),
+			args: args{
+				appID:                   "app2",
+				registrationAccessToken: testToken("registration1", "app1"),
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "token replaced, permission denied error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						project.NewApplicationAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+						),
+					),
+					eventFromEventPusher(
+						project.NewApplicationRegistrationTokenAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"registration1",
+						),
+					),
+					eventFromEventPusher(
+						project.NewApplicationRegistrationTokenAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"registration2",
+						),
+					),
+				),
+			),
+			args: args{
+				appID:                   "app1",
+				registrationAccessToken: testToken("registration1", "app1"),
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "app removed, permission denied error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						project.NewApplicationAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+						),
+					),
+					eventFromEventPusher(
+						project.NewApplicationRegistrationTokenAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"registration1",
+						),
+					),
+					eventFromEventPusher(
+						project.NewApplicationRemovedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+							"",
+						),
+					),
+				),
+			),
+			args: args{
+				appID:                   "app1",
+				registrationAccessToken: testToken("registration1", "app1"),
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "valid token, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						project.NewApplicationAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+						),
+					),
+					eventFromEventPusher(
+						project.NewApplicationRegistrationTokenAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"registration1",
+						),
+					),
+				),
+			),
+			args: args{
+				appID:                   "app1",
+				registrationAccessToken: testToken("registration1", "app1"),
+			},
+			res: res{
+				resourceOwner: "org1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.eventstore(t),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			resourceOwner, err := r.VerifyRegistrationAccessToken(context.Background(), "project1", tt.args.appID, tt.args.registrationAccessToken)
+			if tt.res.err != nil {
+				assert.True(t, tt.res.err(err), "got wrong err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.resourceOwner, resourceOwner)
+		})
+	}
+}
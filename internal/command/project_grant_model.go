@@ -111,6 +111,16 @@ func (wm *ProjectGrantWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return query
 }
 
+// SnapshotKey implements [eventstore.Snapshotter]
+func (wm *ProjectGrantWriteModel) SnapshotKey() string {
+	return wm.AggregateID + ":" + wm.GrantID
+}
+
+// SnapshotVersion implements [eventstore.Snapshotter]
+func (wm *ProjectGrantWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 type ProjectGrantPreConditionReadModel struct {
 	eventstore.WriteModel
 
@@ -85,6 +85,16 @@ func (wm *ProjectWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+// SnapshotKey implements [eventstore.Snapshotter]
+func (wm *ProjectWriteModel) SnapshotKey() string {
+	return wm.AggregateID
+}
+
+// SnapshotVersion implements [eventstore.Snapshotter]
+func (wm *ProjectWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func (wm *ProjectWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
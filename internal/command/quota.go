@@ -18,6 +18,7 @@ type QuotaUnit string
 const (
 	QuotaRequestsAllAuthenticated QuotaUnit = "requests.all.authenticated"
 	QuotaActionsAllRunsSeconds    QuotaUnit = "actions.all.runs.seconds"
+	QuotaActionsTargetCalls       QuotaUnit = "actions.target.calls"
 )
 
 func (q QuotaUnit) Enum() quota.Unit {
@@ -26,6 +27,8 @@ func (q QuotaUnit) Enum() quota.Unit {
 		return quota.RequestsAllAuthenticated
 	case QuotaActionsAllRunsSeconds:
 		return quota.ActionsAllRunsSeconds
+	case QuotaActionsTargetCalls:
+		return quota.ActionsTargetCalls
 	default:
 		return quota.Unimplemented
 	}
@@ -0,0 +1,112 @@
+package command
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type snapshotterReducer struct {
+	version  string
+	checksum string
+}
+
+// snapshotterReducers are the versions and the checksums of the reducers of the write models implementing [eventstore.Snapshotter].
+// Snapshots are not invalidated if the reduce logic changes, if a checksum does not match anymore
+// increase the SnapshotVersion of the write model and update its version and checksum.
+var snapshotterReducers = map[string]snapshotterReducer{
+	"OrgMemberWriteModel":    {version: "1", checksum: "91fa7143a95edd17"},
+	"OrgWriteModel":          {version: "1", checksum: "e8e5982ee9bd764f"},
+	"ProjectGrantWriteModel": {version: "1", checksum: "027cd3544637c7ba"},
+	"ProjectWriteModel":      {version: "1", checksum: "48872f8130ded6e5"},
+}
+
+// snapshotterReducerMethods are the methods of a write model which define the reduced state
+var snapshotterReducerMethods = []string{"AppendEvents", "Reduce"}
+
+func TestSnapshotters_reducersVersioned(t *testing.T) {
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+
+	versions := make(map[string]string)
+	methods := make(map[string]map[string]*ast.FuncDecl)
+	for _, file := range packages["command"].Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+			receiver := receiverName(fn.Recv.List[0].Type)
+			if fn.Name.Name == "SnapshotVersion" {
+				versions[receiver] = snapshotVersion(t, fset, fn)
+			}
+			if methods[receiver] == nil {
+				methods[receiver] = make(map[string]*ast.FuncDecl)
+			}
+			methods[receiver][fn.Name.Name] = fn
+		}
+	}
+
+	got := make(map[string]snapshotterReducer, len(versions))
+	for receiver, version := range versions {
+		hash := sha256.New()
+		for _, name := range snapshotterReducerMethods {
+			fn, ok := methods[receiver][name]
+			if !ok {
+				continue
+			}
+			// comments are not part of the checksum
+			fn.Doc = nil
+			require.NoError(t, printer.Fprint(hash, fset, fn))
+		}
+		got[receiver] = snapshotterReducer{version: version, checksum: fmt.Sprintf("%x", hash.Sum(nil)[:8])}
+	}
+
+	for receiver, reducer := range got {
+		want, ok := snapshotterReducers[receiver]
+		if !assert.True(t, ok, "add %s to snapshotterReducers: %#v", receiver, reducer) {
+			continue
+		}
+		if want.checksum != reducer.checksum && want.version == reducer.version {
+			t.Errorf("the reducer of %s changed, increase its SnapshotVersion and update its checksum to %s", receiver, reducer.checksum)
+			continue
+		}
+		assert.Equal(t, want, reducer, "update the version and checksum of %s in snapshotterReducers", receiver)
+	}
+	for receiver := range snapshotterReducers {
+		assert.Contains(t, got, receiver, "remove %s from snapshotterReducers", receiver)
+	}
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// snapshotVersion returns the source of the returned version
+func snapshotVersion(t *testing.T, fset *token.FileSet, fn *ast.FuncDecl) string {
+	require.Len(t, fn.Body.List, 1, "SnapshotVersion of %s must only return the version", receiverName(fn.Recv.List[0].Type))
+	ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+	require.True(t, ok, "SnapshotVersion of %s must only return the version", receiverName(fn.Recv.List[0].Type))
+	var version bytes.Buffer
+	require.NoError(t, printer.Fprint(&version, fset, ret.Results[0]))
+	return version.String()
+}
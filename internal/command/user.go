@@ -12,8 +12,10 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/feature"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	user_v2 "github.com/zitadel/zitadel/internal/v2/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -22,6 +24,9 @@ func (c *Commands) ChangeUsername(ctx context.Context, orgID, userID, userName s
 	if orgID == "" || userID == "" || userName == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-2N9fs", "Errors.IDMissing")
 	}
+	if authz.GetFeatures(ctx).ShouldUseImprovedPerformance(feature.ImprovedPerformanceTypeUserCommands) {
+		return c.changeUsernameV2(ctx, orgID, userID, userName)
+	}
 
 	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
 	if err != nil {
@@ -61,19 +66,16 @@ func (c *Commands) DeactivateUser(ctx context.Context, userID, resourceOwner str
 	if userID == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-m0gDf", "Errors.User.UserIDMissing")
 	}
+	if authz.GetFeatures(ctx).ShouldUseImprovedPerformance(feature.ImprovedPerformanceTypeUserCommands) {
+		return c.changeUserStateV2(ctx, userID, resourceOwner, checkUserDeactivatable, user_v2.NewDeactivatedCommand(ctx))
+	}
 
 	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
 	if err != nil {
 		return nil, err
 	}
-	if !isUserStateExists(existingUser.UserState) {
-		return nil, zerrors.ThrowNotFound(nil, "COMMAND-3M9ds", "Errors.User.NotFound")
-	}
-	if isUserStateInitial(existingUser.UserState) {
-		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ke0fw", "Errors.User.CantDeactivateInitial")
-	}
-	if isUserStateInactive(existingUser.UserState) {
-		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-5M0sf", "Errors.User.AlreadyInactive")
+	if err = checkUserDeactivatable(existingUser.UserState); err != nil {
+		return nil, err
 	}
 
 	pushedEvents, err := c.eventstore.Push(ctx,
@@ -88,20 +90,33 @@ func (c *Commands) DeactivateUser(ctx context.Context, userID, resourceOwner str
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
+func checkUserDeactivatable(state domain.UserState) error {
+	if !isUserStateExists(state) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-3M9ds", "Errors.User.NotFound")
+	}
+	if isUserStateInitial(state) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-ke0fw", "Errors.User.CantDeactivateInitial")
+	}
+	if isUserStateInactive(state) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-5M0sf", "Errors.User.AlreadyInactive")
+	}
+	return nil
+}
+
 func (c *Commands) ReactivateUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
 	if userID == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-4M9ds", "Errors.User.UserIDMissing")
 	}
+	if authz.GetFeatures(ctx).ShouldUseImprovedPerformance(feature.ImprovedPerformanceTypeUserCommands) {
+		return c.changeUserStateV2(ctx, userID, resourceOwner, checkUserReactivatable, user_v2.NewReactivatedCommand(ctx))
+	}
 
 	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
 	if err != nil {
 		return nil, err
 	}
-	if !isUserStateExists(existingUser.UserState) {
-		return nil, zerrors.ThrowNotFound(nil, "COMMAND-4M0sd", "Errors.User.NotFound")
-	}
-	if !isUserStateInactive(existingUser.UserState) {
-		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-6M0sf", "Errors.User.NotInactive")
+	if err = checkUserReactivatable(existingUser.UserState); err != nil {
+		return nil, err
 	}
 
 	pushedEvents, err := c.eventstore.Push(ctx,
@@ -116,20 +131,30 @@ func (c *Commands) ReactivateUser(ctx context.Context, userID, resourceOwner str
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
+func checkUserReactivatable(state domain.UserState) error {
+	if !isUserStateExists(state) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-4M0sd", "Errors.User.NotFound")
+	}
+	if !isUserStateInactive(state) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-6M0sf", "Errors.User.NotInactive")
+	}
+	return nil
+}
+
 func (c *Commands) LockUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
 	if userID == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-2M0sd", "Errors.User.UserIDMissing")
 	}
+	if authz.GetFeatures(ctx).ShouldUseImprovedPerformance(feature.ImprovedPerformanceTypeUserCommands) {
+		return c.changeUserStateV2(ctx, userID, resourceOwner, checkUserLockable, user_v2.NewLockedCommand(ctx))
+	}
 
 	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
 	if err != nil {
 		return nil, err
 	}
-	if !isUserStateExists(existingUser.UserState) {
-		return nil, zerrors.ThrowNotFound(nil, "COMMAND-5M9fs", "Errors.User.NotFound")
-	}
-	if !hasUserState(existingUser.UserState, domain.UserStateActive, domain.UserStateInitial) {
-		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-3NN8v", "Errors.User.ShouldBeActiveOrInitial")
+	if err = checkUserLockable(existingUser.UserState); err != nil {
+		return nil, err
 	}
 
 	pushedEvents, err := c.eventstore.Push(ctx,
@@ -144,20 +169,30 @@ func (c *Commands) LockUser(ctx context.Context, userID, resourceOwner string) (
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
+func checkUserLockable(state domain.UserState) error {
+	if !isUserStateExists(state) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-5M9fs", "Errors.User.NotFound")
+	}
+	if !hasUserState(state, domain.UserStateActive, domain.UserStateInitial) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-3NN8v", "Errors.User.ShouldBeActiveOrInitial")
+	}
+	return nil
+}
+
 func (c *Commands) UnlockUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
 	if userID == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-M0dse", "Errors.User.UserIDMissing")
 	}
+	if authz.GetFeatures(ctx).ShouldUseImprovedPerformance(feature.ImprovedPerformanceTypeUserCommands) {
+		return c.changeUserStateV2(ctx, userID, resourceOwner, checkUserUnlockable, user_v2.NewUnlockedCommand(ctx))
+	}
 
 	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
 	if err != nil {
 		return nil, err
 	}
-	if !isUserStateExists(existingUser.UserState) {
-		return nil, zerrors.ThrowNotFound(nil, "COMMAND-M0dos", "Errors.User.NotFound")
-	}
-	if !hasUserState(existingUser.UserState, domain.UserStateLocked) {
-		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-4M0ds", "Errors.User.NotLocked")
+	if err = checkUserUnlockable(existingUser.UserState); err != nil {
+		return nil, err
 	}
 
 	pushedEvents, err := c.eventstore.Push(ctx,
@@ -172,6 +207,16 @@ func (c *Commands) UnlockUser(ctx context.Context, userID, resourceOwner string)
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
+func checkUserUnlockable(state domain.UserState) error {
+	if !isUserStateExists(state) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-M0dos", "Errors.User.NotFound")
+	}
+	if !hasUserState(state, domain.UserStateLocked) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-4M0ds", "Errors.User.NotLocked")
+	}
+	return nil
+}
+
 func (c *Commands) RemoveUser(ctx context.Context, userID, resourceOwner string, cascadingUserMemberships []*CascadingMembership, cascadingGrantIDs ...string) (*domain.ObjectDetails, error) {
 	if userID == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-2M0ds", "Errors.User.UserIDMissing")
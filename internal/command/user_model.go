@@ -95,6 +95,7 @@ func (wm *UserWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(
 			user.HumanAddedType,
 			user.HumanRegisteredType,
+			user.HumanInitialCodeAddedType,
 			user.HumanInitializedCheckSucceededType,
 			user.UserIDPLinkAddedType,
 			user.UserIDPLinkRemovedType,
@@ -109,6 +110,7 @@ func (wm *UserWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.UserRemovedType,
 			user.UserV1AddedType,
 			user.UserV1RegisteredType,
+			user.UserV1InitialCodeAddedType,
 			user.UserV1InitializedCheckSucceededType).
 		Builder()
 
@@ -0,0 +1,111 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
+	"github.com/zitadel/zitadel/internal/v2/readmodel"
+	user_v2 "github.com/zitadel/zitadel/internal/v2/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// changeUserStateV2 queries the state of the user and pushes the command using the eventstore v2
+// check validates if the state of the user allows the command
+func (c *Commands) changeUserStateV2(ctx context.Context, userID, resourceOwner string, check func(domain.UserState) error, command *es_v4.Command) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	state := readmodel.NewUserState(userID, resourceOwner)
+	_, err := c.eventstoreV4.Query(ctx, es_v4.NewQuery(instanceID, state, es_v4.AppendFilters(state.Filter()...)))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Qu8se", "Errors.Internal")
+	}
+	if err = check(userStateV2ToDomain(state.State.State)); err != nil {
+		return nil, err
+	}
+
+	err = c.eventstoreV4.Push(ctx, es_v4.NewPushIntent(
+		instanceID,
+		es_v4.AppendAggregate(
+			state.Owner,
+			user_v2.AggregateType,
+			state.ID,
+			// the read model only contains the events changing the state of the user
+			es_v4.CurrentSequenceAtLeast(state.Sequence),
+			es_v4.AppendCommands(command),
+		),
+		es_v4.PushReducer(state),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ObjectDetails{
+		Sequence:      uint64(state.Sequence),
+		EventDate:     state.ChangeDate,
+		ResourceOwner: state.Owner,
+	}, nil
+}
+
+// changeUsernameV2 queries the username of the user and pushes the changed username using the eventstore v2
+func (c *Commands) changeUsernameV2(ctx context.Context, orgID, userID, userName string) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	existingUser := readmodel.NewUserUsername(userID, orgID)
+	_, err := c.eventstoreV4.Query(ctx, es_v4.NewQuery(instanceID, existingUser, es_v4.AppendFilters(existingUser.Filter()...)))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Un3qx", "Errors.Internal")
+	}
+	if !isUserStateExists(userStateV2ToDomain(existingUser.State.State)) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-5N9ds", "Errors.User.NotFound")
+	}
+	if existingUser.Username == userName {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-6m9gs", "Errors.User.UsernameNotChanged")
+	}
+
+	domainPolicy, err := c.domainPolicyWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-38fnu", "Errors.Org.DomainPolicy.NotExisting")
+	}
+	if err = c.userValidateDomain(ctx, orgID, userName, domainPolicy.UserLoginMustBeDomain); err != nil {
+		return nil, err
+	}
+
+	err = c.eventstoreV4.Push(ctx, es_v4.NewPushIntent(
+		instanceID,
+		es_v4.AppendAggregate(
+			existingUser.Owner,
+			user_v2.AggregateType,
+			existingUser.ID,
+			// the read model only contains the events changing the state or the username of the user
+			es_v4.CurrentSequenceAtLeast(existingUser.Sequence),
+			es_v4.AppendCommands(user_v2.NewUsernameChangedCommand(ctx, existingUser.Owner, existingUser.Username, userName, domainPolicy.UserLoginMustBeDomain)),
+		),
+		es_v4.PushReducer(existingUser),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ObjectDetails{
+		Sequence:      uint64(existingUser.Sequence),
+		EventDate:     existingUser.ChangeDate,
+		ResourceOwner: existingUser.Owner,
+	}, nil
+}
+
+func userStateV2ToDomain(state user_v2.State) domain.UserState {
+	switch state {
+	case user_v2.ActiveState:
+		return domain.UserStateActive
+	case user_v2.InactiveState:
+		return domain.UserStateInactive
+	case user_v2.LockedState:
+		return domain.UserStateLocked
+	case user_v2.RemovedState:
+		return domain.UserStateDeleted
+	case user_v2.InitialState:
+		return domain.UserStateInitial
+	case user_v2.UndefinedState:
+		return domain.UserStateUnspecified
+	default:
+		return domain.UserStateUnspecified
+	}
+}
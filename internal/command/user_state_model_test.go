@@ -0,0 +1,466 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/feature"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
+	user_v2 "github.com/zitadel/zitadel/internal/v2/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// testEventstoreV4 reduces the given events on query and the commands of the intent on push
+type testEventstoreV4 struct {
+	events  []*es_v4.StorageEvent
+	pushErr error
+	pushed  []*es_v4.PushAggregate
+}
+
+func (es *testEventstoreV4) Health(context.Context) error {
+	return nil
+}
+
+func (es *testEventstoreV4) Query(_ context.Context, query *es_v4.Query) (int, error) {
+	return len(es.events), query.Reduce(es.events...)
+}
+
+func (es *testEventstoreV4) Push(_ context.Context, intent *es_v4.PushIntent) error {
+	if es.pushErr != nil {
+		return es.pushErr
+	}
+	sequence := uint32(len(es.events))
+	for _, aggregate := range intent.Aggregates() {
+		if !es_v4.CheckSequence(sequence, aggregate.CurrentSequence()) {
+			return zerrors.ThrowInvalidArgument(nil, "TEST-KOM6E", "Errors.Internal.Eventstore.SequenceNotMatched")
+		}
+		es.pushed = append(es.pushed, aggregate)
+		for _, command := range aggregate.Commands() {
+			sequence++
+			payload, err := json.Marshal(command.Payload)
+			if err != nil {
+				return err
+			}
+			err = intent.Reduce(testStorageEventV4(*aggregate.Aggregate(), command.Type, sequence, string(payload)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func testStorageEventV4(aggregate es_v4.Aggregate, typ string, sequence uint32, payload string) *es_v4.StorageEvent {
+	return &es_v4.StorageEvent{
+		Action: es_v4.Action[es_v4.Unmarshal]{
+			Type: typ,
+			Payload: func(ptr any) error {
+				return json.Unmarshal([]byte(payload), ptr)
+			},
+		},
+		Aggregate: aggregate,
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, int(sequence), 0, time.UTC),
+		Position:  es_v4.GlobalPosition{Position: float64(sequence)},
+		Sequence:  sequence,
+	}
+}
+
+func TestCommandSide_changeUserStateV2(t *testing.T) {
+	ctx := authz.WithFeatures(
+		authz.WithInstanceID(context.Background(), "instance1"),
+		feature.Features{ImprovedPerformance: []feature.ImprovedPerformanceType{feature.ImprovedPerformanceTypeUserCommands}},
+	)
+	userAggregate := es_v4.Aggregate{ID: "user1", Type: user_v2.AggregateType, Owner: "org1", Instance: "instance1"}
+	pushErr := errors.New("push failed")
+	type res struct {
+		want   *domain.ObjectDetails
+		pushed []string
+		err    func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore *testEventstoreV4
+		change     func(c *Commands) (*domain.ObjectDetails, error)
+		res        res
+	}{
+		{
+			name:       "user not existing, not found error",
+			eventstore: &testEventstoreV4{},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.LockUser(ctx, "user1", "org1")
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "user removed, not found error",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, "{}"),
+					testStorageEventV4(userAggregate, user_v2.RemovedType, 2, "{}"),
+				},
+			},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.DeactivateUser(ctx, "user1", "org1")
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "user inactive, precondition error",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, "{}"),
+					testStorageEventV4(userAggregate, user_v2.DeactivatedType, 2, "{}"),
+				},
+			},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.LockUser(ctx, "user1", "org1")
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "lock user, ok",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(userAggregate, user_v2.MachineAddedType, 1, "{}"),
+				},
+			},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.LockUser(ctx, "user1", "org1")
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					Sequence:      2,
+					EventDate:     time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC),
+					ResourceOwner: "org1",
+				},
+				pushed: []string{user_v2.LockedType},
+			},
+		},
+		{
+			name: "unlock user, ok",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, "{}"),
+					testStorageEventV4(userAggregate, user_v2.LockedType, 2, "{}"),
+				},
+			},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.UnlockUser(ctx, "user1", "org1")
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					Sequence:      3,
+					EventDate:     time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC),
+					ResourceOwner: "org1",
+				},
+				pushed: []string{user_v2.UnlockedType},
+			},
+		},
+		{
+			name: "reactivate user, ok",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(userAggregate, user_v2.V1AddedType, 1, "{}"),
+					testStorageEventV4(userAggregate, user_v2.DeactivatedType, 2, "{}"),
+				},
+			},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.ReactivateUser(ctx, "user1", "")
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					Sequence:      3,
+					EventDate:     time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC),
+					ResourceOwner: "org1",
+				},
+				pushed: []string{user_v2.ReactivatedType},
+			},
+		},
+		{
+			name: "push fails, error",
+			eventstore: &testEventstoreV4{
+				events: []*es_v4.StorageEvent{
+					testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, "{}"),
+				},
+				pushErr: pushErr,
+			},
+			change: func(c *Commands) (*domain.ObjectDetails, error) {
+				return c.DeactivateUser(ctx, "user1", "org1")
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, pushErr)
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstoreV4: es_v4.NewEventstoreFromOne(tt.eventstore),
+			}
+			got, err := tt.change(c)
+			if tt.res.err != nil {
+				require.True(t, tt.res.err(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.want, got)
+			var pushed []string
+			for _, aggregate := range tt.eventstore.pushed {
+				assert.Equal(t, "org1", aggregate.Owner())
+				for _, command := range aggregate.Commands() {
+					pushed = append(pushed, command.Type)
+				}
+			}
+			assert.Equal(t, tt.res.pushed, pushed)
+		})
+	}
+}
+
+// TestCommandSide_DeactivateUser_initialStateParity runs the same precondition through the eventstore v1 and v2 paths
+func TestCommandSide_DeactivateUser_initialStateParity(t *testing.T) {
+	v1Ctx := authz.WithInstanceID(context.Background(), "instance1")
+	v2Ctx := authz.WithFeatures(authz.WithInstanceID(context.Background(), "instance1"), feature.Features{ImprovedPerformance: []feature.ImprovedPerformanceType{feature.ImprovedPerformanceTypeUserCommands}})
+	userAggregate := es_v4.Aggregate{ID: "user1", Type: user_v2.AggregateType, Owner: "org1", Instance: "instance1"}
+
+	v1 := &Commands{
+		eventstore: eventstoreExpect(t,
+			expectFilter(
+				eventFromEventPusher(
+					user.NewHumanAddedEvent(context.Background(),
+						&user.NewAggregate("user1", "org1").Aggregate,
+						"username",
+						"firstname",
+						"lastname",
+						"nickname",
+						"displayname",
+						language.German,
+						domain.GenderUnspecified,
+						"email@test.ch",
+						true,
+					),
+				),
+				eventFromEventPusher(
+					user.NewHumanInitialCodeAddedEvent(context.Background(),
+						&user.NewAggregate("user1", "org1").Aggregate,
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("userinit"),
+						},
+						time.Hour,
+						"",
+					),
+				),
+			),
+		),
+	}
+	v2 := &Commands{
+		eventstoreV4: es_v4.NewEventstoreFromOne(&testEventstoreV4{
+			events: []*es_v4.StorageEvent{
+				testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, "{}"),
+				testStorageEventV4(userAggregate, user_v2.HumanInitCodeAddedType, 2, "{}"),
+			},
+		}),
+	}
+
+	_, v1Err := v1.DeactivateUser(v1Ctx, "user1", "org1")
+	_, v2Err := v2.DeactivateUser(v2Ctx, "user1", "org1")
+	want := zerrors.ThrowNotFound(nil, "COMMAND-ke0fw", "Errors.User.CantDeactivateInitial")
+	assert.ErrorIs(t, v1Err, want)
+	assert.ErrorIs(t, v2Err, want)
+}
+
+func TestCommandSide_changeUsernameV2(t *testing.T) {
+	ctx := authz.WithFeatures(
+		authz.WithInstanceID(context.Background(), "instance1"),
+		feature.Features{ImprovedPerformance: []feature.ImprovedPerformanceType{feature.ImprovedPerformanceTypeUserCommands}},
+	)
+	userAggregate := es_v4.Aggregate{ID: "user1", Type: user_v2.AggregateType, Owner: "org1", Instance: "instance1"}
+	type fields struct {
+		eventstore   func(t *testing.T) *eventstore.Eventstore
+		eventstoreV4 *testEventstoreV4
+	}
+	type res struct {
+		want   *domain.ObjectDetails
+		pushed []*es_v4.Command
+		err    func(error) bool
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		username string
+		res      res
+	}{
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore:   expectEventstore(),
+				eventstoreV4: &testEventstoreV4{},
+			},
+			username: "new",
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "user removed, not found error",
+			fields: fields{
+				eventstore: expectEventstore(),
+				eventstoreV4: &testEventstoreV4{
+					events: []*es_v4.StorageEvent{
+						testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, `{"userName":"old"}`),
+						testStorageEventV4(userAggregate, user_v2.RemovedType, 2, "{}"),
+					},
+				},
+			},
+			username: "new",
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "username not changed, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(),
+				eventstoreV4: &testEventstoreV4{
+					events: []*es_v4.StorageEvent{
+						testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, `{"userName":"old"}`),
+						testStorageEventV4(userAggregate, user_v2.UsernameChangedType, 2, `{"userName":"changed"}`),
+					},
+				},
+			},
+			username: "changed",
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "domain policy not found, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(),
+				),
+				eventstoreV4: &testEventstoreV4{
+					events: []*es_v4.StorageEvent{
+						testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, `{"userName":"old"}`),
+					},
+				},
+			},
+			username: "new",
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "domain verified by other org, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("instance1").Aggregate,
+								false,
+								true,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainVerifiedEvent(context.Background(),
+								&org.NewAggregate("org2").Aggregate,
+								"test.ch",
+							),
+						),
+					),
+				),
+				eventstoreV4: &testEventstoreV4{
+					events: []*es_v4.StorageEvent{
+						testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, `{"userName":"old"}`),
+					},
+				},
+			},
+			username: "new@test.ch",
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "username changed, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("instance1").Aggregate,
+								true,
+								true,
+								true,
+							),
+						),
+					),
+				),
+				eventstoreV4: &testEventstoreV4{
+					events: []*es_v4.StorageEvent{
+						testStorageEventV4(userAggregate, user_v2.HumanAddedType, 1, `{"userName":"old"}`),
+						testStorageEventV4(userAggregate, user_v2.DomainClaimedType, 2, `{"userName":"claimed"}`),
+					},
+				},
+			},
+			username: "new@test.ch",
+			res: res{
+				want: &domain.ObjectDetails{
+					Sequence:      3,
+					EventDate:     time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC),
+					ResourceOwner: "org1",
+				},
+				pushed: []*es_v4.Command{
+					user_v2.NewUsernameChangedCommand(ctx, "org1", "claimed", "new@test.ch", true),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				eventstoreV4: es_v4.NewEventstoreFromOne(tt.fields.eventstoreV4),
+			}
+			got, err := c.ChangeUsername(ctx, "org1", "user1", tt.username)
+			if tt.res.err != nil {
+				require.True(t, tt.res.err(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.want, got)
+			require.Len(t, tt.fields.eventstoreV4.pushed, 1)
+			assert.Equal(t, tt.res.pushed, tt.fields.eventstoreV4.pushed[0].Commands())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"net/url"
 	"strings"
 	"time"
 
@@ -45,6 +46,19 @@ type OIDCApp struct {
 	ClockSkew                time.Duration
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage bool
+	// BackChannelLogoutURI is called by ZITADEL with a logout token
+	// as soon as a session the app received tokens for is terminated
+	BackChannelLogoutURI string
+	// BackChannelLogoutSessionRequired requires the sid claim in the logout token
+	BackChannelLogoutSessionRequired bool
+	// RequirePushedAuthRequests only allows authorization requests
+	// pushed to the pushed authorization request endpoint (RFC 9126)
+	RequirePushedAuthRequests bool
+	// RequireDPoP only issues tokens bound to a DPoP proof (RFC 9449)
+	RequireDPoP bool
+	// TLSClientAuthSubjectDN is the subject distinguished name of the certificate
+	// the client authenticates with using the tls_client_auth method (RFC 8705)
+	TLSClientAuthSubjectDN string
 
 	State AppState
 }
@@ -118,8 +132,16 @@ const (
 	OIDCAuthMethodTypePost
 	OIDCAuthMethodTypeNone
 	OIDCAuthMethodTypePrivateKeyJWT
+	OIDCAuthMethodTypeTLSClientAuth
+	OIDCAuthMethodTypeSelfSignedTLSClientAuth
 )
 
+// KeysAllowed returns if public keys can be registered for the client to authenticate with,
+// either to sign client assertions or to self-sign its certificate
+func (t OIDCAuthMethodType) KeysAllowed() bool {
+	return t == OIDCAuthMethodTypePrivateKeyJWT || t == OIDCAuthMethodTypeSelfSignedTLSClientAuth
+}
+
 type Compliance struct {
 	NoneCompliant bool
 	Problems      []string
@@ -133,7 +155,7 @@ const (
 )
 
 func (a *OIDCApp) IsValid() bool {
-	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
+	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() || !a.BackChannelLogoutURIValid() || !a.TLSClientAuthValid() {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
@@ -158,6 +180,33 @@ func (a *OIDCApp) OriginsValid() bool {
 	return true
 }
 
+// BackChannelLogoutURIValid checks that the back-channel logout uri, if set, is an absolute http(s) url without fragment
+// as required by https://openid.net/specs/openid-connect-backchannel-1_0.html#BCRegistration
+func (a *OIDCApp) BackChannelLogoutURIValid() bool {
+	return IsValidBackChannelLogoutURI(a.BackChannelLogoutURI)
+}
+
+// TLSClientAuthValid checks that the subject distinguished name is set, if the client authenticates with tls_client_auth
+func (a *OIDCApp) TLSClientAuthValid() bool {
+	return IsValidTLSClientAuth(a.AuthMethodType, a.TLSClientAuthSubjectDN)
+}
+
+func IsValidTLSClientAuth(authMethod OIDCAuthMethodType, subjectDN string) bool {
+	return authMethod != OIDCAuthMethodTypeTLSClientAuth || strings.TrimSpace(subjectDN) != ""
+}
+
+func IsValidBackChannelLogoutURI(uri string) bool {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return true
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "https" || parsed.Scheme == "http") && parsed.Host != "" && parsed.Fragment == ""
+}
+
 func ContainsRequiredGrantTypes(responseTypes []OIDCResponseType, grantTypes []OIDCGrantType) bool {
 	required := RequiredOIDCGrantTypes(responseTypes, grantTypes)
 	return ContainsOIDCGrantTypes(required, grantTypes)
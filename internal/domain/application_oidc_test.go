@@ -177,6 +177,49 @@ func TestApplicationValid(t *testing.T) {
 			},
 			result: false,
 		},
+		{
+			name: "invalid oidc application: back-channel logout uri with fragment",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:           models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                "AppID",
+					AppName:              "Name",
+					ResponseTypes:        []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:           []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					BackChannelLogoutURI: "https://test.com/logout#fragment",
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid oidc application: relative back-channel logout uri",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:           models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                "AppID",
+					AppName:              "Name",
+					ResponseTypes:        []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:           []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					BackChannelLogoutURI: "/logout",
+				},
+			},
+			result: false,
+		},
+		{
+			name: "valid oidc application: back-channel logout uri",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:                       models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                            "AppID",
+					AppName:                          "Name",
+					ResponseTypes:                    []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:                       []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					BackChannelLogoutURI:             "https://test.com/logout",
+					BackChannelLogoutSessionRequired: true,
+				},
+			},
+			result: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
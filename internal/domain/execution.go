@@ -1,5 +1,12 @@
 package domain
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
 type ExecutionType uint
 
 func (s ExecutionType) Valid() bool {
@@ -45,3 +52,91 @@ const (
 
 	executionTargetTypeStateCount
 )
+
+// ExecutionMode defines how the targets of an execution are called
+type ExecutionMode uint
+
+func (s ExecutionMode) Valid() bool {
+	return s < executionModeStateCount
+}
+
+const (
+	// ExecutionModeSequential calls the targets in order, the response of a target is passed to the next target
+	ExecutionModeSequential ExecutionMode = iota
+	// ExecutionModeParallel calls the targets concurrently, the responses of the targets are ignored
+	ExecutionModeParallel
+
+	executionModeStateCount
+)
+
+type ExecutionTargetConditionOperator uint
+
+func (s ExecutionTargetConditionOperator) Valid() bool {
+	return s > ExecutionTargetConditionOperatorUnspecified && s < executionTargetConditionOperatorStateCount
+}
+
+const (
+	ExecutionTargetConditionOperatorUnspecified ExecutionTargetConditionOperator = iota
+	ExecutionTargetConditionOperatorEquals
+	ExecutionTargetConditionOperatorNotEquals
+	ExecutionTargetConditionOperatorExists
+	ExecutionTargetConditionOperatorNotExists
+
+	executionTargetConditionOperatorStateCount
+)
+
+// ExecutionTargetCondition defines a condition on the information sent to a target,
+// the target is only called if the condition matches.
+// The Path selects a value of the JSON body, for example "$.request.organization.orgId" or "$.request.emails[0]".
+type ExecutionTargetCondition struct {
+	Path     string                           `json:"path"`
+	Operator ExecutionTargetConditionOperator `json:"operator"`
+	Value    string                           `json:"value,omitempty"`
+}
+
+func (c *ExecutionTargetCondition) IsValid() error {
+	if !c.Operator.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-j8e2xq5tlv", "Errors.Execution.TargetConditionInvalid")
+	}
+	if _, err := c.PathSegments(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PathSegments returns the keys and indexes of the path in order,
+// the path has to start with "$" followed by ".key" or "[index]" segments
+func (c *ExecutionTargetCondition) PathSegments() ([]string, error) {
+	path, ok := strings.CutPrefix(c.Path, "$")
+	if !ok || path == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-w3n9fk1ryd", "Errors.Execution.TargetConditionInvalid")
+	}
+	segments := make([]string, 0, strings.Count(path, ".")+strings.Count(path, "["))
+	for path != "" {
+		var segment string
+		switch path[0] {
+		case '.':
+			end := strings.IndexAny(path[1:], ".[")
+			if end < 0 {
+				end = len(path) - 1
+			}
+			segment, path = path[1:end+1], path[end+1:]
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-b5qz0c7mhe", "Errors.Execution.TargetConditionInvalid")
+			}
+			segment, path = path[1:end], path[end+1:]
+			if _, err := strconv.ParseUint(segment, 10, 32); err != nil {
+				return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-r1k6vy4dsa", "Errors.Execution.TargetConditionInvalid")
+			}
+		default:
+			return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-g7t3mo8wju", "Errors.Execution.TargetConditionInvalid")
+		}
+		if segment == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-p2x5ne0hqc", "Errors.Execution.TargetConditionInvalid")
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
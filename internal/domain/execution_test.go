@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestExecutionTargetCondition_PathSegments(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "empty, error",
+			path:    "",
+			wantErr: true,
+		},
+		{
+			name:    "root only, error",
+			path:    "$",
+			wantErr: true,
+		},
+		{
+			name:    "missing root, error",
+			path:    "request.userId",
+			wantErr: true,
+		},
+		{
+			name:    "empty key, error",
+			path:    "$.request..userId",
+			wantErr: true,
+		},
+		{
+			name:    "unclosed index, error",
+			path:    "$.request.emails[0",
+			wantErr: true,
+		},
+		{
+			name:    "invalid index, error",
+			path:    "$.request.emails[first]",
+			wantErr: true,
+		},
+		{
+			name:    "missing separator, error",
+			path:    "$.request.emails[0]email",
+			wantErr: true,
+		},
+		{
+			name: "keys, ok",
+			path: "$.request.organization.orgId",
+			want: []string{"request", "organization", "orgId"},
+		},
+		{
+			name: "keys and indexes, ok",
+			path: "$.request.emails[0].email",
+			want: []string{"request", "emails", "0", "email"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ExecutionTargetCondition{Path: tt.path, Operator: ExecutionTargetConditionOperatorExists}
+			got, err := c.PathSegments()
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				assert.Equal(t, err, c.IsValid())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -155,12 +155,18 @@ func AllFunctions() []string {
 	functions := make([]string, 0)
 	for _, flowType := range AllFlowTypes() {
 		for _, triggerType := range flowType.TriggerTypes() {
-			functions = append(functions, flowType.LocalizationKey()+"."+triggerType.LocalizationKey())
+			functions = append(functions, ActionFunction(flowType, triggerType))
 		}
 	}
 	return functions
 }
 
+// ActionFunction returns the name of the function for a trigger type of a flow,
+// used as condition of executions of type [ExecutionTypeFunction]
+func ActionFunction(flowType FlowType, triggerType TriggerType) string {
+	return flowType.LocalizationKey() + "." + triggerType.LocalizationKey()
+}
+
 func FunctionExists() func(string) bool {
 	functions := AllFunctions()
 	return func(s string) bool {
@@ -13,6 +13,7 @@ const (
 	SecretGeneratorTypeAppSecret
 	SecretGeneratorTypeOTPSMS
 	SecretGeneratorTypeOTPEmail
+	SecretGeneratorTypeSigningKey
 
 	secretGeneratorTypeCount
 )
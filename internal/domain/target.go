@@ -6,6 +6,8 @@ const (
 	TargetTypeWebhook TargetType = iota
 	TargetTypeCall
 	TargetTypeAsync
+	// TargetTypeGRPC calls the target service over gRPC and handles the response the same as [TargetTypeCall]
+	TargetTypeGRPC
 )
 
 type TargetState int32
@@ -24,3 +26,79 @@ func (s TargetState) Valid() bool {
 func (s TargetState) Exists() bool {
 	return s != TargetUnspecified && s != TargetRemoved
 }
+
+type TargetDeliveryState int32
+
+const (
+	TargetDeliveryStateUnspecified TargetDeliveryState = iota
+	// TargetDeliveryStatePending is a delivery which is waiting for its next attempt
+	TargetDeliveryStatePending
+	// TargetDeliveryStateAttempting is a delivery which is currently called
+	TargetDeliveryStateAttempting
+	// TargetDeliveryStateSucceeded is a delivery which was successfully called
+	TargetDeliveryStateSucceeded
+	// TargetDeliveryStateFailed is a delivery which failed on all attempts and waits to be redriven
+	TargetDeliveryStateFailed
+	targetDeliveryStateCount
+)
+
+func (s TargetDeliveryState) Valid() bool {
+	return s >= 0 && s < targetDeliveryStateCount
+}
+
+func (s TargetDeliveryState) Exists() bool {
+	return s != TargetDeliveryStateUnspecified
+}
+
+// TargetDelivery is a call to a target which is persisted to be delivered in the background
+// If a Deliverer is set, the body is not sent to a target but delivered by the registered deliverer of the name,
+// the TargetID is then the id of the receiver, e.g. the client of a back-channel logout.
+type TargetDelivery struct {
+	TargetID    string
+	ExecutionID string
+	Deliverer   string
+	Body        []byte
+}
+
+type TargetAuthType int32
+
+const (
+	TargetAuthTypeNone TargetAuthType = iota
+	// TargetAuthTypeHeaders sends static headers with every call
+	TargetAuthTypeHeaders
+	// TargetAuthTypeBasic sends the username and password as basic auth
+	TargetAuthTypeBasic
+	// TargetAuthTypeBearer sends a static bearer token
+	TargetAuthTypeBearer
+	// TargetAuthTypeClientCredentials sends a bearer token issued by the OAuth2 client credentials grant
+	TargetAuthTypeClientCredentials
+	// TargetAuthTypeClientCertificate authenticates with a client certificate on the TLS connection
+	TargetAuthTypeClientCertificate
+	targetAuthTypeCount
+)
+
+func (t TargetAuthType) Valid() bool {
+	return t >= 0 && t < targetAuthTypeCount
+}
+
+// TargetAuth contains the credentials used to authenticate the calls to a target,
+// it is only stored encrypted and never returned by the API
+type TargetAuth struct {
+	Type TargetAuthType `json:"type"`
+
+	Headers map[string]string `json:"headers,omitempty"`
+
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+
+	Token string `json:"token,omitempty"`
+
+	TokenEndpoint string   `json:"tokenEndpoint,omitempty"`
+	ClientID      string   `json:"clientId,omitempty"`
+	ClientSecret  string   `json:"clientSecret,omitempty"`
+	Scopes        []string `json:"scopes,omitempty"`
+
+	// Certificate and Key are PEM encoded
+	Certificate []byte `json:"certificate,omitempty"`
+	Key         []byte `json:"key,omitempty"`
+}
@@ -7,8 +7,18 @@ import (
 type Config struct {
 	PushTimeout time.Duration
 	MaxRetries  uint32
+	// SnapshotThreshold is the minimum amount of events reduced by a [Snapshotter] before its state is stored as snapshot
+	// 0 disables snapshots
+	SnapshotThreshold uint32
+	// EncryptPersonalData encrypts the registered personal data of pushed events with the key of the aggregate
+	// requires PersonalDataKeys
+	EncryptPersonalData bool
 
 	Pusher   Pusher
 	Querier  Querier
 	Searcher Searcher
+	// Snapshots stores the states of [Snapshotter]s, if nil snapshots are disabled
+	Snapshots SnapshotStore
+	// PersonalDataKeys manages the keys of the personal data in events, if nil personal data are neither encrypted nor decrypted
+	PersonalDataKeys PersonalDataKeys
 }
@@ -0,0 +1,27 @@
+package eventstore
+
+import (
+	"maps"
+	"slices"
+)
+
+var endOfLifeEventTypes = map[AggregateType][]EventType{}
+
+// RegisterEndOfLife registers the events after which the aggregate is not used anymore.
+// Write models do not need the events of aggregates whose last event is one of these events,
+// therefore the events can be archived after the audit log retention.
+func RegisterEndOfLife(aggregateType AggregateType, eventTypes ...EventType) {
+	if aggregateType == "" || len(eventTypes) == 0 {
+		return
+	}
+	endOfLifeEventTypes[aggregateType] = append(endOfLifeEventTypes[aggregateType], eventTypes...)
+}
+
+// EndOfLifeEventTypes returns the events registered by [RegisterEndOfLife] mapped by their aggregate type
+func EndOfLifeEventTypes() map[AggregateType][]EventType {
+	eventTypes := maps.Clone(endOfLifeEventTypes)
+	for aggregateType, types := range eventTypes {
+		eventTypes[aggregateType] = slices.Clone(types)
+	}
+	return eventTypes
+}
@@ -24,6 +24,12 @@ type Eventstore struct {
 	querier  Querier
 	searcher Searcher
 
+	snapshots         SnapshotStore
+	snapshotThreshold uint32
+
+	personalDataKeys           PersonalDataKeys
+	encryptPersonalDataEnabled bool
+
 	instances         []string
 	lastInstanceQuery time.Time
 	instancesMu       sync.Mutex
@@ -68,6 +74,12 @@ func NewEventstore(config *Config) *Eventstore {
 		querier:  config.Querier,
 		searcher: config.Searcher,
 
+		snapshots:         config.Snapshots,
+		snapshotThreshold: config.SnapshotThreshold,
+
+		personalDataKeys:           config.PersonalDataKeys,
+		encryptPersonalDataEnabled: config.EncryptPersonalData,
+
 		instancesMu: sync.Mutex{},
 	}
 }
@@ -93,6 +105,11 @@ func (es *Eventstore) Push(ctx context.Context, cmds ...Command) ([]Event, error
 		events []Event
 		err    error
 	)
+	keys := make(personalDataKeyCache)
+	cmds, err = es.encryptPersonalData(ctx, keys, cmds)
+	if err != nil {
+		return nil, err
+	}
 
 	// Retry when there is a collision of the sequence as part of the primary key.
 	// "duplicate key value violates unique constraint \"events2_pkey\" (SQLSTATE 23505)"
@@ -110,7 +127,7 @@ retry:
 		return nil, err
 	}
 
-	mappedEvents, err := es.mapEvents(events)
+	mappedEvents, err := es.mapEvents(ctx, keys, events)
 	if err != nil {
 		return mappedEvents, err
 	}
@@ -151,8 +168,9 @@ func (es *Eventstore) Search(ctx context.Context, conditions ...map[FieldType]an
 func (es *Eventstore) Filter(ctx context.Context, searchQuery *SearchQueryBuilder) ([]Event, error) {
 	events := make([]Event, 0, searchQuery.GetLimit())
 	searchQuery.ensureInstanceID(ctx)
+	keys := make(personalDataKeyCache)
 	err := es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
-		event, err := es.mapEvent(event)
+		event, err := es.mapEvent(ctx, keys, event)
 		if err != nil {
 			return err
 		}
@@ -165,10 +183,10 @@ func (es *Eventstore) Filter(ctx context.Context, searchQuery *SearchQueryBuilde
 	return events, nil
 }
 
-func (es *Eventstore) mapEvents(events []Event) (mappedEvents []Event, err error) {
+func (es *Eventstore) mapEvents(ctx context.Context, keys personalDataKeyCache, events []Event) (mappedEvents []Event, err error) {
 	mappedEvents = make([]Event, len(events))
 	for i, event := range events {
-		mappedEvents[i], err = es.mapEventLocked(event)
+		mappedEvents[i], err = es.mapEvent(ctx, keys, event)
 		if err != nil {
 			return nil, err
 		}
@@ -176,16 +194,12 @@ func (es *Eventstore) mapEvents(events []Event) (mappedEvents []Event, err error
 	return mappedEvents, nil
 }
 
-func (es *Eventstore) mapEvent(event Event) (Event, error) {
-	return es.mapEventLocked(event)
-}
-
-func (es *Eventstore) mapEventLocked(event Event) (Event, error) {
-	interceptors, ok := eventInterceptors[event.Type()]
-	if !ok || interceptors.eventMapper == nil {
-		return BaseEventFromRepo(event), nil
+func (es *Eventstore) mapEvent(ctx context.Context, keys personalDataKeyCache, event Event) (Event, error) {
+	event, err := es.decryptPersonalData(ctx, keys, event)
+	if err != nil {
+		return nil, err
 	}
-	return interceptors.eventMapper(event)
+	return MapEvent(event)
 }
 
 // TODO: refactor so we can change to the following interface:
@@ -207,8 +221,9 @@ type reducer interface {
 // FilterToReducer filters the events based on the search query, appends all events to the reducer and calls it's reduce function
 func (es *Eventstore) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, r reducer) error {
 	searchQuery.ensureInstanceID(ctx)
+	keys := make(personalDataKeyCache)
 	return es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
-		event, err := es.mapEvent(event)
+		event, err := es.mapEvent(ctx, keys, event)
 		if err != nil {
 			return err
 		}
@@ -255,7 +270,11 @@ type QueryReducer interface {
 
 // FilterToQueryReducer filters the events based on the search query of the query function,
 // appends all events to the reducer and calls it's reduce function
+// If the reducer implements [Snapshotter] and snapshots are enabled, it resumes from the latest snapshot
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r QueryReducer) error {
+	if snapshotter, ok := r.(Snapshotter); ok && es.snapshots != nil && es.snapshotThreshold > 0 {
+		return es.filterToSnapshotter(ctx, r.Query(), snapshotter)
+	}
 	return es.FilterToReducer(ctx, r.Query(), r)
 }
 
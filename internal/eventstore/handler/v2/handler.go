@@ -27,6 +27,7 @@ type EventStore interface {
 	InstanceIDs(ctx context.Context, maxAge time.Duration, forceLoad bool, query *eventstore.SearchQueryBuilder) ([]string, error)
 	FilterToQueryReducer(ctx context.Context, reducer eventstore.QueryReducer) error
 	Filter(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
+	LatestSequence(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) (float64, error)
 	Push(ctx context.Context, cmds ...eventstore.Command) ([]eventstore.Event, error)
 	FillFields(ctx context.Context, events ...eventstore.FillFieldsEvent) error
 }
@@ -41,6 +42,8 @@ type Config struct {
 	HandleActiveInstances time.Duration
 	TransactionDuration   time.Duration
 	MaxFailureCount       uint8
+	// LagInterval is the interval the lag of the projection is measured in for the metrics, 0 disables the measurement
+	LagInterval time.Duration
 
 	TriggerWithoutEvents Reduce
 }
@@ -58,9 +61,12 @@ type Handler struct {
 	requeueEvery          time.Duration
 	handleActiveInstances time.Duration
 	txDuration            time.Duration
+	lagInterval           time.Duration
 	now                   nowFunc
 
 	triggeredInstancesSync sync.Map
+	// bulkDurations contains the duration of the last bulk per instance
+	bulkDurations sync.Map
 
 	triggerWithoutEvents Reduce
 }
@@ -137,6 +143,24 @@ type Projection interface {
 	Reducers() []AggregateReducer
 }
 
+// Flusher can be implemented by projections which collect the data of the executed statements
+// to handle them at once, e.g. to publish them in a single request.
+// Flush is called with the executer of the statements after they were executed
+// and before the current state is updated.
+// If Flush fails the iteration is rolled back and the events are handled again in the next iteration.
+type Flusher interface {
+	Flush(ctx context.Context, ex Executer) error
+}
+
+// EventTypesFilterer can be implemented by projections which only handle a subset of their event types,
+// depending on the state of the instance, e.g. the event types configured by its users.
+// FilterEventTypes is called once per iteration with the event types of the reducers,
+// only the events of the returned event types are queried.
+// If no event types are returned, no events are queried and the current state is advanced to the latest event of the reducers.
+type EventTypesFilterer interface {
+	FilterEventTypes(ctx context.Context, eventTypes map[eventstore.AggregateType][]eventstore.EventType) (map[eventstore.AggregateType][]eventstore.EventType, error)
+}
+
 func NewHandler(
 	ctx context.Context,
 	config *Config,
@@ -169,6 +193,7 @@ func NewHandler(
 		triggeredInstancesSync: sync.Map{},
 		triggerWithoutEvents:   config.TriggerWithoutEvents,
 		txDuration:             config.TransactionDuration,
+		lagInterval:            config.LagInterval,
 	}
 
 	return handler
@@ -176,6 +201,9 @@ func NewHandler(
 
 func (h *Handler) Start(ctx context.Context) {
 	go h.schedule(ctx)
+	if h.lagInterval > 0 {
+		go h.measureLag(ctx)
+	}
 	if h.triggerWithoutEvents != nil {
 		return
 	}
@@ -455,6 +483,7 @@ func (h *Handler) lockInstance(ctx context.Context, config *triggerConfig) func(
 }
 
 func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (additionalIteration bool, err error) {
+	start := time.Now()
 	defer func() {
 		pgErr := new(pgconn.PgError)
 		if errors.As(err, &pgErr) {
@@ -522,6 +551,12 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 	if lastProcessedIndex < 0 {
 		return false, err
 	}
+	if flusher, ok := h.projection.(Flusher); ok {
+		if flushErr := flusher.Flush(ctx, tx); flushErr != nil {
+			h.log().WithError(flushErr).Debug("flush of statements failed")
+			return false, flushErr
+		}
+	}
 
 	currentState.position = statements[lastProcessedIndex].Position
 	currentState.offset = statements[lastProcessedIndex].offset
@@ -530,6 +565,9 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 	currentState.sequence = statements[lastProcessedIndex].Sequence
 	currentState.eventTimestamp = statements[lastProcessedIndex].CreationDate
 	err = h.setState(tx, currentState)
+	if err == nil {
+		h.bulkDurations.Store(currentState.instanceID, time.Since(start))
+	}
 
 	return additionalIteration, err
 }
@@ -543,7 +581,14 @@ func (h *Handler) generateStatements(ctx context.Context, tx *sql.Tx, currentSta
 		return []*Statement{stmt}, false, nil
 	}
 
-	events, err := h.es.Filter(ctx, h.eventQuery(currentState).SetTx(tx))
+	eventTypes, err := h.instanceEventTypes(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(eventTypes) == 0 {
+		return nil, false, h.skipToLatestPosition(ctx, tx, currentState)
+	}
+	events, err := h.es.Filter(ctx, h.eventTypesQuery(currentState, eventTypes).SetTx(tx))
 	if err != nil {
 		h.log().WithError(err).Debug("filter eventstore failed")
 		return nil, false, err
@@ -577,6 +622,34 @@ func (h *Handler) generateStatements(ctx context.Context, tx *sql.Tx, currentSta
 	return statements, additionalIteration, nil
 }
 
+// skipToLatestPosition advances the current state to the latest event of the reducers,
+// if none of the events have to be reduced for the instance.
+// Otherwise, all the events since the current state would be queried as soon as there are event types to reduce.
+func (h *Handler) skipToLatestPosition(ctx context.Context, tx *sql.Tx, currentState *state) error {
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsMaxSequence).
+		AwaitOpenTransactions().
+		InstanceID(currentState.instanceID).
+		SetTx(tx)
+	for aggregateType, eventTypes := range h.eventTypes {
+		builder = builder.
+			AddQuery().
+			AggregateTypes(aggregateType).
+			EventTypes(eventTypes...).
+			Builder()
+	}
+	position, err := h.es.LatestSequence(ctx, builder)
+	if err != nil || position <= currentState.position {
+		return err
+	}
+	currentState.position = position
+	currentState.offset = 0
+	currentState.aggregateID = ""
+	currentState.aggregateType = ""
+	currentState.sequence = 0
+	currentState.eventTimestamp = h.now()
+	return nil
+}
+
 func skipPreviouslyReducedStatements(statements []*Statement, currentState *state) int {
 	for i, statement := range statements {
 		if statement.Position == currentState.position &&
@@ -639,7 +712,25 @@ func (h *Handler) executeStatement(ctx context.Context, tx *sql.Tx, currentState
 	return nil
 }
 
+// instanceEventTypes returns the event types to query for the instance of the context,
+// which are all event types of the reducers unless the projection implements [EventTypesFilterer]
+func (h *Handler) instanceEventTypes(ctx context.Context) (map[eventstore.AggregateType][]eventstore.EventType, error) {
+	filterer, ok := h.projection.(EventTypesFilterer)
+	if !ok {
+		return h.eventTypes, nil
+	}
+	eventTypes, err := filterer.FilterEventTypes(ctx, h.eventTypes)
+	if err != nil {
+		h.log().WithError(err).Debug("filter event types failed")
+	}
+	return eventTypes, err
+}
+
 func (h *Handler) eventQuery(currentState *state) *eventstore.SearchQueryBuilder {
+	return h.eventTypesQuery(currentState, h.eventTypes)
+}
+
+func (h *Handler) eventTypesQuery(currentState *state, eventTypes map[eventstore.AggregateType][]eventstore.EventType) *eventstore.SearchQueryBuilder {
 	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AwaitOpenTransactions().
 		Limit(uint64(h.bulkLimit)).
@@ -655,7 +746,7 @@ func (h *Handler) eventQuery(currentState *state) *eventstore.SearchQueryBuilder
 		}
 	}
 
-	for aggregateType, eventTypes := range h.eventTypes {
+	for aggregateType, eventTypes := range eventTypes {
 		builder = builder.
 			AddQuery().
 			AggregateTypes(aggregateType).
@@ -198,11 +198,18 @@ func (h *Handler) Init(ctx context.Context) error {
 	if err != nil {
 		return zerrors.ThrowInternal(err, "CRDB-SAdf2", "begin failed")
 	}
-	for i, execute := range check.Init().Executes {
+	if err = h.executeChecks(tx, check.Init()); err != nil {
+		logging.OnError(tx.Rollback()).Debug("unable to rollback")
+		return err
+	}
+	return tx.Commit()
+}
+
+func (h *Handler) executeChecks(ex handler.Executer, check *handler.Check) error {
+	for i, execute := range check.Executes {
 		logging.WithFields("projection", h.projection.Name(), "execute", i).Debug("executing check")
-		next, err := execute(tx, h.projection.Name())
+		next, err := execute(ex, h.projection.Name())
 		if err != nil {
-			logging.OnError(tx.Rollback()).Debug("unable to rollback")
 			return err
 		}
 		if !next {
@@ -210,7 +217,7 @@ func (h *Handler) Init(ctx context.Context) error {
 			break
 		}
 	}
-	return tx.Commit()
+	return nil
 }
 
 func NewTableCheck(table *Table, opts ...execOption) *handler.Check {
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// maxLagEvents limits the events counted as lag of a projection
+	maxLagEvents = 1000
+
+	ProjectionEventLag                = "zitadel.projection.event_lag"
+	ProjectionEventLagDescription     = "Events not yet reduced by the projection, counted up to 1000"
+	ProjectionTimeLag                 = "zitadel.projection.time_lag_milliseconds"
+	ProjectionTimeLagDescription      = "Milliseconds since the oldest event not yet reduced by the projection was created"
+	ProjectionBulkDuration            = "zitadel.projection.bulk_duration_milliseconds"
+	ProjectionBulkDurationDescription = "Duration of the last bulk of events reduced by the projection in milliseconds"
+	ProjectionFailedEvents            = "zitadel.projection.failed_events"
+	ProjectionFailedEventsDescription = "Events which failed to be reduced by the projection"
+	projectionLabel                   = "projection"
+	instanceLabel                     = "instance"
+)
+
+var (
+	//go:embed lag_state.sql
+	lagStateStmt string
+	//go:embed lag_events.sql
+	lagEventsStmt string
+
+	// measuredHandlers are the handlers which periodically measure their lag
+	measuredHandlers sync.Map
+	registerMetrics  sync.Once
+)
+
+// Lag describes how far a projection of an instance is behind the eventstore
+type Lag struct {
+	ProjectionName string
+	InstanceID     string
+	// Position of the last reduced event
+	Position float64
+	// EventTimestamp is the creation date of the last reduced event
+	EventTimestamp time.Time
+	// LastRun is the time the state of the projection was updated
+	LastRun time.Time
+	// Events not yet reduced, counted up to 1000
+	Events uint32
+	// Time since the oldest event not yet reduced was created, 0 if there are no events
+	Time time.Duration
+	// BulkDuration of the last bulk of events reduced by this process
+	BulkDuration time.Duration
+	// FailedEvents which failed to be reduced
+	FailedEvents uint32
+}
+
+// Lag measures the lag of the projection for the instance of the context
+func (h *Handler) Lag(ctx context.Context) (_ *Lag, err error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	lag := &Lag{
+		ProjectionName: h.ProjectionName(),
+		InstanceID:     instanceID,
+	}
+	if duration, ok := h.bulkDurations.Load(instanceID); ok {
+		lag.BulkDuration = duration.(time.Duration)
+	}
+
+	var (
+		position     sql.NullFloat64
+		timestamp    sql.NullTime
+		lastRun      sql.NullTime
+		failedEvents int64
+	)
+	err = h.client.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			return row.Scan(&position, &timestamp, &lastRun, &failedEvents)
+		},
+		lagStateStmt, h.ProjectionName(), instanceID,
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Ow7ck3", "unable to query state")
+	}
+	lag.Position = position.Float64
+	lag.EventTimestamp = timestamp.Time
+	lag.LastRun = lastRun.Time
+	lag.FailedEvents = uint32(failedEvents)
+
+	if h.triggerWithoutEvents != nil {
+		return lag, nil
+	}
+	// events reduced at the same position as the last reduced event cannot be distinguished,
+	// the offset is unknown outside of the transaction of the projection
+	eventTypes, err := h.instanceEventTypes(ctx)
+	if err != nil || len(eventTypes) == 0 {
+		return lag, err
+	}
+	var (
+		events int64
+		oldest sql.NullTime
+	)
+	stmt, args := lagEventsQuery(instanceID, lag.Position, eventTypes)
+	err = h.client.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			return row.Scan(&events, &oldest)
+		},
+		stmt, args...,
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Jq3xv1", "unable to query events")
+	}
+	lag.Events = uint32(events)
+	if oldest.Valid {
+		lag.Time = h.now().Sub(oldest.Time)
+	}
+	return lag, nil
+}
+
+// lagEventsQuery counts the events of the event types after the position up to [maxLagEvents]
+// and queries the creation date of the oldest of them
+func lagEventsQuery(instanceID string, position float64, eventTypes map[eventstore.AggregateType][]eventstore.EventType) (string, []any) {
+	args := []any{instanceID, position, maxLagEvents}
+	aggregateTypes := make([]eventstore.AggregateType, 0, len(eventTypes))
+	for aggregateType := range eventTypes {
+		aggregateTypes = append(aggregateTypes, aggregateType)
+	}
+	slices.Sort(aggregateTypes)
+
+	conditions := make([]string, len(aggregateTypes))
+	for i, aggregateType := range aggregateTypes {
+		args = append(args, aggregateType)
+		conditions[i] = "aggregate_type = $" + strconv.Itoa(len(args))
+		if types := eventTypes[aggregateType]; len(types) > 0 {
+			args = append(args, database.TextArray[eventstore.EventType](types))
+			conditions[i] = "(" + conditions[i] + " AND event_type = ANY($" + strconv.Itoa(len(args)) + "))"
+		}
+	}
+	return fmt.Sprintf(lagEventsStmt, strings.Join(conditions, " OR ")), args
+}
+
+// measureLag periodically measures the lag of the projection for all instances
+// and exposes it as metrics
+func (h *Handler) measureLag(ctx context.Context) {
+	registerMetrics.Do(registerLagMetrics)
+	measuredHandlers.Store(h, []*Lag(nil))
+	defer measuredHandlers.Delete(h)
+
+	t := time.NewTicker(h.lagInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			instances, err := h.existingInstances(ctx)
+			if err != nil {
+				h.log().WithError(err).Debug("unable to query instances for lag")
+				continue
+			}
+			lags := make([]*Lag, 0, len(instances))
+			for _, instance := range instances {
+				lag, err := h.Lag(authz.WithInstanceID(ctx, instance))
+				if err != nil {
+					h.log().WithField("instance", instance).WithError(err).Debug("unable to measure lag")
+					continue
+				}
+				lags = append(lags, lag)
+			}
+			measuredHandlers.Store(h, lags)
+		}
+	}
+}
+
+func registerLagMetrics() {
+	for name, gauge := range map[string]struct {
+		description string
+		value       func(*Lag) int64
+	}{
+		ProjectionEventLag:     {ProjectionEventLagDescription, func(l *Lag) int64 { return int64(l.Events) }},
+		ProjectionTimeLag:      {ProjectionTimeLagDescription, func(l *Lag) int64 { return l.Time.Milliseconds() }},
+		ProjectionBulkDuration: {ProjectionBulkDurationDescription, func(l *Lag) int64 { return l.BulkDuration.Milliseconds() }},
+		ProjectionFailedEvents: {ProjectionFailedEventsDescription, func(l *Lag) int64 { return int64(l.FailedEvents) }},
+	} {
+		err := metrics.RegisterValueObserver(name, gauge.description, observeLags(gauge.value))
+		logging.WithFields("metric", name).OnError(err).Warn("unable to register projection metric")
+	}
+}
+
+func observeLags(value func(*Lag) int64) metric.Int64Callback {
+	return func(_ context.Context, observer metric.Int64Observer) error {
+		measuredHandlers.Range(func(_, lags any) bool {
+			for _, lag := range lags.([]*Lag) {
+				observer.Observe(value(lag), metric.WithAttributes(
+					attribute.String(projectionLabel, lag.ProjectionName),
+					attribute.String(instanceLabel, lag.InstanceID),
+				))
+			}
+			return true
+		})
+		return nil
+	}
+}
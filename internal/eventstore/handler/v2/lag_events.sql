@@ -0,0 +1,9 @@
+SELECT
+    (SELECT count(*) FROM (
+        SELECT 1 FROM eventstore.events2
+        WHERE instance_id = $1 AND "position" > $2 AND (%[1]s)
+        LIMIT $3
+    ) e)
+    , (SELECT min(created_at) FROM eventstore.events2
+        WHERE instance_id = $1 AND "position" > $2 AND (%[1]s)
+    );
@@ -0,0 +1,11 @@
+SELECT
+    s."position"
+    , s.event_date
+    , s.last_updated
+    , (SELECT count(*) FROM projections.failed_events2 f WHERE f.projection_name = $1 AND f.instance_id = $2)
+FROM
+    (SELECT $1::TEXT AS projection_name, $2::TEXT AS instance_id) p
+LEFT JOIN
+    projections.current_states s
+    ON s.projection_name = p.projection_name
+    AND s.instance_id = p.instance_id;
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestHandler_Lag(t *testing.T) {
+	now := time.Now()
+	eventDate := now.Add(-time.Hour)
+	lastRun := now.Add(-time.Minute)
+
+	tests := []struct {
+		name                 string
+		events               []driver.Value
+		triggerWithoutEvents Reduce
+		want                 *Lag
+	}{
+		{
+			name:   "up to date",
+			events: []driver.Value{int64(0), nil},
+			want: &Lag{
+				ProjectionName: "projections.test",
+				InstanceID:     "instance",
+				Position:       10,
+				EventTimestamp: eventDate,
+				LastRun:        lastRun,
+				BulkDuration:   200 * time.Millisecond,
+				FailedEvents:   1,
+			},
+		},
+		{
+			name:   "behind",
+			events: []driver.Value{int64(2), now.Add(-30 * time.Second)},
+			want: &Lag{
+				ProjectionName: "projections.test",
+				InstanceID:     "instance",
+				Position:       10,
+				EventTimestamp: eventDate,
+				LastRun:        lastRun,
+				Events:         2,
+				Time:           30 * time.Second,
+				BulkDuration:   200 * time.Millisecond,
+				FailedEvents:   1,
+			},
+		},
+		{
+			name:                 "without events",
+			triggerWithoutEvents: func(eventstore.Event) (*Statement, error) { return nil, nil },
+			want: &Lag{
+				ProjectionName: "projections.test",
+				InstanceID:     "instance",
+				Position:       10,
+				EventTimestamp: eventDate,
+				LastRun:        lastRun,
+				BulkDuration:   200 * time.Millisecond,
+				FailedEvents:   1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectState := mock.ExpectQuery(lagStateStmt,
+				mock.WithQueryArgs("projections.test", "instance"),
+				mock.WithQueryResult(
+					[]string{"position", "event_date", "last_updated", "count"},
+					[][]driver.Value{{10.0, eventDate, lastRun, int64(1)}},
+				),
+			)
+			var sqlMock *mock.SQLMock
+			if tt.events == nil {
+				sqlMock = mock.NewSQLMock(t, mock.ExpectBegin(nil), expectState, mock.ExpectCommit(nil))
+			} else {
+				sqlMock = mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					expectState,
+					mock.ExpectCommit(nil),
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(fmt.Sprintf(lagEventsStmt, "(aggregate_type = $4 AND event_type = ANY($5))"),
+						mock.WithQueryArgs("instance", 10.0, maxLagEvents, eventstore.AggregateType("test"), database.TextArray[eventstore.EventType]{"test.added"}),
+						mock.WithQueryResult([]string{"count", "min"}, [][]driver.Value{tt.events}),
+					),
+					mock.ExpectCommit(nil),
+				)
+			}
+			h := &Handler{
+				client:               &database.DB{DB: sqlMock.DB},
+				projection:           &projection{name: "projections.test"},
+				eventTypes:           map[eventstore.AggregateType][]eventstore.EventType{"test": {"test.added"}},
+				bulkLimit:            100,
+				triggerWithoutEvents: tt.triggerWithoutEvents,
+				now:                  func() time.Time { return now },
+			}
+			h.bulkDurations.Store("instance", 200*time.Millisecond)
+
+			got, err := h.Lag(authz.WithInstanceID(context.Background(), "instance"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			sqlMock.Assert(t)
+		})
+	}
+}
+
+func Test_lagEventsQuery(t *testing.T) {
+	stmt, args := lagEventsQuery("instance", 10, map[eventstore.AggregateType][]eventstore.EventType{
+		"user": {"user.added", "user.removed"},
+		"org":  nil,
+	})
+	assert.Equal(t, fmt.Sprintf(lagEventsStmt, "aggregate_type = $4 OR (aggregate_type = $5 AND event_type = ANY($6))"), stmt)
+	assert.Equal(t, []any{
+		"instance",
+		10.0,
+		maxLagEvents,
+		eventstore.AggregateType("org"),
+		eventstore.AggregateType("user"),
+		database.TextArray[eventstore.EventType]{"user.added", "user.removed"},
+	}, args)
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// shadowSchemaSuffix is appended to the schema of a projection to get the schema of its shadow tables
+const shadowSchemaSuffix = "_rebuild"
+
+var (
+	//go:embed rebuild_states.sql
+	rebuildStatesStmt string
+	//go:embed rebuild_tables.sql
+	rebuildTablesStmt string
+
+	// rebuildSwapStateStmts hand the state and the failed events of the shadow over to the projection
+	rebuildSwapStateStmts = []string{
+		"DELETE FROM projections.current_states WHERE projection_name = $1",
+		"UPDATE projections.current_states SET projection_name = $1 WHERE projection_name = $2",
+		"DELETE FROM projections.failed_events2 WHERE projection_name = $1",
+		"UPDATE projections.failed_events2 SET projection_name = $1 WHERE projection_name = $2",
+	}
+)
+
+// shadowProjection reduces the events of the projection into the tables of the shadow name
+type shadowProjection struct {
+	Projection
+	name string
+}
+
+// Name implements Projection
+func (p *shadowProjection) Name() string {
+	return p.name
+}
+
+// Init implements initializer
+func (p *shadowProjection) Init() *handler.Check {
+	if check, ok := p.Projection.(initializer); ok {
+		return check.Init()
+	}
+	return new(handler.Check)
+}
+
+// ShadowName returns the name of the projection used during a rebuild,
+// the tables are created in the schema of the projection suffixed with `_rebuild`.
+func ShadowName(projectionName string) (string, error) {
+	schema, table, ok := strings.Cut(projectionName, ".")
+	if !ok || schema == "" || table == "" {
+		return "", zerrors.ThrowInvalidArgument(nil, "V2-Rb8sk2", "Errors.ProjectionName.Invalid")
+	}
+	return schema + shadowSchemaSuffix + "." + table, nil
+}
+
+// Rebuild projects all events into shadow tables while the tables of the projection stay available.
+// As soon as the shadow reached the position of the projection for all instances,
+// the tables of the projection are replaced by the shadow tables in a single transaction.
+// An interrupted rebuild continues from the state of the shadow.
+func (h *Handler) Rebuild(ctx context.Context) error {
+	if h.triggerWithoutEvents != nil {
+		return zerrors.ThrowPreconditionFailed(nil, "V2-Wq3bn7", "projections triggered without events cannot be rebuilt")
+	}
+	shadow, err := h.shadow()
+	if err != nil {
+		return err
+	}
+	schema, _, _ := strings.Cut(shadow.ProjectionName(), ".")
+	if _, err = h.client.ExecContext(ctx, "CREATE SCHEMA IF NOT EXISTS "+schema); err != nil {
+		return zerrors.ThrowInternal(err, "V2-Hc5ld0", "unable to create schema of shadow tables")
+	}
+	if err = shadow.Init(ctx); err != nil {
+		return err
+	}
+	h.log().Info("projection rebuild started")
+
+	for {
+		instances, err := h.existingInstances(ctx)
+		if err != nil {
+			return err
+		}
+		for _, instance := range instances {
+			if _, err = shadow.Trigger(authz.WithInstanceID(ctx, instance), WithAwaitRunning()); err != nil {
+				return err
+			}
+		}
+		swapped, err := h.swap(ctx, shadow, instances)
+		if err != nil || swapped {
+			h.log().OnError(err).Warn("swap of shadow tables failed")
+			return err
+		}
+		h.log().Debug("shadow tables behind projection")
+	}
+}
+
+func (h *Handler) shadow() (*Handler, error) {
+	name, err := ShadowName(h.projection.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{
+		projection: &shadowProjection{
+			Projection: h.projection,
+			name:       name,
+		},
+		client:           h.client,
+		es:               h.es,
+		bulkLimit:        h.bulkLimit,
+		eventTypes:       h.eventTypes,
+		now:              h.now,
+		maxFailureCount:  h.maxFailureCount,
+		retryFailedAfter: h.retryFailedAfter,
+		txDuration:       h.txDuration,
+	}, nil
+}
+
+// swap replaces the tables of the projection with the shadow tables
+// if the shadow reached the position of the projection for every existing instance.
+// The states of the projection are locked during the swap so the projection cannot proceed.
+func (h *Handler) swap(ctx context.Context, shadow *Handler, instances []string) (swapped bool, err error) {
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return false, zerrors.ThrowInternal(err, "V2-Ph6za1", "begin failed")
+	}
+	defer func() {
+		if err != nil || !swapped {
+			logging.OnError(tx.Rollback()).Debug("unable to rollback")
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			swapped = false
+			err = zerrors.ThrowInternal(err, "V2-Ke2xo4", "commit failed")
+		}
+	}()
+
+	positions, err := lockPositions(ctx, tx, h.projection.Name())
+	if err != nil {
+		return false, err
+	}
+	shadowPositions, err := lockPositions(ctx, tx, shadow.projection.Name())
+	if err != nil {
+		return false, err
+	}
+	for _, instance := range instances {
+		if shadowPositions[instance] < positions[instance] {
+			return false, nil
+		}
+	}
+
+	tables, err := projectionTables(ctx, tx, h.projection.Name())
+	if err != nil {
+		return false, err
+	}
+	shadowTables, err := projectionTables(ctx, tx, shadow.projection.Name())
+	if err != nil {
+		return false, err
+	}
+	if len(tables) > 0 {
+		// views depending on the tables are recreated by the checks of the projection
+		if _, err = tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+strings.Join(tables, ", ")+" CASCADE"); err != nil {
+			return false, zerrors.ThrowInternal(err, "V2-Ut4cv9", "unable to drop tables")
+		}
+	}
+	schema, _, _ := strings.Cut(h.projection.Name(), ".")
+	for _, table := range shadowTables {
+		if _, err = tx.ExecContext(ctx, "ALTER TABLE "+table+" SET SCHEMA "+schema); err != nil {
+			return false, zerrors.ThrowInternal(err, "V2-Yo1wd5", "unable to move shadow table")
+		}
+	}
+	if check, ok := h.projection.(initializer); ok {
+		if err = h.executeChecks(tx, check.Init()); err != nil {
+			return false, err
+		}
+	}
+	for _, stmt := range rebuildSwapStateStmts {
+		if _, err = tx.ExecContext(ctx, stmt, h.projection.Name(), shadow.projection.Name()); err != nil {
+			return false, zerrors.ThrowInternal(err, "V2-Ga9qe3", "unable to swap states")
+		}
+	}
+	h.log().WithField("tables", len(shadowTables)).Info("projection rebuilt")
+	return true, nil
+}
+
+func lockPositions(ctx context.Context, tx *sql.Tx, projectionName string) (_ map[string]float64, err error) {
+	rows, err := tx.QueryContext(ctx, rebuildStatesStmt, projectionName)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Xn7cr2", "unable to lock states")
+	}
+	defer rows.Close()
+
+	positions := make(map[string]float64)
+	for rows.Next() {
+		var (
+			instanceID string
+			position   sql.NullFloat64
+		)
+		if err = rows.Scan(&instanceID, &position); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-Dm4tb8", "unable to scan state")
+		}
+		positions[instanceID] = position.Float64
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Qv5ne6", "unable to lock states")
+	}
+	return positions, nil
+}
+
+// projectionTables returns the table named like the projection and its suffixed tables
+func projectionTables(ctx context.Context, tx *sql.Tx, projectionName string) (_ []string, err error) {
+	schema, table, _ := strings.Cut(projectionName, ".")
+	rows, err := tx.QueryContext(ctx, rebuildTablesStmt, schema, table, table+`\_%`)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Lk3vs7", "unable to query tables")
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-Fz8ow1", "unable to scan table")
+		}
+		tables = append(tables, schema+"."+name)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Ji6mq4", "unable to query tables")
+	}
+	return tables, nil
+}
@@ -0,0 +1,10 @@
+SELECT
+    table_name
+FROM
+    information_schema.tables
+WHERE
+    table_schema = $1
+    AND table_type = 'BASE TABLE'
+    AND (table_name = $2 OR table_name LIKE $3)
+ORDER BY
+    table_name;
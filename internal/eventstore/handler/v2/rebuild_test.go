@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestShadowName(t *testing.T) {
+	tests := []struct {
+		name           string
+		projectionName string
+		want           string
+		wantErr        bool
+	}{
+		{
+			name:           "without schema",
+			projectionName: "users",
+			wantErr:        true,
+		},
+		{
+			name:           "empty table",
+			projectionName: "projections.",
+			wantErr:        true,
+		},
+		{
+			name:           "valid",
+			projectionName: "projections.users14",
+			want:           "projections_rebuild.users14",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ShadowName(tt.projectionName)
+			if tt.wantErr {
+				if !zerrors.IsErrorInvalidArgument(err) {
+					t.Errorf("expected invalid argument, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("want: %q got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHandler_swap(t *testing.T) {
+	tests := []struct {
+		name        string
+		instances   []string
+		mock        *mock.SQLMock
+		wantSwapped bool
+	}{
+		{
+			name:      "shadow behind",
+			instances: []string{"instance1", "instance2"},
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(rebuildStatesStmt,
+					mock.WithQueryArgs("projections.test"),
+					mock.WithQueryResult(
+						[]string{"instance_id", "position"},
+						[][]driver.Value{{"instance1", 10.0}, {"instance2", 20.0}},
+					),
+				),
+				mock.ExpectQuery(rebuildStatesStmt,
+					mock.WithQueryArgs("projections_rebuild.test"),
+					mock.WithQueryResult(
+						[]string{"instance_id", "position"},
+						[][]driver.Value{{"instance1", 10.0}, {"instance2", 15.0}},
+					),
+				),
+				mock.ExpectRollback(nil),
+			),
+		},
+		{
+			name:      "removed instance ignored",
+			instances: []string{"instance1"},
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(rebuildStatesStmt,
+					mock.WithQueryArgs("projections.test"),
+					mock.WithQueryResult(
+						[]string{"instance_id", "position"},
+						[][]driver.Value{{"instance1", 10.0}, {"removed", 20.0}},
+					),
+				),
+				mock.ExpectQuery(rebuildStatesStmt,
+					mock.WithQueryArgs("projections_rebuild.test"),
+					mock.WithQueryResult(
+						[]string{"instance_id", "position"},
+						[][]driver.Value{{"instance1", 12.0}},
+					),
+				),
+				mock.ExpectQuery(rebuildTablesStmt,
+					mock.WithQueryArgs("projections", "test", `test\_%`),
+					mock.WithQueryResult(
+						[]string{"table_name"},
+						[][]driver.Value{{"test"}, {"test_suffix"}},
+					),
+				),
+				mock.ExpectQuery(rebuildTablesStmt,
+					mock.WithQueryArgs("projections_rebuild", "test", `test\_%`),
+					mock.WithQueryResult(
+						[]string{"table_name"},
+						[][]driver.Value{{"test"}, {"test_suffix"}},
+					),
+				),
+				mock.ExcpectExec("DROP TABLE IF EXISTS projections.test, projections.test_suffix CASCADE", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec("ALTER TABLE projections_rebuild.test SET SCHEMA projections", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec("ALTER TABLE projections_rebuild.test_suffix SET SCHEMA projections", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec(rebuildSwapStateStmts[0], mock.WithExecArgs("projections.test", "projections_rebuild.test"), mock.WithExecRowsAffected(2)),
+				mock.ExcpectExec(rebuildSwapStateStmts[1], mock.WithExecArgs("projections.test", "projections_rebuild.test"), mock.WithExecRowsAffected(1)),
+				mock.ExcpectExec(rebuildSwapStateStmts[2], mock.WithExecArgs("projections.test", "projections_rebuild.test"), mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec(rebuildSwapStateStmts[3], mock.WithExecArgs("projections.test", "projections_rebuild.test"), mock.WithExecNoRowsAffected()),
+				mock.ExpectCommit(nil),
+			),
+			wantSwapped: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				client:     &database.DB{DB: tt.mock.DB},
+				projection: &projection{name: "projections.test"},
+			}
+			shadow, err := h.shadow()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			swapped, err := h.swap(context.Background(), shadow, tt.instances)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if swapped != tt.wantSwapped {
+				t.Errorf("want swapped: %v got: %v", tt.wantSwapped, swapped)
+			}
+
+			tt.mock.Assert(t)
+		})
+	}
+}
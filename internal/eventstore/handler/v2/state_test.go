@@ -449,3 +449,76 @@ func TestHandler_currentState(t *testing.T) {
 		})
 	}
 }
+
+type latestSequenceEventStore struct {
+	EventStore
+	position float64
+}
+
+func (es *latestSequenceEventStore) LatestSequence(context.Context, *eventstore.SearchQueryBuilder) (float64, error) {
+	return es.position, nil
+}
+
+func TestHandler_skipToLatestPosition(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		latest       float64
+		currentState *state
+		want         *state
+	}{
+		{
+			name:   "newer events",
+			latest: 42.5,
+			currentState: &state{
+				instanceID:     "instance",
+				position:       10,
+				aggregateID:    "aggregate",
+				aggregateType:  "type",
+				sequence:       3,
+				offset:         1,
+				eventTimestamp: now.Add(-time.Hour),
+			},
+			want: &state{
+				instanceID:     "instance",
+				position:       42.5,
+				eventTimestamp: now,
+			},
+		},
+		{
+			name:   "no newer events",
+			latest: 10,
+			currentState: &state{
+				instanceID:    "instance",
+				position:      10,
+				aggregateID:   "aggregate",
+				aggregateType: "type",
+				sequence:      3,
+			},
+			want: &state{
+				instanceID:    "instance",
+				position:      10,
+				aggregateID:   "aggregate",
+				aggregateType: "type",
+				sequence:      3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				es: &latestSequenceEventStore{position: tt.latest},
+				eventTypes: map[eventstore.AggregateType][]eventstore.EventType{
+					"user": {"user.added"},
+				},
+				now: func() time.Time { return now },
+			}
+			if err := h.skipToLatestPosition(context.Background(), nil, tt.currentState); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.currentState, tt.want) {
+				t.Errorf("unexpected state: got %+v, want %+v", tt.currentState, tt.want)
+			}
+		})
+	}
+}
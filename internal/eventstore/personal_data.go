@@ -0,0 +1,334 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// PersonalDataErased replaces the personal data of events after the key of the aggregate was destroyed
+	PersonalDataErased = "[erased]"
+
+	personalDataPrefix = "$pd1:"
+)
+
+// PersonalDataKeys manages the data encryption keys of the aggregates containing personal data.
+// The keys are encrypted with the key of the instance.
+type PersonalDataKeys interface {
+	// PersonalDataKey returns the key of the aggregate, if the key was destroyed it returns nil
+	PersonalDataKey(ctx context.Context, instanceID, aggregateID string) ([]byte, error)
+	// EnsurePersonalDataKey returns the key of the aggregate and creates it if it does not exist
+	EnsurePersonalDataKey(ctx context.Context, instanceID, aggregateID string) ([]byte, error)
+}
+
+type personalDataEventType struct {
+	aggregateType AggregateType
+	fields        []string
+	erases        bool
+}
+
+var personalDataEventTypes = map[EventType]personalDataEventType{}
+
+// RegisterPersonalDataFields registers the top level string fields of the payload which contain personal data.
+// If enabled, the fields are encrypted with the key of the aggregate before the event is pushed.
+func RegisterPersonalDataFields(aggregateType AggregateType, eventType EventType, fields ...string) {
+	if eventType == "" || len(fields) == 0 {
+		return
+	}
+	registered := personalDataEventTypes[eventType]
+	registered.aggregateType = aggregateType
+	registered.fields = append(registered.fields, fields...)
+	personalDataEventTypes[eventType] = registered
+}
+
+// RegisterPersonalDataErasure registers an event which destroys the key of the aggregate.
+// Afterwards the personal data of all events of the aggregate are replaced by [PersonalDataErased].
+func RegisterPersonalDataErasure(aggregateType AggregateType, eventType EventType) {
+	if eventType == "" {
+		return
+	}
+	registered := personalDataEventTypes[eventType]
+	registered.aggregateType = aggregateType
+	registered.erases = true
+	personalDataEventTypes[eventType] = registered
+}
+
+// ErasesPersonalData returns true if the key of the aggregate must be destroyed when the command is pushed
+func ErasesPersonalData(command Command) bool {
+	registered, ok := personalDataEventTypes[command.Type()]
+	return ok && registered.erases && registered.aggregateType == command.Aggregate().Type
+}
+
+func personalDataFields(aggregate *Aggregate, eventType EventType) []string {
+	registered, ok := personalDataEventTypes[eventType]
+	if !ok || aggregate == nil || registered.aggregateType != aggregate.Type {
+		return nil
+	}
+	return registered.fields
+}
+
+// personalDataKeyCache caches the keys of the aggregates during a single call to the eventstore
+type personalDataKeyCache map[string][]byte
+
+// encryptPersonalData replaces the commands containing personal data
+// by commands with the encrypted payload
+func (es *Eventstore) encryptPersonalData(ctx context.Context, keys personalDataKeyCache, cmds []Command) ([]Command, error) {
+	if es.personalDataKeys == nil || !es.encryptPersonalDataEnabled {
+		return cmds, nil
+	}
+	encrypted := slices.Clone(cmds)
+	for i, cmd := range cmds {
+		fields := personalDataFields(cmd.Aggregate(), cmd.Type())
+		if len(fields) == 0 {
+			continue
+		}
+		data, err := EventData(cmd)
+		if err != nil {
+			return nil, err
+		}
+		payload, changed, err := encryptPersonalDataPayload(ctx, es.personalDataKeys, keys, cmd.Aggregate(), fields, data)
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			continue
+		}
+		encrypted[i] = &personalDataCommand{Command: cmd, payload: payload}
+	}
+	return encrypted, nil
+}
+
+func encryptPersonalDataPayload(ctx context.Context, store PersonalDataKeys, keys personalDataKeyCache, aggregate *Aggregate, fields []string, payload []byte) ([]byte, bool, error) {
+	return transformPersonalData(payload, fields, func(value string) (string, error) {
+		if strings.HasPrefix(value, personalDataPrefix) {
+			return value, nil
+		}
+		key, err := keys.get(ctx, store, aggregate, true)
+		if err != nil {
+			return "", err
+		}
+		return encryptPersonalDataValue(key, aggregate.ID, value)
+	})
+}
+
+// PersonalDataEncrypter encrypts the personal data of commands which are not pushed by the [Eventstore],
+// e.g. by the storage of the v2 eventstore
+type PersonalDataEncrypter struct {
+	keys PersonalDataKeys
+}
+
+func NewPersonalDataEncrypter(keys PersonalDataKeys) *PersonalDataEncrypter {
+	return &PersonalDataEncrypter{keys: keys}
+}
+
+// Encrypter returns the function encrypting the payloads of the commands of a single push,
+// the keys of the aggregates are cached by the function and created if they don't exist.
+// It returns nil if no keys are configured.
+func (e *PersonalDataEncrypter) Encrypter() func(ctx context.Context, instanceID, aggregateType, aggregateID, eventType string, payload []byte) ([]byte, error) {
+	if e == nil || e.keys == nil {
+		return nil
+	}
+	var (
+		mu   sync.Mutex
+		keys = make(personalDataKeyCache)
+	)
+	return func(ctx context.Context, instanceID, aggregateType, aggregateID, eventType string, payload []byte) ([]byte, error) {
+		aggregate := &Aggregate{ID: aggregateID, Type: AggregateType(aggregateType), InstanceID: instanceID}
+		fields := personalDataFields(aggregate, EventType(eventType))
+		if len(fields) == 0 {
+			return payload, nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		payload, _, err := encryptPersonalDataPayload(ctx, e.keys, keys, aggregate, fields, payload)
+		return payload, err
+	}
+}
+
+// decryptPersonalData decrypts the personal data of the event,
+// if the key of the aggregate was destroyed the values are replaced by [PersonalDataErased]
+func (es *Eventstore) decryptPersonalData(ctx context.Context, keys personalDataKeyCache, event Event) (Event, error) {
+	if es.personalDataKeys == nil {
+		return event, nil
+	}
+	payload, changed, err := decryptPersonalDataPayload(ctx, es.personalDataKeys, keys, event.Aggregate(), event.Type(), event.DataAsBytes())
+	if err != nil || !changed {
+		return event, err
+	}
+	return &payloadEvent{Event: event, data: payload}, nil
+}
+
+// PersonalDataDecrypter decrypts the personal data of events which are not read by the [Eventstore],
+// e.g. by the storage of the v2 eventstore
+type PersonalDataDecrypter struct {
+	keys PersonalDataKeys
+}
+
+func NewPersonalDataDecrypter(keys PersonalDataKeys) *PersonalDataDecrypter {
+	return &PersonalDataDecrypter{keys: keys}
+}
+
+// Decrypter returns the function decrypting the payloads of the events of a single query,
+// the keys of the aggregates are cached by the function.
+// If the key of an aggregate was destroyed the values are replaced by [PersonalDataErased].
+// It returns nil if no keys are configured.
+func (d *PersonalDataDecrypter) Decrypter() func(ctx context.Context, instanceID, aggregateType, aggregateID, eventType string, payload []byte) ([]byte, error) {
+	if d == nil || d.keys == nil {
+		return nil
+	}
+	var (
+		mu   sync.Mutex
+		keys = make(personalDataKeyCache)
+	)
+	return func(ctx context.Context, instanceID, aggregateType, aggregateID, eventType string, payload []byte) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		aggregate := &Aggregate{ID: aggregateID, Type: AggregateType(aggregateType), InstanceID: instanceID}
+		payload, _, err := decryptPersonalDataPayload(ctx, d.keys, keys, aggregate, EventType(eventType), payload)
+		return payload, err
+	}
+}
+
+func decryptPersonalDataPayload(ctx context.Context, store PersonalDataKeys, keys personalDataKeyCache, aggregate *Aggregate, eventType EventType, payload []byte) ([]byte, bool, error) {
+	fields := personalDataFields(aggregate, eventType)
+	if len(fields) == 0 {
+		return payload, false, nil
+	}
+	return transformPersonalData(payload, fields, func(value string) (string, error) {
+		if !strings.HasPrefix(value, personalDataPrefix) {
+			return value, nil
+		}
+		key, err := keys.get(ctx, store, aggregate, false)
+		if err != nil {
+			return "", err
+		}
+		if key == nil {
+			return PersonalDataErased, nil
+		}
+		decrypted, err := decryptPersonalDataValue(key, aggregate.ID, value)
+		if err != nil {
+			// the key was recreated after the erasure
+			return PersonalDataErased, nil
+		}
+		return decrypted, nil
+	})
+}
+
+// get returns the key of the aggregate from the cache or the store
+func (keys personalDataKeyCache) get(ctx context.Context, store PersonalDataKeys, aggregate *Aggregate, ensure bool) (key []byte, err error) {
+	cacheKey := aggregate.InstanceID + ":" + aggregate.ID
+	if key, ok := keys[cacheKey]; ok && (key != nil || !ensure) {
+		return key, nil
+	}
+	if ensure {
+		key, err = store.EnsurePersonalDataKey(ctx, aggregate.InstanceID, aggregate.ID)
+	} else {
+		key, err = store.PersonalDataKey(ctx, aggregate.InstanceID, aggregate.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	keys[cacheKey] = key
+	return key, nil
+}
+
+// transformPersonalData calls transform for each string value of the fields
+func transformPersonalData(data []byte, fields []string, transform func(string) (string, error)) (_ []byte, changed bool, err error) {
+	if len(data) == 0 {
+		return data, false, nil
+	}
+	payload := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(data, &payload); err != nil {
+		return nil, false, zerrors.ThrowInternal(err, "V2-Pd3nk", "unable to unmarshal payload")
+	}
+	for _, field := range fields {
+		raw, ok := payload[field]
+		if !ok {
+			continue
+		}
+		var value string
+		if json.Unmarshal(raw, &value) != nil || value == "" {
+			continue
+		}
+		transformed, err := transform(value)
+		if err != nil {
+			return nil, false, err
+		}
+		if transformed == value {
+			continue
+		}
+		if payload[field], err = json.Marshal(transformed); err != nil {
+			return nil, false, zerrors.ThrowInternal(err, "V2-Pd8wq", "unable to marshal payload")
+		}
+		changed = true
+	}
+	if !changed {
+		return data, false, nil
+	}
+	data, err = json.Marshal(payload)
+	if err != nil {
+		return nil, false, zerrors.ThrowInternal(err, "V2-Pd0vb", "unable to marshal payload")
+	}
+	return data, true, nil
+}
+
+// encryptPersonalDataValue encrypts the value using AES-GCM, the aggregate id is used as additional data
+func encryptPersonalDataValue(key []byte, aggregateID, value string) (string, error) {
+	gcm, err := personalDataCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", zerrors.ThrowInternal(err, "V2-Pd5mz", "unable to create nonce")
+	}
+	encrypted := gcm.Seal(nonce, nonce, []byte(value), []byte(aggregateID))
+	return personalDataPrefix + base64.RawStdEncoding.EncodeToString(encrypted), nil
+}
+
+func decryptPersonalDataValue(key []byte, aggregateID, value string) (string, error) {
+	gcm, err := personalDataCipher(key)
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, personalDataPrefix))
+	if err != nil {
+		return "", err
+	}
+	if len(encrypted) < gcm.NonceSize() {
+		return "", zerrors.ThrowInternal(nil, "V2-Pd2lx", "invalid personal data")
+	}
+	decrypted, err := gcm.Open(nil, encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():], []byte(aggregateID))
+	if err != nil {
+		return "", err
+	}
+	return string(decrypted), nil
+}
+
+func personalDataCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Pd6ke", "invalid personal data key")
+	}
+	return cipher.NewGCM(block)
+}
+
+// personalDataCommand overwrites the payload of the command with the encrypted payload
+type personalDataCommand struct {
+	Command
+	payload []byte
+}
+
+// Payload implements [Command]
+func (c *personalDataCommand) Payload() any {
+	return c.payload
+}
@@ -0,0 +1,180 @@
+package eventstore
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPersonalDataKeys struct {
+	keys map[string][]byte
+}
+
+func (k *testPersonalDataKeys) PersonalDataKey(_ context.Context, instanceID, aggregateID string) ([]byte, error) {
+	return k.keys[instanceID+":"+aggregateID], nil
+}
+
+func (k *testPersonalDataKeys) EnsurePersonalDataKey(_ context.Context, instanceID, aggregateID string) ([]byte, error) {
+	if key, ok := k.keys[instanceID+":"+aggregateID]; ok {
+		return key, nil
+	}
+	key := []byte(strings.Repeat(aggregateID, 32)[:32])
+	k.keys[instanceID+":"+aggregateID] = key
+	return key, nil
+}
+
+type testPersonalDataCommand struct {
+	BaseEvent
+	Name  string `json:"name,omitempty"`
+	Other string `json:"other,omitempty"`
+}
+
+func (c *testPersonalDataCommand) Payload() any {
+	return c
+}
+
+func (c *testPersonalDataCommand) UniqueConstraints() []*UniqueConstraint {
+	return nil
+}
+
+func personalDataTestCommand(aggregateID, name string) *testPersonalDataCommand {
+	return &testPersonalDataCommand{
+		BaseEvent: BaseEvent{
+			EventType: "pd.event",
+			Agg: &Aggregate{
+				ID:         aggregateID,
+				Type:       "pd.aggregate",
+				InstanceID: "instance",
+			},
+		},
+		Name:  name,
+		Other: "other",
+	}
+}
+
+func TestEventstore_personalData(t *testing.T) {
+	RegisterPersonalDataFields("pd.aggregate", "pd.event", "name")
+
+	tests := []struct {
+		name string
+		// destroy simulates the deletion or recreation of the key after the command was pushed
+		destroy    func(keys *testPersonalDataKeys)
+		disabled   bool
+		wantStored func(t *testing.T, name string)
+		wantName   string
+	}{
+		{
+			name: "encrypted",
+			wantStored: func(t *testing.T, name string) {
+				assert.True(t, strings.HasPrefix(name, personalDataPrefix))
+			},
+			wantName: "gigi",
+		},
+		{
+			name: "key destroyed",
+			destroy: func(keys *testPersonalDataKeys) {
+				delete(keys.keys, "instance:user1")
+			},
+			wantStored: func(t *testing.T, name string) {
+				assert.True(t, strings.HasPrefix(name, personalDataPrefix))
+			},
+			wantName: PersonalDataErased,
+		},
+		{
+			name: "key recreated",
+			destroy: func(keys *testPersonalDataKeys) {
+				keys.keys["instance:user1"] = []byte(strings.Repeat("x", 32))
+			},
+			wantStored: func(t *testing.T, name string) {
+				assert.True(t, strings.HasPrefix(name, personalDataPrefix))
+			},
+			wantName: PersonalDataErased,
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			wantStored: func(t *testing.T, name string) {
+				assert.Equal(t, "gigi", name)
+			},
+			wantName: "gigi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := &testPersonalDataKeys{keys: make(map[string][]byte)}
+			es := NewEventstore(&Config{
+				PersonalDataKeys:    keys,
+				EncryptPersonalData: !tt.disabled,
+			})
+
+			cmds, err := es.encryptPersonalData(context.Background(), make(personalDataKeyCache), []Command{personalDataTestCommand("user1", "gigi")})
+			require.NoError(t, err)
+			data, err := EventData(cmds[0])
+			require.NoError(t, err)
+
+			stored := new(testPersonalDataCommand)
+			require.NoError(t, (&BaseEvent{Data: data}).Unmarshal(stored))
+			tt.wantStored(t, stored.Name)
+			assert.Equal(t, "other", stored.Other)
+
+			if tt.destroy != nil {
+				tt.destroy(keys)
+			}
+			event, err := es.decryptPersonalData(context.Background(), make(personalDataKeyCache), &BaseEvent{
+				EventType: "pd.event",
+				Agg:       cmds[0].Aggregate(),
+				Data:      data,
+			})
+			require.NoError(t, err)
+
+			got := new(testPersonalDataCommand)
+			require.NoError(t, event.Unmarshal(got))
+			assert.Equal(t, tt.wantName, got.Name)
+			assert.Equal(t, "other", got.Other)
+		})
+	}
+}
+
+func TestEventstore_decryptPersonalData_plaintext(t *testing.T) {
+	RegisterPersonalDataFields("pd.aggregate", "pd.event", "name")
+
+	es := NewEventstore(&Config{
+		PersonalDataKeys:    &testPersonalDataKeys{keys: make(map[string][]byte)},
+		EncryptPersonalData: true,
+	})
+	// events pushed before the encryption was enabled are not changed
+	stored := &BaseEvent{
+		EventType: "pd.event",
+		Agg:       &Aggregate{ID: "user1", Type: "pd.aggregate", InstanceID: "instance"},
+		Data:      []byte(`{"name":"gigi"}`),
+	}
+	event, err := es.decryptPersonalData(context.Background(), make(personalDataKeyCache), stored)
+	require.NoError(t, err)
+	assert.Same(t, stored, event)
+}
+
+func TestPersonalDataEncrypter(t *testing.T) {
+	RegisterPersonalDataFields("pd.aggregate", "pd.event", "name")
+	keys := &testPersonalDataKeys{keys: make(map[string][]byte)}
+
+	encrypt := NewPersonalDataEncrypter(keys).Encrypter()
+	encrypted, err := encrypt(context.Background(), "instance", "pd.aggregate", "user1", "pd.event", []byte(`{"name":"gigi","other":"other"}`))
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "gigi")
+	assert.Contains(t, string(encrypted), `"other":"other"`)
+
+	decrypted, err := NewPersonalDataDecrypter(keys).Decrypter()(context.Background(), "instance", "pd.aggregate", "user1", "pd.event", encrypted)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"gigi","other":"other"}`, string(decrypted))
+
+	// payloads of other events are stored as passed
+	other := []byte(`{"name":"gigi"}`)
+	unchanged, err := encrypt(context.Background(), "instance", "pd.aggregate", "user1", "other.event", other)
+	require.NoError(t, err)
+	assert.Equal(t, other, unchanged)
+
+	assert.Nil(t, NewPersonalDataEncrypter(nil).Encrypter())
+}
@@ -0,0 +1,238 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Snapshotter is implemented by query reducers which allow to persist their reduced state.
+// The state is stored as json, therefore it must be fully represented by its exported fields.
+//
+// Snapshots are only used if the query of the reducer filters the events of a single aggregate.
+type Snapshotter interface {
+	QueryReducer
+	// SnapshotKey identifies the state within the type of the reducer,
+	// it must contain all parameters which limit the reduced events (e.g. the user id of a member)
+	SnapshotKey() string
+	// SnapshotVersion must be increased if the reduce logic changes.
+	// Changes of the fields of the state, of the aggregate and event types of the query
+	// and of the upcasters of the aggregate type are detected by the revision of the snapshot.
+	SnapshotVersion() uint16
+
+	writeModel() *WriteModel
+}
+
+// Snapshot is the persisted state of a [Snapshotter] after reducing the events up to the sequence
+type Snapshot struct {
+	InstanceID string
+	Key        string
+	// Revision of the state, see [snapshotRevision]
+	Revision string
+	// Sequence of the last event reduced into the state
+	Sequence uint64
+
+	AggregateID       string
+	ResourceOwner     string
+	ProcessedSequence uint64
+	ChangeDate        time.Time
+
+	Payload []byte
+}
+
+type SnapshotStore interface {
+	// Snapshot returns the snapshot of the given key and revision
+	// if no snapshot was found it returns nil
+	Snapshot(ctx context.Context, instanceID, key, revision string) (*Snapshot, error)
+	// SetSnapshot stores the snapshot, existing snapshots of the same key are overwritten
+	// if their revision differs or their sequence is lower
+	SetSnapshot(ctx context.Context, snapshot *Snapshot) error
+}
+
+func (wm *WriteModel) writeModel() *WriteModel {
+	return wm
+}
+
+// filterToSnapshotter resumes the reducer from its latest snapshot
+// and stores a new snapshot if at least snapshotThreshold events were reduced
+func (es *Eventstore) filterToSnapshotter(ctx context.Context, searchQuery *SearchQueryBuilder, r Snapshotter) error {
+	searchQuery.ensureInstanceID(ctx)
+	key, ok := snapshotKey(searchQuery, r)
+	if !ok {
+		return es.FilterToReducer(ctx, searchQuery, r)
+	}
+	instanceID := *searchQuery.GetInstanceID()
+	revision := snapshotRevision(r, searchQuery.GetQueries()[0])
+
+	snapshot, err := es.snapshots.Snapshot(ctx, instanceID, key, revision)
+	logging.WithFields("key", key).OnError(err).Warn("unable to load snapshot")
+	if snapshot != nil {
+		if err = restoreSnapshot(snapshot, r); err != nil {
+			return err
+		}
+		searchQuery.SequenceGreater(snapshot.Sequence)
+	}
+
+	var (
+		reduced  uint32
+		sequence uint64
+		keys     = make(personalDataKeyCache)
+	)
+	err = es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
+		event, err := es.mapEvent(ctx, keys, event)
+		if err != nil {
+			return err
+		}
+		reduced++
+		sequence = event.Sequence()
+		r.AppendEvents(event)
+		return r.Reduce()
+	})
+	if err != nil || reduced < es.snapshotThreshold {
+		return err
+	}
+
+	payload, err := json.Marshal(r)
+	if err != nil {
+		logging.WithFields("key", key).WithError(err).Warn("unable to marshal snapshot")
+		return nil
+	}
+	wm := r.writeModel()
+	err = es.snapshots.SetSnapshot(ctx, &Snapshot{
+		InstanceID:        instanceID,
+		Key:               key,
+		Revision:          revision,
+		Sequence:          sequence,
+		AggregateID:       wm.AggregateID,
+		ResourceOwner:     wm.ResourceOwner,
+		ProcessedSequence: wm.ProcessedSequence,
+		ChangeDate:        wm.ChangeDate,
+		Payload:           payload,
+	})
+	logging.WithFields("key", key).OnError(err).Warn("unable to store snapshot")
+	return nil
+}
+
+// snapshotKey returns the key of the snapshot
+// it returns false if the query cannot be resumed from a snapshot
+//
+// Only queries for the events of a single aggregate are resumable, because they are ordered by the sequence
+// and no event with a lower sequence can be committed afterwards.
+func snapshotKey(searchQuery *SearchQueryBuilder, r Snapshotter) (string, bool) {
+	if searchQuery.GetInstanceID() == nil ||
+		len(searchQuery.GetQueries()) != 1 ||
+		searchQuery.GetColumns() != ColumnsEvent ||
+		searchQuery.GetTx() != nil ||
+		searchQuery.GetDesc() ||
+		searchQuery.GetLimit() > 0 ||
+		searchQuery.GetOffset() > 0 ||
+		searchQuery.GetEditorUser() != "" ||
+		searchQuery.GetPositionAfter() > 0 ||
+		searchQuery.GetEventSequenceGreater() > 0 ||
+		!searchQuery.GetCreationDateAfter().IsZero() ||
+		!searchQuery.GetCreationDateBefore().IsZero() {
+		return "", false
+	}
+	query := searchQuery.GetQueries()[0]
+	if len(query.GetAggregateTypes()) != 1 || len(query.GetAggregateIDs()) != 1 || len(query.GetEventData()) > 0 {
+		return "", false
+	}
+	return fmt.Sprintf("%T:%s:%s", r, r.SnapshotKey(), searchQuery.GetResourceOwner()), true
+}
+
+type snapshotRevisionKey struct {
+	typ reflect.Type
+	// query consists of the aggregate type and the sorted event types of the query
+	query string
+}
+
+// snapshotRevisions caches the revisions by type of the [Snapshotter] and its query
+var snapshotRevisions sync.Map
+
+// snapshotRevision identifies the state stored in a snapshot,
+// snapshots of other revisions are ignored and replaced.
+// It consists of the [Snapshotter.SnapshotVersion] and a hash of the json fields of the state,
+// the aggregate and event types of the query, as they define the reduced events,
+// and the upcasters registered for the aggregate type, as they change the payload of the reduced events.
+func snapshotRevision(r Snapshotter, query *SearchQuery) string {
+	aggregateType := query.GetAggregateTypes()[0]
+	eventTypes := make([]string, len(query.GetEventTypes()))
+	for i, eventType := range query.GetEventTypes() {
+		eventTypes[i] = string(eventType)
+	}
+	slices.Sort(eventTypes)
+	key := snapshotRevisionKey{typ: reflect.TypeOf(r), query: string(aggregateType) + ":" + strings.Join(eventTypes, ",")}
+	if revision, ok := snapshotRevisions.Load(key); ok {
+		return revision.(string)
+	}
+	var layout strings.Builder
+	writeTypeLayout(&layout, key.typ, make(map[reflect.Type]bool))
+	layout.WriteString(key.query)
+	for _, upcaster := range registeredUpcasters(aggregateType) {
+		layout.WriteString(upcaster)
+	}
+	hash := sha256.Sum256([]byte(layout.String()))
+	revision := fmt.Sprintf("%d.%x", r.SnapshotVersion(), hash[:8])
+	snapshotRevisions.Store(key, revision)
+	return revision
+}
+
+// writeTypeLayout writes the fields of the type which are marshalled to json
+func writeTypeLayout(layout *strings.Builder, typ reflect.Type, visited map[reflect.Type]bool) {
+	layout.WriteString(typ.String())
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		layout.WriteRune('(')
+		writeTypeLayout(layout, typ.Elem(), visited)
+		layout.WriteRune(')')
+	case reflect.Map:
+		layout.WriteRune('(')
+		writeTypeLayout(layout, typ.Key(), visited)
+		layout.WriteRune(',')
+		writeTypeLayout(layout, typ.Elem(), visited)
+		layout.WriteRune(')')
+	case reflect.Struct:
+		if visited[typ] {
+			return
+		}
+		visited[typ] = true
+		layout.WriteRune('{')
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+				continue
+			}
+			layout.WriteString(field.Name)
+			layout.WriteRune(' ')
+			layout.WriteString(tag)
+			layout.WriteRune(' ')
+			writeTypeLayout(layout, field.Type, visited)
+			layout.WriteRune(';')
+		}
+		layout.WriteRune('}')
+	}
+}
+
+func restoreSnapshot(snapshot *Snapshot, r Snapshotter) error {
+	if err := json.Unmarshal(snapshot.Payload, r); err != nil {
+		return zerrors.ThrowInternal(err, "V2-Snp3x", "Errors.Internal")
+	}
+	wm := r.writeModel()
+	wm.AggregateID = snapshot.AggregateID
+	wm.ResourceOwner = snapshot.ResourceOwner
+	wm.InstanceID = snapshot.InstanceID
+	wm.ProcessedSequence = snapshot.ProcessedSequence
+	wm.ChangeDate = snapshot.ChangeDate
+	return nil
+}
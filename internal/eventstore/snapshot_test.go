@@ -0,0 +1,292 @@
+package eventstore
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type testSnapshotModel struct {
+	WriteModel
+
+	aggregateIDs []string
+	Reduced      int
+}
+
+func (wm *testSnapshotModel) Reduce() error {
+	wm.Reduced += len(wm.Events)
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *testSnapshotModel) Query() *SearchQueryBuilder {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		ResourceOwner("ro").
+		AddQuery().
+		AggregateTypes("snapshot.aggregate").
+		AggregateIDs(wm.aggregateIDs...).
+		Builder()
+}
+
+func (wm *testSnapshotModel) SnapshotKey() string {
+	return "id"
+}
+
+func (wm *testSnapshotModel) SnapshotVersion() uint16 {
+	return 2
+}
+
+// snapshotQuerier returns the events with a sequence greater than the one of the query
+type snapshotQuerier struct {
+	testQuerier
+	query *SearchQueryBuilder
+}
+
+func (repo *snapshotQuerier) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, reduce Reducer) error {
+	repo.query = searchQuery
+	for _, event := range repo.events {
+		if event.Sequence() <= searchQuery.GetEventSequenceGreater() {
+			continue
+		}
+		if err := reduce(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testSnapshotStore struct {
+	snapshot *Snapshot
+	err      error
+	set      *Snapshot
+}
+
+func (s *testSnapshotStore) Snapshot(_ context.Context, instanceID, key, revision string) (*Snapshot, error) {
+	if s.snapshot == nil || s.snapshot.InstanceID != instanceID || s.snapshot.Key != key || s.snapshot.Revision != revision {
+		return nil, s.err
+	}
+	return s.snapshot, s.err
+}
+
+func (s *testSnapshotStore) SetSnapshot(_ context.Context, snapshot *Snapshot) error {
+	s.set = snapshot
+	return nil
+}
+
+func snapshotTestEvents(count int) []Event {
+	events := make([]Event, count)
+	for i := range events {
+		events[i] = &BaseEvent{
+			EventType: "snapshot.event",
+			Agg: &Aggregate{
+				ID:            "id",
+				Type:          "snapshot.aggregate",
+				ResourceOwner: "ro",
+				InstanceID:    "instanceID",
+			},
+			Seq:      uint64(i + 1),
+			Creation: time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC),
+		}
+	}
+	return events
+}
+
+const testSnapshotKey = "*eventstore.testSnapshotModel:id:ro"
+
+var testSnapshotRevision = snapshotRevision(new(testSnapshotModel), new(testSnapshotModel).Query().GetQueries()[0])
+
+func TestEventstore_FilterToQueryReducer_snapshot(t *testing.T) {
+	tests := []struct {
+		name         string
+		aggregateIDs []string
+		threshold    uint32
+		events       int
+		store        *testSnapshotStore
+		wantReduced  int
+		wantSequence uint64
+		wantSet      *Snapshot
+		wantErr      error
+	}{
+		{
+			name:         "below threshold",
+			aggregateIDs: []string{"id"},
+			threshold:    5,
+			events:       4,
+			store:        &testSnapshotStore{},
+			wantReduced:  4,
+			wantSequence: 4,
+		},
+		{
+			name:         "store snapshot",
+			aggregateIDs: []string{"id"},
+			threshold:    5,
+			events:       5,
+			store:        &testSnapshotStore{},
+			wantReduced:  5,
+			wantSequence: 5,
+			wantSet: &Snapshot{
+				InstanceID:        "instanceID",
+				Key:               testSnapshotKey,
+				Revision:          testSnapshotRevision,
+				Sequence:          5,
+				AggregateID:       "id",
+				ResourceOwner:     "ro",
+				ProcessedSequence: 5,
+				ChangeDate:        time.Date(2024, 1, 1, 0, 0, 4, 0, time.UTC),
+				Payload:           []byte(`{"Reduced":5}`),
+			},
+		},
+		{
+			name:         "resume from snapshot",
+			aggregateIDs: []string{"id"},
+			threshold:    5,
+			events:       7,
+			store: &testSnapshotStore{
+				snapshot: &Snapshot{
+					InstanceID:        "instanceID",
+					Key:               testSnapshotKey,
+					Revision:          testSnapshotRevision,
+					Sequence:          5,
+					AggregateID:       "id",
+					ResourceOwner:     "ro",
+					ProcessedSequence: 5,
+					Payload:           []byte(`{"Reduced":5}`),
+				},
+			},
+			wantReduced:  7,
+			wantSequence: 7,
+		},
+		{
+			name:         "snapshot of other revision ignored",
+			aggregateIDs: []string{"id"},
+			threshold:    10,
+			events:       7,
+			store: &testSnapshotStore{
+				snapshot: &Snapshot{
+					InstanceID: "instanceID",
+					Key:        testSnapshotKey,
+					Revision:   "1.0000000000000000",
+					Sequence:   5,
+					Payload:    []byte(`{"Reduced":5}`),
+				},
+			},
+			wantReduced:  7,
+			wantSequence: 7,
+		},
+		{
+			name:         "load error falls back to events",
+			aggregateIDs: []string{"id"},
+			threshold:    10,
+			events:       3,
+			store: &testSnapshotStore{
+				err: zerrors.ThrowInternal(nil, "TEST-3Rnl2", "test err"),
+			},
+			wantReduced:  3,
+			wantSequence: 3,
+		},
+		{
+			name:         "invalid payload",
+			aggregateIDs: []string{"id"},
+			threshold:    5,
+			events:       7,
+			store: &testSnapshotStore{
+				snapshot: &Snapshot{
+					InstanceID: "instanceID",
+					Key:        testSnapshotKey,
+					Revision:   testSnapshotRevision,
+					Sequence:   5,
+					Payload:    []byte(`{"Reduced":"5"}`),
+				},
+			},
+			wantErr: zerrors.ThrowInternal(nil, "V2-Snp3x", "Errors.Internal"),
+		},
+		{
+			name:         "multiple aggregates not resumable",
+			aggregateIDs: []string{"id", "id2"},
+			threshold:    1,
+			events:       3,
+			store:        &testSnapshotStore{},
+			wantReduced:  3,
+			wantSequence: 3,
+		},
+		{
+			name:         "disabled",
+			aggregateIDs: []string{"id"},
+			threshold:    0,
+			events:       3,
+			store:        &testSnapshotStore{},
+			wantReduced:  3,
+			wantSequence: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := NewEventstore(&Config{
+				Querier: &snapshotQuerier{
+					testQuerier: testQuerier{events: snapshotTestEvents(tt.events)},
+				},
+				Snapshots:         tt.store,
+				SnapshotThreshold: tt.threshold,
+			})
+			wm := &testSnapshotModel{aggregateIDs: tt.aggregateIDs}
+
+			err := es.FilterToQueryReducer(authz.NewMockContext("instanceID", "ro", "editor"), wm)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.wantReduced, wm.Reduced)
+			assert.Equal(t, tt.wantSequence, wm.ProcessedSequence)
+			assert.Equal(t, "id", wm.AggregateID)
+			assert.Equal(t, tt.wantSet, tt.store.set)
+		})
+	}
+}
+
+type testSnapshotModelV2 struct {
+	WriteModel
+
+	Reduced int
+	Name    string
+}
+
+func (wm *testSnapshotModelV2) Query() *SearchQueryBuilder {
+	return nil
+}
+
+func (wm *testSnapshotModelV2) SnapshotKey() string {
+	return "id"
+}
+
+func (wm *testSnapshotModelV2) SnapshotVersion() uint16 {
+	return 2
+}
+
+func snapshotRevisionQuery(aggregateType AggregateType, eventTypes ...EventType) *SearchQuery {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		AddQuery().
+		AggregateTypes(aggregateType).
+		AggregateIDs("id").
+		EventTypes(eventTypes...).
+		Builder().
+		GetQueries()[0]
+}
+
+func Test_snapshotRevision(t *testing.T) {
+	revision := snapshotRevision(new(testSnapshotModel), snapshotRevisionQuery("snapshot.revision", "snapshot.added", "snapshot.changed"))
+	assert.Equal(t, revision, snapshotRevision(new(testSnapshotModel), snapshotRevisionQuery("snapshot.revision", "snapshot.changed", "snapshot.added")), "revision must be stable")
+	assert.True(t, strings.HasPrefix(revision, "2."), "revision must start with the version")
+	assert.NotEqual(t, revision, snapshotRevision(new(testSnapshotModelV2), snapshotRevisionQuery("snapshot.revision", "snapshot.added", "snapshot.changed")), "changed fields must change the revision")
+	assert.NotEqual(t, revision, snapshotRevision(new(testSnapshotModel), snapshotRevisionQuery("snapshot.revision", "snapshot.added")), "changed event types must change the revision")
+	assert.NotEqual(t, revision, snapshotRevision(new(testSnapshotModel), snapshotRevisionQuery("snapshot.other", "snapshot.added", "snapshot.changed")), "changed aggregate type must change the revision")
+
+	RegisterUpcaster("snapshot.upcasted", "snapshot.upcasted.event", "v1", RenameFields(map[string]string{"old": "new"}))
+	t.Cleanup(func() { delete(upcasters, "snapshot.upcasted.event") })
+	assert.NotEqual(t, revision, snapshotRevision(new(testSnapshotModel), snapshotRevisionQuery("snapshot.upcasted", "snapshot.added", "snapshot.changed")), "upcasters of the aggregate type must change the revision")
+}
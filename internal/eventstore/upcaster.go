@@ -0,0 +1,156 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Upcaster converts the stored payload of an event into the payload of the next version.
+type Upcaster func(data []byte) ([]byte, error)
+
+type versionedUpcaster struct {
+	aggregateType AggregateType
+	version       Version
+	upcaster      Upcaster
+}
+
+var upcasters = map[EventType][]versionedUpcaster{}
+
+// RegisterUpcaster registers an upcaster for the payload of events stored with the version of the aggregate.
+// Before an event is mapped all upcasters of its version and newer versions are applied in the order of the versions.
+//
+// Events of the current version of the aggregate are upcasted as well,
+// therefore upcasters registered for the current version must be idempotent.
+func RegisterUpcaster(aggregateType AggregateType, eventType EventType, version Version, upcaster Upcaster) {
+	if upcaster == nil || eventType == "" {
+		return
+	}
+	registered := append(upcasters[eventType], versionedUpcaster{aggregateType: aggregateType, version: version, upcaster: upcaster})
+	slices.SortStableFunc(registered, func(a, b versionedUpcaster) int {
+		return compareVersions(a.version, b.version)
+	})
+	upcasters[eventType] = registered
+}
+
+// registeredUpcasters returns the event types and versions of the upcasters registered for the aggregate type
+func registeredUpcasters(aggregateType AggregateType) []string {
+	registered := make([]string, 0)
+	for eventType, eventUpcasters := range upcasters {
+		for _, upcaster := range eventUpcasters {
+			if upcaster.aggregateType != aggregateType {
+				continue
+			}
+			registered = append(registered, string(eventType)+"@"+string(upcaster.version))
+		}
+	}
+	slices.Sort(registered)
+	return registered
+}
+
+// RenameFields returns an [Upcaster] which renames top level fields of the payload.
+// The old field is removed if the new field is already set.
+func RenameFields(renames map[string]string) Upcaster {
+	return func(data []byte) ([]byte, error) {
+		if len(data) == 0 {
+			return data, nil
+		}
+		payload := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, err
+		}
+		var changed bool
+		for from, to := range renames {
+			value, ok := payload[from]
+			if !ok {
+				continue
+			}
+			delete(payload, from)
+			changed = true
+			if _, ok := payload[to]; !ok {
+				payload[to] = value
+			}
+		}
+		if !changed {
+			return data, nil
+		}
+		return json.Marshal(payload)
+	}
+}
+
+// MapEvent applies the registered upcasters and maps the event to the type registered by [RegisterFilterEventMapper]
+func MapEvent(event Event) (Event, error) {
+	event, err := upcast(event)
+	if err != nil {
+		return nil, err
+	}
+	interceptors, ok := eventInterceptors[event.Type()]
+	if !ok || interceptors.eventMapper == nil {
+		return BaseEventFromRepo(event), nil
+	}
+	return interceptors.eventMapper(event)
+}
+
+func upcast(event Event) (Event, error) {
+	registered := upcasters[event.Type()]
+	if len(registered) == 0 {
+		return event, nil
+	}
+	data := event.DataAsBytes()
+	var err error
+	for _, upcaster := range registered {
+		if upcaster.aggregateType != event.Aggregate().Type || compareVersions(upcaster.version, event.Aggregate().Version) < 0 {
+			continue
+		}
+		data, err = upcaster.upcaster(data)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-Upc4s", "unable to upcast event")
+		}
+	}
+	return &payloadEvent{Event: event, data: data}, nil
+}
+
+// payloadEvent overwrites the payload of the stored event
+type payloadEvent struct {
+	Event
+	data []byte
+}
+
+// DataAsBytes implements [Event]
+func (e *payloadEvent) DataAsBytes() []byte {
+	return e.data
+}
+
+// Unmarshal implements [Event]
+func (e *payloadEvent) Unmarshal(ptr any) error {
+	if len(e.data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.data, ptr)
+}
+
+// compareVersions compares the numeric parts of the versions, missing parts are treated as 0
+func compareVersions(a, b Version) int {
+	aParts := strings.Split(strings.TrimPrefix(string(a), "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(string(b), "v"), ".")
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		if diff := versionPart(aParts, i) - versionPart(bParts, i); diff != 0 {
+			if diff < 0 {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	part, _ := strconv.Atoi(parts[i])
+	return part
+}
@@ -0,0 +1,121 @@
+package eventstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_compareVersions(t *testing.T) {
+	tests := []struct {
+		a, b Version
+		want int
+	}{
+		{"v1", "v1", 0},
+		{"v1", "v1.0.0", 0},
+		{"v1", "v2", -1},
+		{"v2", "v1", 1},
+		{"v1.2", "v1.10", -1},
+		{"v10", "v9.9.9", 1},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.a)+"-"+string(tt.b), func(t *testing.T) {
+			assert.Equal(t, tt.want, compareVersions(tt.a, tt.b))
+		})
+	}
+}
+
+func TestRenameFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no payload",
+			data: "",
+			want: "",
+		},
+		{
+			name: "renamed",
+			data: `{"old": 1, "other": "a"}`,
+			want: `{"new": 1, "other": "a"}`,
+		},
+		{
+			name: "new field set",
+			data: `{"old": 1, "new": 2}`,
+			want: `{"new": 2}`,
+		},
+		{
+			name: "unchanged",
+			data: `{"new": 2}`,
+			want: `{"new": 2}`,
+		},
+		{
+			name:    "invalid payload",
+			data:    `[]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenameFields(map[string]string{"old": "new"})([]byte(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Empty(t, got)
+				return
+			}
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestMapEvent_upcast(t *testing.T) {
+	aggregateType := AggregateType("upcast.aggregate")
+	eventType := EventType("upcast.event")
+	RegisterUpcaster(aggregateType, eventType, "v2", RenameFields(map[string]string{"second": "third"}))
+	RegisterUpcaster(aggregateType, eventType, "v1", RenameFields(map[string]string{"first": "second"}))
+
+	tests := []struct {
+		name    string
+		version Version
+		data    string
+		want    string
+	}{
+		{
+			name:    "all upcasters of older versions",
+			version: "v1",
+			data:    `{"first": 1}`,
+			want:    `{"third": 1}`,
+		},
+		{
+			name:    "only upcasters of newer versions",
+			version: "v2",
+			data:    `{"first": 1}`,
+			want:    `{"first": 1}`,
+		},
+		{
+			name:    "current version",
+			version: "v3",
+			data:    `{"second": 1}`,
+			want:    `{"second": 1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := MapEvent(&BaseEvent{
+				Agg:       &Aggregate{ID: "id", Type: aggregateType, Version: tt.version},
+				EventType: eventType,
+				Data:      []byte(tt.data),
+			})
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(event.DataAsBytes()))
+		})
+	}
+}
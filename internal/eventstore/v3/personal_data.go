@@ -0,0 +1,111 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed personal_data_key.sql
+	personalDataKeyStmt string
+	//go:embed personal_data_key_add.sql
+	addPersonalDataKeyStmt string
+	//go:embed personal_data_keys_delete.sql
+	deletePersonalDataKeysStmt string
+)
+
+// personalDataKeyLength is the length of the AES-256 keys
+const personalDataKeyLength = 32
+
+var _ eventstore.PersonalDataKeys = (*PersonalDataKeys)(nil)
+
+// PersonalDataKeys stores the keys of the personal data in events encrypted with the key algorithm
+type PersonalDataKeys struct {
+	client       *database.DB
+	keyAlgorithm crypto.EncryptionAlgorithm
+}
+
+func NewPersonalDataKeys(client *database.DB, keyAlgorithm crypto.EncryptionAlgorithm) *PersonalDataKeys {
+	return &PersonalDataKeys{
+		client:       client,
+		keyAlgorithm: keyAlgorithm,
+	}
+}
+
+// PersonalDataKey implements [eventstore.PersonalDataKeys]
+func (k *PersonalDataKeys) PersonalDataKey(ctx context.Context, instanceID, aggregateID string) (_ []byte, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	encrypted := new(crypto.CryptoValue)
+	err = k.client.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			return row.Scan(encrypted)
+		},
+		personalDataKeyStmt,
+		instanceID, aggregateID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Pdk2s", "Errors.Internal")
+	}
+	key, err := crypto.Decrypt(encrypted, k.keyAlgorithm)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Pdk8a", "Errors.Internal")
+	}
+	return key, nil
+}
+
+// EnsurePersonalDataKey implements [eventstore.PersonalDataKeys]
+func (k *PersonalDataKeys) EnsurePersonalDataKey(ctx context.Context, instanceID, aggregateID string) (_ []byte, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	key := make([]byte, personalDataKeyLength)
+	if _, err = rand.Read(key); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Pdk5n", "Errors.Internal")
+	}
+	encrypted, err := crypto.Encrypt(key, k.keyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	// concurrent pushes might create the key at the same time, the first one wins
+	if _, err = k.client.ExecContext(ctx, addPersonalDataKeyStmt, instanceID, aggregateID, encrypted); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Pdk0r", "Errors.Internal")
+	}
+	return k.PersonalDataKey(ctx, instanceID, aggregateID)
+}
+
+// handlePersonalDataErasure destroys the keys of the aggregates of commands erasing the personal data
+func handlePersonalDataErasure(ctx context.Context, tx *sql.Tx, commands []eventstore.Command) error {
+	placeholders := make([]string, 0)
+	args := make([]any, 0)
+	for _, command := range commands {
+		if !eventstore.ErasesPersonalData(command) {
+			continue
+		}
+		placeholders = append(placeholders, fmt.Sprintf("(instance_id = $%d AND aggregate_id = $%d)", len(args)+1, len(args)+2))
+		args = append(args, command.Aggregate().InstanceID, command.Aggregate().ID)
+	}
+	if len(placeholders) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(deletePersonalDataKeysStmt, strings.Join(placeholders, " OR ")), args...)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V3-Pdk4e", "Errors.Internal")
+	}
+	return nil
+}
@@ -0,0 +1 @@
+SELECT "key" FROM eventstore.personal_data_keys WHERE instance_id = $1 AND aggregate_id = $2
@@ -0,0 +1 @@
+INSERT INTO eventstore.personal_data_keys (instance_id, aggregate_id, "key") VALUES ($1, $2, $3) ON CONFLICT (instance_id, aggregate_id) DO NOTHING
@@ -0,0 +1 @@
+DELETE FROM eventstore.personal_data_keys WHERE %s
@@ -0,0 +1,99 @@
+package eventstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_handlePersonalDataErasure(t *testing.T) {
+	eventstore.RegisterPersonalDataErasure("erasure.type", "event.type")
+	erasureAggregate := func(id string) *eventstore.Aggregate {
+		return &eventstore.Aggregate{
+			ID:         id,
+			Type:       "erasure.type",
+			InstanceID: "instance",
+			Version:    "v1",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		commands []eventstore.Command
+		mock     *mock.SQLMock
+		wantErr  bool
+	}{
+		{
+			name: "no erasure",
+			commands: []eventstore.Command{
+				&mockCommand{aggregate: mockAggregate("id")},
+			},
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+			),
+		},
+		{
+			name: "single erasure",
+			commands: []eventstore.Command{
+				&mockCommand{aggregate: mockAggregate("id")},
+				&mockCommand{aggregate: erasureAggregate("user1")},
+			},
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(
+					"DELETE FROM eventstore.personal_data_keys WHERE (instance_id = $1 AND aggregate_id = $2)\n",
+					mock.WithExecArgs("instance", "user1"),
+					mock.WithExecRowsAffected(1),
+				),
+			),
+		},
+		{
+			name: "multiple erasures",
+			commands: []eventstore.Command{
+				&mockCommand{aggregate: erasureAggregate("user1")},
+				&mockCommand{aggregate: erasureAggregate("user2")},
+			},
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(
+					"DELETE FROM eventstore.personal_data_keys WHERE (instance_id = $1 AND aggregate_id = $2) OR (instance_id = $3 AND aggregate_id = $4)\n",
+					mock.WithExecArgs("instance", "user1", "instance", "user2"),
+					mock.WithExecRowsAffected(2),
+				),
+			),
+		},
+		{
+			name: "delete fails",
+			commands: []eventstore.Command{
+				&mockCommand{aggregate: erasureAggregate("user1")},
+			},
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(
+					"DELETE FROM eventstore.personal_data_keys WHERE (instance_id = $1 AND aggregate_id = $2)\n",
+					mock.WithExecArgs("instance", "user1"),
+					mock.WithExecErr(context.DeadlineExceeded),
+				),
+			),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := tt.mock.DB.Begin()
+			require.NoError(t, err)
+
+			err = handlePersonalDataErasure(context.Background(), tx, tt.commands)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			tt.mock.Assert(t)
+		})
+	}
+}
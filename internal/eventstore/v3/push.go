@@ -45,6 +45,10 @@ func (es *Eventstore) Push(ctx context.Context, commands ...eventstore.Command)
 			return err
 		}
 
+		if err = handlePersonalDataErasure(ctx, tx, commands); err != nil {
+			return err
+		}
+
 		// CockroachDB by default does not allow multiple modifications of the same table using ON CONFLICT
 		// Thats why we enable it manually
 		if es.client.Type() == "cockroach" {
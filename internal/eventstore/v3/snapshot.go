@@ -0,0 +1,80 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed snapshot.sql
+	snapshotStmt string
+	//go:embed snapshot_set.sql
+	setSnapshotStmt string
+)
+
+// Snapshot implements the [eventstore.SnapshotStore] interface
+func (es *Eventstore) Snapshot(ctx context.Context, instanceID, key, revision string) (snapshot *eventstore.Snapshot, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	snapshot = &eventstore.Snapshot{
+		InstanceID: instanceID,
+		Key:        key,
+		Revision:   revision,
+	}
+	var changeDate sql.NullTime
+	err = es.client.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			return row.Scan(
+				&snapshot.Sequence,
+				&snapshot.AggregateID,
+				&snapshot.ResourceOwner,
+				&snapshot.ProcessedSequence,
+				&changeDate,
+				&snapshot.Payload,
+			)
+		},
+		snapshotStmt,
+		instanceID, key, revision,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Snp4q", "Errors.Internal")
+	}
+	snapshot.ChangeDate = changeDate.Time
+	return snapshot, nil
+}
+
+// SetSnapshot implements the [eventstore.SnapshotStore] interface
+func (es *Eventstore) SetSnapshot(ctx context.Context, snapshot *eventstore.Snapshot) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	var changeDate sql.NullTime
+	if !snapshot.ChangeDate.IsZero() {
+		changeDate = sql.NullTime{Time: snapshot.ChangeDate, Valid: true}
+	}
+	_, err = es.client.ExecContext(ctx, setSnapshotStmt,
+		snapshot.InstanceID,
+		snapshot.Key,
+		snapshot.Revision,
+		snapshot.Sequence,
+		snapshot.AggregateID,
+		snapshot.ResourceOwner,
+		snapshot.ProcessedSequence,
+		changeDate,
+		snapshot.Payload,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V3-Snp9w", "Errors.Internal")
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+SELECT
+    "sequence"
+    , aggregate_id
+    , resource_owner
+    , processed_sequence
+    , change_date
+    , payload
+FROM
+    eventstore.snapshots
+WHERE
+    instance_id = $1
+    AND snapshot_key = $2
+    AND revision = $3
@@ -0,0 +1,25 @@
+INSERT INTO eventstore.snapshots (
+    instance_id
+    , snapshot_key
+    , revision
+    , "sequence"
+    , aggregate_id
+    , resource_owner
+    , processed_sequence
+    , change_date
+    , payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) ON CONFLICT (instance_id, snapshot_key) DO UPDATE SET
+    revision = EXCLUDED.revision
+    , "sequence" = EXCLUDED."sequence"
+    , aggregate_id = EXCLUDED.aggregate_id
+    , resource_owner = EXCLUDED.resource_owner
+    , processed_sequence = EXCLUDED.processed_sequence
+    , change_date = EXCLUDED.change_date
+    , payload = EXCLUDED.payload
+    , created_at = now()
+-- concurrent commands must not replace a newer snapshot of the same revision
+WHERE
+    snapshots.revision <> EXCLUDED.revision
+    OR snapshots."sequence" < EXCLUDED."sequence"
@@ -0,0 +1,142 @@
+package execution
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// targetClientIdleTimeout is the duration after which the client or gRPC connection of a target which was not called is removed,
+// e.g. because the target was removed or its authentication was changed to static credentials
+const targetClientIdleTimeout = time.Hour
+
+// targetClients caches the clients of targets with client credentials or client certificates by the id of the target,
+// so tokens and connections are reused. The client is replaced if the credentials of the target change.
+var targetClients = &targetClientCache{clients: make(map[string]*targetClientEntry)}
+
+type targetClientCache struct {
+	mu          sync.Mutex
+	clients     map[string]*targetClientEntry
+	lastCleanup time.Time
+}
+
+type targetClientEntry struct {
+	key      string
+	client   *http.Client
+	lastUsed time.Time
+}
+
+// targetClient returns the client used to call the target with the provided authentication
+func targetClient(targetID string, auth *domain.TargetAuth) (*http.Client, error) {
+	// static credentials are set as headers on the request
+	if auth == nil || (auth.Type != domain.TargetAuthTypeClientCredentials && auth.Type != domain.TargetAuthTypeClientCertificate) {
+		return http.DefaultClient, nil
+	}
+	return targetClients.get(targetID, auth, time.Now())
+}
+
+func (c *targetClientCache) get(targetID string, auth *domain.TargetAuth, now time.Time) (*http.Client, error) {
+	key, err := targetClientKey(auth)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeIdle(now)
+
+	existing, ok := c.clients[targetID]
+	if ok && existing.key == key {
+		existing.lastUsed = now
+		return existing.client, nil
+	}
+	client, err := newTargetClient(auth)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		existing.client.CloseIdleConnections()
+	}
+	c.clients[targetID] = &targetClientEntry{key: key, client: client, lastUsed: now}
+	return client, nil
+}
+
+// removeIdle removes the clients which were not used for [targetClientIdleTimeout],
+// the clients are checked at most once per timeout
+func (c *targetClientCache) removeIdle(now time.Time) {
+	if now.Sub(c.lastCleanup) < targetClientIdleTimeout {
+		return
+	}
+	c.lastCleanup = now
+	for targetID, entry := range c.clients {
+		if now.Sub(entry.lastUsed) >= targetClientIdleTimeout {
+			entry.client.CloseIdleConnections()
+			delete(c.clients, targetID)
+		}
+	}
+}
+
+func targetClientKey(auth *domain.TargetAuth) (string, error) {
+	value, err := json.Marshal(auth)
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "EXEC-j0c6bw2qmz", "Errors.Internal")
+	}
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func newTargetClient(auth *domain.TargetAuth) (*http.Client, error) {
+	switch auth.Type {
+	case domain.TargetAuthTypeClientCertificate:
+		certificate, err := tls.X509KeyPair(auth.Certificate, auth.Key)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "EXEC-t5y1wq9zvn", "Errors.Target.InvalidAuth")
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			MinVersion:   tls.VersionTLS12,
+		}
+		return &http.Client{Transport: transport}, nil
+	case domain.TargetAuthTypeClientCredentials:
+		config := &clientcredentials.Config{
+			ClientID:     auth.ClientID,
+			ClientSecret: auth.ClientSecret,
+			TokenURL:     auth.TokenEndpoint,
+			Scopes:       auth.Scopes,
+		}
+		// the token is cached by the client and only requested again after it expired
+		return config.Client(context.Background()), nil
+	case domain.TargetAuthTypeNone, domain.TargetAuthTypeHeaders, domain.TargetAuthTypeBasic, domain.TargetAuthTypeBearer:
+		return http.DefaultClient, nil
+	default:
+		return http.DefaultClient, nil
+	}
+}
+
+// setAuthHeaders sets the static credentials of the target on the request
+func setAuthHeaders(req *http.Request, auth *domain.TargetAuth) {
+	if auth == nil {
+		return
+	}
+	switch auth.Type {
+	case domain.TargetAuthTypeHeaders:
+		for name, value := range auth.Headers {
+			req.Header.Set(name, value)
+		}
+	case domain.TargetAuthTypeBasic:
+		req.SetBasicAuth(auth.Username, auth.Password)
+	case domain.TargetAuthTypeBearer:
+		req.Header.Set("Authorization", "Bearer "+auth.Token)
+	case domain.TargetAuthTypeNone, domain.TargetAuthTypeClientCredentials, domain.TargetAuthTypeClientCertificate:
+	}
+}
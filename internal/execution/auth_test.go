@@ -0,0 +1,197 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func Test_callAuth(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "client" || clientSecret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+			http.Error(w, "invalid client", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	tests := []struct {
+		name    string
+		auth    *domain.TargetAuth
+		check   func(r *http.Request) bool
+		wantErr bool
+	}{
+		{
+			name: "no auth",
+			auth: nil,
+			check: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") == ""
+			},
+		},
+		{
+			name: "headers",
+			auth: &domain.TargetAuth{
+				Type:    domain.TargetAuthTypeHeaders,
+				Headers: map[string]string{"X-Api-Key": "key"},
+			},
+			check: func(r *http.Request) bool {
+				return r.Header.Get("X-Api-Key") == "key"
+			},
+		},
+		{
+			name: "basic",
+			auth: &domain.TargetAuth{
+				Type:     domain.TargetAuthTypeBasic,
+				Username: "user",
+				Password: "password",
+			},
+			check: func(r *http.Request) bool {
+				username, password, ok := r.BasicAuth()
+				return ok && username == "user" && password == "password"
+			},
+		},
+		{
+			name: "bearer",
+			auth: &domain.TargetAuth{
+				Type:  domain.TargetAuthTypeBearer,
+				Token: "token",
+			},
+			check: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") == "Bearer token"
+			},
+		},
+		{
+			name: "client credentials",
+			auth: &domain.TargetAuth{
+				Type:          domain.TargetAuthTypeClientCredentials,
+				TokenEndpoint: tokenServer.URL,
+				ClientID:      "client",
+				ClientSecret:  "secret",
+			},
+			check: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") == "Bearer access-token"
+			},
+		},
+		{
+			name: "client credentials, invalid client",
+			auth: &domain.TargetAuth{
+				Type:          domain.TargetAuthTypeClientCredentials,
+				TokenEndpoint: tokenServer.URL,
+				ClientID:      "client",
+				ClientSecret:  "wrong",
+			},
+			check: func(r *http.Request) bool {
+				return true
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !tt.check(r) {
+					http.Error(w, "unauthorized", http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			_, err := call(context.Background(), "target", server.URL, time.Second, []byte(`{}`), "", tt.auth)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_targetClient(t *testing.T) {
+	auth := &domain.TargetAuth{
+		Type:          domain.TargetAuthTypeClientCredentials,
+		TokenEndpoint: "https://example.com/oauth/v2/token",
+		ClientID:      "client",
+		ClientSecret:  "secret",
+	}
+	client, err := targetClient("target", auth)
+	require.NoError(t, err)
+	assert.NotSame(t, http.DefaultClient, client)
+
+	cached, err := targetClient("target", auth)
+	require.NoError(t, err)
+	assert.Same(t, client, cached, "client should be reused")
+
+	other, err := targetClient("other", auth)
+	require.NoError(t, err)
+	assert.NotSame(t, client, other, "other target should use its own client")
+
+	changed, err := targetClient("target", &domain.TargetAuth{
+		Type:          domain.TargetAuthTypeClientCredentials,
+		TokenEndpoint: "https://example.com/oauth/v2/token",
+		ClientID:      "client",
+		ClientSecret:  "rotated",
+	})
+	require.NoError(t, err)
+	assert.NotSame(t, client, changed, "changed credentials should use a new client")
+
+	static, err := targetClient("static", &domain.TargetAuth{Type: domain.TargetAuthTypeBearer, Token: "token"})
+	require.NoError(t, err)
+	assert.Same(t, http.DefaultClient, static)
+
+	_, err = targetClient("invalid", &domain.TargetAuth{
+		Type:        domain.TargetAuthTypeClientCertificate,
+		Certificate: []byte("invalid"),
+		Key:         []byte("invalid"),
+	})
+	assert.Error(t, err)
+}
+
+func Test_targetClientCache(t *testing.T) {
+	auth := &domain.TargetAuth{
+		Type:          domain.TargetAuthTypeClientCredentials,
+		TokenEndpoint: "https://example.com/oauth/v2/token",
+		ClientID:      "client",
+		ClientSecret:  "secret",
+	}
+	rotated := &domain.TargetAuth{
+		Type:          domain.TargetAuthTypeClientCredentials,
+		TokenEndpoint: "https://example.com/oauth/v2/token",
+		ClientID:      "client",
+		ClientSecret:  "rotated",
+	}
+	now := time.Now()
+	cache := &targetClientCache{clients: make(map[string]*targetClientEntry), lastCleanup: now}
+
+	_, err := cache.get("target", auth, now)
+	require.NoError(t, err)
+	_, err = cache.get("target", rotated, now)
+	require.NoError(t, err)
+	assert.Len(t, cache.clients, 1, "changed credentials should replace the client")
+
+	_, err = cache.get("removed", auth, now)
+	require.NoError(t, err)
+	assert.Len(t, cache.clients, 2)
+
+	_, err = cache.get("target", rotated, now.Add(targetClientIdleTimeout/2))
+	require.NoError(t, err)
+	_, err = cache.get("target", rotated, now.Add(targetClientIdleTimeout))
+	require.NoError(t, err)
+	assert.Len(t, cache.clients, 1, "idle client should be removed")
+	assert.Contains(t, cache.clients, "target")
+}
@@ -0,0 +1,61 @@
+package execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var targetCallLogs *logstore.Service[*record.TargetCallLog]
+
+// SetLogstoreService sets the service which stores and limits the calls to targets
+func SetLogstoreService(svc *logstore.Service[*record.TargetCallLog]) {
+	targetCallLogs = svc
+}
+
+// callHTTP calls the endpoint of the target, the call is limited by the quota of the instance and logged with its result
+func callHTTP(ctx context.Context, target Target, body []byte) (statusCode int, resp []byte, err error) {
+	if err := checkTargetCallQuota(ctx); err != nil {
+		return 0, nil, err
+	}
+	start := time.Now()
+	statusCode, resp, err = callWithStatus(ctx, target.GetTargetID(), target.GetEndpoint(), target.GetTimeout(), body, target.GetSigningKey(), target.GetAuth())
+	logTargetCall(ctx, target, start, statusCode, err)
+	return statusCode, resp, err
+}
+
+// checkTargetCallQuota returns an error if the quota for target calls of the instance is exhausted
+func checkTargetCallQuota(ctx context.Context) error {
+	if targetCallLogs == nil {
+		return nil
+	}
+	remaining := targetCallLogs.Limit(ctx, authz.GetInstance(ctx).InstanceID())
+	if remaining != nil && *remaining == 0 {
+		return zerrors.ThrowResourceExhausted(nil, "EXEC-x4d8rn2kpq", "Errors.Quota.TargetCalls.Exhausted")
+	}
+	return nil
+}
+
+func logTargetCall(ctx context.Context, target Target, start time.Time, statusCode int, err error) {
+	if targetCallLogs == nil || !targetCallLogs.Enabled() {
+		return
+	}
+	r := &record.TargetCallLog{
+		LogDate:    start,
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+		TargetID:   target.GetTargetID(),
+		StatusCode: statusCode,
+		Took:       time.Since(start),
+	}
+	if getter, ok := target.(executionIDGetter); ok {
+		r.ExecutionID = getter.GetExecutionID()
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	targetCallLogs.Handle(ctx, r)
+}
@@ -0,0 +1,115 @@
+package execution
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var _ logstore.UsageStorer[*record.TargetCallLog] = (*mockTargetCallStorage)(nil)
+
+type mockTargetCallStorage struct {
+	remaining *uint64
+	emitted   []*record.TargetCallLog
+}
+
+func (m *mockTargetCallStorage) QuotaUnit() quota.Unit {
+	return quota.ActionsTargetCalls
+}
+
+func (m *mockTargetCallStorage) Emit(_ context.Context, bulk []*record.TargetCallLog) error {
+	m.emitted = append(m.emitted, bulk...)
+	return nil
+}
+
+func (m *mockTargetCallStorage) GetRemainingQuotaUsage(context.Context, string, quota.Unit) (*uint64, error) {
+	return m.remaining, nil
+}
+
+func Test_callHTTP(t *testing.T) {
+	var called int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+		http.Error(w, "error", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	exhausted := uint64(0)
+	available := uint64(10)
+	tests := []struct {
+		name       string
+		remaining  *uint64
+		wantCalled int
+		wantLog    *record.TargetCallLog
+		wantErr    func(error) bool
+	}{
+		{
+			name:      "quota exhausted, not called",
+			remaining: &exhausted,
+			wantErr:   zerrors.IsResourceExhausted,
+		},
+		{
+			name:       "no quota, called and logged",
+			wantCalled: 1,
+			wantLog: &record.TargetCallLog{
+				InstanceID: "instance",
+				TargetID:   "target",
+				StatusCode: http.StatusForbidden,
+				Error:      "ID=EXEC-dra6yamk98 Message=Errors.Execution.Failed",
+			},
+			wantErr: zerrors.IsUnknown,
+		},
+		{
+			name:       "quota available, called and logged",
+			remaining:  &available,
+			wantCalled: 1,
+			wantLog: &record.TargetCallLog{
+				InstanceID: "instance",
+				TargetID:   "target",
+				StatusCode: http.StatusForbidden,
+				Error:      "ID=EXEC-dra6yamk98 Message=Errors.Execution.Failed",
+			},
+			wantErr: zerrors.IsUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = 0
+			ctx := authz.WithInstanceID(context.Background(), "instance")
+			storage := &mockTargetCallStorage{remaining: tt.remaining}
+			dbEmitter, err := logstore.NewEmitter[*record.TargetCallLog](ctx, clock.NewMock(), &logstore.EmitterConfig{Enabled: true}, storage)
+			require.NoError(t, err)
+			SetLogstoreService(logstore.New(storage, dbEmitter))
+			defer SetLogstoreService(nil)
+
+			target := &mockTarget{TargetID: "target", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL, Timeout: time.Second}
+			_, _, err = callHTTP(ctx, target, []byte("{}"))
+			assert.True(t, tt.wantErr(err))
+			assert.Equal(t, tt.wantCalled, called)
+			if tt.wantLog == nil {
+				assert.Empty(t, storage.emitted)
+				return
+			}
+			require.Len(t, storage.emitted, 1)
+			emitted := storage.emitted[0]
+			assert.Equal(t, tt.wantLog.InstanceID, emitted.InstanceID)
+			assert.Equal(t, tt.wantLog.TargetID, emitted.TargetID)
+			assert.Equal(t, tt.wantLog.StatusCode, emitted.StatusCode)
+			assert.Equal(t, tt.wantLog.Error, emitted.Error)
+			assert.NotZero(t, emitted.Took)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package execution
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+type conditionGetter interface {
+	GetCondition() *domain.ExecutionTargetCondition
+}
+
+type executionModeGetter interface {
+	GetExecutionMode() domain.ExecutionMode
+}
+
+// executionGroup are the targets of a single execution, which are called with the mode of the execution
+type executionGroup struct {
+	mode    domain.ExecutionMode
+	targets []Target
+}
+
+// executionGroups groups the targets by their execution in the order of the first target of each execution,
+// as the targets of multiple executions with different modes can be called at once.
+// The order of the targets of an execution is kept, targets without execution belong to the same group.
+func executionGroups(targets []Target) []*executionGroup {
+	groups := make([]*executionGroup, 0, 1)
+	byExecution := make(map[string]*executionGroup, 1)
+	for _, target := range targets {
+		var executionID string
+		if getter, ok := target.(executionIDGetter); ok {
+			executionID = getter.GetExecutionID()
+		}
+		group, ok := byExecution[executionID]
+		if !ok {
+			group = &executionGroup{mode: targetExecutionMode(target)}
+			byExecution[executionID] = group
+			groups = append(groups, group)
+		}
+		group.targets = append(group.targets, target)
+	}
+	return groups
+}
+
+// targetExecutionMode returns the mode of the execution the target belongs to
+func targetExecutionMode(target Target) domain.ExecutionMode {
+	if getter, ok := target.(executionModeGetter); ok {
+		return getter.GetExecutionMode()
+	}
+	return domain.ExecutionModeSequential
+}
+
+// targetConditionMatches returns if the target should be called with the body,
+// targets without condition are always called
+func targetConditionMatches(target Target, body []byte) bool {
+	getter, ok := target.(conditionGetter)
+	if !ok || getter.GetCondition() == nil {
+		return true
+	}
+	return conditionMatches(getter.GetCondition(), body)
+}
+
+// conditionMatches evaluates the condition on the JSON body,
+// invalid conditions and bodies never match
+func conditionMatches(condition *domain.ExecutionTargetCondition, body []byte) bool {
+	segments, err := condition.PathSegments()
+	if err != nil {
+		return false
+	}
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return false
+	}
+	value, exists := valueOfPath(data, segments)
+	switch condition.Operator {
+	case domain.ExecutionTargetConditionOperatorExists:
+		return exists
+	case domain.ExecutionTargetConditionOperatorNotExists:
+		return !exists
+	case domain.ExecutionTargetConditionOperatorEquals:
+		return exists && valueToString(value) == condition.Value
+	case domain.ExecutionTargetConditionOperatorNotEquals:
+		return !exists || valueToString(value) != condition.Value
+	case domain.ExecutionTargetConditionOperatorUnspecified:
+		return false
+	default:
+		return false
+	}
+}
+
+// valueOfPath returns the value of the unmarshalled JSON data at the path,
+// a null value is handled as not existing
+func valueOfPath(data interface{}, segments []string) (interface{}, bool) {
+	for _, segment := range segments {
+		switch current := data.(type) {
+		case map[string]interface{}:
+			data = current[segment]
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+			data = current[index]
+		default:
+			return nil, false
+		}
+	}
+	return data, data != nil
+}
+
+// valueToString returns strings unquoted and all other values in their JSON representation
+func valueToString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
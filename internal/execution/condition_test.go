@@ -0,0 +1,83 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func Test_conditionMatches(t *testing.T) {
+	body := []byte(`{"request":{"organization":{"orgId":"org"},"emails":[{"email":"a@example.com"}],"isVerified":true,"empty":null}}`)
+	tests := []struct {
+		name      string
+		condition *domain.ExecutionTargetCondition
+		want      bool
+	}{
+		{
+			name:      "invalid path, no match",
+			condition: &domain.ExecutionTargetCondition{Path: "request", Operator: domain.ExecutionTargetConditionOperatorExists},
+		},
+		{
+			name:      "unspecified operator, no match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request"},
+		},
+		{
+			name:      "equals, match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.organization.orgId", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "org"},
+			want:      true,
+		},
+		{
+			name:      "equals other value, no match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.organization.orgId", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "other"},
+		},
+		{
+			name:      "equals missing, no match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.organization.name", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "org"},
+		},
+		{
+			name:      "equals index, match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.emails[0].email", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "a@example.com"},
+			want:      true,
+		},
+		{
+			name:      "equals bool, match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.isVerified", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "true"},
+			want:      true,
+		},
+		{
+			name:      "not equals, match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.organization.orgId", Operator: domain.ExecutionTargetConditionOperatorNotEquals, Value: "other"},
+			want:      true,
+		},
+		{
+			name:      "not equals missing, match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.organization.name", Operator: domain.ExecutionTargetConditionOperatorNotEquals, Value: "org"},
+			want:      true,
+		},
+		{
+			name:      "exists, match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.organization", Operator: domain.ExecutionTargetConditionOperatorExists},
+			want:      true,
+		},
+		{
+			name:      "exists index out of range, no match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.emails[1]", Operator: domain.ExecutionTargetConditionOperatorExists},
+		},
+		{
+			name:      "exists null, no match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.request.empty", Operator: domain.ExecutionTargetConditionOperatorExists},
+		},
+		{
+			name:      "not exists, match",
+			condition: &domain.ExecutionTargetCondition{Path: "$.response", Operator: domain.ExecutionTargetConditionOperatorNotExists},
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, conditionMatches(tt.condition, body))
+		})
+	}
+}
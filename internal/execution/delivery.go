@@ -0,0 +1,268 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/delivery"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// DefaultDeliveryPollInterval is used if no [DeliveryConfig.PollInterval] is set
+const DefaultDeliveryPollInterval = 10 * time.Second
+
+type DeliveryConfig struct {
+	// PollInterval is the interval in which the due deliveries are queried,
+	// [DefaultDeliveryPollInterval] is used if it's not set
+	PollInterval time.Duration
+	// BatchSize is the maximum amount of deliveries attempted per poll
+	BatchSize uint16
+	// MaxAttempts is the amount of attempts after which a delivery is marked as failed
+	MaxAttempts uint32
+	// InitialBackoff is the duration until the first retry, the duration is doubled for every further retry
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum duration between two attempts
+	MaxBackoff time.Duration
+	// TimeoutBackoffFactor bounds the duration between two attempts by the timeout of the target multiplied by the factor,
+	// so targets with a short timeout are retried sooner, 0 disables the bound
+	TimeoutBackoffFactor uint32
+}
+
+// backoff returns the duration until the next attempt after the amount of failed attempts,
+// bounded by [DeliveryConfig.MaxBackoff] and the timeout of the target
+func (c *DeliveryConfig) backoff(failures uint32, timeout time.Duration) time.Duration {
+	maxBackoff := c.MaxBackoff
+	if bound := timeout * time.Duration(c.TimeoutBackoffFactor); bound > 0 && (maxBackoff == 0 || bound < maxBackoff) {
+		maxBackoff = bound
+	}
+	backoff := c.InitialBackoff
+	for i := uint32(1); i < failures && (maxBackoff == 0 || backoff < maxBackoff); i++ {
+		backoff *= 2
+	}
+	if maxBackoff > 0 && backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+type DeliveryCommands interface {
+	AddTargetDeliveries(ctx context.Context, resourceOwner string, deliveries []*domain.TargetDelivery) ([]string, error)
+	StartTargetDeliveryAttempt(ctx context.Context, id, resourceOwner string, staleAfter time.Duration) (uint32, error)
+	SucceedTargetDelivery(ctx context.Context, id, resourceOwner string, attempt uint32) (*domain.ObjectDetails, error)
+	FailTargetDeliveryAttempt(ctx context.Context, id, resourceOwner string, attempt uint32, reason error, nextAttempt time.Time) (*domain.ObjectDetails, error)
+	FailTargetDelivery(ctx context.Context, id, resourceOwner string, attempt uint32, reason error) (*domain.ObjectDetails, error)
+}
+
+// Deliverer delivers the body of deliveries which are not calls to targets,
+// e.g. the logout tokens of the back-channel logout.
+type Deliverer interface {
+	// Deliver sends the body to the receiver, the delivery is retried if an error is returned
+	Deliver(ctx context.Context, receiverID string, body []byte) error
+	// Timeout is the maximum duration of a single delivery
+	Timeout() time.Duration
+}
+
+var deliverers = make(map[string]Deliverer)
+
+// RegisterDeliverer sets the deliverer of the deliveries with the name,
+// it must be registered before the deliveries are started
+func RegisterDeliverer(name string, deliverer Deliverer) {
+	deliverers[name] = deliverer
+}
+
+type DeliveryQueries interface {
+	DueTargetDeliveries(ctx context.Context, now, staleBefore time.Time, limit uint16) ([]*query.DueTargetDelivery, error)
+}
+
+// deliveryWorker persists the calls to async targets and delivers them with retries
+type deliveryWorker struct {
+	config   *DeliveryConfig
+	commands DeliveryCommands
+	queries  DeliveryQueries
+}
+
+// deliveries is set on [Register] and delivers the calls of async targets
+var deliveries *deliveryWorker
+
+func newDeliveryWorker(config *DeliveryConfig, commands DeliveryCommands, queries DeliveryQueries) *deliveryWorker {
+	workerConfig := *config
+	if workerConfig.PollInterval <= 0 {
+		workerConfig.PollInterval = DefaultDeliveryPollInterval
+	}
+	return &deliveryWorker{
+		config:   &workerConfig,
+		commands: commands,
+		queries:  queries,
+	}
+}
+
+type targetDelivery struct {
+	id           string
+	instanceID   string
+	body         []byte
+	failureCount uint32
+	// target is nil if the target does not exist anymore
+	target Target
+	// deliverer is set instead of the target for deliveries of a registered [Deliverer]
+	deliverer  Deliverer
+	receiverID string
+}
+
+func (d *targetDelivery) exists() bool {
+	return d.target != nil || d.deliverer != nil
+}
+
+func (d *targetDelivery) timeout() time.Duration {
+	switch {
+	case d.deliverer != nil:
+		return d.deliverer.Timeout()
+	case d.target != nil:
+		return d.target.GetTimeout()
+	}
+	return 0
+}
+
+func (d *targetDelivery) call(ctx context.Context) error {
+	if d.deliverer != nil {
+		return d.deliverer.Deliver(ctx, d.receiverID, d.body)
+	}
+	return deliveryCall(ctx, d.target, d.body)
+}
+
+type executionIDGetter interface {
+	GetExecutionID() string
+}
+
+// enqueue persists the calls to the targets at once and directly starts the first attempts in the background
+func (w *deliveryWorker) enqueue(ctx context.Context, targets []Target, body []byte) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	cmds := make([]*domain.TargetDelivery, len(targets))
+	for i, target := range targets {
+		cmds[i] = &domain.TargetDelivery{
+			TargetID: target.GetTargetID(),
+			Body:     body,
+		}
+		if getter, ok := target.(executionIDGetter); ok {
+			cmds[i].ExecutionID = getter.GetExecutionID()
+		}
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	ids, err := w.commands.AddTargetDeliveries(ctx, instanceID, cmds)
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		go w.deliver(&targetDelivery{
+			id:         id,
+			instanceID: instanceID,
+			body:       body,
+			target:     targets[i],
+		})
+	}
+	return nil
+}
+
+// Start polls the due deliveries of all instances until the context is done
+func (w *deliveryWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.config.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.deliverDue(ctx)
+			}
+		}
+	}()
+}
+
+func (w *deliveryWorker) deliverDue(ctx context.Context) {
+	now := time.Now()
+	due, err := w.queries.DueTargetDeliveries(ctx, now, now.Add(-w.config.PollInterval), w.config.BatchSize)
+	if err != nil {
+		logging.WithError(err).Warn("unable to query due deliveries")
+		return
+	}
+	var wg sync.WaitGroup
+	for _, d := range due {
+		wg.Add(1)
+		go func(d *targetDelivery) {
+			defer wg.Done()
+			w.deliver(d)
+		}(targetDeliveryFromQuery(d))
+	}
+	wg.Wait()
+}
+
+func targetDeliveryFromQuery(d *query.DueTargetDelivery) *targetDelivery {
+	td := &targetDelivery{
+		id:           d.ID,
+		instanceID:   d.InstanceID,
+		body:         d.Body,
+		failureCount: d.FailureCount,
+	}
+	if d.Deliverer != "" {
+		td.deliverer = deliverers[d.Deliverer]
+		td.receiverID = d.ReceiverID
+		return td
+	}
+	// the target is only set if it exists, as a nil pointer in the interface would not be nil
+	if d.Target != nil {
+		td.target = d.Target
+	}
+	return td
+}
+
+// deliver starts the next attempt of the delivery, calls the target and records the result.
+// If the attempt is already started by another worker or the delivery is not due, nothing is done.
+func (w *deliveryWorker) deliver(d *targetDelivery) {
+	ctx := HandlerContext(delivery.NewAggregate(d.id, d.instanceID))
+	staleAfter := w.config.PollInterval + d.timeout()
+	attempt, err := w.commands.StartTargetDeliveryAttempt(ctx, d.id, d.instanceID, staleAfter)
+	if err != nil {
+		logging.WithFields("delivery", d.id).WithError(err).Debug("delivery not attempted")
+		return
+	}
+	if !d.exists() {
+		_, err = w.commands.FailTargetDelivery(ctx, d.id, d.instanceID, attempt, zerrors.ThrowNotFound(nil, "EXEC-3deav9xyzv", "Errors.Target.NotFound"))
+		logging.WithFields("delivery", d.id).OnError(err).Warn("unable to fail delivery")
+		return
+	}
+
+	callErr := d.call(ctx)
+	if callErr == nil {
+		_, err = w.commands.SucceedTargetDelivery(ctx, d.id, d.instanceID, attempt)
+		logging.WithFields("delivery", d.id).OnError(err).Warn("unable to succeed delivery")
+		return
+	}
+	logging.WithFields("delivery", d.id, "attempt", attempt).WithError(callErr).Info("delivery attempt failed")
+
+	failures := d.failureCount + 1
+	if failures >= w.config.MaxAttempts {
+		_, err = w.commands.FailTargetDelivery(ctx, d.id, d.instanceID, attempt, callErr)
+		logging.WithFields("delivery", d.id).OnError(err).Warn("unable to fail delivery")
+		return
+	}
+	_, err = w.commands.FailTargetDeliveryAttempt(ctx, d.id, d.instanceID, attempt, callErr, time.Now().Add(w.config.backoff(failures, d.timeout())))
+	logging.WithFields("delivery", d.id).OnError(err).Warn("unable to fail delivery attempt")
+}
+
+// deliveryCall calls the target with the persisted body, the response is ignored
+func deliveryCall(ctx context.Context, target Target, body []byte) error {
+	if target.GetTargetType() == domain.TargetTypeGRPC {
+		_, err := callGRPC(ctx, target, body)
+		return err
+	}
+	_, _, err := callHTTP(ctx, target, body)
+	return err
+}
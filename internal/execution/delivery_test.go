@@ -0,0 +1,260 @@
+package execution
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestDeliveryConfig_backoff(t *testing.T) {
+	config := &DeliveryConfig{
+		InitialBackoff:       time.Second,
+		MaxBackoff:           10 * time.Second,
+		TimeoutBackoffFactor: 2,
+	}
+	tests := []struct {
+		name     string
+		failures uint32
+		timeout  time.Duration
+		want     time.Duration
+	}{
+		{"first failure", 1, 10 * time.Second, time.Second},
+		{"second failure", 2, 10 * time.Second, 2 * time.Second},
+		{"third failure", 3, 10 * time.Second, 4 * time.Second},
+		{"fourth failure", 4, 10 * time.Second, 8 * time.Second},
+		{"limited by max backoff", 5, 10 * time.Second, 10 * time.Second},
+		{"many failures", 100, 10 * time.Second, 10 * time.Second},
+		{"limited by timeout", 4, 2 * time.Second, 4 * time.Second},
+		{"many failures limited by timeout", 100, 2 * time.Second, 4 * time.Second},
+		{"no timeout", 5, 0, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, config.backoff(tt.failures, tt.timeout))
+		})
+	}
+}
+
+type mockDeliveryCommands struct {
+	added       []*domain.TargetDelivery
+	startErr    error
+	succeeded   bool
+	failed      bool
+	failReason  error
+	nextAttempt time.Time
+}
+
+func (m *mockDeliveryCommands) AddTargetDeliveries(_ context.Context, _ string, deliveries []*domain.TargetDelivery) ([]string, error) {
+	m.added = append(m.added, deliveries...)
+	ids := make([]string, len(deliveries))
+	for i := range deliveries {
+		ids[i] = "id"
+	}
+	return ids, nil
+}
+
+func (m *mockDeliveryCommands) StartTargetDeliveryAttempt(context.Context, string, string, time.Duration) (uint32, error) {
+	if m.startErr != nil {
+		return 0, m.startErr
+	}
+	return 1, nil
+}
+
+func (m *mockDeliveryCommands) SucceedTargetDelivery(context.Context, string, string, uint32) (*domain.ObjectDetails, error) {
+	m.succeeded = true
+	return &domain.ObjectDetails{}, nil
+}
+
+func (m *mockDeliveryCommands) FailTargetDeliveryAttempt(_ context.Context, _, _ string, _ uint32, reason error, nextAttempt time.Time) (*domain.ObjectDetails, error) {
+	m.failReason = reason
+	m.nextAttempt = nextAttempt
+	return &domain.ObjectDetails{}, nil
+}
+
+func (m *mockDeliveryCommands) FailTargetDelivery(_ context.Context, _, _ string, _ uint32, reason error) (*domain.ObjectDetails, error) {
+	m.failed = true
+	m.failReason = reason
+	return &domain.ObjectDetails{}, nil
+}
+
+func Test_deliveryWorker_deliver(t *testing.T) {
+	type args struct {
+		startErr     error
+		statusCode   int
+		failureCount uint32
+		noTarget     bool
+		deliverer    bool
+	}
+	type want struct {
+		called      bool
+		succeeded   bool
+		failed      bool
+		nextAttempt bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"attempted by other worker",
+			args{
+				startErr:   zerrors.ThrowAlreadyExists(nil, "id", "Errors.Target.Delivery.AlreadyAttempted"),
+				statusCode: http.StatusOK,
+			},
+			want{},
+		},
+		{
+			"target removed",
+			args{
+				noTarget: true,
+			},
+			want{
+				failed: true,
+			},
+		},
+		{
+			"call ok",
+			args{
+				statusCode: http.StatusOK,
+			},
+			want{
+				called:    true,
+				succeeded: true,
+			},
+		},
+		{
+			"call failed, retry",
+			args{
+				statusCode:   http.StatusInternalServerError,
+				failureCount: 1,
+			},
+			want{
+				called:      true,
+				nextAttempt: true,
+			},
+		},
+		{
+			"deliverer ok",
+			args{
+				statusCode: http.StatusOK,
+				noTarget:   true,
+				deliverer:  true,
+			},
+			want{
+				called:    true,
+				succeeded: true,
+			},
+		},
+		{
+			"deliverer failed, retry",
+			args{
+				statusCode:   http.StatusInternalServerError,
+				failureCount: 1,
+				noTarget:     true,
+				deliverer:    true,
+			},
+			want{
+				called:      true,
+				nextAttempt: true,
+			},
+		},
+		{
+			"call failed, last attempt",
+			args{
+				statusCode:   http.StatusInternalServerError,
+				failureCount: 2,
+			},
+			want{
+				called: true,
+				failed: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(tt.args.statusCode)
+			}))
+			defer server.Close()
+
+			commands := &mockDeliveryCommands{startErr: tt.args.startErr}
+			w := newDeliveryWorker(&DeliveryConfig{
+				PollInterval:   time.Second,
+				MaxAttempts:    3,
+				InitialBackoff: time.Minute,
+				MaxBackoff:     time.Hour,
+			}, commands, nil)
+			d := &targetDelivery{
+				id:           "id",
+				instanceID:   "instance",
+				body:         []byte(`{"key":"value"}`),
+				failureCount: tt.args.failureCount,
+			}
+			if !tt.args.noTarget {
+				d.target = &mockTarget{
+					TargetID:   "target",
+					TargetType: domain.TargetTypeAsync,
+					Endpoint:   server.URL,
+					Timeout:    time.Second,
+				}
+			}
+			if tt.args.deliverer {
+				d.receiverID = "receiver"
+				d.deliverer = &mockDeliverer{
+					deliver: func(ctx context.Context, receiverID string, body []byte) error {
+						called = true
+						assert.Equal(t, "receiver", receiverID)
+						assert.Equal(t, d.body, body)
+						if tt.args.statusCode != http.StatusOK {
+							return zerrors.ThrowUnavailable(nil, "id", "Errors.Internal")
+						}
+						return nil
+					},
+				}
+			}
+			w.deliver(d)
+
+			assert.Equal(t, tt.want.called, called)
+			assert.Equal(t, tt.want.succeeded, commands.succeeded)
+			assert.Equal(t, tt.want.failed, commands.failed)
+			if tt.want.nextAttempt {
+				// the second failure is retried after twice the initial backoff
+				assert.WithinDuration(t, time.Now().Add(2*time.Minute), commands.nextAttempt, 5*time.Second)
+			} else {
+				assert.True(t, commands.nextAttempt.IsZero())
+			}
+		})
+	}
+}
+
+type mockDeliverer struct {
+	deliver func(ctx context.Context, receiverID string, body []byte) error
+}
+
+func (m *mockDeliverer) Deliver(ctx context.Context, receiverID string, body []byte) error {
+	return m.deliver(ctx, receiverID, body)
+}
+
+func (m *mockDeliverer) Timeout() time.Duration {
+	return time.Second
+}
+
+func Test_newDeliveryWorker_pollInterval(t *testing.T) {
+	config := &DeliveryConfig{}
+	w := newDeliveryWorker(config, nil, nil)
+	assert.Equal(t, DefaultDeliveryPollInterval, w.config.PollInterval)
+	assert.Zero(t, config.PollInterval)
+
+	w = newDeliveryWorker(&DeliveryConfig{PollInterval: time.Minute}, nil, nil)
+	assert.Equal(t, time.Minute, w.config.PollInterval)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/zitadel/logging"
@@ -26,9 +27,14 @@ type Target interface {
 	GetEndpoint() string
 	GetTargetType() domain.TargetType
 	GetTimeout() time.Duration
+	GetSigningKey() string
+	GetAuth() *domain.TargetAuth
 }
 
-// CallTargets call a list of targets in order with handling of error and responses
+// CallTargets call a list of targets in order with handling of error and responses.
+// The targets are grouped by their execution and each group is called with the mode of its execution,
+// targets of parallel executions are called concurrently by [callTargetsParallel].
+// Targets with a condition are only called if the condition matches the request body.
 func CallTargets(
 	ctx context.Context,
 	targets []Target,
@@ -37,27 +43,142 @@ func CallTargets(
 	ctx, span := tracing.NewSpan(ctx)
 	defer span.EndWithError(err)
 
+	if _, err = callTargets(ctx, targets, info, callTarget); err != nil {
+		return nil, err
+	}
+	return info.GetContent(), nil
+}
+
+// targetCall calls the target with the body and returns the response, which is set for the following targets.
+// The error interrupts the execution if the target is set to interrupt on error.
+type targetCall func(ctx context.Context, target Target, body []byte) ([]byte, error)
+
+// callTarget is the [targetCall] of [CallTargets]
+func callTarget(ctx context.Context, target Target, body []byte) ([]byte, error) {
+	return CallTarget(ctx, target, requestBody(body))
+}
+
+// callTargets calls the targets grouped by their execution with the mode of the execution,
+// so the targets are handled the same no matter how they are called, e.g. by [CallTargets] or [SimulateTargets].
+// If the execution is interrupted, the target which interrupted it is returned with the error.
+func callTargets(
+	ctx context.Context,
+	targets []Target,
+	info ContextInfo,
+	call targetCall,
+) (Target, error) {
+	for _, group := range executionGroups(targets) {
+		var (
+			interruptedBy Target
+			err           error
+		)
+		if group.mode == domain.ExecutionModeParallel {
+			interruptedBy, err = callTargetsParallel(ctx, group.targets, info, call)
+		} else {
+			interruptedBy, err = callTargetsSequential(ctx, group.targets, info, call)
+		}
+		if err != nil {
+			return interruptedBy, err
+		}
+	}
+	return nil, nil
+}
+
+// callTargetsSequential calls the targets in order, the response of a target is used for the following targets
+func callTargetsSequential(
+	ctx context.Context,
+	targets []Target,
+	info ContextInfo,
+	call targetCall,
+) (Target, error) {
 	for _, target := range targets {
-		// call the type of target
-		resp, err := CallTarget(ctx, target, info)
+		body := info.GetHTTPRequestBody()
+		if !targetConditionMatches(target, body) {
+			continue
+		}
+		resp, err := call(ctx, target, body)
 		// handle error if interrupt is set
 		if err != nil && target.IsInterruptOnError() {
-			return nil, err
+			return target, err
 		}
 		if len(resp) > 0 {
 			// error in unmarshalling
 			if err := info.SetHTTPResponseBody(resp); err != nil {
-				return nil, err
+				return target, err
 			}
 		}
 	}
-	return info.GetContent(), nil
+	return nil, nil
+}
+
+// maxParallelTargets is the maximum amount of concurrent calls to the targets of a parallel execution
+var maxParallelTargets uint16 = 10
+
+// callTargetsParallel calls the targets concurrently with the same body.
+// The calls are bounded by [maxParallelTargets], the error of the first target with interrupt on error is returned after all calls finished.
+// Otherwise the responses are set in the order of the targets after all calls finished,
+// so the response of a later target overwrites the response of a previous one.
+func callTargetsParallel(
+	ctx context.Context,
+	targets []Target,
+	info ContextInfo,
+	call targetCall,
+) (Target, error) {
+	body := info.GetHTTPRequestBody()
+	workers := make(chan struct{}, max(maxParallelTargets, 1))
+	errs := make([]error, len(targets))
+	responses := make([][]byte, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		if !targetConditionMatches(target, body) {
+			continue
+		}
+		workers <- struct{}{}
+		wg.Add(1)
+		go func(i int, target Target) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			resp, err := call(ctx, target, body)
+			if err != nil {
+				logging.WithFields("target", target.GetTargetID()).OnError(err).Info("call to target of parallel execution failed")
+				if target.IsInterruptOnError() {
+					errs[i] = err
+				}
+				return
+			}
+			responses[i] = resp
+		}(i, target)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return targets[i], err
+		}
+	}
+	for i, resp := range responses {
+		if len(resp) == 0 {
+			continue
+		}
+		if err := info.SetHTTPResponseBody(resp); err != nil {
+			return targets[i], err
+		}
+	}
+	return nil, nil
 }
 
 type ContextInfoRequest interface {
 	GetHTTPRequestBody() []byte
 }
 
+// requestBody is the same body for all targets
+type requestBody []byte
+
+func (b requestBody) GetHTTPRequestBody() []byte {
+	return b
+}
+
 // CallTarget call the desired type of target with handling of responses
 func CallTarget(
 	ctx context.Context,
@@ -70,30 +191,47 @@ func CallTarget(
 	switch target.GetTargetType() {
 	// get request, ignore response and return request and error for handling in list of targets
 	case domain.TargetTypeWebhook:
-		return nil, webhook(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody())
+		_, _, err = callHTTP(ctx, target, info.GetHTTPRequestBody())
+		return nil, err
 	// get request, return response and error
 	case domain.TargetTypeCall:
-		return call(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody())
+		_, res, err = callHTTP(ctx, target, info.GetHTTPRequestBody())
+		return res, err
+	// persist request to be delivered in the background, ignore response
 	case domain.TargetTypeAsync:
-		go func(target Target, info ContextInfoRequest) {
-			if _, err := call(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody()); err != nil {
-				logging.WithFields("target", target.GetTargetID()).OnError(err).Info(err)
-			}
-		}(target, info)
-		return nil, nil
+		return nil, callAsync(ctx, target, info.GetHTTPRequestBody())
+	// call the target service, return response and error
+	case domain.TargetTypeGRPC:
+		return callGRPC(ctx, target, info.GetHTTPRequestBody())
 	default:
 		return nil, zerrors.ThrowInternal(nil, "EXEC-auqnansr2m", "Errors.Execution.Unknown")
 	}
 }
 
+// callAsync persists the call to be delivered in the background with retries
+func callAsync(ctx context.Context, target Target, body []byte) error {
+	if deliveries == nil {
+		return zerrors.ThrowInternal(nil, "EXEC-D3l1v", "Errors.Internal")
+	}
+	return deliveries.enqueue(ctx, []Target{target}, body)
+}
+
 // webhook call a webhook, ignore the response but return the errror
-func webhook(ctx context.Context, url string, timeout time.Duration, body []byte) error {
-	_, err := call(ctx, url, timeout, body)
+func webhook(ctx context.Context, targetID, url string, timeout time.Duration, body []byte, signingKey string, auth *domain.TargetAuth) error {
+	_, err := call(ctx, targetID, url, timeout, body, signingKey, auth)
 	return err
 }
 
-// call function to do a post HTTP request to a desired url with timeout
-func call(ctx context.Context, url string, timeout time.Duration, body []byte) (_ []byte, err error) {
+// call function to do a post HTTP request to a desired url with timeout,
+// the body is signed with the signing key in the [SigningHeader] if a key is provided
+// and the request is authenticated with the credentials of the target if provided
+func call(ctx context.Context, targetID, url string, timeout time.Duration, body []byte, signingKey string, auth *domain.TargetAuth) ([]byte, error) {
+	_, resp, err := callWithStatus(ctx, targetID, url, timeout, body, signingKey, auth)
+	return resp, err
+}
+
+// callWithStatus is the same as [call] but additionally returns the status code of the response
+func callWithStatus(ctx context.Context, targetID, url string, timeout time.Duration, body []byte, signingKey string, auth *domain.TargetAuth) (_ int, _ []byte, err error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() {
@@ -103,20 +241,28 @@ func call(ctx context.Context, url string, timeout time.Duration, body []byte) (
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if signingKey != "" {
+		req.Header.Set(SigningHeader, ComputeSignatureHeader(time.Now(), body, signingKey))
+	}
+	setAuthHeaders(req, auth)
 
-	client := http.DefaultClient
+	client, err := targetClient(targetID, auth)
+	if err != nil {
+		return 0, nil, err
+	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
 	defer resp.Body.Close()
 
 	// Check for success between 200 and 299, redirect 300 to 399 is handled by the client, return error with statusCode >= 400
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		return io.ReadAll(resp.Body)
+		data, err := io.ReadAll(resp.Body)
+		return resp.StatusCode, data, err
 	}
-	return nil, zerrors.ThrowUnknown(nil, "EXEC-dra6yamk98", "Errors.Execution.Failed")
+	return resp.StatusCode, nil, zerrors.ThrowUnknown(nil, "EXEC-dra6yamk98", "Errors.Execution.Failed")
 }
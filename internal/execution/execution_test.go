@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -25,6 +26,10 @@ type mockTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	SigningKey       string
+	Auth             *domain.TargetAuth
+	Condition        *domain.ExecutionTargetCondition
+	Mode             domain.ExecutionMode
 }
 
 func (e *mockTarget) GetTargetID() string {
@@ -42,6 +47,18 @@ func (e *mockTarget) GetTargetType() domain.TargetType {
 func (e *mockTarget) GetTimeout() time.Duration {
 	return e.Timeout
 }
+func (e *mockTarget) GetSigningKey() string {
+	return e.SigningKey
+}
+func (e *mockTarget) GetAuth() *domain.TargetAuth {
+	return e.Auth
+}
+func (e *mockTarget) GetCondition() *domain.ExecutionTargetCondition {
+	return e.Condition
+}
+func (e *mockTarget) GetExecutionMode() domain.ExecutionMode {
+	return e.Mode
+}
 
 func Test_Call(t *testing.T) {
 	type args struct {
@@ -131,7 +148,7 @@ func Test_Call(t *testing.T) {
 
 func testCall(ctx context.Context, timeout time.Duration, body []byte) func(string) ([]byte, error) {
 	return func(url string) ([]byte, error) {
-		return call(ctx, url, timeout, body)
+		return call(ctx, "target", url, timeout, body, "", nil)
 	}
 }
 
@@ -345,3 +362,122 @@ func Test_CallTarget(t *testing.T) {
 		})
 	}
 }
+
+func Test_CallTargets(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		called []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		called = append(called, r.URL.Path)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/call":
+			_, _ = io.WriteString(w, `{"request":"changed"}`)
+		case "/webhook":
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "error", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	matching := &domain.ExecutionTargetCondition{Path: "$.request.request", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "content"}
+	notMatching := &domain.ExecutionTargetCondition{Path: "$.request.request", Operator: domain.ExecutionTargetConditionOperatorNotEquals, Value: "content"}
+
+	type res struct {
+		called  []string
+		content interface{}
+		wantErr bool
+	}
+	tests := []struct {
+		name    string
+		targets []Target
+		res     res
+	}{
+		{
+			"sequential, condition not matching, not called",
+			[]Target{
+				&mockTarget{TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second, Condition: notMatching},
+				&mockTarget{TargetID: "webhook", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/webhook", Timeout: time.Second, Condition: matching},
+			},
+			res{
+				called:  []string{"/webhook"},
+				content: &request{Request: "content"},
+			},
+		},
+		{
+			"sequential, response used by next condition",
+			[]Target{
+				&mockTarget{TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second, Condition: matching},
+				&mockTarget{TargetID: "webhook", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/webhook", Timeout: time.Second, Condition: matching},
+			},
+			res{
+				called:  []string{"/call"},
+				content: &request{Request: "changed"},
+			},
+		},
+		{
+			"parallel, responses set after all calls",
+			[]Target{
+				&mockTarget{TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second, Mode: domain.ExecutionModeParallel},
+				&mockTarget{TargetID: "webhook", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/webhook", Timeout: time.Second, Mode: domain.ExecutionModeParallel, Condition: matching},
+				&mockTarget{TargetID: "skipped", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/skipped", Timeout: time.Second, Mode: domain.ExecutionModeParallel, Condition: notMatching},
+			},
+			res{
+				called:  []string{"/call", "/webhook"},
+				content: &request{Request: "changed"},
+			},
+		},
+		{
+			"mixed executions, mode of each execution applied",
+			[]Target{
+				&mockTarget{ExecutionID: "request/sequential", TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second, Mode: domain.ExecutionModeSequential, Condition: matching},
+				&mockTarget{ExecutionID: "request/parallel", TargetID: "webhook", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/webhook", Timeout: time.Second, Mode: domain.ExecutionModeParallel, Condition: notMatching},
+				&mockTarget{ExecutionID: "request/sequential", TargetID: "skipped", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/skipped", Timeout: time.Second, Mode: domain.ExecutionModeSequential, Condition: matching},
+			},
+			res{
+				called:  []string{"/call", "/webhook"},
+				content: &request{Request: "changed"},
+			},
+		},
+		{
+			"parallel, all called and error returned",
+			[]Target{
+				&mockTarget{TargetID: "error", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/error", Timeout: time.Second, Mode: domain.ExecutionModeParallel, InterruptOnError: true},
+				&mockTarget{TargetID: "webhook", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/webhook", Timeout: time.Second, Mode: domain.ExecutionModeParallel},
+			},
+			res{
+				called:  []string{"/error", "/webhook"},
+				wantErr: true,
+			},
+		},
+		{
+			"parallel, error without interrupt ignored",
+			[]Target{
+				&mockTarget{TargetID: "error", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/error", Timeout: time.Second, Mode: domain.ExecutionModeParallel},
+				&mockTarget{TargetID: "webhook", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/webhook", Timeout: time.Second, Mode: domain.ExecutionModeParallel},
+			},
+			res{
+				called:  []string{"/error", "/webhook"},
+				content: &request{Request: "content"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = nil
+			content, err := CallTargets(context.Background(), tt.targets, &mockContextInfo{Request: &request{Request: "content"}})
+			if tt.res.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.ElementsMatch(t, tt.res.called, called)
+			if tt.res.content != nil {
+				assert.Equal(t, tt.res.content, content)
+			}
+		})
+	}
+}
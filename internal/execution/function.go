@@ -0,0 +1,41 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type FunctionQueries interface {
+	TargetsByExecutionID(ctx context.Context, ids []string) (execution []*query.ExecutionTarget, err error)
+}
+
+// FunctionID returns the ID of the execution for the function of the trigger type in the flow
+func FunctionID(flowType domain.FlowType, triggerType domain.TriggerType) string {
+	return exec_repo.ID(domain.ExecutionTypeFunction, domain.ActionFunction(flowType, triggerType))
+}
+
+// QueryExecutionTargetsForFunction queries the targets of the execution for the function,
+// if the actions feature is not enabled no targets are returned.
+func QueryExecutionTargetsForFunction(ctx context.Context, queries FunctionQueries, function string) (_ []Target, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer span.EndWithError(err)
+
+	if !authz.GetInstance(ctx).Features().Actions {
+		return nil, nil
+	}
+
+	queriedTargets, err := queries.TargetsByExecutionID(ctx, []string{function})
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]Target, len(queriedTargets))
+	for i, target := range queriedTargets {
+		targets[i] = target
+	}
+	return targets, nil
+}
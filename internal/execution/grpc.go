@@ -0,0 +1,270 @@
+package execution
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// GRPCInvoker calls the target service of targets with the type [domain.TargetTypeGRPC].
+// The body is the same JSON as sent to the HTTP targets, the returned response is handled the same as the response of HTTP targets.
+type GRPCInvoker interface {
+	Invoke(ctx context.Context, conn grpc.ClientConnInterface, executionType domain.ExecutionType, body []byte) ([]byte, error)
+}
+
+var grpcInvoker GRPCInvoker
+
+// RegisterGRPCInvoker sets the invoker used to call targets with the type [domain.TargetTypeGRPC]
+func RegisterGRPCInvoker(invoker GRPCInvoker) {
+	grpcInvoker = invoker
+}
+
+// callGRPC calls the target service of the target with the variant of the execution type of the target
+func callGRPC(ctx context.Context, target Target, body []byte) (_ []byte, err error) {
+	ctx, cancel := context.WithTimeout(ctx, target.GetTimeout())
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		cancel()
+		span.EndWithError(err)
+	}()
+
+	if grpcInvoker == nil {
+		return nil, zerrors.ThrowInternal(nil, "EXEC-u3ojzq8k1w", "Errors.Execution.Unknown")
+	}
+	executionType := targetExecutionType(target)
+	if executionType == domain.ExecutionTypeUnspecified {
+		return nil, zerrors.ThrowInternal(nil, "EXEC-p9sd4fvx2a", "Errors.Execution.Unknown")
+	}
+	conn, err := grpcConns.get(target, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTargetCallQuota(ctx); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := grpcInvoker.Invoke(ctx, conn, executionType, body)
+	logTargetCall(ctx, target, start, grpcStatusCode(err), err)
+	return resp, err
+}
+
+// grpcStatusCode returns the code of the gRPC status of the error, errors without status are [codes.Unknown]
+func grpcStatusCode(err error) int {
+	return int(status.Code(err))
+}
+
+func targetExecutionType(target Target) domain.ExecutionType {
+	getter, ok := target.(executionIDGetter)
+	if !ok {
+		return domain.ExecutionTypeUnspecified
+	}
+	for _, executionType := range []domain.ExecutionType{
+		domain.ExecutionTypeRequest,
+		domain.ExecutionTypeResponse,
+		domain.ExecutionTypeFunction,
+		domain.ExecutionTypeEvent,
+	} {
+		if strings.HasPrefix(getter.GetExecutionID(), exec_repo.IDAll(executionType)) {
+			return executionType
+		}
+	}
+	return domain.ExecutionTypeUnspecified
+}
+
+// grpcConns caches the connection of every gRPC target,
+// the connection is replaced if the endpoint, the signing key or the credentials of the target change
+var grpcConns = &grpcConnCache{conns: make(map[string]*grpcConn)}
+
+type grpcConnCache struct {
+	mu          sync.Mutex
+	conns       map[string]*grpcConn
+	lastCleanup time.Time
+}
+
+type grpcConn struct {
+	key      string
+	conn     *grpc.ClientConn
+	lastUsed time.Time
+}
+
+func (c *grpcConnCache) get(target Target, now time.Time) (*grpc.ClientConn, error) {
+	key, err := grpcConnKey(target)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeIdle(now)
+
+	existing, ok := c.conns[target.GetTargetID()]
+	if ok && existing.key == key {
+		existing.lastUsed = now
+		return existing.conn, nil
+	}
+	conn, err := newGRPCConn(target)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		_ = existing.conn.Close()
+	}
+	c.conns[target.GetTargetID()] = &grpcConn{key: key, conn: conn, lastUsed: now}
+	return conn, nil
+}
+
+// removeIdle closes and removes the connections which were not used for [targetClientIdleTimeout],
+// e.g. because the target was removed, the connections are checked at most once per timeout
+func (c *grpcConnCache) removeIdle(now time.Time) {
+	if now.Sub(c.lastCleanup) < targetClientIdleTimeout {
+		return
+	}
+	c.lastCleanup = now
+	for targetID, entry := range c.conns {
+		if now.Sub(entry.lastUsed) >= targetClientIdleTimeout {
+			_ = entry.conn.Close()
+			delete(c.conns, targetID)
+		}
+	}
+}
+
+func grpcConnKey(target Target) (string, error) {
+	value, err := json.Marshal(target.GetAuth())
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "EXEC-8c2rjk5wfo", "Errors.Internal")
+	}
+	hash := sha256.New()
+	hash.Write([]byte(target.GetEndpoint()))
+	hash.Write([]byte(target.GetSigningKey()))
+	hash.Write(value)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// newGRPCConn creates the connection to the endpoint of the target,
+// the scheme of the endpoint defines if TLS is used (https) or not (http)
+func newGRPCConn(target Target) (*grpc.ClientConn, error) {
+	endpoint, err := url.Parse(target.GetEndpoint())
+	if err != nil || endpoint.Host == "" {
+		return nil, zerrors.ThrowInvalidArgument(err, "EXEC-2ee1g0rk6h", "Errors.Target.InvalidURL")
+	}
+	auth := target.GetAuth()
+	opts := make([]grpc.DialOption, 0, 3)
+	switch endpoint.Scheme {
+	case "https":
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if auth != nil && auth.Type == domain.TargetAuthTypeClientCertificate {
+			certificate, err := tls.X509KeyPair(auth.Certificate, auth.Key)
+			if err != nil {
+				return nil, zerrors.ThrowInvalidArgument(err, "EXEC-0f3mzt8wqe", "Errors.Target.InvalidAuth")
+			}
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	case "http":
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "EXEC-w4ao1m6dcs", "Errors.Target.InvalidURL")
+	}
+	if creds := newGRPCAuth(auth); creds != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(creds))
+	}
+	if signingKey := target.GetSigningKey(); signingKey != "" {
+		opts = append(opts, grpc.WithUnaryInterceptor(grpcSigningInterceptor(signingKey)))
+	}
+	conn, err := grpc.NewClient(endpoint.Host, opts...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-5m0xvdh7ky", "Errors.Internal")
+	}
+	return conn, nil
+}
+
+// grpcSigningInterceptor sends the signature of the deterministic protobuf encoding of the request
+// in the metadata with the lower case name of the [SigningHeader]
+func grpcSigningInterceptor(signingKey string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if msg, ok := req.(proto.Message); ok {
+			payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+			if err != nil {
+				return err
+			}
+			ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(SigningHeader), ComputeSignatureHeader(time.Now(), payload, signingKey))
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// grpcAuth sends the credentials of the target in the metadata of every call
+type grpcAuth struct {
+	auth        *domain.TargetAuth
+	tokenSource oauth2.TokenSource
+}
+
+func newGRPCAuth(auth *domain.TargetAuth) *grpcAuth {
+	if auth == nil {
+		return nil
+	}
+	switch auth.Type {
+	case domain.TargetAuthTypeHeaders, domain.TargetAuthTypeBasic, domain.TargetAuthTypeBearer:
+		return &grpcAuth{auth: auth}
+	case domain.TargetAuthTypeClientCredentials:
+		config := &clientcredentials.Config{
+			ClientID:     auth.ClientID,
+			ClientSecret: auth.ClientSecret,
+			TokenURL:     auth.TokenEndpoint,
+			Scopes:       auth.Scopes,
+		}
+		return &grpcAuth{auth: auth, tokenSource: config.TokenSource(context.Background())}
+	case domain.TargetAuthTypeNone, domain.TargetAuthTypeClientCertificate:
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (a *grpcAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	switch a.auth.Type {
+	case domain.TargetAuthTypeHeaders:
+		md := make(map[string]string, len(a.auth.Headers))
+		for name, value := range a.auth.Headers {
+			md[strings.ToLower(name)] = value
+		}
+		return md, nil
+	case domain.TargetAuthTypeBasic:
+		return map[string]string{"authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte(a.auth.Username+":"+a.auth.Password))}, nil
+	case domain.TargetAuthTypeBearer:
+		return map[string]string{"authorization": "Bearer " + a.auth.Token}, nil
+	case domain.TargetAuthTypeClientCredentials:
+		token, err := a.tokenSource.Token()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"authorization": token.Type() + " " + token.AccessToken}, nil
+	case domain.TargetAuthTypeNone, domain.TargetAuthTypeClientCertificate:
+	}
+	return nil, nil
+}
+
+// RequireTransportSecurity allows the credentials on connections without TLS, the same as for HTTP targets
+func (a *grpcAuth) RequireTransportSecurity() bool {
+	return false
+}
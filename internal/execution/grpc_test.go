@@ -0,0 +1,211 @@
+package execution
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+type mockGRPCTarget struct {
+	mockTarget
+	ExecutionID string
+}
+
+func (e *mockGRPCTarget) GetExecutionID() string {
+	return e.ExecutionID
+}
+
+// mockGRPCInvoker sends the body as struct to a single method and returns the response as JSON
+type mockGRPCInvoker struct {
+	executionType domain.ExecutionType
+}
+
+func (m *mockGRPCInvoker) Invoke(ctx context.Context, conn grpc.ClientConnInterface, executionType domain.ExecutionType, body []byte) ([]byte, error) {
+	m.executionType = executionType
+	req := new(structpb.Struct)
+	if err := protojson.Unmarshal(body, req); err != nil {
+		return nil, err
+	}
+	resp := new(structpb.Struct)
+	if err := conn.Invoke(ctx, "/test.TargetService/Call", req, resp); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(resp)
+}
+
+// startGRPCServer starts a server which checks the signature and the authorization of all calls and responds with the request
+func startGRPCServer(t *testing.T, signingKey, authorization string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		req := new(structpb.Struct)
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		if authorization != "" && strings.Join(md.Get("authorization"), "") != authorization {
+			return status.Error(codes.Unauthenticated, "unauthenticated")
+		}
+		payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+		if err != nil {
+			return err
+		}
+		if err := ValidatePayload(payload, strings.Join(md.Get(strings.ToLower(SigningHeader)), ""), signingKey, DefaultSignatureTolerance); err != nil {
+			return status.Error(codes.PermissionDenied, "invalid signature")
+		}
+		return stream.SendMsg(req)
+	}))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return "http://" + listener.Addr().String()
+}
+
+func Test_callGRPC(t *testing.T) {
+	endpoint := startGRPCServer(t, "key", "Bearer token")
+	invoker := &mockGRPCInvoker{}
+	RegisterGRPCInvoker(invoker)
+	t.Cleanup(func() { RegisterGRPCInvoker(nil) })
+
+	tests := []struct {
+		name       string
+		target     *mockGRPCTarget
+		wantType   domain.ExecutionType
+		wantErr    bool
+		wantResult []byte
+	}{
+		{
+			name: "unknown execution type, error",
+			target: &mockGRPCTarget{
+				mockTarget: mockTarget{
+					TargetID:   "unknown",
+					TargetType: domain.TargetTypeGRPC,
+					Endpoint:   endpoint,
+					Timeout:    time.Second,
+					SigningKey: "key",
+				},
+				ExecutionID: "unknown",
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong signing key, error",
+			target: &mockGRPCTarget{
+				mockTarget: mockTarget{
+					TargetID:   "wrong key",
+					TargetType: domain.TargetTypeGRPC,
+					Endpoint:   endpoint,
+					Timeout:    time.Second,
+					SigningKey: "other",
+					Auth:       &domain.TargetAuth{Type: domain.TargetAuthTypeBearer, Token: "token"},
+				},
+				ExecutionID: "request/zitadel.session.v2.SessionService/ListSessions",
+			},
+			wantType: domain.ExecutionTypeRequest,
+			wantErr:  true,
+		},
+		{
+			name: "missing auth, error",
+			target: &mockGRPCTarget{
+				mockTarget: mockTarget{
+					TargetID:   "missing auth",
+					TargetType: domain.TargetTypeGRPC,
+					Endpoint:   endpoint,
+					Timeout:    time.Second,
+					SigningKey: "key",
+				},
+				ExecutionID: "function/preuserinfo",
+			},
+			wantType: domain.ExecutionTypeFunction,
+			wantErr:  true,
+		},
+		{
+			name: "signed and authenticated, ok",
+			target: &mockGRPCTarget{
+				mockTarget: mockTarget{
+					TargetID:   "ok",
+					TargetType: domain.TargetTypeGRPC,
+					Endpoint:   endpoint,
+					Timeout:    time.Second,
+					SigningKey: "key",
+					Auth:       &domain.TargetAuth{Type: domain.TargetAuthTypeBearer, Token: "token"},
+				},
+				ExecutionID: "event/user.human.added",
+			},
+			wantType:   domain.ExecutionTypeEvent,
+			wantResult: []byte(`{"key":"value"}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoker.executionType = domain.ExecutionTypeUnspecified
+			resp, err := callGRPC(context.Background(), tt.target, []byte(`{"key":"value"}`))
+			assert.Equal(t, tt.wantType, invoker.executionType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, string(tt.wantResult), string(resp))
+		})
+	}
+}
+
+func Test_grpcConnCache_get(t *testing.T) {
+	now := time.Now()
+	cache := &grpcConnCache{conns: make(map[string]*grpcConn), lastCleanup: now}
+	target := &mockTarget{
+		TargetID:   "target",
+		TargetType: domain.TargetTypeGRPC,
+		Endpoint:   "https://example.com:443",
+		SigningKey: "key",
+	}
+	conn, err := cache.get(target, now)
+	require.NoError(t, err)
+
+	cached, err := cache.get(target, now)
+	require.NoError(t, err)
+	assert.Same(t, conn, cached, "connection should be reused")
+
+	target.SigningKey = "rotated"
+	changed, err := cache.get(target, now)
+	require.NoError(t, err)
+	assert.NotSame(t, conn, changed, "changed signing key should use a new connection")
+
+	_, err = cache.get(&mockTarget{
+		TargetID:   "invalid",
+		TargetType: domain.TargetTypeGRPC,
+		Endpoint:   "ftp://example.com",
+	}, now)
+	assert.Error(t, err)
+
+	_, err = cache.get(&mockTarget{
+		TargetID:   "removed",
+		TargetType: domain.TargetTypeGRPC,
+		Endpoint:   "https://example.com:443",
+	}, now)
+	require.NoError(t, err)
+	assert.Len(t, cache.conns, 2)
+
+	_, err = cache.get(target, now.Add(targetClientIdleTimeout/2))
+	require.NoError(t, err)
+	_, err = cache.get(target, now.Add(targetClientIdleTimeout))
+	require.NoError(t, err)
+	assert.Len(t, cache.conns, 1, "idle connection should be removed")
+	assert.Contains(t, cache.conns, "target")
+}
@@ -0,0 +1,140 @@
+// Package grpctarget calls the [target.TargetServiceClient] of targets with the type [domain.TargetTypeGRPC].
+// The JSON bodies of the executions are converted to the typed messages of the target service and back,
+// so the calls are handled the same way as calls to REST targets.
+package grpctarget
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	target "github.com/zitadel/zitadel/pkg/grpc/action/target/v1"
+)
+
+var _ execution.GRPCInvoker = (*Invoker)(nil)
+
+type Invoker struct{}
+
+func New() *Invoker {
+	return &Invoker{}
+}
+
+// unmarshalOptions ignores fields of the body which are not part of the target service
+var unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+func (i *Invoker) Invoke(ctx context.Context, conn grpc.ClientConnInterface, executionType domain.ExecutionType, body []byte) ([]byte, error) {
+	client := target.NewTargetServiceClient(conn)
+	switch executionType {
+	case domain.ExecutionTypeRequest:
+		return callRequest(ctx, client, body)
+	case domain.ExecutionTypeResponse:
+		return callResponse(ctx, client, body)
+	case domain.ExecutionTypeFunction:
+		return callFunction(ctx, client, body)
+	case domain.ExecutionTypeEvent:
+		return handleEvent(ctx, client, body)
+	case domain.ExecutionTypeUnspecified:
+		fallthrough
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "GRPCT-q3v8n1xk0d", "Errors.Execution.Unknown")
+	}
+}
+
+func callRequest(ctx context.Context, client target.TargetServiceClient, body []byte) ([]byte, error) {
+	req := new(target.CallRequestRequest)
+	if err := unmarshalOptions.Unmarshal(body, req); err != nil {
+		return nil, zerrors.ThrowInternal(err, "GRPCT-7zt0c4yb2m", "Errors.Internal")
+	}
+	resp, err := client.CallRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Request == nil {
+		return marshal(req.GetRequest())
+	}
+	return marshal(resp.GetRequest())
+}
+
+func callResponse(ctx context.Context, client target.TargetServiceClient, body []byte) ([]byte, error) {
+	req := new(target.CallResponseRequest)
+	if err := unmarshalOptions.Unmarshal(body, req); err != nil {
+		return nil, zerrors.ThrowInternal(err, "GRPCT-d5hq9w2r6s", "Errors.Internal")
+	}
+	resp, err := client.CallResponse(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Response == nil {
+		return marshal(req.GetResponse())
+	}
+	return marshal(resp.GetResponse())
+}
+
+func callFunction(ctx context.Context, client target.TargetServiceClient, body []byte) ([]byte, error) {
+	req, err := functionRequest(body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.CallFunction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetResponse() == nil {
+		return []byte("{}"), nil
+	}
+	return marshal(resp.GetResponse())
+}
+
+// functionRequest splits the body of the function executions into the name of the function and its context
+func functionRequest(body []byte) (*target.CallFunctionRequest, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, zerrors.ThrowInternal(err, "GRPCT-1k8xv5mp3e", "Errors.Internal")
+	}
+	req := new(target.CallFunctionRequest)
+	if function, ok := fields["function"]; ok {
+		if err := json.Unmarshal(function, &req.Function); err != nil {
+			return nil, zerrors.ThrowInternal(err, "GRPCT-n6b2yf0w9c", "Errors.Internal")
+		}
+		delete(fields, "function")
+	}
+	functionContext, err := json.Marshal(fields)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "GRPCT-s0m4jz7q1v", "Errors.Internal")
+	}
+	req.Context = new(structpb.Struct)
+	if err := unmarshalOptions.Unmarshal(functionContext, req.Context); err != nil {
+		return nil, zerrors.ThrowInternal(err, "GRPCT-h2r9e5ku8t", "Errors.Internal")
+	}
+	return req, nil
+}
+
+func handleEvent(ctx context.Context, client target.TargetServiceClient, body []byte) ([]byte, error) {
+	req := new(target.HandleEventRequest)
+	if err := unmarshalOptions.Unmarshal(body, req); err != nil {
+		return nil, zerrors.ThrowInternal(err, "GRPCT-w8c1pa6n4g", "Errors.Internal")
+	}
+	if _, err := client.HandleEvent(ctx, req); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// marshal returns the JSON of the message, an unset message is returned as JSON null
+func marshal(msg proto.Message) ([]byte, error) {
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return []byte("null"), nil
+	}
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "GRPCT-b7y3tq0x5l", "Errors.Internal")
+	}
+	return data, nil
+}
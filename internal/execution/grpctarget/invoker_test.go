@@ -0,0 +1,83 @@
+package grpctarget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	target "github.com/zitadel/zitadel/pkg/grpc/action/target/v1"
+)
+
+func Test_functionRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    []byte
+		want    *target.CallFunctionRequest
+		wantErr bool
+	}{
+		{
+			name:    "invalid json, error",
+			body:    []byte("invalid"),
+			wantErr: true,
+		},
+		{
+			name: "function and context, ok",
+			body: []byte(`{"function":"function/preuserinfo","userinfo":{"sub":"user"}}`),
+			want: &target.CallFunctionRequest{
+				Function: "function/preuserinfo",
+				Context: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"userinfo": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+						"sub": structpb.NewStringValue("user"),
+					}}),
+				}},
+			},
+		},
+		{
+			name: "no function, ok",
+			body: []byte(`{}`),
+			want: &target.CallFunctionRequest{
+				Context: &structpb.Struct{Fields: map[string]*structpb.Value{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := functionRequest(tt.body)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.GetFunction(), got.GetFunction())
+			assert.Equal(t, tt.want.GetContext().AsMap(), got.GetContext().AsMap())
+		})
+	}
+}
+
+func Test_marshal(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *structpb.Struct
+		want string
+	}{
+		{
+			name: "unset, null",
+			msg:  nil,
+			want: "null",
+		},
+		{
+			name: "set, json",
+			msg:  &structpb.Struct{Fields: map[string]*structpb.Value{"key": structpb.NewStringValue("value")}},
+			want: `{"key":"value"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := marshal(tt.msg)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/delivery"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
+)
+
+const (
+	HandlerTable = "projections.execution_handler"
+	// ExecutionUserID is used as the editor in the context of the handler
+	ExecutionUserID  = "EXECUTION"
+	eventGroupSuffix = ".*"
+)
+
+type Queries interface {
+	LatestExecutionPosition(ctx context.Context) (position float64, err error)
+	EventExecutionIDs(ctx context.Context) (ids []string, err error)
+	TargetsByEventExecutionID(ctx context.Context, ids []string, createdAt time.Time) (execution []*query.ExecutionTarget, err error)
+}
+
+// targetEnqueuer persists the calls to targets, which are delivered in the background
+type targetEnqueuer interface {
+	enqueue(ctx context.Context, targets []Target, body []byte) error
+}
+
+type eventHandler struct {
+	queries    Queries
+	deliveries targetEnqueuer
+	reducers   []handler.AggregateReducer
+	// executionIDs caches the [instanceExecutionIDs] by instance id
+	executionIDs sync.Map
+}
+
+// instanceExecutionIDs are the event execution IDs of an instance
+// at the position of the latest execution event
+type instanceExecutionIDs struct {
+	position float64
+	ids      map[string]struct{}
+}
+
+var _ handler.EventTypesFilterer = (*eventHandler)(nil)
+
+// NewEventHandler creates a handler which delivers the events to the targets of the executions of type [domain.ExecutionTypeEvent].
+// All the given event types can be reduced, but only the event types which have an event execution in the instance are queried.
+// The event types are mapped to their aggregate types based on the registered event mappers.
+// The targets are not called by the handler, the calls are persisted as deliveries and delivered in the background.
+func NewEventHandler(
+	ctx context.Context,
+	config handler.Config,
+	eventTypes []string,
+	queries Queries,
+	deliveries targetEnqueuer,
+) *handler.Handler {
+	h := &eventHandler{
+		queries:    queries,
+		deliveries: deliveries,
+	}
+	h.reducers = h.aggregateReducers(eventTypes)
+	return handler.NewHandler(ctx, &config, h)
+}
+
+func (h *eventHandler) Name() string {
+	return HandlerTable
+}
+
+func (h *eventHandler) Reducers() []handler.AggregateReducer {
+	return h.reducers
+}
+
+func (h *eventHandler) aggregateReducers(eventTypes []string) []handler.AggregateReducer {
+	aggregates := make(map[eventstore.AggregateType][]handler.EventReducer)
+	for _, eventType := range eventTypes {
+		aggregateType := eventstore.AggregateTypeFromEventType(eventstore.EventType(eventType))
+		// the events of deliveries are not handled, as async targets would otherwise trigger themselves
+		if aggregateType == "" || aggregateType == delivery.AggregateType {
+			continue
+		}
+		aggregates[aggregateType] = append(aggregates[aggregateType], handler.EventReducer{
+			Event:  eventstore.EventType(eventType),
+			Reduce: h.reduce,
+		})
+	}
+	reducers := make([]handler.AggregateReducer, 0, len(aggregates))
+	for aggregateType, eventReducers := range aggregates {
+		reducers = append(reducers, handler.AggregateReducer{
+			Aggregate:     aggregateType,
+			EventReducers: eventReducers,
+		})
+	}
+	return reducers
+}
+
+// FilterEventTypes implements [handler.EventTypesFilterer],
+// only the event types for which an event execution is set in the instance are queried.
+func (h *eventHandler) FilterEventTypes(ctx context.Context, eventTypes map[eventstore.AggregateType][]eventstore.EventType) (map[eventstore.AggregateType][]eventstore.EventType, error) {
+	executionIDs, err := h.eventExecutionIDs(ctx)
+	if err != nil || len(executionIDs) == 0 {
+		return nil, err
+	}
+	filtered := make(map[eventstore.AggregateType][]eventstore.EventType)
+	for aggregateType, types := range eventTypes {
+		for _, eventType := range types {
+			if hasEventExecution(executionIDs, string(eventType)) {
+				filtered[aggregateType] = append(filtered[aggregateType], eventType)
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// eventExecutionIDs returns the event execution IDs of the instance.
+// They are cached and only queried again if an execution of the instance was set or removed since,
+// so the projections of the executions and targets are not triggered on every iteration.
+func (h *eventHandler) eventExecutionIDs(ctx context.Context) (map[string]struct{}, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	position, err := h.queries.LatestExecutionPosition(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := h.executionIDs.Load(instanceID); ok && cached.(*instanceExecutionIDs).position == position {
+		return cached.(*instanceExecutionIDs).ids, nil
+	}
+	ids, err := h.queries.EventExecutionIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	executionIDs := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		executionIDs[id] = struct{}{}
+	}
+	h.executionIDs.Store(instanceID, &instanceExecutionIDs{position: position, ids: executionIDs})
+	return executionIDs, nil
+}
+
+func hasEventExecution(executionIDs map[string]struct{}, eventType string) bool {
+	for _, id := range idsForEventType(eventType) {
+		if _, ok := executionIDs[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// reduce persists a delivery of the event for every target with a matching condition,
+// the deliveries of an event are added at once, so they are either all added or the event is reduced again.
+// The targets are resolved when the statement is executed, so no queries are made while the events are reduced.
+func (h *eventHandler) reduce(event eventstore.Event) (*handler.Statement, error) {
+	return handler.NewStatement(event, func(handler.Executer, string) error {
+		ctx := HandlerContext(event.Aggregate())
+		targets, err := h.queries.TargetsByEventExecutionID(ctx, idsForEventType(string(event.Type())), event.CreatedAt())
+		if err != nil {
+			return err
+		}
+		body := NewContextInfoEvent(event).GetHTTPRequestBody()
+		matched := make([]Target, 0, len(targets))
+		for _, target := range targets {
+			if targetConditionMatches(target, body) {
+				matched = append(matched, target)
+			}
+		}
+		if len(matched) == 0 {
+			return nil
+		}
+		return h.deliveries.enqueue(ctx, matched, body)
+	}), nil
+}
+
+func HandlerContext(event *eventstore.Aggregate) context.Context {
+	ctx := authz.WithInstanceID(context.Background(), event.InstanceID)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: ExecutionUserID, OrgID: event.ResourceOwner})
+}
+
+// idsForEventType returns the IDs of all possible event executions for an event type,
+// ordered from the most to the least specific, for example:
+// [ "event/user.human.added",
+// "event/user.human.*",
+// "event/user.*",
+// "event" ]
+func idsForEventType(eventType string) []string {
+	ids := []string{exec_repo.ID(domain.ExecutionTypeEvent, eventType)}
+	for i := strings.LastIndex(eventType, "."); i > 0; i = strings.LastIndex(eventType, ".") {
+		eventType = eventType[:i]
+		ids = append(ids, exec_repo.ID(domain.ExecutionTypeEvent, eventType+eventGroupSuffix))
+	}
+	return append(ids, exec_repo.IDAll(domain.ExecutionTypeEvent))
+}
+
+var _ ContextInfo = &ContextInfoEvent{}
+
+type ContextInfoEvent struct {
+	AggregateID   string          `json:"aggregateID,omitempty"`
+	AggregateType string          `json:"aggregateType,omitempty"`
+	ResourceOwner string          `json:"resourceOwner,omitempty"`
+	InstanceID    string          `json:"instanceID,omitempty"`
+	Version       string          `json:"version,omitempty"`
+	Sequence      uint64          `json:"sequence,omitempty"`
+	Position      float64         `json:"position,omitempty"`
+	EventType     string          `json:"eventType,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt,omitempty"`
+	UserID        string          `json:"userID,omitempty"`
+	EventPayload  json.RawMessage `json:"eventPayload,omitempty"`
+}
+
+func NewContextInfoEvent(event eventstore.Event) *ContextInfoEvent {
+	return &ContextInfoEvent{
+		AggregateID:   event.Aggregate().ID,
+		AggregateType: string(event.Aggregate().Type),
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		InstanceID:    event.Aggregate().InstanceID,
+		Version:       string(event.Aggregate().Version),
+		Sequence:      event.Sequence(),
+		Position:      event.Position(),
+		EventType:     string(event.Type()),
+		CreatedAt:     event.CreatedAt(),
+		UserID:        event.Creator(),
+		EventPayload:  event.DataAsBytes(),
+	}
+}
+
+func (c *ContextInfoEvent) GetHTTPRequestBody() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// SetHTTPResponseBody ignores the response, as events can not be manipulated
+func (c *ContextInfoEvent) SetHTTPResponseBody([]byte) error {
+	return nil
+}
+
+func (c *ContextInfoEvent) GetContent() interface{} {
+	return c.EventPayload
+}
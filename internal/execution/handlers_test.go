@@ -0,0 +1,220 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_idsForEventType(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		want      []string
+	}{
+		{
+			"single part",
+			"event",
+			[]string{"event/event", "event"},
+		},
+		{
+			"two parts",
+			"user.added",
+			[]string{"event/user.added", "event/user.*", "event"},
+		},
+		{
+			"multiple parts",
+			"user.human.added",
+			[]string{"event/user.human.added", "event/user.human.*", "event/user.*", "event"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, idsForEventType(tt.eventType))
+		})
+	}
+}
+
+type mockEventQueries struct {
+	position           float64
+	executionIDs       []string
+	executionIDsCalled int
+	targets            []*query.ExecutionTarget
+}
+
+func (m *mockEventQueries) LatestExecutionPosition(context.Context) (float64, error) {
+	return m.position, nil
+}
+
+func (m *mockEventQueries) EventExecutionIDs(context.Context) ([]string, error) {
+	m.executionIDsCalled++
+	return m.executionIDs, nil
+}
+
+func (m *mockEventQueries) TargetsByEventExecutionID(context.Context, []string, time.Time) ([]*query.ExecutionTarget, error) {
+	return m.targets, nil
+}
+
+type mockEnqueuer struct {
+	targets []Target
+	body    []byte
+}
+
+func (m *mockEnqueuer) enqueue(_ context.Context, targets []Target, body []byte) error {
+	m.targets = targets
+	m.body = body
+	return nil
+}
+
+func Test_eventHandler_FilterEventTypes(t *testing.T) {
+	eventTypes := map[eventstore.AggregateType][]eventstore.EventType{
+		"user": {"user.human.added", "user.machine.added", "user.removed"},
+		"org":  {"org.added"},
+	}
+	tests := []struct {
+		name         string
+		executionIDs []string
+		want         map[eventstore.AggregateType][]eventstore.EventType
+	}{
+		{
+			"no executions",
+			nil,
+			nil,
+		},
+		{
+			"event type",
+			[]string{"event/user.removed"},
+			map[eventstore.AggregateType][]eventstore.EventType{
+				"user": {"user.removed"},
+			},
+		},
+		{
+			"event group",
+			[]string{"event/user.human.*", "event/org.*"},
+			map[eventstore.AggregateType][]eventstore.EventType{
+				"user": {"user.human.added"},
+				"org":  {"org.added"},
+			},
+		},
+		{
+			"all events",
+			[]string{"event"},
+			eventTypes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &eventHandler{queries: &mockEventQueries{executionIDs: tt.executionIDs}}
+			got, err := h.FilterEventTypes(context.Background(), eventTypes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_eventHandler_FilterEventTypes_cached(t *testing.T) {
+	eventTypes := map[eventstore.AggregateType][]eventstore.EventType{
+		"user": {"user.human.added", "user.removed"},
+	}
+	queries := &mockEventQueries{position: 1, executionIDs: []string{"event/user.removed"}}
+	h := &eventHandler{queries: queries}
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+
+	got, err := h.FilterEventTypes(ctx, eventTypes)
+	require.NoError(t, err)
+	assert.Equal(t, map[eventstore.AggregateType][]eventstore.EventType{"user": {"user.removed"}}, got)
+	assert.Equal(t, 1, queries.executionIDsCalled)
+
+	// unchanged executions are not queried again
+	queries.executionIDs = []string{"event/user.*"}
+	got, err = h.FilterEventTypes(ctx, eventTypes)
+	require.NoError(t, err)
+	assert.Equal(t, map[eventstore.AggregateType][]eventstore.EventType{"user": {"user.removed"}}, got)
+	assert.Equal(t, 1, queries.executionIDsCalled)
+
+	// changed executions are queried again
+	queries.position = 2
+	got, err = h.FilterEventTypes(ctx, eventTypes)
+	require.NoError(t, err)
+	assert.Equal(t, eventTypes, got)
+	assert.Equal(t, 2, queries.executionIDsCalled)
+
+	// the executions are cached per instance
+	_, err = h.FilterEventTypes(authz.WithInstanceID(context.Background(), "instance2"), eventTypes)
+	require.NoError(t, err)
+	assert.Equal(t, 3, queries.executionIDsCalled)
+}
+
+func Test_eventHandler_reduce(t *testing.T) {
+	event := &eventstore.BaseEvent{
+		Agg:       &eventstore.Aggregate{ID: "user", Type: "user", ResourceOwner: "org", InstanceID: "instance"},
+		EventType: "user.human.added",
+		Data:      []byte(`{"userName":"username"}`),
+	}
+	tests := []struct {
+		name        string
+		targets     []*query.ExecutionTarget
+		wantTargets []string
+	}{
+		{
+			"no targets",
+			nil,
+			nil,
+		},
+		{
+			"condition does not match",
+			[]*query.ExecutionTarget{
+				{TargetID: "target", Condition: &domain.ExecutionTargetCondition{Path: "$.eventPayload.userName", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "other"}},
+			},
+			nil,
+		},
+		{
+			"deliveries of matching targets",
+			[]*query.ExecutionTarget{
+				{TargetID: "target1"},
+				{TargetID: "target2", Condition: &domain.ExecutionTargetCondition{Path: "$.eventPayload.userName", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "other"}},
+				{TargetID: "target3", Condition: &domain.ExecutionTargetCondition{Path: "$.eventPayload.userName", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "username"}},
+			},
+			[]string{"target1", "target3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enqueuer := new(mockEnqueuer)
+			h := &eventHandler{
+				queries:    &mockEventQueries{targets: tt.targets},
+				deliveries: enqueuer,
+			}
+			stmt, err := h.reduce(event)
+			require.NoError(t, err)
+			if stmt.Execute != nil {
+				require.NoError(t, stmt.Execute(nil, HandlerTable))
+			}
+			targetIDs := make([]string, 0, len(enqueuer.targets))
+			for _, target := range enqueuer.targets {
+				targetIDs = append(targetIDs, target.GetTargetID())
+			}
+			if tt.wantTargets == nil {
+				assert.Empty(t, targetIDs)
+				return
+			}
+			assert.Equal(t, tt.wantTargets, targetIDs)
+			assert.JSONEq(t, `{"userName":"username"}`, string(eventPayload(t, enqueuer.body)))
+		})
+	}
+}
+
+func eventPayload(t *testing.T, body []byte) []byte {
+	var info ContextInfoEvent
+	require.NoError(t, json.Unmarshal(body, &info))
+	return info.EventPayload
+}
@@ -0,0 +1,56 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+var projections []*handler.Handler
+
+func Register(
+	ctx context.Context,
+	executionsCustomConfig projection.CustomConfig,
+	deliveryConfig *DeliveryConfig,
+	maxParallel uint16,
+	es *eventstore.Eventstore,
+	queries *query.Queries,
+	commands DeliveryCommands,
+) {
+	if deliveryConfig == nil {
+		deliveryConfig = new(DeliveryConfig)
+	}
+	// the events and the calls of async targets are always persisted as deliveries,
+	// the first attempt is made directly, retries are made when the due deliveries are polled
+	deliveries = newDeliveryWorker(deliveryConfig, commands, queries)
+	projections = append(projections, NewEventHandler(ctx, projection.ApplyCustomConfig(executionsCustomConfig), es.EventTypes(), queries, deliveries))
+	if maxParallel > 0 {
+		maxParallelTargets = maxParallel
+	}
+}
+
+func Start(ctx context.Context) {
+	for _, projection := range projections {
+		projection.Start(ctx)
+	}
+	if deliveries != nil {
+		deliveries.Start(ctx)
+	}
+}
+
+func ProjectInstance(ctx context.Context) error {
+	for _, projection := range projections {
+		_, err := projection.Trigger(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Projections() []*handler.Handler {
+	return projections
+}
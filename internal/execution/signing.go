@@ -0,0 +1,83 @@
+package execution
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// SigningHeader is the header of the calls to the targets which contains the timestamp and the signature of the payload,
+	// for example: ZITADEL-Signature: t=1712345678,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd
+	SigningHeader = "ZITADEL-Signature"
+
+	signingTimestamp = "t"
+	signingVersion   = "v1"
+	// DefaultSignatureTolerance is the maximum age of a signature accepted by [ValidatePayload]
+	DefaultSignatureTolerance = 5 * time.Minute
+)
+
+// ComputeSignatureHeader returns the value of the [SigningHeader] for the payload, signed with HMAC-SHA256 over "<timestamp>.<payload>"
+func ComputeSignatureHeader(t time.Time, payload []byte, signingKey string) string {
+	return fmt.Sprintf("%s=%d,%s=%s", signingTimestamp, t.Unix(), signingVersion, hex.EncodeToString(computeSignature(t, payload, signingKey)))
+}
+
+func computeSignature(t time.Time, payload []byte, signingKey string) []byte {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(strconv.FormatInt(t.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// ValidatePayload checks the [SigningHeader] of a call from ZITADEL against the payload and the signing key of the target.
+// Signatures which are older than the tolerance are rejected to prevent replayed calls.
+func ValidatePayload(payload []byte, header string, signingKey string, tolerance time.Duration) error {
+	t, signatures, err := parseSignatureHeader(header)
+	if err != nil {
+		return err
+	}
+	if tolerance > 0 && time.Since(t) > tolerance {
+		return zerrors.ThrowPreconditionFailed(nil, "EXEC-2ks8fv9d0c", "Errors.Execution.SignatureExpired")
+	}
+	expected := computeSignature(t, payload, signingKey)
+	for _, signature := range signatures {
+		if hmac.Equal(expected, signature) {
+			return nil
+		}
+	}
+	return zerrors.ThrowPreconditionFailed(nil, "EXEC-6m1xk4c0ad", "Errors.Execution.InvalidSignature")
+}
+
+func parseSignatureHeader(header string) (t time.Time, signatures [][]byte, err error) {
+	for _, pair := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case signingTimestamp:
+			timestamp, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return time.Time{}, nil, zerrors.ThrowInvalidArgument(err, "EXEC-0c7d1tnqk9", "Errors.Execution.InvalidSignature")
+			}
+			t = time.Unix(timestamp, 0)
+		case signingVersion:
+			signature, err := hex.DecodeString(value)
+			if err != nil {
+				continue
+			}
+			signatures = append(signatures, signature)
+		}
+	}
+	if t.IsZero() || len(signatures) == 0 {
+		return time.Time{}, nil, zerrors.ThrowInvalidArgument(nil, "EXEC-hk3x9t7w2e", "Errors.Execution.InvalidSignature")
+	}
+	return t, signatures, nil
+}
@@ -0,0 +1,114 @@
+package execution
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestValidatePayload(t *testing.T) {
+	payload := []byte(`{"request":"values"}`)
+	now := time.Now()
+	type args struct {
+		payload    []byte
+		header     string
+		signingKey string
+		tolerance  time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr func(error) bool
+	}{
+		{
+			"valid signature",
+			args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload, "key"),
+				signingKey: "key",
+				tolerance:  DefaultSignatureTolerance,
+			},
+			nil,
+		},
+		{
+			"wrong key",
+			args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload, "other"),
+				signingKey: "key",
+				tolerance:  DefaultSignatureTolerance,
+			},
+			zerrors.IsPreconditionFailed,
+		},
+		{
+			"changed payload",
+			args{
+				payload:    []byte(`{"request":"changed"}`),
+				header:     ComputeSignatureHeader(now, payload, "key"),
+				signingKey: "key",
+				tolerance:  DefaultSignatureTolerance,
+			},
+			zerrors.IsPreconditionFailed,
+		},
+		{
+			"expired signature",
+			args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now.Add(-time.Hour), payload, "key"),
+				signingKey: "key",
+				tolerance:  DefaultSignatureTolerance,
+			},
+			zerrors.IsPreconditionFailed,
+		},
+		{
+			"invalid header",
+			args{
+				payload:    payload,
+				header:     "v1=abc",
+				signingKey: "key",
+				tolerance:  DefaultSignatureTolerance,
+			},
+			zerrors.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePayload(tt.args.payload, tt.args.header, tt.args.signingKey, tt.args.tolerance)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "got wrong err: %v", err)
+		})
+	}
+}
+
+func Test_callSigned(t *testing.T) {
+	body := []byte(`{"request":"values"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sentBody, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if err := ValidatePayload(sentBody, r.Header.Get(SigningHeader), "key", DefaultSignatureTolerance); err != nil {
+			http.Error(w, "error", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"response":"values"}`)
+	}))
+	defer server.Close()
+
+	resp, err := call(context.Background(), "target", server.URL, time.Second, body, "key", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"response":"values"}`), resp)
+
+	_, err = call(context.Background(), "target", server.URL, time.Second, body, "other", nil)
+	assert.Error(t, err)
+}
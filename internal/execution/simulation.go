@@ -0,0 +1,104 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SimulatedCall is the result of a call to a target by [SimulateTargets]
+type SimulatedCall struct {
+	TargetID    string
+	ExecutionID string
+	Duration    time.Duration
+	// StatusCode is the HTTP status code of REST targets or the gRPC status code of gRPC targets,
+	// 0 if the target was not reachable
+	StatusCode int
+	Response   []byte
+	Err        error
+	// Interrupted is set if the error of the call interrupted the execution
+	Interrupted bool
+}
+
+// SimulateTargets calls the targets with the same handling of errors, responses and conditions as [CallTargets],
+// additionally the result of every call is returned, targets whose condition does not match are not called and not returned.
+// The calls to async targets are not persisted but sent directly.
+// If a call interrupts the execution, the remaining targets are not called and interrupted is true.
+func SimulateTargets(
+	ctx context.Context,
+	targets []Target,
+	info ContextInfo,
+) (calls []*SimulatedCall, content interface{}, interrupted bool) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer span.End()
+
+	var mu sync.Mutex
+	simulated := make(map[Target]*SimulatedCall, len(targets))
+	interruptedBy, err := callTargets(ctx, targets, info, func(ctx context.Context, target Target, body []byte) ([]byte, error) {
+		call := simulateTarget(ctx, target, body)
+		mu.Lock()
+		simulated[target] = call
+		mu.Unlock()
+		return call.result(target)
+	})
+
+	calls = make([]*SimulatedCall, 0, len(simulated))
+	for _, group := range executionGroups(targets) {
+		for _, target := range group.targets {
+			if call, ok := simulated[target]; ok {
+				calls = append(calls, call)
+			}
+		}
+	}
+	if err == nil {
+		return calls, info.GetContent(), false
+	}
+	if call, ok := simulated[interruptedBy]; ok {
+		call.Interrupted = true
+		// the response of the call could not be set
+		if call.Err == nil {
+			call.Err = err
+		}
+	}
+	return calls, nil, true
+}
+
+// result returns the response and the error of the call as they are used in the execution,
+// only the responses of calls are used and errors of async calls never interrupt the execution
+func (c *SimulatedCall) result(target Target) ([]byte, error) {
+	switch target.GetTargetType() {
+	case domain.TargetTypeAsync:
+		return nil, nil
+	case domain.TargetTypeCall, domain.TargetTypeGRPC:
+		return c.Response, c.Err
+	case domain.TargetTypeWebhook:
+		return nil, c.Err
+	default:
+		return nil, c.Err
+	}
+}
+
+func simulateTarget(ctx context.Context, target Target, body []byte) *SimulatedCall {
+	simulated := &SimulatedCall{
+		TargetID: target.GetTargetID(),
+	}
+	if getter, ok := target.(executionIDGetter); ok {
+		simulated.ExecutionID = getter.GetExecutionID()
+	}
+	start := time.Now()
+	switch target.GetTargetType() {
+	case domain.TargetTypeWebhook, domain.TargetTypeCall, domain.TargetTypeAsync:
+		simulated.StatusCode, simulated.Response, simulated.Err = callHTTP(ctx, target, body)
+	case domain.TargetTypeGRPC:
+		simulated.Response, simulated.Err = callGRPC(ctx, target, body)
+		simulated.StatusCode = grpcStatusCode(simulated.Err)
+	default:
+		simulated.Err = zerrors.ThrowInternal(nil, "EXEC-2kq8m0vd5n", "Errors.Execution.Unknown")
+	}
+	simulated.Duration = time.Since(start)
+	return simulated
+}
@@ -0,0 +1,189 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var _ ContextInfo = &mockContextInfo{}
+
+type mockContextInfo struct {
+	Request *request `json:"request"`
+}
+
+func (c *mockContextInfo) GetHTTPRequestBody() []byte {
+	data, _ := json.Marshal(c)
+	return data
+}
+
+func (c *mockContextInfo) GetContent() interface{} {
+	return c.Request
+}
+
+func (c *mockContextInfo) SetHTTPResponseBody(resp []byte) error {
+	return json.Unmarshal(resp, c.Request)
+}
+
+func Test_SimulateTargets(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		called []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		called = append(called, r.URL.Path)
+		mu.Unlock()
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		switch r.URL.Path {
+		case "/call":
+			_, _ = io.WriteString(w, `{"request":"changed"}`)
+		case "/echo":
+			_, _ = w.Write(body)
+		default:
+			http.Error(w, "error", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	type res struct {
+		called      []string
+		calls       []*SimulatedCall
+		content     interface{}
+		interrupted bool
+	}
+	tests := []struct {
+		name    string
+		targets []Target
+		res     res
+	}{
+		{
+			"webhook response ignored, call response used",
+			[]Target{
+				&mockTarget{TargetID: "webhook", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/echo", Timeout: time.Second},
+				&mockTarget{TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second},
+			},
+			res{
+				called: []string{"/echo", "/call"},
+				calls: []*SimulatedCall{
+					{TargetID: "webhook", StatusCode: http.StatusOK, Response: []byte(`{"request":{"request":"content"}}`)},
+					{TargetID: "call", StatusCode: http.StatusOK, Response: []byte(`{"request":"changed"}`)},
+				},
+				content: &request{Request: "changed"},
+			},
+		},
+		{
+			"error not interrupting, next target called",
+			[]Target{
+				&mockTarget{TargetID: "error", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/error", Timeout: time.Second},
+				&mockTarget{TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second},
+			},
+			res{
+				called: []string{"/error", "/call"},
+				calls: []*SimulatedCall{
+					{TargetID: "error", StatusCode: http.StatusForbidden},
+					{TargetID: "call", StatusCode: http.StatusOK, Response: []byte(`{"request":"changed"}`)},
+				},
+				content: &request{Request: "changed"},
+			},
+		},
+		{
+			"error interrupting, next target not called",
+			[]Target{
+				&mockTarget{TargetID: "error", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/error", Timeout: time.Second, InterruptOnError: true},
+				&mockTarget{TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second},
+			},
+			res{
+				called: []string{"/error"},
+				calls: []*SimulatedCall{
+					{TargetID: "error", StatusCode: http.StatusForbidden, Interrupted: true},
+				},
+				interrupted: true,
+			},
+		},
+		{
+			"async error, not interrupting",
+			[]Target{
+				&mockTarget{TargetID: "async", TargetType: domain.TargetTypeAsync, Endpoint: server.URL + "/error", Timeout: time.Second, InterruptOnError: true},
+			},
+			res{
+				called: []string{"/error"},
+				calls: []*SimulatedCall{
+					{TargetID: "async", StatusCode: http.StatusForbidden},
+				},
+				content: &request{Request: "content"},
+			},
+		},
+		{
+			"condition not matching, not called",
+			[]Target{
+				&mockTarget{TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second, Condition: &domain.ExecutionTargetCondition{Path: "$.request.request", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "other"}},
+			},
+			res{
+				calls:   []*SimulatedCall{},
+				content: &request{Request: "content"},
+			},
+		},
+		{
+			"parallel, all targets called and interrupted",
+			[]Target{
+				&mockTarget{TargetID: "error", TargetType: domain.TargetTypeWebhook, Endpoint: server.URL + "/error", Timeout: time.Second, InterruptOnError: true, Mode: domain.ExecutionModeParallel},
+				&mockTarget{TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second, Mode: domain.ExecutionModeParallel},
+			},
+			res{
+				called: []string{"/error", "/call"},
+				calls: []*SimulatedCall{
+					{TargetID: "error", StatusCode: http.StatusForbidden, Interrupted: true},
+					{TargetID: "call", StatusCode: http.StatusOK, Response: []byte(`{"request":"changed"}`)},
+				},
+				interrupted: true,
+			},
+		},
+		{
+			"parallel, responses set after all calls",
+			[]Target{
+				&mockTarget{TargetID: "call", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second, Mode: domain.ExecutionModeParallel},
+				&mockTarget{TargetID: "call2", TargetType: domain.TargetTypeCall, Endpoint: server.URL + "/call", Timeout: time.Second, Mode: domain.ExecutionModeParallel, Condition: &domain.ExecutionTargetCondition{Path: "$.request.request", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "content"}},
+			},
+			res{
+				called: []string{"/call", "/call"},
+				calls: []*SimulatedCall{
+					{TargetID: "call", StatusCode: http.StatusOK, Response: []byte(`{"request":"changed"}`)},
+					{TargetID: "call2", StatusCode: http.StatusOK, Response: []byte(`{"request":"changed"}`)},
+				},
+				content: &request{Request: "changed"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = nil
+			calls, content, interrupted := SimulateTargets(context.Background(), tt.targets, &mockContextInfo{Request: &request{Request: "content"}})
+			// the targets of parallel executions are called concurrently
+			assert.ElementsMatch(t, tt.res.called, called)
+			assert.Equal(t, tt.res.interrupted, interrupted)
+			assert.Equal(t, tt.res.content, content)
+			require.Len(t, calls, len(tt.res.calls))
+			for i, call := range calls {
+				assert.Equal(t, tt.res.calls[i].TargetID, call.TargetID)
+				assert.Equal(t, tt.res.calls[i].StatusCode, call.StatusCode)
+				assert.Equal(t, tt.res.calls[i].Interrupted, call.Interrupted)
+				assert.Equal(t, tt.res.calls[i].StatusCode >= http.StatusBadRequest, call.Err != nil)
+				if tt.res.calls[i].Response != nil {
+					assert.JSONEq(t, string(tt.res.calls[i].Response), string(call.Response))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type Config struct {
+	// Sinks the events are exported to, mapped by their name.
+	// Each sink keeps track of its own position.
+	Sinks map[string]*SinkConfig
+}
+
+type SinkConfig struct {
+	// AggregateTypes restricts the exported events to the aggregate types, all aggregate types are exported if empty
+	AggregateTypes []string
+	// EventTypes restricts the exported events to the event types, all event types are exported if empty.
+	// Groups of event types can be defined using the suffix `.*`, e.g. `user.human.*`
+	EventTypes []string
+	// RedactFields are the payload fields whose values are replaced before the events are published
+	RedactFields []string
+
+	// exactly one of the following sinks must be configured
+
+	File  *FileConfig
+	HTTP  *HTTPConfig
+	Kafka *KafkaConfig
+}
+
+func (c *SinkConfig) sink() (Sink, error) {
+	var (
+		sink  Sink
+		count int
+	)
+	if c.File != nil {
+		sink = newFileSink(c.File)
+		count++
+	}
+	if c.HTTP != nil {
+		sink = newHTTPSink(c.HTTP)
+		count++
+	}
+	if c.Kafka != nil {
+		sink = newKafkaSink(c.Kafka)
+		count++
+	}
+	if count != 1 {
+		return nil, zerrors.ThrowInvalidArgument(nil, "EXPORT-d1rk5m", "exactly one of file, http or kafka must be configured")
+	}
+	if validator, ok := sink.(interface{ validate() error }); ok {
+		if err := validator.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return sink, nil
+}
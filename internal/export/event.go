@@ -0,0 +1,45 @@
+package export
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Event is the representation of an event published to the sinks
+type Event struct {
+	InstanceID    string         `json:"instanceId"`
+	AggregateType string         `json:"aggregateType"`
+	AggregateID   string         `json:"aggregateId"`
+	ResourceOwner string         `json:"resourceOwner"`
+	Sequence      uint64         `json:"sequence"`
+	Position      float64        `json:"position"`
+	Type          string         `json:"type"`
+	Revision      uint16         `json:"revision"`
+	Creator       string         `json:"creator"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	Payload       map[string]any `json:"payload,omitempty"`
+}
+
+func newEvent(event eventstore.Event) (*Event, error) {
+	exported := &Event{
+		InstanceID:    event.Aggregate().InstanceID,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		Sequence:      event.Sequence(),
+		Position:      event.Position(),
+		Type:          string(event.Type()),
+		Revision:      event.Revision(),
+		Creator:       event.Creator(),
+		CreatedAt:     event.CreatedAt(),
+	}
+	if data := event.DataAsBytes(); len(data) > 0 {
+		if err := json.Unmarshal(data, &exported.Payload); err != nil {
+			return nil, zerrors.ThrowInternal(err, "EXPORT-p2Vd8q", "unable to unmarshal payload")
+		}
+	}
+	return exported, nil
+}
@@ -0,0 +1,120 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const rotatedFileTimeFormat = "20060102T150405.000000000Z"
+
+type FileConfig struct {
+	// Path of the file the events are appended to as newline-delimited JSON
+	Path string
+	// MaxSize in bytes after which the file is rotated, 0 disables the rotation by size
+	MaxSize int64
+	// MaxAge after which the file is rotated, 0 disables the rotation by age
+	MaxAge time.Duration
+}
+
+// fileSink appends the events to a file,
+// rotated files are renamed to the path suffixed by the rotation time.
+type fileSink struct {
+	config *FileConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+func newFileSink(config *FileConfig) *fileSink {
+	return &fileSink{
+		config: config,
+		now:    time.Now,
+	}
+}
+
+func (s *fileSink) validate() error {
+	if s.config.Path == "" {
+		return zerrors.ThrowInvalidArgument(nil, "EXPORT-k8Jd2f", "path of file sink is empty")
+	}
+	return nil
+}
+
+func (s *fileSink) Publish(_ context.Context, events []*Event) error {
+	var lines bytes.Buffer
+	encoder := json.NewEncoder(&lines)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return zerrors.ThrowInternal(err, "EXPORT-Tq4z1v", "unable to marshal event")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.open(); err != nil {
+		return err
+	}
+	if s.shouldRotate(int64(lines.Len())) {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(lines.Bytes())
+	s.size += int64(n)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EXPORT-Gm2s9b", "unable to write events")
+	}
+	if err = s.file.Sync(); err != nil {
+		return zerrors.ThrowInternal(err, "EXPORT-Vn5k0x", "unable to sync file")
+	}
+	return nil
+}
+
+func (s *fileSink) open() error {
+	if s.file != nil {
+		return nil
+	}
+	file, err := os.OpenFile(s.config.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EXPORT-a7Rw3c", "unable to open file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return zerrors.ThrowInternal(err, "EXPORT-Lx8n4p", "unable to stat file")
+	}
+	s.file = file
+	s.size = info.Size()
+	s.openedAt = s.now()
+	return nil
+}
+
+func (s *fileSink) shouldRotate(size int64) bool {
+	if s.size == 0 {
+		return false
+	}
+	if s.config.MaxSize > 0 && s.size+size > s.config.MaxSize {
+		return true
+	}
+	return s.config.MaxAge > 0 && s.now().Sub(s.openedAt) >= s.config.MaxAge
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return zerrors.ThrowInternal(err, "EXPORT-Ue6c2m", "unable to close file")
+	}
+	s.file = nil
+	if err := os.Rename(s.config.Path, s.config.Path+"."+s.now().UTC().Format(rotatedFileTimeFormat)); err != nil {
+		return zerrors.ThrowInternal(err, "EXPORT-Oz3j7h", "unable to rotate file")
+	}
+	return s.open()
+}
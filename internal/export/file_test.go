@@ -0,0 +1,79 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fileSink_Publish(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		config      func(dir string) *FileConfig
+		advance     time.Duration
+		wantRotated bool
+	}{
+		{
+			name: "no rotation",
+			config: func(dir string) *FileConfig {
+				return &FileConfig{Path: filepath.Join(dir, "events.ndjson"), MaxSize: 1 << 20, MaxAge: time.Hour}
+			},
+			advance: time.Minute,
+		},
+		{
+			name: "rotation by size",
+			config: func(dir string) *FileConfig {
+				return &FileConfig{Path: filepath.Join(dir, "events.ndjson"), MaxSize: 10}
+			},
+			wantRotated: true,
+		},
+		{
+			name: "rotation by age",
+			config: func(dir string) *FileConfig {
+				return &FileConfig{Path: filepath.Join(dir, "events.ndjson"), MaxAge: time.Hour}
+			},
+			advance:     time.Hour,
+			wantRotated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			config := tt.config(dir)
+			current := now
+			sink := newFileSink(config)
+			sink.now = func() time.Time { return current }
+			t.Cleanup(func() {
+				if sink.file != nil {
+					_ = sink.file.Close()
+				}
+			})
+
+			require.NoError(t, sink.Publish(context.Background(), []*Event{{AggregateID: "1"}, {AggregateID: "2"}}))
+			current = current.Add(tt.advance)
+			require.NoError(t, sink.Publish(context.Background(), []*Event{{AggregateID: "3"}}))
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			content, err := os.ReadFile(config.Path)
+			require.NoError(t, err)
+			if !tt.wantRotated {
+				assert.Len(t, entries, 1)
+				assert.Equal(t, 3, strings.Count(string(content), "\n"))
+				return
+			}
+			require.Len(t, entries, 2)
+			assert.Equal(t, 1, strings.Count(string(content), "\n"))
+			rotated, err := os.ReadFile(config.Path + "." + current.Format(rotatedFileTimeFormat))
+			require.NoError(t, err)
+			assert.Equal(t, 2, strings.Count(string(rotated), "\n"))
+		})
+	}
+}
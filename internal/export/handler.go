@@ -0,0 +1,142 @@
+package export
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+)
+
+const (
+	HandlerTablePrefix = "projections.export_"
+	eventGroupSuffix   = ".*"
+)
+
+// Sink publishes the exported events.
+// The events are ordered by their position,
+// they are published again if the position could not be stored after publishing.
+type Sink interface {
+	Publish(ctx context.Context, events []*Event) error
+}
+
+type exportHandler struct {
+	name     string
+	sink     Sink
+	redact   []RedactHook
+	reducers []handler.AggregateReducer
+
+	mu      sync.Mutex
+	batches map[handler.Executer][]*Event
+}
+
+var (
+	_ handler.Projection = (*exportHandler)(nil)
+	_ handler.Flusher    = (*exportHandler)(nil)
+)
+
+// NewHandler creates a handler which publishes the events matching the filters of the sink config.
+// The event types are mapped to their aggregate types based on the registered event mappers.
+func NewHandler(
+	ctx context.Context,
+	config handler.Config,
+	name string,
+	sinkConfig *SinkConfig,
+	eventTypes []string,
+) (*handler.Handler, error) {
+	sink, err := sinkConfig.sink()
+	if err != nil {
+		return nil, err
+	}
+	h := &exportHandler{
+		name:    name,
+		sink:    sink,
+		redact:  registeredRedactHooks(),
+		batches: make(map[handler.Executer][]*Event),
+	}
+	if len(sinkConfig.RedactFields) > 0 {
+		h.redact = append(h.redact, RedactFields(sinkConfig.RedactFields...))
+	}
+	h.reducers = h.aggregateReducers(sinkConfig, eventTypes)
+	return handler.NewHandler(ctx, &config, h), nil
+}
+
+func (h *exportHandler) Name() string {
+	return HandlerTablePrefix + h.name
+}
+
+func (h *exportHandler) Reducers() []handler.AggregateReducer {
+	return h.reducers
+}
+
+func (h *exportHandler) aggregateReducers(config *SinkConfig, eventTypes []string) []handler.AggregateReducer {
+	aggregates := make(map[eventstore.AggregateType][]handler.EventReducer)
+	for _, eventType := range eventTypes {
+		aggregateType := eventstore.AggregateTypeFromEventType(eventstore.EventType(eventType))
+		if aggregateType == "" || !config.matches(string(aggregateType), eventType) {
+			continue
+		}
+		aggregates[aggregateType] = append(aggregates[aggregateType], handler.EventReducer{
+			Event:  eventstore.EventType(eventType),
+			Reduce: h.reduce,
+		})
+	}
+	reducers := make([]handler.AggregateReducer, 0, len(aggregates))
+	for aggregateType, eventReducers := range aggregates {
+		reducers = append(reducers, handler.AggregateReducer{
+			Aggregate:     aggregateType,
+			EventReducers: eventReducers,
+		})
+	}
+	return reducers
+}
+
+func (c *SinkConfig) matches(aggregateType, eventType string) bool {
+	if len(c.AggregateTypes) > 0 && !slices.Contains(c.AggregateTypes, aggregateType) {
+		return false
+	}
+	if len(c.EventTypes) == 0 {
+		return true
+	}
+	for _, filter := range c.EventTypes {
+		if filter == eventType {
+			return true
+		}
+		if group, ok := strings.CutSuffix(filter, eventGroupSuffix); ok && strings.HasPrefix(eventType, group+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// reduce collects the event, it is published in [exportHandler.Flush]
+func (h *exportHandler) reduce(event eventstore.Event) (*handler.Statement, error) {
+	exported, err := newEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	for _, redact := range h.redact {
+		redact(exported)
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, _ string) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.batches[ex] = append(h.batches[ex], exported)
+		return nil
+	}), nil
+}
+
+// Flush implements [handler.Flusher] and publishes the events collected by the statements executed by ex.
+func (h *exportHandler) Flush(ctx context.Context, ex handler.Executer) error {
+	h.mu.Lock()
+	events := h.batches[ex]
+	delete(h.batches, ex)
+	h.mu.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+	return h.sink.Publish(ctx, events)
+}
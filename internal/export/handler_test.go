@@ -0,0 +1,245 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func TestSinkConfig_matches(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *SinkConfig
+		aggregateType string
+		eventType     string
+		want          bool
+	}{
+		{
+			name:          "no filters",
+			config:        &SinkConfig{},
+			aggregateType: "user",
+			eventType:     "user.human.added",
+			want:          true,
+		},
+		{
+			name:          "aggregate type matches",
+			config:        &SinkConfig{AggregateTypes: []string{"org", "user"}},
+			aggregateType: "user",
+			eventType:     "user.human.added",
+			want:          true,
+		},
+		{
+			name:          "aggregate type does not match",
+			config:        &SinkConfig{AggregateTypes: []string{"org"}},
+			aggregateType: "user",
+			eventType:     "user.human.added",
+		},
+		{
+			name:          "event type matches",
+			config:        &SinkConfig{EventTypes: []string{"user.human.added"}},
+			aggregateType: "user",
+			eventType:     "user.human.added",
+			want:          true,
+		},
+		{
+			name:          "event group matches",
+			config:        &SinkConfig{EventTypes: []string{"user.human.*"}},
+			aggregateType: "user",
+			eventType:     "user.human.added",
+			want:          true,
+		},
+		{
+			name:          "event group does not match prefix",
+			config:        &SinkConfig{EventTypes: []string{"user.human.*"}},
+			aggregateType: "user",
+			eventType:     "user.humanity.added",
+		},
+		{
+			name:          "aggregate type matches, event type does not",
+			config:        &SinkConfig{AggregateTypes: []string{"user"}, EventTypes: []string{"user.machine.*"}},
+			aggregateType: "user",
+			eventType:     "user.human.added",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.matches(tt.aggregateType, tt.eventType))
+		})
+	}
+}
+
+func TestSinkConfig_sink(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *SinkConfig
+		want    Sink
+		wantErr bool
+	}{
+		{
+			name:    "no sink",
+			config:  &SinkConfig{},
+			wantErr: true,
+		},
+		{
+			name: "multiple sinks",
+			config: &SinkConfig{
+				File: &FileConfig{Path: "events.ndjson"},
+				HTTP: &HTTPConfig{Endpoint: "https://example.com"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid http endpoint",
+			config:  &SinkConfig{HTTP: &HTTPConfig{Endpoint: "/events"}},
+			wantErr: true,
+		},
+		{
+			name:    "kafka without topic",
+			config:  &SinkConfig{Kafka: &KafkaConfig{Brokers: []string{"localhost:9092"}}},
+			wantErr: true,
+		},
+		{
+			name:   "file",
+			config: &SinkConfig{File: &FileConfig{Path: "events.ndjson"}},
+			want:   newFileSink(&FileConfig{Path: "events.ndjson"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.sink()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.want, got)
+		})
+	}
+}
+
+type mockSink struct {
+	published [][]*Event
+	err       error
+}
+
+func (s *mockSink) Publish(_ context.Context, events []*Event) error {
+	s.published = append(s.published, events)
+	return s.err
+}
+
+type mockExecuter struct {
+	handler.Executer
+}
+
+func Test_exportHandler_reduceAndFlush(t *testing.T) {
+	createdAt := time.Now().UTC()
+	sink := new(mockSink)
+	h := &exportHandler{
+		name:    "test",
+		sink:    sink,
+		redact:  []RedactHook{RedactFields("email")},
+		batches: make(map[handler.Executer][]*Event),
+	}
+	ex := new(mockExecuter)
+
+	for i, data := range []string{`{"email":"gigi@zitadel.com","userName":"gigi"}`, ``} {
+		stmt, err := h.reduce(&repository.Event{
+			Seq:           uint64(i + 1),
+			Pos:           float64(i + 1),
+			CreationDate:  createdAt,
+			Typ:           "user.human.added",
+			Data:          []byte(data),
+			EditorUser:    "editor",
+			Version:       "v1",
+			AggregateID:   "user1",
+			AggregateType: "user",
+			ResourceOwner: sql.NullString{String: "org1", Valid: true},
+			InstanceID:    "instance1",
+		})
+		require.NoError(t, err)
+		require.NoError(t, stmt.Execute(ex, h.Name()))
+	}
+	assert.Empty(t, sink.published)
+
+	require.NoError(t, h.Flush(context.Background(), ex))
+	require.Len(t, sink.published, 1)
+	assert.Equal(t, []*Event{
+		{
+			InstanceID:    "instance1",
+			AggregateType: "user",
+			AggregateID:   "user1",
+			ResourceOwner: "org1",
+			Sequence:      1,
+			Position:      1,
+			Type:          "user.human.added",
+			Creator:       "editor",
+			CreatedAt:     createdAt,
+			Payload:       map[string]any{"email": redactedValue, "userName": "gigi"},
+		},
+		{
+			InstanceID:    "instance1",
+			AggregateType: "user",
+			AggregateID:   "user1",
+			ResourceOwner: "org1",
+			Sequence:      2,
+			Position:      2,
+			Type:          "user.human.added",
+			Creator:       "editor",
+			CreatedAt:     createdAt,
+		},
+	}, sink.published[0])
+
+	// the batch is removed after it was flushed
+	require.NoError(t, h.Flush(context.Background(), ex))
+	assert.Len(t, sink.published, 1)
+	assert.Empty(t, h.batches)
+}
+
+func Test_exportHandler_aggregateReducers(t *testing.T) {
+	eventstore.RegisterFilterEventMapper("export_test", "export_test.added", func(e eventstore.Event) (eventstore.Event, error) { return e, nil })
+	eventstore.RegisterFilterEventMapper("export_test", "export_test.removed", func(e eventstore.Event) (eventstore.Event, error) { return e, nil })
+
+	h := new(exportHandler)
+	reducers := h.aggregateReducers(
+		&SinkConfig{EventTypes: []string{"export_test.added"}},
+		[]string{"export_test.added", "export_test.removed", "unregistered.added"},
+	)
+	require.Len(t, reducers, 1)
+	assert.Equal(t, eventstore.AggregateType("export_test"), reducers[0].Aggregate)
+	require.Len(t, reducers[0].EventReducers, 1)
+	assert.Equal(t, eventstore.EventType("export_test.added"), reducers[0].EventReducers[0].Event)
+}
+
+func TestRedactFields(t *testing.T) {
+	event := &Event{
+		Payload: map[string]any{
+			"email": "gigi@zitadel.com",
+			"profile": map[string]any{
+				"phone":     "+41 79 123 45 67",
+				"firstName": "Gigi",
+			},
+			"addresses": []any{
+				map[string]any{"email": "giraffe@zitadel.com"},
+			},
+		},
+	}
+	RedactFields("email", "phone")(event)
+	assert.Equal(t, map[string]any{
+		"email": redactedValue,
+		"profile": map[string]any{
+			"phone":     redactedValue,
+			"firstName": "Gigi",
+		},
+		"addresses": []any{
+			map[string]any{"email": redactedValue},
+		},
+	}, event.Payload)
+}
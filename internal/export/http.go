@@ -0,0 +1,90 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type HTTPConfig struct {
+	// Endpoint the events are posted to as JSON object `{"events": [...]}`
+	Endpoint string
+	// Headers added to each request
+	Headers http.Header
+	// Timeout of a request
+	Timeout time.Duration
+	// BatchSize is the maximum amount of events per request, all events of an iteration are sent at once if 0
+	BatchSize int
+}
+
+type httpSink struct {
+	config *HTTPConfig
+	client *http.Client
+}
+
+func newHTTPSink(config *HTTPConfig) *httpSink {
+	return &httpSink{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+func (s *httpSink) validate() error {
+	endpoint, err := url.Parse(s.config.Endpoint)
+	if err != nil || !endpoint.IsAbs() {
+		return zerrors.ThrowInvalidArgument(err, "EXPORT-c9Xh4e", "endpoint of http sink is invalid")
+	}
+	return nil
+}
+
+type httpBatch struct {
+	Events []*Event `json:"events"`
+}
+
+func (s *httpSink) Publish(ctx context.Context, events []*Event) error {
+	batchSize := s.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(events)
+	}
+	for start := 0; start < len(events); start += batchSize {
+		end := min(start+batchSize, len(events))
+		if err := s.post(ctx, events[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *httpSink) post(ctx context.Context, events []*Event) error {
+	body, err := json.Marshal(&httpBatch{Events: events})
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EXPORT-Wb7p1k", "unable to marshal events")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EXPORT-f3Nq8s", "unable to create request")
+	}
+	for key, values := range s.config.Headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return zerrors.ThrowUnavailable(err, "EXPORT-Zk2r6t", "unable to call endpoint")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return zerrors.ThrowUnavailable(fmt.Errorf("calling %s returned %s", s.config.Endpoint, resp.Status), "EXPORT-h5Yc0w", "endpoint didn't return a success status")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_httpSink_Publish(t *testing.T) {
+	tests := []struct {
+		name        string
+		batchSize   int
+		status      int
+		wantBatches []int
+		wantErr     bool
+	}{
+		{
+			name:        "all at once",
+			status:      http.StatusOK,
+			wantBatches: []int{3},
+		},
+		{
+			name:        "batched",
+			batchSize:   2,
+			status:      http.StatusNoContent,
+			wantBatches: []int{2, 1},
+		},
+		{
+			name:        "error status",
+			batchSize:   2,
+			status:      http.StatusInternalServerError,
+			wantBatches: []int{2},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var batches []int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, "secret", r.Header.Get("Authorization"))
+				batch := new(httpBatch)
+				if !assert.NoError(t, json.NewDecoder(r.Body).Decode(batch)) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				batches = append(batches, len(batch.Events))
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			sink := newHTTPSink(&HTTPConfig{
+				Endpoint:  server.URL,
+				Headers:   http.Header{"Authorization": []string{"secret"}},
+				BatchSize: tt.batchSize,
+			})
+			require.NoError(t, sink.validate())
+			err := sink.Publish(context.Background(), []*Event{{AggregateID: "1"}, {AggregateID: "2"}, {AggregateID: "3"}})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantBatches, batches)
+		})
+	}
+}
@@ -0,0 +1,630 @@
+package export
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// The kafka sink implements the metadata (version 1) and produce (version 3) requests of the kafka protocol
+// https://kafka.apache.org/protocol#The_Messages_Metadata
+// https://kafka.apache.org/protocol#The_Messages_Produce
+const (
+	kafkaProduceAPIKey      int16 = 0
+	kafkaProduceAPIVersion  int16 = 3
+	kafkaMetadataAPIKey     int16 = 3
+	kafkaMetadataAPIVersion int16 = 1
+	kafkaRequiredAcksAll    int16 = -1
+	kafkaRecordBatchMagic   int8  = 2
+
+	defaultKafkaClientID       = "zitadel"
+	defaultKafkaTimeout        = 10 * time.Second
+	defaultKafkaMetadataMaxAge = 5 * time.Minute
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+type KafkaConfig struct {
+	// Brokers used to look up the partitions of the topic and their leaders, e.g. localhost:9092
+	Brokers []string
+	// Topic the events are produced to
+	Topic string
+	// ClientID sent to the brokers, defaults to zitadel
+	ClientID string
+	// Timeout of a request
+	Timeout time.Duration
+	// MetadataMaxAge after which the partitions and their leaders are looked up again, defaults to 5m
+	MetadataMaxAge time.Duration
+	// TLS of the connections to the brokers
+	TLS KafkaTLSConfig
+	// SASL authentication of the connections to the brokers
+	SASL KafkaSASLConfig
+}
+
+type KafkaTLSConfig struct {
+	// Enabled connects to the brokers using TLS
+	Enabled bool
+	// CAFile is the path to the PEM encoded certificate authorities of the brokers, the system pool is used if empty
+	CAFile string
+	// CertFile and KeyFile are the paths to the PEM encoded client certificate and its key,
+	// required if the brokers authenticate the clients by certificates
+	CertFile string
+	KeyFile  string
+}
+
+func (c *KafkaTLSConfig) config() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CAFile != "" {
+		ca, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "EXPORT-h3Nq7w", "unable to read ca file of kafka sink")
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, zerrors.ThrowInvalidArgument(nil, "EXPORT-z6Kd1p", "ca file of kafka sink contains no certificate")
+		}
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "EXPORT-s8Vb2m", "unable to load client certificate of kafka sink")
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// kafkaSink produces the events as records to the partitions of a topic.
+// The key of a record is the aggregate id, the value the JSON encoded event.
+// The partition of a record is selected by the hash of its key like the default partitioner of kafka,
+// so the events of an aggregate keep their order.
+// The records wait for the acknowledgement of all in-sync replicas.
+// If a broker fails, the whole batch is produced again, so records might be duplicated.
+type kafkaSink struct {
+	config *KafkaConfig
+
+	mu sync.Mutex
+	// conns to the brokers by their node id
+	conns map[int32]*kafkaConn
+	// brokers are the addresses of the brokers by their node id
+	brokers map[int32]string
+	// leaders are the node ids of the leaders by partition, -1 if a partition has no leader
+	leaders    []int32
+	metadataAt time.Time
+}
+
+func newKafkaSink(config *KafkaConfig) *kafkaSink {
+	return &kafkaSink{
+		config: config,
+		conns:  make(map[int32]*kafkaConn),
+	}
+}
+
+func (s *kafkaSink) validate() error {
+	if len(s.config.Brokers) == 0 || s.config.Topic == "" {
+		return zerrors.ThrowInvalidArgument(nil, "EXPORT-n6Gf3r", "brokers and topic of kafka sink must be set")
+	}
+	if s.config.TLS.Enabled {
+		if _, err := s.config.TLS.config(); err != nil {
+			return err
+		}
+	}
+	return s.config.SASL.validate()
+}
+
+type kafkaRecord struct {
+	key       []byte
+	value     []byte
+	headers   [][2][]byte
+	timestamp time.Time
+}
+
+func (s *kafkaSink) Publish(ctx context.Context, events []*Event) error {
+	records := make([]*kafkaRecord, len(events))
+	for i, event := range events {
+		value, err := json.Marshal(event)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "EXPORT-y1Qe5d", "unable to marshal event")
+		}
+		records[i] = &kafkaRecord{
+			key:   []byte(event.AggregateID),
+			value: value,
+			headers: [][2][]byte{
+				{[]byte("instanceId"), []byte(event.InstanceID)},
+				{[]byte("type"), []byte(event.Type)},
+			},
+			timestamp: event.CreatedAt,
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.produce(ctx, records)
+	if err != nil {
+		// the state of the connections and the leaders is unknown, the next request looks them up again
+		s.reset()
+	}
+	return err
+}
+
+func (s *kafkaSink) reset() {
+	for id, conn := range s.conns {
+		_ = conn.Close()
+		delete(s.conns, id)
+	}
+	s.metadataAt = time.Time{}
+}
+
+func (s *kafkaSink) produce(ctx context.Context, records []*kafkaRecord) error {
+	deadline := s.deadline(ctx)
+	maxAge := s.config.MetadataMaxAge
+	if maxAge == 0 {
+		maxAge = defaultKafkaMetadataMaxAge
+	}
+	if time.Since(s.metadataAt) > maxAge {
+		if err := s.refreshMetadata(ctx, deadline); err != nil {
+			return err
+		}
+	}
+
+	// records of a partition keep their order, partitions are grouped by their leaders
+	partitions := make(map[int32]map[int32][]*kafkaRecord)
+	for _, record := range records {
+		partition := kafkaPartition(record.key, len(s.leaders))
+		leader := s.leaders[partition]
+		if leader < 0 {
+			return zerrors.ThrowUnavailable(fmt.Errorf("partition %d of %s has no leader", partition, s.config.Topic), "EXPORT-u4Wm9c", "kafka partition unavailable")
+		}
+		if partitions[leader] == nil {
+			partitions[leader] = make(map[int32][]*kafkaRecord)
+		}
+		partitions[leader][partition] = append(partitions[leader][partition], record)
+	}
+	leaders := make([]int32, 0, len(partitions))
+	for leader := range partitions {
+		leaders = append(leaders, leader)
+	}
+	slices.Sort(leaders)
+
+	for _, leader := range leaders {
+		conn, err := s.conn(ctx, leader, deadline)
+		if err != nil {
+			return err
+		}
+		response, err := conn.roundTrip(kafkaProduceAPIKey, kafkaProduceAPIVersion, s.produceRequest(deadline, partitions[leader]))
+		if err != nil {
+			return err
+		}
+		if err = s.checkProduceResponse(response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *kafkaSink) deadline(ctx context.Context) time.Time {
+	timeout := s.config.Timeout
+	if timeout == 0 {
+		timeout = defaultKafkaTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	return deadline
+}
+
+// refreshMetadata looks up the partitions of the topic and their leaders using the first reachable broker of the config
+func (s *kafkaSink) refreshMetadata(ctx context.Context, deadline time.Time) (err error) {
+	var conn *kafkaConn
+	for _, broker := range s.config.Brokers {
+		conn, err = s.dial(ctx, broker, deadline)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	request := appendKafkaInt32(nil, 1)
+	request = appendKafkaString(request, s.config.Topic)
+	response, err := conn.roundTrip(kafkaMetadataAPIKey, kafkaMetadataAPIVersion, request)
+	if err != nil {
+		return err
+	}
+
+	r := &kafkaReader{b: response}
+	brokers := make(map[int32]string)
+	for count := r.int32(); count > 0; count-- {
+		nodeID := r.int32()
+		host := r.string()
+		port := r.int32()
+		// rack
+		r.string()
+		brokers[nodeID] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	// controller id
+	r.int32()
+	var leaders []int32
+	for topics := r.int32(); topics > 0; topics-- {
+		errorCode := r.int16()
+		topic := r.string()
+		// is internal
+		r.int8()
+		if r.err == nil && errorCode != 0 {
+			return zerrors.ThrowUnavailable(fmt.Errorf("metadata of %s failed with error code %d", topic, errorCode), "EXPORT-a7Rt3j", "kafka topic unavailable")
+		}
+		for partitions := r.int32(); partitions > 0; partitions-- {
+			// error code
+			r.int16()
+			partition := r.int32()
+			leader := r.int32()
+			// replica and in-sync replica nodes
+			r.next(4 * int(r.int32()))
+			r.next(4 * int(r.int32()))
+			if r.err != nil || topic != s.config.Topic || partition < 0 {
+				continue
+			}
+			for int(partition) >= len(leaders) {
+				leaders = append(leaders, -1)
+			}
+			leaders[partition] = leader
+		}
+	}
+	if r.err != nil {
+		return zerrors.ThrowInternal(r.err, "EXPORT-f2Yp6k", "invalid metadata response")
+	}
+	if len(leaders) == 0 {
+		return zerrors.ThrowUnavailable(fmt.Errorf("no partitions of %s found", s.config.Topic), "EXPORT-x5Hc8e", "kafka topic unavailable")
+	}
+
+	s.brokers = brokers
+	s.leaders = leaders
+	s.metadataAt = time.Now()
+	return nil
+}
+
+func (s *kafkaSink) conn(ctx context.Context, nodeID int32, deadline time.Time) (*kafkaConn, error) {
+	if conn, ok := s.conns[nodeID]; ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, zerrors.ThrowInternal(err, "EXPORT-r4Sm8v", "unable to set deadline")
+		}
+		return conn, nil
+	}
+	broker, ok := s.brokers[nodeID]
+	if !ok {
+		return nil, zerrors.ThrowUnavailable(fmt.Errorf("broker %d not found", nodeID), "EXPORT-k1Gv5t", "kafka broker unavailable")
+	}
+	conn, err := s.dial(ctx, broker, deadline)
+	if err != nil {
+		return nil, err
+	}
+	s.conns[nodeID] = conn
+	return conn, nil
+}
+
+// dial connects and authenticates to the broker
+func (s *kafkaSink) dial(ctx context.Context, broker string, deadline time.Time) (_ *kafkaConn, err error) {
+	dialer := &net.Dialer{Deadline: deadline}
+	var conn net.Conn
+	if s.config.TLS.Enabled {
+		var config *tls.Config
+		if config, err = s.config.TLS.config(); err != nil {
+			return nil, err
+		}
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: config}).DialContext(ctx, "tcp", broker)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", broker)
+	}
+	if err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "EXPORT-b2Pk9z", "unable to connect to broker")
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return nil, zerrors.ThrowInternal(err, "EXPORT-c6Lw0s", "unable to set deadline")
+	}
+	clientID := s.config.ClientID
+	if clientID == "" {
+		clientID = defaultKafkaClientID
+	}
+	c := &kafkaConn{Conn: conn, clientID: clientID}
+	if err = c.authenticate(&s.config.SASL); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *kafkaSink) produceRequest(deadline time.Time, partitions map[int32][]*kafkaRecord) []byte {
+	// transactional id
+	req := appendKafkaInt16(nil, -1)
+	req = appendKafkaInt16(req, kafkaRequiredAcksAll)
+	req = appendKafkaInt32(req, int32(max(time.Until(deadline).Milliseconds(), 1)))
+	// one topic with the partitions led by the broker
+	req = appendKafkaInt32(req, 1)
+	req = appendKafkaString(req, s.config.Topic)
+	req = appendKafkaInt32(req, int32(len(partitions)))
+	for partition, records := range partitions {
+		req = appendKafkaInt32(req, partition)
+		req = appendKafkaBytes(req, kafkaRecordBatch(records))
+	}
+	return req
+}
+
+func (s *kafkaSink) checkProduceResponse(response []byte) error {
+	r := &kafkaReader{b: response}
+	for topics := r.int32(); topics > 0; topics-- {
+		topic := r.string()
+		for partitions := r.int32(); partitions > 0; partitions-- {
+			partition := r.int32()
+			errorCode := r.int16()
+			// base offset and log append time
+			r.int64()
+			r.int64()
+			if r.err == nil && errorCode != 0 {
+				return zerrors.ThrowUnavailable(fmt.Errorf("producing to %s/%d failed with error code %d", topic, partition, errorCode), "EXPORT-q5Ld1x", "broker rejected records")
+			}
+		}
+	}
+	if r.err != nil {
+		return zerrors.ThrowInternal(r.err, "EXPORT-w9Fz4u", "invalid produce response")
+	}
+	return nil
+}
+
+// kafkaConn is a connection to a broker
+type kafkaConn struct {
+	net.Conn
+	clientID      string
+	correlationID int32
+}
+
+// roundTrip sends the request and returns the response without its header
+func (c *kafkaConn) roundTrip(apiKey, apiVersion int16, body []byte) ([]byte, error) {
+	c.correlationID++
+	req := appendKafkaInt16(nil, apiKey)
+	req = appendKafkaInt16(req, apiVersion)
+	req = appendKafkaInt32(req, c.correlationID)
+	req = appendKafkaString(req, c.clientID)
+	req = append(req, body...)
+
+	if _, err := c.Write(appendKafkaBytes(nil, req)); err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "EXPORT-j0Ty7n", "unable to send request to broker")
+	}
+	response, err := readKafkaMessage(c)
+	if err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "EXPORT-e8Wc3l", "unable to read response of broker")
+	}
+	r := &kafkaReader{b: response}
+	if id := r.int32(); r.err != nil || id != c.correlationID {
+		return nil, zerrors.ThrowInternal(fmt.Errorf("expected correlation id %d got %d", c.correlationID, id), "EXPORT-m7Hb2g", "unexpected response of broker")
+	}
+	return r.b, nil
+}
+
+// kafkaPartition selects the partition of the key like the default partitioner of kafka
+func kafkaPartition(key []byte, partitions int) int32 {
+	return int32(int(kafkaMurmur2(key)&0x7fffffff) % partitions)
+}
+
+// kafkaMurmur2 is the 32-bit murmur2 hash used by the default partitioner of kafka
+func kafkaMurmur2(data []byte) int32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+	length := len(data)
+	h := seed ^ uint32(length)
+	for i := 0; i+4 <= length; i += 4 {
+		k := binary.LittleEndian.Uint32(data[i:])
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+	tail := data[length&^3:]
+	switch len(tail) {
+	case 3:
+		h ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(tail[0])
+		h *= m
+	}
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return int32(h)
+}
+
+// kafkaRecordBatch encodes the records as record batch (magic 2)
+// https://kafka.apache.org/documentation/#recordbatch
+func kafkaRecordBatch(records []*kafkaRecord) []byte {
+	baseTimestamp := records[0].timestamp.UnixMilli()
+	maxTimestamp := baseTimestamp
+	for _, record := range records {
+		maxTimestamp = max(maxTimestamp, record.timestamp.UnixMilli())
+	}
+
+	// attributes: no compression, create time
+	crcPart := appendKafkaInt16(nil, 0)
+	crcPart = appendKafkaInt32(crcPart, int32(len(records)-1))
+	crcPart = appendKafkaInt64(crcPart, baseTimestamp)
+	crcPart = appendKafkaInt64(crcPart, maxTimestamp)
+	// producer id, producer epoch and base sequence are not used
+	crcPart = appendKafkaInt64(crcPart, -1)
+	crcPart = appendKafkaInt16(crcPart, -1)
+	crcPart = appendKafkaInt32(crcPart, -1)
+	crcPart = appendKafkaInt32(crcPart, int32(len(records)))
+	for i, record := range records {
+		crcPart = appendKafkaRecord(crcPart, i, record.timestamp.UnixMilli()-baseTimestamp, record)
+	}
+
+	// base offset
+	batch := appendKafkaInt64(nil, 0)
+	// batch length: partition leader epoch, magic, crc and the crc part
+	batch = appendKafkaInt32(batch, int32(4+1+4+len(crcPart)))
+	batch = appendKafkaInt32(batch, -1)
+	batch = append(batch, byte(kafkaRecordBatchMagic))
+	batch = binary.BigEndian.AppendUint32(batch, crc32.Checksum(crcPart, castagnoli))
+	return append(batch, crcPart...)
+}
+
+func appendKafkaRecord(b []byte, offsetDelta int, timestampDelta int64, record *kafkaRecord) []byte {
+	// attributes are unused
+	body := []byte{0}
+	body = binary.AppendVarint(body, timestampDelta)
+	body = binary.AppendVarint(body, int64(offsetDelta))
+	body = appendKafkaVarintBytes(body, record.key)
+	body = appendKafkaVarintBytes(body, record.value)
+	body = binary.AppendVarint(body, int64(len(record.headers)))
+	for _, header := range record.headers {
+		body = appendKafkaVarintBytes(body, header[0])
+		body = appendKafkaVarintBytes(body, header[1])
+	}
+	b = binary.AppendVarint(b, int64(len(body)))
+	return append(b, body...)
+}
+
+func appendKafkaInt16(b []byte, v int16) []byte {
+	return binary.BigEndian.AppendUint16(b, uint16(v))
+}
+
+func appendKafkaInt32(b []byte, v int32) []byte {
+	return binary.BigEndian.AppendUint32(b, uint32(v))
+}
+
+func appendKafkaInt64(b []byte, v int64) []byte {
+	return binary.BigEndian.AppendUint64(b, uint64(v))
+}
+
+func appendKafkaString(b []byte, s string) []byte {
+	b = appendKafkaInt16(b, int16(len(s)))
+	return append(b, s...)
+}
+
+func appendKafkaBytes(b, v []byte) []byte {
+	b = appendKafkaInt32(b, int32(len(v)))
+	return append(b, v...)
+}
+
+func appendKafkaVarintBytes(b, v []byte) []byte {
+	b = binary.AppendVarint(b, int64(len(v)))
+	return append(b, v...)
+}
+
+// readKafkaMessage reads a size delimited message
+func readKafkaMessage(r io.Reader) ([]byte, error) {
+	var size int32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("invalid message size %d", size)
+	}
+	message := make([]byte, size)
+	_, err := io.ReadFull(r, message)
+	return message, err
+}
+
+// kafkaReader decodes the primitive types of the kafka protocol,
+// the first error is kept and the following reads return zero values
+type kafkaReader struct {
+	b   []byte
+	err error
+}
+
+func (r *kafkaReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || len(r.b) < n {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *kafkaReader) int8() int8 {
+	if v := r.next(1); v != nil {
+		return int8(v[0])
+	}
+	return 0
+}
+
+func (r *kafkaReader) int16() int16 {
+	if v := r.next(2); v != nil {
+		return int16(binary.BigEndian.Uint16(v))
+	}
+	return 0
+}
+
+func (r *kafkaReader) int32() int32 {
+	if v := r.next(4); v != nil {
+		return int32(binary.BigEndian.Uint32(v))
+	}
+	return 0
+}
+
+func (r *kafkaReader) int64() int64 {
+	if v := r.next(8); v != nil {
+		return int64(binary.BigEndian.Uint64(v))
+	}
+	return 0
+}
+
+func (r *kafkaReader) string() string {
+	size := r.int16()
+	if size < 0 {
+		return ""
+	}
+	return string(r.next(int(size)))
+}
+
+func (r *kafkaReader) bytes() []byte {
+	return r.next(int(r.int32()))
+}
+
+func (r *kafkaReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.b)
+	if n <= 0 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *kafkaReader) varintBytes() []byte {
+	size := r.varint()
+	if size < 0 {
+		return nil
+	}
+	return r.next(int(size))
+}
@@ -0,0 +1,227 @@
+package export
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// The kafka sink authenticates using the sasl handshake (version 1) and authenticate (version 0) requests
+// https://kafka.apache.org/protocol#sasl_handshake
+const (
+	kafkaSASLHandshakeAPIKey        int16 = 17
+	kafkaSASLHandshakeAPIVersion    int16 = 1
+	kafkaSASLAuthenticateAPIKey     int16 = 36
+	kafkaSASLAuthenticateAPIVersion int16 = 0
+
+	KafkaSASLMechanismPlain       = "PLAIN"
+	KafkaSASLMechanismScramSHA256 = "SCRAM-SHA-256"
+	KafkaSASLMechanismScramSHA512 = "SCRAM-SHA-512"
+)
+
+type KafkaSASLConfig struct {
+	// Mechanism is one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512, the connections are not authenticated if empty
+	Mechanism string
+	Username  string
+	Password  string
+}
+
+func (c *KafkaSASLConfig) validate() error {
+	if c.Mechanism == "" {
+		return nil
+	}
+	if _, err := c.conversation(); err != nil {
+		return err
+	}
+	if c.Username == "" || c.Password == "" {
+		return zerrors.ThrowInvalidArgument(nil, "EXPORT-p2Wj6r", "username and password of kafka sasl must be set")
+	}
+	return nil
+}
+
+// kafkaSASLConversation computes the messages sent to the broker
+type kafkaSASLConversation interface {
+	// step returns the message for the challenge of the broker, which is nil for the first message.
+	// done is true if the authentication completed and no message must be sent.
+	step(challenge []byte) (message []byte, done bool, err error)
+}
+
+func (c *KafkaSASLConfig) conversation() (kafkaSASLConversation, error) {
+	switch c.Mechanism {
+	case KafkaSASLMechanismPlain:
+		return &plainConversation{username: c.Username, password: c.Password}, nil
+	case KafkaSASLMechanismScramSHA256:
+		return &scramConversation{hash: sha256.New, username: c.Username, password: c.Password}, nil
+	case KafkaSASLMechanismScramSHA512:
+		return &scramConversation{hash: sha512.New, username: c.Username, password: c.Password}, nil
+	default:
+		return nil, zerrors.ThrowInvalidArgument(fmt.Errorf("unknown mechanism %q", c.Mechanism), "EXPORT-t9Bn4q", "sasl mechanism of kafka sink is not supported")
+	}
+}
+
+// authenticate runs the sasl handshake and authentication if a mechanism is configured
+func (c *kafkaConn) authenticate(config *KafkaSASLConfig) error {
+	if config.Mechanism == "" {
+		return nil
+	}
+	conversation, err := config.conversation()
+	if err != nil {
+		return err
+	}
+	response, err := c.roundTrip(kafkaSASLHandshakeAPIKey, kafkaSASLHandshakeAPIVersion, appendKafkaString(nil, config.Mechanism))
+	if err != nil {
+		return err
+	}
+	r := &kafkaReader{b: response}
+	if errorCode := r.int16(); r.err == nil && errorCode != 0 {
+		var mechanisms []string
+		for count := r.int32(); count > 0; count-- {
+			mechanisms = append(mechanisms, r.string())
+		}
+		return zerrors.ThrowPreconditionFailed(fmt.Errorf("sasl handshake failed with error code %d, enabled mechanisms: %v", errorCode, mechanisms), "EXPORT-v3Qs8d", "sasl mechanism not enabled on broker")
+	}
+	if r.err != nil {
+		return zerrors.ThrowInternal(r.err, "EXPORT-l8Dk2f", "invalid sasl handshake response")
+	}
+
+	var challenge []byte
+	for {
+		message, done, err := conversation.step(challenge)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		response, err := c.roundTrip(kafkaSASLAuthenticateAPIKey, kafkaSASLAuthenticateAPIVersion, appendKafkaBytes(nil, message))
+		if err != nil {
+			return err
+		}
+		r := &kafkaReader{b: response}
+		errorCode := r.int16()
+		errorMessage := r.string()
+		challenge = r.bytes()
+		if r.err != nil {
+			return zerrors.ThrowInternal(r.err, "EXPORT-g5Ze1n", "invalid sasl authenticate response")
+		}
+		if errorCode != 0 {
+			return zerrors.ThrowUnauthenticated(fmt.Errorf("sasl authentication failed with error code %d: %s", errorCode, errorMessage), "EXPORT-o7Jx3h", "sasl authentication on broker failed")
+		}
+	}
+}
+
+// plainConversation implements the PLAIN mechanism
+// https://www.rfc-editor.org/rfc/rfc4616
+type plainConversation struct {
+	username, password string
+	sent               bool
+}
+
+func (c *plainConversation) step([]byte) ([]byte, bool, error) {
+	if c.sent {
+		return nil, true, nil
+	}
+	c.sent = true
+	return []byte("\x00" + c.username + "\x00" + c.password), false, nil
+}
+
+// scramConversation implements the SCRAM-SHA-256 and SCRAM-SHA-512 mechanisms
+// https://www.rfc-editor.org/rfc/rfc5802
+type scramConversation struct {
+	hash               func() hash.Hash
+	username, password string
+	// nonce of the client, generated if empty
+	nonce string
+
+	state           int
+	clientFirstBare string
+	serverSignature []byte
+}
+
+func (c *scramConversation) step(challenge []byte) (message []byte, done bool, err error) {
+	defer func() { c.state++ }()
+	switch c.state {
+	case 0:
+		if c.nonce == "" {
+			nonce := make([]byte, 24)
+			if _, err = rand.Read(nonce); err != nil {
+				return nil, false, zerrors.ThrowInternal(err, "EXPORT-i4Mf7y", "unable to generate scram nonce")
+			}
+			c.nonce = base64.RawStdEncoding.EncodeToString(nonce)
+		}
+		username := strings.NewReplacer("=", "=3D", ",", "=2C").Replace(c.username)
+		c.clientFirstBare = "n=" + username + ",r=" + c.nonce
+		return []byte("n,," + c.clientFirstBare), false, nil
+	case 1:
+		return c.clientFinal(string(challenge))
+	case 2:
+		attributes := scramAttributes(string(challenge))
+		if serverErr, ok := attributes["e"]; ok {
+			return nil, false, zerrors.ThrowUnauthenticated(fmt.Errorf("scram failed: %s", serverErr), "EXPORT-b6Tr0k", "sasl authentication on broker failed")
+		}
+		signature, err := base64.StdEncoding.DecodeString(attributes["v"])
+		if err != nil || !hmac.Equal(signature, c.serverSignature) {
+			return nil, false, zerrors.ThrowUnauthenticated(err, "EXPORT-w1Xc5p", "invalid scram server signature")
+		}
+		return nil, true, nil
+	default:
+		return nil, true, nil
+	}
+}
+
+func (c *scramConversation) clientFinal(serverFirst string) ([]byte, bool, error) {
+	attributes := scramAttributes(serverFirst)
+	nonce := attributes["r"]
+	salt, err := base64.StdEncoding.DecodeString(attributes["s"])
+	if err != nil {
+		return nil, false, zerrors.ThrowInternal(err, "EXPORT-q0Ev6b", "invalid scram salt")
+	}
+	iterations, err := strconv.Atoi(attributes["i"])
+	if err != nil || iterations <= 0 {
+		return nil, false, zerrors.ThrowInternal(err, "EXPORT-y3Ha8m", "invalid scram iteration count")
+	}
+	if !strings.HasPrefix(nonce, c.nonce) || len(nonce) == len(c.nonce) {
+		return nil, false, zerrors.ThrowInternal(nil, "EXPORT-c2Uo9s", "invalid scram nonce")
+	}
+
+	saltedPassword := pbkdf2.Key([]byte(c.password), salt, iterations, c.hash().Size(), c.hash)
+	clientKey := c.hmac(saltedPassword, "Client Key")
+	storedKey := c.hash()
+	storedKey.Write(clientKey)
+	// channel binding is not supported: base64("n,,")
+	clientFinalWithoutProof := "c=biws,r=" + nonce
+	authMessage := c.clientFirstBare + "," + serverFirst + "," + clientFinalWithoutProof
+
+	proof := c.hmac(storedKey.Sum(nil), authMessage)
+	for i := range proof {
+		proof[i] ^= clientKey[i]
+	}
+	c.serverSignature = c.hmac(c.hmac(saltedPassword, "Server Key"), authMessage)
+	return []byte(clientFinalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof)), false, nil
+}
+
+func (c *scramConversation) hmac(key []byte, message string) []byte {
+	mac := hmac.New(c.hash, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+func scramAttributes(message string) map[string]string {
+	attributes := make(map[string]string)
+	for _, attribute := range strings.Split(message, ",") {
+		if key, value, ok := strings.Cut(attribute, "="); ok {
+			attributes[key] = value
+		}
+	}
+	return attributes
+}
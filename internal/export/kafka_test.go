@@ -0,0 +1,425 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type producedRecord struct {
+	broker    int32
+	partition int32
+	key       string
+	value     *Event
+	headers   map[string]string
+}
+
+// kafkaCluster is a stand-in for brokers which answer metadata requests with the leaders of the partitions,
+// decode produce requests and answer with the given error code
+type kafkaCluster struct {
+	t       *testing.T
+	brokers []*kafkaBroker
+	// leaders are the indexes of the brokers leading the partitions
+	leaders   []int32
+	errorCode int16
+	// password of the user for sasl plain, no authentication is required if empty
+	password string
+
+	metadataRequests atomic.Int32
+	records          chan *producedRecord
+}
+
+type kafkaBroker struct {
+	nodeID   int32
+	listener net.Listener
+}
+
+func newKafkaCluster(t *testing.T, brokers int, leaders []int32, errorCode int16, password string) *kafkaCluster {
+	c := &kafkaCluster{t: t, leaders: leaders, errorCode: errorCode, password: password, records: make(chan *producedRecord, 10)}
+	for i := 0; i < brokers; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = listener.Close() })
+		broker := &kafkaBroker{nodeID: int32(i), listener: listener}
+		c.brokers = append(c.brokers, broker)
+		go c.serve(broker)
+	}
+	return c
+}
+
+func (c *kafkaCluster) bootstrap() []string {
+	return []string{c.brokers[0].listener.Addr().String()}
+}
+
+func (c *kafkaCluster) serve(broker *kafkaBroker) {
+	for {
+		conn, err := broker.listener.Accept()
+		if err != nil {
+			return
+		}
+		go c.handle(broker, conn)
+	}
+}
+
+func (c *kafkaCluster) handle(broker *kafkaBroker, conn net.Conn) {
+	defer conn.Close()
+	authenticated := c.password == ""
+	for {
+		request, err := readKafkaMessage(conn)
+		if err != nil {
+			return
+		}
+		r := &kafkaReader{b: request}
+		apiKey := r.int16()
+		apiVersion := r.int16()
+		response := appendKafkaInt32(nil, r.int32())
+		assert.Equal(c.t, defaultKafkaClientID, r.string())
+
+		switch apiKey {
+		case kafkaSASLHandshakeAPIKey:
+			assert.Equal(c.t, kafkaSASLHandshakeAPIVersion, apiVersion)
+			assert.Equal(c.t, KafkaSASLMechanismPlain, r.string())
+			response = appendKafkaInt16(response, 0)
+			response = appendKafkaInt32(response, 1)
+			response = appendKafkaString(response, KafkaSASLMechanismPlain)
+		case kafkaSASLAuthenticateAPIKey:
+			assert.Equal(c.t, kafkaSASLAuthenticateAPIVersion, apiVersion)
+			authenticated = string(r.bytes()) == "\x00user\x00"+c.password
+			if authenticated {
+				response = appendKafkaInt16(response, 0)
+				response = appendKafkaInt16(response, -1)
+			} else {
+				response = appendKafkaInt16(response, 58)
+				response = appendKafkaString(response, "invalid credentials")
+			}
+			response = appendKafkaBytes(response, nil)
+		case kafkaMetadataAPIKey:
+			require.True(c.t, authenticated)
+			assert.Equal(c.t, kafkaMetadataAPIVersion, apiVersion)
+			c.metadataRequests.Add(1)
+			response = c.metadataResponse(response, r)
+		case kafkaProduceAPIKey:
+			require.True(c.t, authenticated)
+			assert.Equal(c.t, kafkaProduceAPIVersion, apiVersion)
+			response = c.produceResponse(response, broker, r)
+		default:
+			assert.Failf(c.t, "unexpected request", "api key %d", apiKey)
+			return
+		}
+		require.NoError(c.t, r.err)
+		if _, err = conn.Write(appendKafkaBytes(nil, response)); err != nil {
+			return
+		}
+	}
+}
+
+func (c *kafkaCluster) metadataResponse(response []byte, r *kafkaReader) []byte {
+	assert.EqualValues(c.t, 1, r.int32())
+	topic := r.string()
+
+	response = appendKafkaInt32(response, int32(len(c.brokers)))
+	for _, broker := range c.brokers {
+		addr := broker.listener.Addr().(*net.TCPAddr)
+		response = appendKafkaInt32(response, broker.nodeID)
+		response = appendKafkaString(response, addr.IP.String())
+		response = appendKafkaInt32(response, int32(addr.Port))
+		response = appendKafkaInt16(response, -1)
+	}
+	// controller id
+	response = appendKafkaInt32(response, 0)
+	response = appendKafkaInt32(response, 1)
+	response = appendKafkaInt16(response, 0)
+	response = appendKafkaString(response, topic)
+	response = append(response, 0)
+	response = appendKafkaInt32(response, int32(len(c.leaders)))
+	for partition, leader := range c.leaders {
+		response = appendKafkaInt16(response, 0)
+		response = appendKafkaInt32(response, int32(partition))
+		response = appendKafkaInt32(response, leader)
+		response = appendKafkaInt32(response, 1)
+		response = appendKafkaInt32(response, leader)
+		response = appendKafkaInt32(response, 1)
+		response = appendKafkaInt32(response, leader)
+	}
+	return response
+}
+
+func (c *kafkaCluster) produceResponse(response []byte, broker *kafkaBroker, r *kafkaReader) []byte {
+	// transactional id
+	r.string()
+	assert.Equal(c.t, kafkaRequiredAcksAll, r.int16())
+	r.int32()
+	assert.EqualValues(c.t, 1, r.int32())
+	topic := r.string()
+
+	response = appendKafkaInt32(response, 1)
+	response = appendKafkaString(response, topic)
+	partitions := r.int32()
+	response = appendKafkaInt32(response, partitions)
+	for ; partitions > 0; partitions-- {
+		partition := r.int32()
+		assert.Equal(c.t, c.leaders[partition], broker.nodeID, "broker does not lead partition")
+		c.readRecordBatch(broker.nodeID, partition, r.bytes())
+
+		response = appendKafkaInt32(response, partition)
+		response = appendKafkaInt16(response, c.errorCode)
+		response = appendKafkaInt64(response, 0)
+		response = appendKafkaInt64(response, -1)
+	}
+	// throttle time
+	return appendKafkaInt32(response, 0)
+}
+
+func (c *kafkaCluster) readRecordBatch(broker, partition int32, batch []byte) {
+	r := &kafkaReader{b: batch}
+	assert.EqualValues(c.t, 0, r.int64())
+	assert.EqualValues(c.t, len(batch)-12, r.int32())
+	r.int32()
+	assert.Equal(c.t, kafkaRecordBatchMagic, r.int8())
+	crc := uint32(r.int32())
+	assert.Equal(c.t, crc32.Checksum(r.b, castagnoli), crc)
+	// attributes, last offset delta, timestamps, producer id, producer epoch, base sequence
+	r.next(2 + 4 + 8 + 8 + 8 + 2 + 4)
+	for count := r.int32(); count > 0; count-- {
+		record := &kafkaReader{b: r.varintBytes()}
+		record.int8()
+		record.varint()
+		record.varint()
+		produced := &producedRecord{
+			broker:    broker,
+			partition: partition,
+			key:       string(record.varintBytes()),
+			value:     new(Event),
+			headers:   make(map[string]string),
+		}
+		assert.NoError(c.t, json.Unmarshal(record.varintBytes(), produced.value))
+		for headers := record.varint(); headers > 0; headers-- {
+			produced.headers[string(record.varintBytes())] = string(record.varintBytes())
+		}
+		assert.NoError(c.t, record.err)
+		c.records <- produced
+	}
+	assert.NoError(c.t, r.err)
+}
+
+func Test_kafkaSink_Publish(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []*Event{
+		{InstanceID: "instance1", AggregateID: "user1", Type: "user.human.added", CreatedAt: createdAt, Sequence: 1},
+		{InstanceID: "instance1", AggregateID: "user2", Type: "user.human.added", CreatedAt: createdAt.Add(time.Second), Sequence: 1},
+		{InstanceID: "instance1", AggregateID: "user3", Type: "user.human.added", CreatedAt: createdAt.Add(2 * time.Second), Sequence: 1},
+		{InstanceID: "instance1", AggregateID: "user1", Type: "user.human.changed", CreatedAt: createdAt.Add(3 * time.Second), Sequence: 2},
+	}
+	assertProduced := func(t *testing.T, cluster *kafkaCluster) {
+		produced := make(map[string][]*producedRecord)
+		for range events {
+			record := <-cluster.records
+			produced[record.key] = append(produced[record.key], record)
+		}
+		for _, event := range events {
+			record := produced[event.AggregateID][0]
+			produced[event.AggregateID] = produced[event.AggregateID][1:]
+			partition := kafkaPartition([]byte(event.AggregateID), len(cluster.leaders))
+			assert.Equal(t, partition, record.partition)
+			assert.Equal(t, cluster.leaders[partition], record.broker)
+			assert.Equal(t, event, record.value)
+			assert.Equal(t, map[string]string{"instanceId": event.InstanceID, "type": event.Type}, record.headers)
+		}
+	}
+
+	t.Run("produced", func(t *testing.T) {
+		cluster := newKafkaCluster(t, 2, []int32{0, 1, 1}, 0, "")
+		sink := newKafkaSink(&KafkaConfig{Brokers: cluster.bootstrap(), Topic: "events", Timeout: 5 * time.Second})
+		require.NoError(t, sink.validate())
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, sink.Publish(context.Background(), events))
+			assertProduced(t, cluster)
+		}
+		assert.EqualValues(t, 1, cluster.metadataRequests.Load())
+	})
+
+	t.Run("metadata expired", func(t *testing.T) {
+		cluster := newKafkaCluster(t, 1, []int32{0}, 0, "")
+		sink := newKafkaSink(&KafkaConfig{Brokers: cluster.bootstrap(), Topic: "events", Timeout: 5 * time.Second, MetadataMaxAge: time.Nanosecond})
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, sink.Publish(context.Background(), events))
+			assertProduced(t, cluster)
+		}
+		assert.EqualValues(t, 2, cluster.metadataRequests.Load())
+	})
+
+	t.Run("sasl plain", func(t *testing.T) {
+		cluster := newKafkaCluster(t, 2, []int32{1, 0}, 0, "password")
+		sink := newKafkaSink(&KafkaConfig{
+			Brokers: cluster.bootstrap(),
+			Topic:   "events",
+			Timeout: 5 * time.Second,
+			SASL:    KafkaSASLConfig{Mechanism: KafkaSASLMechanismPlain, Username: "user", Password: "password"},
+		})
+		require.NoError(t, sink.validate())
+
+		require.NoError(t, sink.Publish(context.Background(), events))
+		assertProduced(t, cluster)
+	})
+
+	t.Run("sasl plain invalid credentials", func(t *testing.T) {
+		cluster := newKafkaCluster(t, 1, []int32{0}, 0, "password")
+		sink := newKafkaSink(&KafkaConfig{
+			Brokers: cluster.bootstrap(),
+			Topic:   "events",
+			Timeout: 5 * time.Second,
+			SASL:    KafkaSASLConfig{Mechanism: KafkaSASLMechanismPlain, Username: "user", Password: "wrong"},
+		})
+
+		assert.Error(t, sink.Publish(context.Background(), events))
+		assert.EqualValues(t, 0, cluster.metadataRequests.Load())
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		// not leader for partition
+		cluster := newKafkaCluster(t, 1, []int32{0}, 6, "")
+		sink := newKafkaSink(&KafkaConfig{Brokers: cluster.bootstrap(), Topic: "events", Timeout: 5 * time.Second})
+
+		assert.Error(t, sink.Publish(context.Background(), events))
+		assertProduced(t, cluster)
+		assert.Empty(t, sink.conns)
+
+		// the leaders are looked up again
+		assert.Error(t, sink.Publish(context.Background(), events))
+		assertProduced(t, cluster)
+		assert.EqualValues(t, 2, cluster.metadataRequests.Load())
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		sink := newKafkaSink(&KafkaConfig{Brokers: []string{addr}, Topic: "events", Timeout: time.Second})
+		assert.Error(t, sink.Publish(context.Background(), events))
+	})
+
+	t.Run("second broker used for metadata", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := listener.Addr().String()
+		require.NoError(t, listener.Close())
+		cluster := newKafkaCluster(t, 1, []int32{0}, 0, "")
+
+		sink := newKafkaSink(&KafkaConfig{Brokers: append([]string{addr}, cluster.bootstrap()...), Topic: "events", Timeout: time.Second})
+		require.NoError(t, sink.Publish(context.Background(), events))
+		assertProduced(t, cluster)
+	})
+}
+
+func Test_kafkaSink_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *KafkaConfig
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			config: &KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "events"},
+		},
+		{
+			name:    "no brokers",
+			config:  &KafkaConfig{Topic: "events"},
+			wantErr: true,
+		},
+		{
+			name:    "no topic",
+			config:  &KafkaConfig{Brokers: []string{"localhost:9092"}},
+			wantErr: true,
+		},
+		{
+			name:    "ca file not found",
+			config:  &KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "events", TLS: KafkaTLSConfig{Enabled: true, CAFile: "not-found.pem"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sasl mechanism",
+			config:  &KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "events", SASL: KafkaSASLConfig{Mechanism: "GSSAPI", Username: "user", Password: "password"}},
+			wantErr: true,
+		},
+		{
+			name:    "sasl without password",
+			config:  &KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "events", SASL: KafkaSASLConfig{Mechanism: KafkaSASLMechanismScramSHA512, Username: "user"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newKafkaSink(tt.config).validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_kafkaMurmur2(t *testing.T) {
+	// test vectors of the default partitioner of kafka
+	tests := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	}
+	for key, want := range tests {
+		assert.Equal(t, want, kafkaMurmur2([]byte(key)), key)
+	}
+}
+
+func Test_scramConversation(t *testing.T) {
+	// example of https://www.rfc-editor.org/rfc/rfc7677#section-3
+	conversation := &scramConversation{hash: sha256.New, username: "user", password: "pencil", nonce: "rOprNGfwEbeRWgbNEkqO"}
+
+	message, done, err := conversation.step(nil)
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, "n,,n=user,r=rOprNGfwEbeRWgbNEkqO", string(message))
+
+	message, done, err = conversation.step([]byte("r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"))
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=", string(message))
+
+	_, done, err = conversation.step([]byte("v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4="))
+	require.NoError(t, err)
+	assert.True(t, done)
+}
+
+func Test_scramConversation_invalidServerSignature(t *testing.T) {
+	conversation := &scramConversation{hash: sha256.New, username: "user", password: "pencil", nonce: "rOprNGfwEbeRWgbNEkqO"}
+	_, _, err := conversation.step(nil)
+	require.NoError(t, err)
+	_, _, err = conversation.step([]byte("r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"))
+	require.NoError(t, err)
+
+	_, _, err = conversation.step([]byte("v=aW52YWxpZA=="))
+	assert.Error(t, err)
+}
+
+func Test_kafkaRecordBatch_crc(t *testing.T) {
+	batch := kafkaRecordBatch([]*kafkaRecord{{key: []byte("key"), value: []byte("value"), timestamp: time.Now()}})
+	// base offset, batch length, partition leader epoch, magic, crc
+	crc := binary.BigEndian.Uint32(batch[17:21])
+	assert.Equal(t, crc32.Checksum(batch[21:], castagnoli), crc)
+}
@@ -0,0 +1,41 @@
+package export
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+var projections []*handler.Handler
+
+// Register creates a handler for each configured sink
+func Register(
+	ctx context.Context,
+	exportCustomConfig projection.CustomConfig,
+	config *Config,
+	es *eventstore.Eventstore,
+) error {
+	if config == nil {
+		return nil
+	}
+	for name, sinkConfig := range config.Sinks {
+		h, err := NewHandler(ctx, projection.ApplyCustomConfig(exportCustomConfig), name, sinkConfig, es.EventTypes())
+		if err != nil {
+			return err
+		}
+		projections = append(projections, h)
+	}
+	return nil
+}
+
+func Start(ctx context.Context) {
+	for _, projection := range projections {
+		projection.Start(ctx)
+	}
+}
+
+func Projections() []*handler.Handler {
+	return projections
+}
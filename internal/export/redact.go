@@ -0,0 +1,59 @@
+package export
+
+import (
+	"slices"
+	"sync"
+)
+
+const redactedValue = "[REDACTED]"
+
+// RedactHook modifies an event before it is published, e.g. to remove personal data
+type RedactHook func(event *Event)
+
+var (
+	redactHooksMu sync.RWMutex
+	redactHooks   []RedactHook
+)
+
+// RegisterRedactHook registers a hook which is applied to the events of all sinks
+// before the configured redact fields of the sink.
+func RegisterRedactHook(hook RedactHook) {
+	redactHooksMu.Lock()
+	defer redactHooksMu.Unlock()
+	redactHooks = append(redactHooks, hook)
+}
+
+func registeredRedactHooks() []RedactHook {
+	redactHooksMu.RLock()
+	defer redactHooksMu.RUnlock()
+	return slices.Clone(redactHooks)
+}
+
+// RedactFields returns a hook which replaces the values of the fields in the payload,
+// the fields are matched on all levels of the payload.
+func RedactFields(fields ...string) RedactHook {
+	return func(event *Event) {
+		redactMap(event.Payload, fields)
+	}
+}
+
+func redactMap(payload map[string]any, fields []string) {
+	for key, value := range payload {
+		if slices.Contains(fields, key) {
+			payload[key] = redactedValue
+			continue
+		}
+		redactValue(value, fields)
+	}
+}
+
+func redactValue(value any, fields []string) {
+	switch v := value.(type) {
+	case map[string]any:
+		redactMap(v, fields)
+	case []any:
+		for _, item := range v {
+			redactValue(item, fields)
+		}
+	}
+}
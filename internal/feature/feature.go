@@ -48,6 +48,8 @@ const (
 	ImprovedPerformanceTypeProject
 	ImprovedPerformanceTypeUserGrant
 	ImprovedPerformanceTypeOrgDomainVerified
+	ImprovedPerformanceTypeUserCommands
+	ImprovedPerformanceTypeOrgCommands
 )
 
 func (f Features) ShouldUseImprovedPerformance(typ ImprovedPerformanceType) bool {
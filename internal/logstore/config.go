@@ -1,8 +1,9 @@
 package logstore
 
 type Configs struct {
-	Access    *Config
-	Execution *Config
+	Access     *Config
+	Execution  *Config
+	TargetCall *Config
 }
 
 type Config struct {
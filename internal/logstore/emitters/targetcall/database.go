@@ -0,0 +1,130 @@
+package targetcall
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	targetCallsTable          = "logstore.target_calls"
+	targetCallsLogDateCol     = "log_date"
+	targetCallsInstanceIDCol  = "instance_id"
+	targetCallsTargetIDCol    = "target_id"
+	targetCallsExecutionIDCol = "execution_id"
+	targetCallsStatusCodeCol  = "status_code"
+	targetCallsTookCol        = "took"
+	targetCallsErrorCol       = "error"
+)
+
+var _ logstore.UsageStorer[*record.TargetCallLog] = (*databaseLogStorage)(nil)
+
+type databaseLogStorage struct {
+	dbClient *database.DB
+	commands *command.Commands
+	queries  *query.Queries
+}
+
+func NewDatabaseLogStorage(dbClient *database.DB, commands *command.Commands, queries *query.Queries) *databaseLogStorage {
+	return &databaseLogStorage{dbClient: dbClient, commands: commands, queries: queries}
+}
+
+func (l *databaseLogStorage) QuotaUnit() quota.Unit {
+	return quota.ActionsTargetCalls
+}
+
+func (l *databaseLogStorage) Emit(ctx context.Context, bulk []*record.TargetCallLog) error {
+	if len(bulk) == 0 {
+		return nil
+	}
+	return errors.Join(
+		l.insert(ctx, bulk),
+		l.incrementUsage(ctx, bulk),
+	)
+}
+
+// insert stores the calls, so they can be queried with [query.Queries.SearchTargetCallLogs]
+func (l *databaseLogStorage) insert(ctx context.Context, bulk []*record.TargetCallLog) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	insert := sq.Insert(targetCallsTable).
+		Columns(
+			targetCallsLogDateCol,
+			targetCallsInstanceIDCol,
+			targetCallsTargetIDCol,
+			targetCallsExecutionIDCol,
+			targetCallsStatusCodeCol,
+			targetCallsTookCol,
+			targetCallsErrorCol,
+		).
+		PlaceholderFormat(sq.Dollar)
+	for _, r := range bulk {
+		insert = insert.Values(r.LogDate, r.InstanceID, r.TargetID, r.ExecutionID, r.StatusCode, int64(r.Took), r.Error)
+	}
+	stmt, args, err := insert.ToSql()
+	if err != nil {
+		return zerrors.ThrowInternal(err, "LOGST-k9q3xz2wfe", "Errors.Internal")
+	}
+	if _, err = l.dbClient.ExecContext(ctx, stmt, args...); err != nil {
+		return zerrors.ThrowInternal(err, "LOGST-v6c0ym8h1d", "Errors.LogStore.TargetCall.StorageFailed")
+	}
+	return nil
+}
+
+func (l *databaseLogStorage) incrementUsage(ctx context.Context, bulk []*record.TargetCallLog) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	byInstance := make(map[string][]*record.TargetCallLog)
+	for _, r := range bulk {
+		if r.InstanceID != "" {
+			byInstance[r.InstanceID] = append(byInstance[r.InstanceID], r)
+		}
+	}
+	for instanceID, instanceBulk := range byInstance {
+		q, getQuotaErr := l.queries.GetQuota(ctx, instanceID, quota.ActionsTargetCalls)
+		if errors.Is(getQuotaErr, sql.ErrNoRows) {
+			continue
+		}
+		err = errors.Join(err, getQuotaErr)
+		if getQuotaErr != nil {
+			continue
+		}
+		sum, incrementErr := l.incrementUsageFromTargetCallLogs(ctx, instanceID, q.CurrentPeriodStart, instanceBulk)
+		err = errors.Join(err, incrementErr)
+		if incrementErr != nil {
+			continue
+		}
+		notifications, getNotificationErr := l.queries.GetDueQuotaNotifications(ctx, instanceID, quota.ActionsTargetCalls, q, q.CurrentPeriodStart, sum)
+		err = errors.Join(err, getNotificationErr)
+		if getNotificationErr != nil || len(notifications) == 0 {
+			continue
+		}
+		ctx = authz.WithInstanceID(ctx, instanceID)
+		reportErr := l.commands.ReportQuotaUsage(ctx, notifications)
+		err = errors.Join(err, reportErr)
+		if reportErr != nil {
+			continue
+		}
+	}
+	return err
+}
+
+func (l *databaseLogStorage) incrementUsageFromTargetCallLogs(ctx context.Context, instanceID string, periodStart time.Time, records []*record.TargetCallLog) (sum uint64, err error) {
+	return projection.QuotaProjection.IncrementUsage(ctx, quota.ActionsTargetCalls, instanceID, periodStart, uint64(len(records)))
+}
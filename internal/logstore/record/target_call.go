@@ -0,0 +1,23 @@
+package record
+
+import (
+	"time"
+)
+
+// TargetCallLog is emitted for every call to a target of the executions
+type TargetCallLog struct {
+	LogDate     time.Time `json:"logDate"`
+	InstanceID  string    `json:"instanceId"`
+	TargetID    string    `json:"targetId"`
+	ExecutionID string    `json:"executionId,omitempty"`
+	// StatusCode is the HTTP status code of REST targets or the gRPC status code of gRPC targets,
+	// 0 if the target was not reachable
+	StatusCode int           `json:"statusCode"`
+	Took       time.Duration `json:"took"`
+	Error      string        `json:"error,omitempty"`
+}
+
+func (t TargetCallLog) Normalize() *TargetCallLog {
+	t.Error = cutString(t.Error, 2000)
+	return &t
+}
@@ -0,0 +1,452 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/oidcsession"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	BackChannelLogoutNotificationsProjectionTable = "projections.notifications_back_channel_logout"
+	// BackChannelLogoutDeliverer is the name of the deliverer sending the logout tokens
+	BackChannelLogoutDeliverer = "back_channel_logout"
+
+	// backChannelLogoutEvent is the member of the events claim identifying a logout token
+	// https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+	backChannelLogoutEvent   = "http://schemas.openid.net/event/backchannel-logout"
+	logoutTokenType          = "logout+jwt"
+	logoutTokenLifetime      = 2 * time.Minute
+	backChannelLogoutTimeout = 5 * time.Second
+)
+
+type backChannelLogoutNotifier struct {
+	commands      Commands
+	queries       *NotificationQueries
+	keyEncryption crypto.EncryptionAlgorithm
+	idGenerator   id.Generator
+	client        *http.Client
+	now           func() time.Time
+}
+
+// NewBackChannelLogoutNotifier returns the handler scheduling the logout tokens
+// for the back-channel logout uri of all clients which received tokens in an ended session.
+// The logout tokens are not sent by the handler, but persisted as deliveries of the executions,
+// which are delivered and retried in the background by the registered [BackChannelLogoutDeliverer].
+func NewBackChannelLogoutNotifier(
+	ctx context.Context,
+	config handler.Config,
+	commands Commands,
+	queries *NotificationQueries,
+	keyEncryption crypto.EncryptionAlgorithm,
+) *handler.Handler {
+	notifier := &backChannelLogoutNotifier{
+		commands:      commands,
+		queries:       queries,
+		keyEncryption: keyEncryption,
+		idGenerator:   id.SonyFlakeGenerator(),
+		client:        newBackChannelLogoutClient(),
+		now:           time.Now,
+	}
+	execution.RegisterDeliverer(BackChannelLogoutDeliverer, notifier)
+	return handler.NewHandler(ctx, &config, notifier)
+}
+
+func (*backChannelLogoutNotifier) Name() string {
+	return BackChannelLogoutNotificationsProjectionTable
+}
+
+func (n *backChannelLogoutNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: session.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  session.TerminateType,
+					Reduce: n.reduceSessionTerminated,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: n.reduceHumanSignedOut,
+				},
+				{
+					Event:  user.UserV1SignedOutType,
+					Reduce: n.reduceHumanSignedOut,
+				},
+			},
+		},
+		{
+			Aggregate: oidcsession.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  oidcsession.AccessTokenRevokedType,
+					Reduce: n.reduceOIDCSessionRevoked,
+				},
+				{
+					Event:  oidcsession.RefreshTokenRevokedType,
+					Reduce: n.reduceOIDCSessionRevoked,
+				},
+			},
+		},
+	}
+}
+
+// reduceSessionTerminated notifies the clients of all oidc sessions of the terminated session
+func (n *backChannelLogoutNotifier) reduceSessionTerminated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.TerminateEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Bcl3a", "reduce.wrong.event.type %s", session.TerminateType)
+	}
+	return n.scheduleLogouts(e, &backChannelLogouts{
+		instanceID: e.Aggregate().InstanceID,
+		sessionID:  e.Aggregate().ID,
+	}), nil
+}
+
+// reduceHumanSignedOut notifies the clients of all oidc sessions of the user, which were created in the (v1) user agent
+func (n *backChannelLogoutNotifier) reduceHumanSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Bcl1u", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanSignedOutType, user.UserV1SignedOutType})
+	}
+	return n.scheduleLogouts(e, &backChannelLogouts{
+		instanceID:  e.Aggregate().InstanceID,
+		userID:      e.Aggregate().ID,
+		userAgentID: e.UserAgentID,
+	}), nil
+}
+
+// reduceOIDCSessionRevoked notifies the client of the oidc session of which the tokens were revoked
+func (n *backChannelLogoutNotifier) reduceOIDCSessionRevoked(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *oidcsession.AccessTokenRevokedEvent, *oidcsession.RefreshTokenRevokedEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Bcl2o", "reduce.wrong.event.type %v", []eventstore.EventType{oidcsession.AccessTokenRevokedType, oidcsession.RefreshTokenRevokedType})
+	}
+	return n.scheduleLogouts(event, &backChannelLogouts{
+		instanceID:    event.Aggregate().InstanceID,
+		oidcSessionID: event.Aggregate().ID,
+	}), nil
+}
+
+// scheduleLogouts persists a delivery for every client of the oidc sessions, which was not yet notified.
+// The deliveries are added per client, so already scheduled clients are skipped if the event is reduced again.
+func (n *backChannelLogoutNotifier) scheduleLogouts(event eventstore.Event, logouts *backChannelLogouts) *handler.Statement {
+	return handler.NewStatement(event, func(handler.Executer, string) error {
+		ctx := HandlerContext(event.Aggregate())
+		if err := n.queries.es.FilterToQueryReducer(ctx, logouts); err != nil {
+			return err
+		}
+		for _, logout := range logouts.pending() {
+			if err := n.scheduleLogout(ctx, logout); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (n *backChannelLogoutNotifier) scheduleLogout(ctx context.Context, logout *backChannelLogout) error {
+	client, err := n.queries.GetOIDCClientByID(ctx, logout.clientID, false)
+	if zerrors.IsNotFound(err) {
+		// the app was removed in the meantime
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if client.BackChannelLogoutURI == "" {
+		return nil
+	}
+	ctx, err = n.queries.Origin(ctx, logout.added)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(&backChannelLogoutDelivery{
+		Issuer:    http_util.ComposedOrigin(ctx),
+		UserID:    logout.userID,
+		SessionID: logout.sessionID,
+	})
+	if err != nil {
+		return zerrors.ThrowInternal(err, "HANDL-Bcl2m", "Errors.Internal")
+	}
+	return n.commands.AddBackChannelLogoutDelivery(ctx, logout.sessionID, logout.userID, logout.clientID, logout.oidcSessions, &domain.TargetDelivery{
+		TargetID:  logout.clientID,
+		Deliverer: BackChannelLogoutDeliverer,
+		Body:      body,
+	})
+}
+
+// backChannelLogoutDelivery is the body of the delivery of a logout token to a client
+type backChannelLogoutDelivery struct {
+	Issuer    string `json:"issuer"`
+	UserID    string `json:"userId"`
+	SessionID string `json:"sessionId,omitempty"`
+}
+
+// Deliver implements [execution.Deliverer].
+// It sends a logout token to the current back-channel logout uri of the client.
+func (n *backChannelLogoutNotifier) Deliver(ctx context.Context, clientID string, body []byte) error {
+	logout := new(backChannelLogoutDelivery)
+	if err := json.Unmarshal(body, logout); err != nil {
+		return zerrors.ThrowInternal(err, "HANDL-Bcl3u", "Errors.Internal")
+	}
+	client, err := n.queries.GetOIDCClientByID(ctx, clientID, false)
+	if zerrors.IsNotFound(err) {
+		// the app was removed in the meantime
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if client.BackChannelLogoutURI == "" {
+		return nil
+	}
+	// the sid claim is only sent to clients requiring it, as other clients might reject unknown sessions
+	var sessionID string
+	if client.BackChannelLogoutSessionRequired {
+		sessionID = logout.SessionID
+	}
+	token, err := n.logoutToken(ctx, logout.Issuer, clientID, logout.UserID, sessionID)
+	if err != nil {
+		return err
+	}
+	return n.send(ctx, client.BackChannelLogoutURI, token)
+}
+
+// Timeout implements [execution.Deliverer]
+func (n *backChannelLogoutNotifier) Timeout() time.Duration {
+	return backChannelLogoutTimeout
+}
+
+// logoutTokenClaims are the claims of the logout token
+// as defined in https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+type logoutTokenClaims struct {
+	Issuer     string              `json:"iss"`
+	Subject    string              `json:"sub,omitempty"`
+	Audience   []string            `json:"aud"`
+	IssuedAt   int64               `json:"iat"`
+	Expiration int64               `json:"exp"`
+	JWTID      string              `json:"jti"`
+	Events     map[string]struct{} `json:"events"`
+	SessionID  string              `json:"sid,omitempty"`
+}
+
+func (n *backChannelLogoutNotifier) logoutToken(ctx context.Context, issuer, clientID, userID, sessionID string) (string, error) {
+	signer, err := n.signer(ctx)
+	if err != nil {
+		return "", err
+	}
+	jti, err := n.idGenerator.Next()
+	if err != nil {
+		return "", err
+	}
+	now := n.now()
+	payload, err := json.Marshal(&logoutTokenClaims{
+		Issuer:     issuer,
+		Subject:    userID,
+		Audience:   []string{clientID},
+		IssuedAt:   now.Unix(),
+		Expiration: now.Add(logoutTokenLifetime).Unix(),
+		JWTID:      jti,
+		Events:     map[string]struct{}{backChannelLogoutEvent: {}},
+		SessionID:  sessionID,
+	})
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "HANDL-Bcl4m", "Errors.Internal")
+	}
+	signed, err := signer.Sign(payload)
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "HANDL-Bcl5s", "Errors.Internal")
+	}
+	return signed.CompactSerialize()
+}
+
+// signer uses the active signing key of the instance, which is also used for the id tokens
+func (n *backChannelLogoutNotifier) signer(ctx context.Context) (jose.Signer, error) {
+	keys, err := n.queries.ActivePrivateSigningKey(ctx, n.now().Add(query.SigningKeyGracefulPeriod))
+	if err != nil {
+		return nil, err
+	}
+	// the key is generated by the oidc provider as soon as it is needed for a token, the delivery is retried until then
+	key := keys.ActiveSigningKey()
+	if key == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "HANDL-Bcl6k", "Errors.Internal")
+	}
+	keyData, err := crypto.Decrypt(key.Key(), n.keyEncryption)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := crypto.BytesToPrivateKey(keyData)
+	if err != nil {
+		return nil, err
+	}
+	return jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: jose.SignatureAlgorithm(key.Algorithm()),
+			Key:       &jose.JSONWebKey{Key: privateKey, KeyID: key.ID()},
+		},
+		(&jose.SignerOptions{}).WithType(logoutTokenType),
+	)
+}
+
+// newBackChannelLogoutClient returns a client which doesn't follow redirects,
+// so the logout token is only sent to the registered back-channel logout uri
+func newBackChannelLogoutClient() *http.Client {
+	return &http.Client{
+		Timeout: backChannelLogoutTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (n *backChannelLogoutNotifier) send(ctx context.Context, uri, token string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, strings.NewReader(url.Values{"logout_token": {token}}.Encode()))
+	if err != nil {
+		return zerrors.ThrowInternal(err, "HANDL-Bcl7r", "Errors.Internal")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return zerrors.ThrowUnavailable(err, "HANDL-Bcl8d", "Errors.Internal")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return zerrors.ThrowUnavailablef(nil, "HANDL-Bcl9f", "back-channel logout uri responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// backChannelLogouts collects the clients which received tokens in the ended oidc sessions
+// and were not yet scheduled to be notified.
+// The oidc sessions are either filtered by the oidcSessionID, the sessionID
+// or the userID and userAgentID of sessions created by the login v1.
+type backChannelLogouts struct {
+	instanceID    string
+	oidcSessionID string
+	sessionID     string
+	userID        string
+	userAgentID   string
+
+	oidcSessions map[string]*backChannelLogoutOIDCSession
+	// order keeps the oidc sessions in the order they were added
+	order []string
+}
+
+type backChannelLogoutOIDCSession struct {
+	added     *oidcsession.AddedEvent
+	scheduled bool
+}
+
+type backChannelLogout struct {
+	sessionID    string
+	clientID     string
+	userID       string
+	added        *oidcsession.AddedEvent
+	oidcSessions []*eventstore.Aggregate
+}
+
+func (l *backChannelLogouts) Reduce() error {
+	return nil
+}
+
+func (l *backChannelLogouts) AppendEvents(events ...eventstore.Event) {
+	if l.oidcSessions == nil {
+		l.oidcSessions = make(map[string]*backChannelLogoutOIDCSession)
+	}
+	for _, event := range events {
+		switch e := event.(type) {
+		case *oidcsession.AddedEvent:
+			if !l.matches(e) {
+				continue
+			}
+			l.oidcSessions[e.Aggregate().ID] = &backChannelLogoutOIDCSession{added: e}
+			l.order = append(l.order, e.Aggregate().ID)
+		case *oidcsession.BackChannelLogoutScheduledEvent:
+			if oidcSession, ok := l.oidcSessions[e.Aggregate().ID]; ok {
+				oidcSession.scheduled = true
+			}
+		}
+	}
+}
+
+// matches filters the oidc sessions of the user agent,
+// as the user agent of the login v1 is not part of the query
+func (l *backChannelLogouts) matches(e *oidcsession.AddedEvent) bool {
+	if l.userAgentID == "" {
+		return true
+	}
+	return e.SessionID == "" &&
+		e.UserAgent != nil &&
+		e.UserAgent.FingerprintID != nil &&
+		*e.UserAgent.FingerprintID == l.userAgentID
+}
+
+func (l *backChannelLogouts) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(l.instanceID).
+		AddQuery().
+		AggregateTypes(oidcsession.AggregateType).
+		EventTypes(
+			oidcsession.AddedType,
+			oidcsession.BackChannelLogoutScheduledType,
+		)
+	switch {
+	case l.oidcSessionID != "":
+		query = query.AggregateIDs(l.oidcSessionID)
+	case l.sessionID != "":
+		query = query.EventData(map[string]interface{}{"sessionID": l.sessionID})
+	default:
+		query = query.EventData(map[string]interface{}{"userID": l.userID})
+	}
+	return query.Builder()
+}
+
+// pending returns the oidc sessions which are not yet scheduled grouped by their client,
+// in the order of the first oidc session of each client
+func (l *backChannelLogouts) pending() []*backChannelLogout {
+	clients := make(map[string]*backChannelLogout)
+	pending := make([]*backChannelLogout, 0)
+	for _, id := range l.order {
+		oidcSession := l.oidcSessions[id]
+		if oidcSession.scheduled {
+			continue
+		}
+		logout, ok := clients[oidcSession.added.ClientID]
+		if !ok {
+			logout = &backChannelLogout{
+				sessionID: oidcSession.added.SessionID,
+				clientID:  oidcSession.added.ClientID,
+				userID:    oidcSession.added.UserID,
+				added:     oidcSession.added,
+			}
+			clients[logout.clientID] = logout
+			pending = append(pending, logout)
+		}
+		logout.oidcSessions = append(logout.oidcSessions, oidcSession.added.Aggregate())
+	}
+	return pending
+}
@@ -0,0 +1,473 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rsa"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	es_repo_mock "github.com/zitadel/zitadel/internal/eventstore/repository/mock"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/oidcsession"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	sessionID   = "session1"
+	instanceID  = "instance1"
+	clientID    = "client1"
+	signingKey  = "key1"
+	userAgentID = "agent1"
+)
+
+func Test_backChannelLogoutNotifier_scheduleLogouts(t *testing.T) {
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name       string
+		reduce     func(n *backChannelLogoutNotifier) (*handler.Statement, error)
+		events     []eventstore.Event
+		expectMock func(queries *mock.MockQueries, commands *mock.MockCommands)
+		want       want
+	}{
+		{
+			name:       "no oidc sessions",
+			reduce:     reduceSessionTerminated,
+			expectMock: func(*mock.MockQueries, *mock.MockCommands) {},
+		},
+		{
+			name:   "client without back-channel logout uri",
+			reduce: reduceSessionTerminated,
+			events: []eventstore.Event{
+				oidcSessionAddedEvent(t, "V2_oidc1", clientID, sessionID, ""),
+			},
+			expectMock: func(queries *mock.MockQueries, _ *mock.MockCommands) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID}, nil)
+			},
+		},
+		{
+			name:   "client removed",
+			reduce: reduceSessionTerminated,
+			events: []eventstore.Event{
+				oidcSessionAddedEvent(t, "V2_oidc1", clientID, sessionID, ""),
+			},
+			expectMock: func(queries *mock.MockQueries, _ *mock.MockCommands) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(nil, zerrors.ThrowNotFound(nil, "QUERY-wu6Ee", "Errors.App.NotFound"))
+			},
+		},
+		{
+			name:   "already scheduled",
+			reduce: reduceSessionTerminated,
+			events: []eventstore.Event{
+				oidcSessionAddedEvent(t, "V2_oidc1", clientID, sessionID, ""),
+				backChannelLogoutScheduledEvent(t, "V2_oidc1", clientID, sessionID),
+			},
+			expectMock: func(*mock.MockQueries, *mock.MockCommands) {},
+		},
+		{
+			name:   "schedule failed",
+			reduce: reduceSessionTerminated,
+			events: []eventstore.Event{
+				oidcSessionAddedEvent(t, "V2_oidc1", clientID, sessionID, ""),
+			},
+			expectMock: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID, BackChannelLogoutURI: "https://client.test/logout"}, nil)
+				commands.EXPECT().AddBackChannelLogoutDelivery(gomock.Any(), sessionID, userID, clientID, gomock.Any(), gomock.Any()).
+					Return(zerrors.ThrowInternal(nil, "id", "Errors.Internal"))
+			},
+			want: want{
+				err: zerrors.ThrowInternal(nil, "id", "Errors.Internal"),
+			},
+		},
+		{
+			name:   "session terminated, scheduled per client",
+			reduce: reduceSessionTerminated,
+			events: []eventstore.Event{
+				oidcSessionAddedEvent(t, "V2_oidc1", clientID, sessionID, ""),
+				oidcSessionAddedEvent(t, "V2_oidc2", "client2", sessionID, ""),
+				oidcSessionAddedEvent(t, "V2_oidc3", clientID, sessionID, ""),
+				backChannelLogoutScheduledEvent(t, "V2_oidc2", "client2", sessionID),
+			},
+			expectMock: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID, BackChannelLogoutURI: "https://client.test/logout"}, nil)
+				commands.EXPECT().AddBackChannelLogoutDelivery(gomock.Any(), sessionID, userID, clientID,
+					[]*eventstore.Aggregate{
+						oidcSessionAggregate("V2_oidc1"),
+						oidcSessionAggregate("V2_oidc3"),
+					},
+					&domain.TargetDelivery{
+						TargetID:  clientID,
+						Deliverer: BackChannelLogoutDeliverer,
+						Body:      []byte(`{"issuer":"` + eventOrigin + `","userId":"` + userID + `","sessionId":"` + sessionID + `"}`),
+					},
+				).Return(nil)
+			},
+		},
+		{
+			name:   "v1 user agent signed out",
+			reduce: reduceHumanSignedOut,
+			events: []eventstore.Event{
+				oidcSessionAddedEvent(t, "V2_oidc1", clientID, "", userAgentID),
+				oidcSessionAddedEvent(t, "V2_oidc2", clientID, "", "agent2"),
+				oidcSessionAddedEvent(t, "V2_oidc3", clientID, sessionID, userAgentID),
+			},
+			expectMock: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID, BackChannelLogoutURI: "https://client.test/logout"}, nil)
+				commands.EXPECT().AddBackChannelLogoutDelivery(gomock.Any(), "", userID, clientID,
+					[]*eventstore.Aggregate{
+						oidcSessionAggregate("V2_oidc1"),
+					},
+					&domain.TargetDelivery{
+						TargetID:  clientID,
+						Deliverer: BackChannelLogoutDeliverer,
+						Body:      []byte(`{"issuer":"` + eventOrigin + `","userId":"` + userID + `"}`),
+					},
+				).Return(nil)
+			},
+		},
+		{
+			name:   "oidc session revoked",
+			reduce: reduceOIDCSessionRevoked,
+			events: []eventstore.Event{
+				oidcSessionAddedEvent(t, "V2_oidc1", clientID, sessionID, ""),
+			},
+			expectMock: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID, BackChannelLogoutURI: "https://client.test/logout"}, nil)
+				commands.EXPECT().AddBackChannelLogoutDelivery(gomock.Any(), sessionID, userID, clientID,
+					[]*eventstore.Aggregate{
+						oidcSessionAggregate("V2_oidc1"),
+					},
+					&domain.TargetDelivery{
+						TargetID:  clientID,
+						Deliverer: BackChannelLogoutDeliverer,
+						Body:      []byte(`{"issuer":"` + eventOrigin + `","userId":"` + userID + `","sessionId":"` + sessionID + `"}`),
+					},
+				).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			tt.expectMock(queries, commands)
+
+			n := &backChannelLogoutNotifier{
+				commands: commands,
+				queries: NewNotificationQueries(
+					queries,
+					eventstore.NewEventstore(&eventstore.Config{
+						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents(tt.events...).MockQuerier,
+					}),
+					externalDomain,
+					externalPort,
+					externalSecure,
+					"",
+					nil,
+					nil,
+					nil,
+				),
+			}
+			stmt, err := tt.reduce(n)
+			require.NoError(t, err)
+			err = stmt.Execute(nil, "")
+			if tt.want.err != nil {
+				assert.ErrorIs(t, err, tt.want.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func reduceSessionTerminated(n *backChannelLogoutNotifier) (*handler.Statement, error) {
+	return n.reduceSessionTerminated(&session.TerminateEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+			AggregateType: session.AggregateType,
+			AggregateID:   sessionID,
+			InstanceID:    instanceID,
+			ResourceOwner: sql.NullString{String: instanceID},
+			Typ:           session.TerminateType,
+		}),
+	})
+}
+
+func reduceHumanSignedOut(n *backChannelLogoutNotifier) (*handler.Statement, error) {
+	return n.reduceHumanSignedOut(&user.HumanSignedOutEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+			AggregateType: user.AggregateType,
+			AggregateID:   userID,
+			InstanceID:    instanceID,
+			ResourceOwner: sql.NullString{String: orgID},
+			Typ:           user.HumanSignedOutType,
+		}),
+		UserAgentID: userAgentID,
+	})
+}
+
+func reduceOIDCSessionRevoked(n *backChannelLogoutNotifier) (*handler.Statement, error) {
+	return n.reduceOIDCSessionRevoked(&oidcsession.RefreshTokenRevokedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+			AggregateType: oidcsession.AggregateType,
+			AggregateID:   "V2_oidc1",
+			InstanceID:    instanceID,
+			ResourceOwner: sql.NullString{String: orgID},
+			Typ:           oidcsession.RefreshTokenRevokedType,
+		}),
+	})
+}
+
+func Test_backChannelLogoutNotifier_Deliver(t *testing.T) {
+	privateKey, _, err := crypto.GenerateKeyPair(2048)
+	require.NoError(t, err)
+	now := time.Now().Truncate(time.Second)
+
+	type want struct {
+		requests  int
+		sessionID string
+		err       error
+	}
+	tests := []struct {
+		name       string
+		status     int
+		expectMock func(queries *mock.MockQueries, logoutURI string)
+		want       want
+	}{
+		{
+			name: "client without back-channel logout uri",
+			expectMock: func(queries *mock.MockQueries, _ string) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID}, nil)
+			},
+		},
+		{
+			name: "client removed",
+			expectMock: func(queries *mock.MockQueries, _ string) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(nil, zerrors.ThrowNotFound(nil, "QUERY-wu6Ee", "Errors.App.NotFound"))
+			},
+		},
+		{
+			name: "no active signing key",
+			expectMock: func(queries *mock.MockQueries, logoutURI string) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID, BackChannelLogoutURI: logoutURI}, nil)
+				queries.EXPECT().ActivePrivateSigningKey(gomock.Any(), now.Add(query.SigningKeyGracefulPeriod)).Return(&query.PrivateKeys{}, nil)
+			},
+			want: want{
+				err: zerrors.ThrowPreconditionFailed(nil, "HANDL-Bcl6k", "Errors.Internal"),
+			},
+		},
+		{
+			name:   "back-channel logout uri fails",
+			status: http.StatusInternalServerError,
+			expectMock: func(queries *mock.MockQueries, logoutURI string) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID, BackChannelLogoutURI: logoutURI}, nil)
+				expectSigningKey(queries, now)
+			},
+			want: want{
+				requests: 1,
+				err:      zerrors.ThrowUnavailable(nil, "HANDL-Bcl9f", ""),
+			},
+		},
+		{
+			name:   "back-channel logout uri redirects",
+			status: http.StatusFound,
+			expectMock: func(queries *mock.MockQueries, logoutURI string) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID, BackChannelLogoutURI: logoutURI}, nil)
+				expectSigningKey(queries, now)
+			},
+			want: want{
+				requests: 1,
+				err:      zerrors.ThrowUnavailable(nil, "HANDL-Bcl9f", ""),
+			},
+		},
+		{
+			name:   "logout token sent without session",
+			status: http.StatusOK,
+			expectMock: func(queries *mock.MockQueries, logoutURI string) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID, BackChannelLogoutURI: logoutURI}, nil)
+				expectSigningKey(queries, now)
+			},
+			want: want{
+				requests: 1,
+			},
+		},
+		{
+			name:   "logout token sent with required session",
+			status: http.StatusOK,
+			expectMock: func(queries *mock.MockQueries, logoutURI string) {
+				queries.EXPECT().GetOIDCClientByID(gomock.Any(), clientID, false).Return(&query.OIDCClient{ClientID: clientID, BackChannelLogoutURI: logoutURI, BackChannelLogoutSessionRequired: true}, nil)
+				expectSigningKey(queries, now)
+			},
+			want: want{
+				requests:  1,
+				sessionID: sessionID,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+				assertLogoutToken(t, r.FormValue("logout_token"), privateKey, now, tt.want.sessionID)
+				w.Header().Set("Location", "/redirected")
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			tt.expectMock(queries, server.URL)
+
+			keyAlg := crypto.NewMockEncryptionAlgorithm(ctrl)
+			keyAlg.EXPECT().Algorithm().AnyTimes().Return("enc")
+			keyAlg.EXPECT().DecryptionKeyIDs().AnyTimes().Return([]string{"id"})
+			keyAlg.EXPECT().Decrypt(gomock.Any(), gomock.Any()).AnyTimes().Return(crypto.PrivateKeyToBytes(privateKey), nil)
+
+			idGenerator := id_mock.NewMockGenerator(ctrl)
+			idGenerator.EXPECT().Next().AnyTimes().Return("jti1", nil)
+
+			n := &backChannelLogoutNotifier{
+				queries:       NewNotificationQueries(queries, nil, externalDomain, externalPort, externalSecure, "", nil, nil, nil),
+				keyEncryption: keyAlg,
+				idGenerator:   idGenerator,
+				client:        newBackChannelLogoutClient(),
+				now:           func() time.Time { return now },
+			}
+			err := n.Deliver(
+				authz.WithInstanceID(context.Background(), instanceID),
+				clientID,
+				[]byte(`{"issuer":"`+eventOrigin+`","userId":"`+userID+`","sessionId":"`+sessionID+`"}`),
+			)
+			if tt.want.err != nil {
+				assert.ErrorIs(t, err, tt.want.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want.requests, requests)
+		})
+	}
+}
+
+func oidcSessionAddedEvent(t *testing.T, oidcSessionID, clientID, sessionID, userAgentID string) eventstore.Event {
+	added := &oidcsession.AddedEvent{
+		UserID:            userID,
+		UserResourceOwner: orgID,
+		SessionID:         sessionID,
+		ClientID:          clientID,
+		TriggeredAtOrigin: eventOrigin,
+	}
+	if userAgentID != "" {
+		added.UserAgent = &domain.UserAgent{FingerprintID: &userAgentID}
+	}
+	data, err := json.Marshal(added)
+	require.NoError(t, err)
+	return oidcSessionEvent(oidcSessionID, oidcsession.AddedType, data)
+}
+
+func backChannelLogoutScheduledEvent(t *testing.T, oidcSessionID, clientID, sessionID string) eventstore.Event {
+	data, err := json.Marshal(&oidcsession.BackChannelLogoutScheduledEvent{
+		SessionID: sessionID,
+		UserID:    userID,
+		ClientID:  clientID,
+	})
+	require.NoError(t, err)
+	return oidcSessionEvent(oidcSessionID, oidcsession.BackChannelLogoutScheduledType, data)
+}
+
+func oidcSessionEvent(oidcSessionID string, typ eventstore.EventType, data []byte) eventstore.Event {
+	return &repository.Event{
+		AggregateType: oidcsession.AggregateType,
+		AggregateID:   oidcSessionID,
+		InstanceID:    instanceID,
+		ResourceOwner: sql.NullString{String: orgID, Valid: true},
+		Typ:           typ,
+		Data:          data,
+		Version:       oidcsession.AggregateVersion,
+	}
+}
+
+func oidcSessionAggregate(oidcSessionID string) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            oidcSessionID,
+		Type:          oidcsession.AggregateType,
+		ResourceOwner: orgID,
+		InstanceID:    instanceID,
+		Version:       oidcsession.AggregateVersion,
+	}
+}
+
+// expectSigningKey returns the encrypted key, which is decrypted by the mocked key encryption
+// the key which expires first is not active anymore and must not be used
+func expectSigningKey(queries *mock.MockQueries, now time.Time) {
+	queries.EXPECT().ActivePrivateSigningKey(gomock.Any(), now.Add(query.SigningKeyGracefulPeriod)).Return(&query.PrivateKeys{
+		Keys: []query.PrivateKey{
+			&testPrivateKey{id: "expiring", value: &crypto.CryptoValue{
+				CryptoType: crypto.TypeEncryption,
+				Algorithm:  "enc",
+				KeyID:      "id",
+				Crypted:    []byte("encrypted"),
+			}},
+			&testPrivateKey{id: signingKey, value: &crypto.CryptoValue{
+				CryptoType: crypto.TypeEncryption,
+				Algorithm:  "enc",
+				KeyID:      "id",
+				Crypted:    []byte("encrypted"),
+			}},
+		},
+	}, nil)
+}
+
+func assertLogoutToken(t *testing.T, token string, privateKey *rsa.PrivateKey, now time.Time, sessionID string) {
+	signed, err := jose.ParseSigned(token, []jose.SignatureAlgorithm{jose.RS256})
+	require.NoError(t, err)
+	assert.Equal(t, signingKey, signed.Signatures[0].Header.KeyID)
+	assert.Equal(t, logoutTokenType, signed.Signatures[0].Header.ExtraHeaders[jose.HeaderType])
+	payload, err := signed.Verify(&privateKey.PublicKey)
+	require.NoError(t, err)
+	claims := new(logoutTokenClaims)
+	require.NoError(t, json.Unmarshal(payload, claims))
+	assert.Equal(t, &logoutTokenClaims{
+		Issuer:     eventOrigin,
+		Subject:    userID,
+		Audience:   []string{clientID},
+		IssuedAt:   now.Unix(),
+		Expiration: now.Add(logoutTokenLifetime).Unix(),
+		JWTID:      "jti1",
+		Events:     map[string]struct{}{backChannelLogoutEvent: {}},
+		SessionID:  sessionID,
+	}, claims)
+}
+
+type testPrivateKey struct {
+	id    string
+	value *crypto.CryptoValue
+}
+
+func (k *testPrivateKey) ID() string               { return k.id }
+func (k *testPrivateKey) Algorithm() string        { return string(jose.RS256) }
+func (k *testPrivateKey) Use() domain.KeyUsage     { return domain.KeyUsageSigning }
+func (k *testPrivateKey) Sequence() uint64         { return 1 }
+func (k *testPrivateKey) Expiry() time.Time        { return time.Now().Add(time.Hour) }
+func (k *testPrivateKey) Key() *crypto.CryptoValue { return k.value }
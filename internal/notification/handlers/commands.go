@@ -3,6 +3,8 @@ package handlers
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/milestone"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 )
@@ -21,4 +23,5 @@ type Commands interface {
 	HumanPhoneVerificationCodeSent(ctx context.Context, orgID, userID string) error
 	UsageNotificationSent(ctx context.Context, dueEvent *quota.NotificationDueEvent) error
 	MilestonePushed(ctx context.Context, msType milestone.Type, endpoints []string, primaryDomain string) error
+	AddBackChannelLogoutDelivery(ctx context.Context, sessionID, userID, clientID string, oidcSessions []*eventstore.Aggregate, logout *domain.TargetDelivery) error
 }
@@ -13,6 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	domain "github.com/zitadel/zitadel/internal/domain"
+	eventstore "github.com/zitadel/zitadel/internal/eventstore"
 	milestone "github.com/zitadel/zitadel/internal/repository/milestone"
 	quota "github.com/zitadel/zitadel/internal/repository/quota"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +43,20 @@ func (m *MockCommands) EXPECT() *MockCommandsMockRecorder {
 	return m.recorder
 }
 
+// AddBackChannelLogoutDelivery mocks base method.
+func (m *MockCommands) AddBackChannelLogoutDelivery(arg0 context.Context, arg1, arg2, arg3 string, arg4 []*eventstore.Aggregate, arg5 *domain.TargetDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBackChannelLogoutDelivery", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBackChannelLogoutDelivery indicates an expected call of AddBackChannelLogoutDelivery.
+func (mr *MockCommandsMockRecorder) AddBackChannelLogoutDelivery(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBackChannelLogoutDelivery", reflect.TypeOf((*MockCommands)(nil).AddBackChannelLogoutDelivery), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // HumanEmailVerificationCodeSent mocks base method.
 func (m *MockCommands) HumanEmailVerificationCodeSent(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/zitadel/zitadel/internal/domain"
 	query "github.com/zitadel/zitadel/internal/query"
@@ -57,6 +58,21 @@ func (mr *MockQueriesMockRecorder) ActiveLabelPolicyByOrg(arg0, arg1, arg2 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveLabelPolicyByOrg", reflect.TypeOf((*MockQueries)(nil).ActiveLabelPolicyByOrg), arg0, arg1, arg2)
 }
 
+// ActivePrivateSigningKey mocks base method.
+func (m *MockQueries) ActivePrivateSigningKey(arg0 context.Context, arg1 time.Time) (*query.PrivateKeys, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivePrivateSigningKey", arg0, arg1)
+	ret0, _ := ret[0].(*query.PrivateKeys)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivePrivateSigningKey indicates an expected call of ActivePrivateSigningKey.
+func (mr *MockQueriesMockRecorder) ActivePrivateSigningKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivePrivateSigningKey", reflect.TypeOf((*MockQueries)(nil).ActivePrivateSigningKey), arg0, arg1)
+}
+
 // CustomTextListByTemplate mocks base method.
 func (m *MockQueries) CustomTextListByTemplate(arg0 context.Context, arg1, arg2 string, arg3 bool) (*query.CustomTexts, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +132,21 @@ func (mr *MockQueriesMockRecorder) GetNotifyUserByID(arg0, arg1, arg2 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifyUserByID", reflect.TypeOf((*MockQueries)(nil).GetNotifyUserByID), arg0, arg1, arg2)
 }
 
+// GetOIDCClientByID mocks base method.
+func (m *MockQueries) GetOIDCClientByID(arg0 context.Context, arg1 string, arg2 bool) (*query.OIDCClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOIDCClientByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*query.OIDCClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOIDCClientByID indicates an expected call of GetOIDCClientByID.
+func (mr *MockQueriesMockRecorder) GetOIDCClientByID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOIDCClientByID", reflect.TypeOf((*MockQueries)(nil).GetOIDCClientByID), arg0, arg1, arg2)
+}
+
 // MailTemplateByOrg mocks base method.
 func (m *MockQueries) MailTemplateByOrg(arg0 context.Context, arg1 string, arg2 bool) (*query.MailTemplate, error) {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/text/language"
 
@@ -25,6 +26,8 @@ type Queries interface {
 	SMTPConfigActive(ctx context.Context, resourceOwner string) (*query.SMTPConfig, error)
 	GetDefaultLanguage(ctx context.Context) language.Tag
 	GetInstanceRestrictions(ctx context.Context) (restrictions query.Restrictions, err error)
+	GetOIDCClientByID(ctx context.Context, clientID string, getKeys bool) (client *query.OIDCClient, err error)
+	ActivePrivateSigningKey(ctx context.Context, t time.Time) (keys *query.PrivateKeys, err error)
 }
 
 type NotificationQueries struct {
@@ -41,24 +41,29 @@ type App struct {
 }
 
 type OIDCApp struct {
-	RedirectURIs             database.TextArray[string]
-	ResponseTypes            database.NumberArray[domain.OIDCResponseType]
-	GrantTypes               database.NumberArray[domain.OIDCGrantType]
-	AppType                  domain.OIDCApplicationType
-	ClientID                 string
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectURIs   database.TextArray[string]
-	Version                  domain.OIDCVersion
-	ComplianceProblems       database.TextArray[string]
-	IsDevMode                bool
-	AccessTokenType          domain.OIDCTokenType
-	AssertAccessTokenRole    bool
-	AssertIDTokenRole        bool
-	AssertIDTokenUserinfo    bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        database.TextArray[string]
-	AllowedOrigins           database.TextArray[string]
-	SkipNativeAppSuccessPage bool
+	RedirectURIs                     database.TextArray[string]
+	ResponseTypes                    database.NumberArray[domain.OIDCResponseType]
+	GrantTypes                       database.NumberArray[domain.OIDCGrantType]
+	AppType                          domain.OIDCApplicationType
+	ClientID                         string
+	AuthMethodType                   domain.OIDCAuthMethodType
+	PostLogoutRedirectURIs           database.TextArray[string]
+	Version                          domain.OIDCVersion
+	ComplianceProblems               database.TextArray[string]
+	IsDevMode                        bool
+	AccessTokenType                  domain.OIDCTokenType
+	AssertAccessTokenRole            bool
+	AssertIDTokenRole                bool
+	AssertIDTokenUserinfo            bool
+	ClockSkew                        time.Duration
+	AdditionalOrigins                database.TextArray[string]
+	AllowedOrigins                   database.TextArray[string]
+	SkipNativeAppSuccessPage         bool
+	BackChannelLogoutURI             string
+	BackChannelLogoutSessionRequired bool
+	RequirePushedAuthRequests        bool
+	RequireDPoP                      bool
+	TLSClientAuthSubjectDN           string
 }
 
 type SAMLApp struct {
@@ -243,6 +248,26 @@ var (
 		name:  projection.AppOIDCConfigColumnSkipNativeAppSuccessPage,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnBackChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnBackChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnBackChannelLogoutSessionRequired = Column{
+		name:  projection.AppOIDCConfigColumnBackChannelLogoutSessionRequired,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequirePushedAuthRequests = Column{
+		name:  projection.AppOIDCConfigColumnRequirePushedAuthRequests,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequireDPoP = Column{
+		name:  projection.AppOIDCConfigColumnRequireDPoP,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnTLSClientAuthSubjectDN = Column{
+		name:  projection.AppOIDCConfigColumnTLSClientAuthSubjectDN,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string) (app *App, err error) {
@@ -523,6 +548,11 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutSessionRequired.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequests.identifier(),
+			AppOIDCConfigColumnRequireDPoP.identifier(),
+			AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -572,6 +602,11 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
 				&oidcConfig.skipNativeAppSuccessPage,
+				&oidcConfig.backChannelLogoutURI,
+				&oidcConfig.backChannelLogoutSessionRequired,
+				&oidcConfig.requirePushedAuthRequests,
+				&oidcConfig.requireDPoP,
+				&oidcConfig.tlsClientAuthSubjectDN,
 
 				&samlConfig.appID,
 				&samlConfig.entityID,
@@ -622,6 +657,11 @@ func prepareOIDCAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutSessionRequired.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequests.identifier(),
+			AppOIDCConfigColumnRequireDPoP.identifier(),
+			AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
 		).From(appsTable.identifier()).
 			Join(join(AppOIDCConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*App, error) {
@@ -658,6 +698,11 @@ func prepareOIDCAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
 				&oidcConfig.skipNativeAppSuccessPage,
+				&oidcConfig.backChannelLogoutURI,
+				&oidcConfig.backChannelLogoutSessionRequired,
+				&oidcConfig.requirePushedAuthRequests,
+				&oidcConfig.requireDPoP,
+				&oidcConfig.tlsClientAuthSubjectDN,
 			)
 
 			if err != nil {
@@ -867,6 +912,11 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutSessionRequired.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequests.identifier(),
+			AppOIDCConfigColumnRequireDPoP.identifier(),
+			AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -919,6 +969,11 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
 					&oidcConfig.skipNativeAppSuccessPage,
+					&oidcConfig.backChannelLogoutURI,
+					&oidcConfig.backChannelLogoutSessionRequired,
+					&oidcConfig.requirePushedAuthRequests,
+					&oidcConfig.requireDPoP,
+					&oidcConfig.tlsClientAuthSubjectDN,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
@@ -974,23 +1029,28 @@ func prepareClientIDsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 }
 
 type sqlOIDCConfig struct {
-	appID                    sql.NullString
-	version                  sql.NullInt32
-	clientID                 sql.NullString
-	redirectUris             database.TextArray[string]
-	applicationType          sql.NullInt16
-	authMethodType           sql.NullInt16
-	postLogoutRedirectUris   database.TextArray[string]
-	devMode                  sql.NullBool
-	accessTokenType          sql.NullInt16
-	accessTokenRoleAssertion sql.NullBool
-	iDTokenRoleAssertion     sql.NullBool
-	iDTokenUserinfoAssertion sql.NullBool
-	clockSkew                sql.NullInt64
-	additionalOrigins        database.TextArray[string]
-	responseTypes            database.NumberArray[domain.OIDCResponseType]
-	grantTypes               database.NumberArray[domain.OIDCGrantType]
-	skipNativeAppSuccessPage sql.NullBool
+	appID                            sql.NullString
+	version                          sql.NullInt32
+	clientID                         sql.NullString
+	redirectUris                     database.TextArray[string]
+	applicationType                  sql.NullInt16
+	authMethodType                   sql.NullInt16
+	postLogoutRedirectUris           database.TextArray[string]
+	devMode                          sql.NullBool
+	accessTokenType                  sql.NullInt16
+	accessTokenRoleAssertion         sql.NullBool
+	iDTokenRoleAssertion             sql.NullBool
+	iDTokenUserinfoAssertion         sql.NullBool
+	clockSkew                        sql.NullInt64
+	additionalOrigins                database.TextArray[string]
+	responseTypes                    database.NumberArray[domain.OIDCResponseType]
+	grantTypes                       database.NumberArray[domain.OIDCGrantType]
+	skipNativeAppSuccessPage         sql.NullBool
+	backChannelLogoutURI             sql.NullString
+	backChannelLogoutSessionRequired sql.NullBool
+	requirePushedAuthRequests        sql.NullBool
+	requireDPoP                      sql.NullBool
+	tlsClientAuthSubjectDN           sql.NullString
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -998,22 +1058,27 @@ func (c sqlOIDCConfig) set(app *App) {
 		return
 	}
 	app.OIDCConfig = &OIDCApp{
-		Version:                  domain.OIDCVersion(c.version.Int32),
-		ClientID:                 c.clientID.String,
-		RedirectURIs:             c.redirectUris,
-		AppType:                  domain.OIDCApplicationType(c.applicationType.Int16),
-		AuthMethodType:           domain.OIDCAuthMethodType(c.authMethodType.Int16),
-		PostLogoutRedirectURIs:   c.postLogoutRedirectUris,
-		IsDevMode:                c.devMode.Bool,
-		AccessTokenType:          domain.OIDCTokenType(c.accessTokenType.Int16),
-		AssertAccessTokenRole:    c.accessTokenRoleAssertion.Bool,
-		AssertIDTokenRole:        c.iDTokenRoleAssertion.Bool,
-		AssertIDTokenUserinfo:    c.iDTokenUserinfoAssertion.Bool,
-		ClockSkew:                time.Duration(c.clockSkew.Int64),
-		AdditionalOrigins:        c.additionalOrigins,
-		ResponseTypes:            c.responseTypes,
-		GrantTypes:               c.grantTypes,
-		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
+		Version:                          domain.OIDCVersion(c.version.Int32),
+		ClientID:                         c.clientID.String,
+		RedirectURIs:                     c.redirectUris,
+		AppType:                          domain.OIDCApplicationType(c.applicationType.Int16),
+		AuthMethodType:                   domain.OIDCAuthMethodType(c.authMethodType.Int16),
+		PostLogoutRedirectURIs:           c.postLogoutRedirectUris,
+		IsDevMode:                        c.devMode.Bool,
+		AccessTokenType:                  domain.OIDCTokenType(c.accessTokenType.Int16),
+		AssertAccessTokenRole:            c.accessTokenRoleAssertion.Bool,
+		AssertIDTokenRole:                c.iDTokenRoleAssertion.Bool,
+		AssertIDTokenUserinfo:            c.iDTokenUserinfoAssertion.Bool,
+		ClockSkew:                        time.Duration(c.clockSkew.Int64),
+		AdditionalOrigins:                c.additionalOrigins,
+		ResponseTypes:                    c.responseTypes,
+		GrantTypes:                       c.grantTypes,
+		SkipNativeAppSuccessPage:         c.skipNativeAppSuccessPage.Bool,
+		BackChannelLogoutURI:             c.backChannelLogoutURI.String,
+		BackChannelLogoutSessionRequired: c.backChannelLogoutSessionRequired.Bool,
+		RequirePushedAuthRequests:        c.requirePushedAuthRequests.Bool,
+		RequireDPoP:                      c.requireDPoP.Bool,
+		TLSClientAuthSubjectDN:           c.tlsClientAuthSubjectDN.String,
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
@@ -45,6 +45,11 @@ var (
 		` projections.apps7_oidc_configs.clock_skew,` +
 		` projections.apps7_oidc_configs.additional_origins,` +
 		` projections.apps7_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps7_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps7_oidc_configs.back_channel_logout_session_required,` +
+		` projections.apps7_oidc_configs.require_pushed_auth_requests,` +
+		` projections.apps7_oidc_configs.require_dpop,` +
+		` projections.apps7_oidc_configs.tls_client_auth_subject_dn,` +
 		//saml config
 		` projections.apps7_saml_configs.app_id,` +
 		` projections.apps7_saml_configs.entity_id,` +
@@ -85,6 +90,11 @@ var (
 		` projections.apps7_oidc_configs.clock_skew,` +
 		` projections.apps7_oidc_configs.additional_origins,` +
 		` projections.apps7_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps7_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps7_oidc_configs.back_channel_logout_session_required,` +
+		` projections.apps7_oidc_configs.require_pushed_auth_requests,` +
+		` projections.apps7_oidc_configs.require_dpop,` +
+		` projections.apps7_oidc_configs.tls_client_auth_subject_dn,` +
 		//saml config
 		` projections.apps7_saml_configs.app_id,` +
 		` projections.apps7_saml_configs.entity_id,` +
@@ -157,6 +167,11 @@ var (
 		"clock_skew",
 		"additional_origins",
 		"skip_native_app_success_page",
+		"back_channel_logout_uri",
+		"back_channel_logout_session_required",
+		"require_pushed_auth_requests",
+		"require_dpop",
+		"tls_client_auth_subject_dn",
 		//saml config
 		"app_id",
 		"entity_id",
@@ -228,6 +243,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -294,6 +314,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -363,6 +388,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -434,6 +464,11 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -520,6 +555,11 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -606,6 +646,11 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -692,6 +737,11 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -778,6 +828,11 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -864,6 +919,11 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							true,
+							"https://logout.ch",
+							true,
+							true,
+							true,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -888,24 +948,28 @@ func Test_AppsPrepare(t *testing.T) {
 						Name:          "app-name",
 						ProjectID:     "project-id",
 						OIDCConfig: &OIDCApp{
-							Version:                  domain.OIDCVersionV1,
-							ClientID:                 "oidc-client-id",
-							RedirectURIs:             database.TextArray[string]{"https://redirect.to/me"},
-							ResponseTypes:            database.NumberArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
-							GrantTypes:               database.NumberArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
-							AppType:                  domain.OIDCApplicationTypeNative,
-							AuthMethodType:           domain.OIDCAuthMethodTypeNone,
-							PostLogoutRedirectURIs:   database.TextArray[string]{"post.logout.ch"},
-							IsDevMode:                false,
-							AccessTokenType:          domain.OIDCTokenTypeJWT,
-							AssertAccessTokenRole:    false,
-							AssertIDTokenRole:        false,
-							AssertIDTokenUserinfo:    true,
-							ClockSkew:                1 * time.Second,
-							AdditionalOrigins:        database.TextArray[string]{"additional.origin"},
-							ComplianceProblems:       nil,
-							AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
-							SkipNativeAppSuccessPage: true,
+							Version:                          domain.OIDCVersionV1,
+							ClientID:                         "oidc-client-id",
+							RedirectURIs:                     database.TextArray[string]{"https://redirect.to/me"},
+							ResponseTypes:                    database.NumberArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							GrantTypes:                       database.NumberArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							AppType:                          domain.OIDCApplicationTypeNative,
+							AuthMethodType:                   domain.OIDCAuthMethodTypeNone,
+							PostLogoutRedirectURIs:           database.TextArray[string]{"post.logout.ch"},
+							IsDevMode:                        false,
+							AccessTokenType:                  domain.OIDCTokenTypeJWT,
+							AssertAccessTokenRole:            false,
+							AssertIDTokenRole:                false,
+							AssertIDTokenUserinfo:            true,
+							ClockSkew:                        1 * time.Second,
+							AdditionalOrigins:                database.TextArray[string]{"additional.origin"},
+							ComplianceProblems:               nil,
+							AllowedOrigins:                   database.TextArray[string]{"https://redirect.to", "additional.origin"},
+							SkipNativeAppSuccessPage:         true,
+							BackChannelLogoutURI:             "https://logout.ch",
+							BackChannelLogoutSessionRequired: true,
+							RequirePushedAuthRequests:        true,
+							RequireDPoP:                      true,
 						},
 					},
 				},
@@ -950,6 +1014,11 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -987,6 +1056,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1024,6 +1098,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
@@ -1195,6 +1274,11 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
@@ -1253,6 +1337,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1316,6 +1405,11 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1394,6 +1488,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -1458,6 +1557,11 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1537,6 +1641,11 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1616,6 +1725,11 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1695,6 +1809,11 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							false,
+							false,
+							false,
+							nil,
 							// saml config
 							nil,
 							nil,
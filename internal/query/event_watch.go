@@ -0,0 +1,245 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	watchEventsBatchSize    = 100
+	watchEventsPollInterval = time.Second
+)
+
+// EventPosition is the position of an event in the eventstore.
+// It is used as cursor to resume watching events.
+type EventPosition struct {
+	Position  float64
+	InTxOrder uint32
+}
+
+type WatchEventsQuery struct {
+	InstanceIDs    []string
+	From           EventPosition
+	AggregateTypes []string
+	AggregateID    string
+	EventTypes     []string
+	EditorUserID   string
+	ResourceOwner  string
+}
+
+type WatchedEvent struct {
+	*Event
+	Position EventPosition
+}
+
+// WatchEvents sends the events matching the query ordered by their position, starting after query.From.
+// Once all stored events are sent, it polls for newly committed events until ctx is done or send returns an error.
+func (q *Queries) WatchEvents(ctx context.Context, query *WatchEventsQuery, send func(*WatchedEvent) error) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if len(query.InstanceIDs) == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "QUERY-w3Kq8a", "Errors.Instance.IDMissing")
+	}
+
+	reducer := &watchEventsReducer{
+		ctx:     ctx,
+		q:       q,
+		query:   query,
+		editors: make(map[string]*EventEditor),
+	}
+	position := query.From
+	for {
+		eventCount, err := q.watchEventsBatch(ctx, reducer, position)
+		if err != nil {
+			return err
+		}
+		for _, event := range reducer.events {
+			if err = send(event); err != nil {
+				return err
+			}
+		}
+		position = reducer.position
+		if eventCount == watchEventsBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchEventsPollInterval):
+		}
+	}
+}
+
+func (q *Queries) watchEventsBatch(ctx context.Context, reducer *watchEventsReducer, from EventPosition) (eventCount int, err error) {
+	if oldest, ok := q.oldestAllowedPosition(ctx); ok && from.Position < oldest {
+		from = EventPosition{Position: oldest}
+	}
+	reducer.reset(from)
+
+	eventCount, err = q.eventStoreV4.Query(
+		ctx,
+		es_v4.NewQuery(
+			reducer.query.InstanceIDs[0],
+			reducer,
+			es_v4.InstancesEqual(reducer.query.InstanceIDs...),
+			// the position is set when the event is inserted,
+			// events of transactions which are still open would be skipped otherwise
+			es_v4.AwaitOpenTransactions(),
+			es_v4.AppendFilters(reducer.query.filter(
+				&es_v4.GlobalPosition{Position: from.Position, InPositionOrder: from.InTxOrder},
+			)),
+		),
+	)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "QUERY-Xo0ak5", "Errors.Query.SQLStatement")
+	}
+	return eventCount, nil
+}
+
+// oldestAllowedPosition returns the position of the oldest event allowed by the audit log retention
+func (q *Queries) oldestAllowedPosition(ctx context.Context) (float64, bool) {
+	auditLogRetention := q.defaultAuditLogRetention
+	if instanceAuditLogRetention := authz.GetInstance(ctx).AuditLogRetention(); instanceAuditLogRetention != nil {
+		auditLogRetention = *instanceAuditLogRetention
+	}
+	if auditLogRetention == 0 {
+		return 0, false
+	}
+	return timeToPosition(time.Now().Add(-auditLogRetention)), true
+}
+
+func timeToPosition(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+func (query *WatchEventsQuery) filter(from *es_v4.GlobalPosition) *es_v4.Filter {
+	aggregateTypes := slices.Clone(query.AggregateTypes)
+	if len(aggregateTypes) == 0 {
+		for _, eventType := range query.EventTypes {
+			aggregateTypes = append(aggregateTypes, string(eventstore.AggregateTypeFromEventType(eventstore.EventType(eventType))))
+		}
+	}
+	slices.Sort(aggregateTypes)
+	aggregateTypes = slices.Compact(aggregateTypes)
+	// event types which are not registered have no aggregate type
+	aggregateTypes = slices.DeleteFunc(aggregateTypes, func(aggregateType string) bool {
+		return aggregateType == ""
+	})
+
+	opts := make([]es_v4.FilterOpt, 0, len(aggregateTypes)+1)
+	opts = append(opts, es_v4.FilterPagination(
+		es_v4.GlobalPositionGreater(from),
+		es_v4.Limit(watchEventsBatchSize),
+	))
+	for _, aggregateType := range aggregateTypes {
+		aggregateOpts := make([]es_v4.AggregateFilterOpt, 0, 3)
+		if query.AggregateID != "" {
+			aggregateOpts = append(aggregateOpts, es_v4.SetAggregateID(query.AggregateID))
+		}
+		if query.ResourceOwner != "" {
+			aggregateOpts = append(aggregateOpts, es_v4.AggregateOwnersEqual(query.ResourceOwner))
+		}
+		if len(query.EventTypes) > 0 || query.EditorUserID != "" {
+			eventOpts := []es_v4.EventFilterOpt{es_v4.SetEventTypes(query.EventTypes...)}
+			if query.EditorUserID != "" {
+				eventOpts = append(eventOpts, es_v4.EventCreatorsEqual(query.EditorUserID))
+			}
+			aggregateOpts = append(aggregateOpts, es_v4.AppendEvent(eventOpts...))
+		}
+		opts = append(opts, es_v4.AppendAggregateFilter(aggregateType, aggregateOpts...))
+	}
+	return es_v4.NewFilter(opts...)
+}
+
+// matches checks the conditions which cannot be filtered by the eventstore
+// if no aggregate type is defined.
+func (query *WatchEventsQuery) matches(event *es_v4.StorageEvent) bool {
+	if query.AggregateID != "" && event.Aggregate.ID != query.AggregateID {
+		return false
+	}
+	if query.ResourceOwner != "" && event.Aggregate.Owner != query.ResourceOwner {
+		return false
+	}
+	if query.EditorUserID != "" && event.Creator != query.EditorUserID {
+		return false
+	}
+	return len(query.EventTypes) == 0 || slices.Contains(query.EventTypes, event.Type)
+}
+
+type watchEventsReducer struct {
+	ctx   context.Context
+	q     *Queries
+	query *WatchEventsQuery
+
+	events   []*WatchedEvent
+	position EventPosition
+	editors  map[string]*EventEditor
+}
+
+func (r *watchEventsReducer) reset(position EventPosition) {
+	r.events = r.events[:0]
+	r.position = position
+}
+
+func (r *watchEventsReducer) Reduce(events ...*es_v4.StorageEvent) error {
+	for _, event := range events {
+		r.position = EventPosition{
+			Position:  event.Position.Position,
+			InTxOrder: event.Position.InPositionOrder,
+		}
+		if !r.query.matches(event) {
+			continue
+		}
+		var payload json.RawMessage
+		if err := event.Payload(&payload); err != nil {
+			return zerrors.ThrowInternal(err, "QUERY-n4Lw9d", "Errors.Internal")
+		}
+		r.events = append(r.events, &WatchedEvent{
+			Event: &Event{
+				Editor: r.editor(event.Aggregate.Instance, event.Creator),
+				Aggregate: &eventstore.Aggregate{
+					ID:            event.Aggregate.ID,
+					Type:          eventstore.AggregateType(event.Aggregate.Type),
+					ResourceOwner: event.Aggregate.Owner,
+					InstanceID:    event.Aggregate.Instance,
+				},
+				Sequence:     uint64(event.Sequence),
+				CreationDate: event.CreatedAt,
+				Type:         event.Type,
+				Payload:      payload,
+			},
+			Position: r.position,
+		})
+	}
+	return nil
+}
+
+func (r *watchEventsReducer) editor(instanceID, userID string) *EventEditor {
+	key := instanceID + ":" + userID
+	editor, ok := r.editors[key]
+	if !ok {
+		ctx := r.ctx
+		if authz.GetInstance(ctx).InstanceID() != instanceID {
+			ctx = authz.WithInstanceID(ctx, instanceID)
+		}
+		editor = r.q.editorUserByID(ctx, userID)
+		r.editors[key] = editor
+	}
+	return &EventEditor{
+		ID:                userID,
+		Service:           "zitadel",
+		DisplayName:       editor.DisplayName,
+		PreferedLoginName: editor.PreferedLoginName,
+		AvatarKey:         editor.AvatarKey,
+	}
+}
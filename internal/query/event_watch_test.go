@@ -0,0 +1,233 @@
+package query
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	old_database "github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/postgres"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/v2/database"
+	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
+	es_v4_pg "github.com/zitadel/zitadel/internal/v2/eventstore/postgres"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestWatchEventsQuery_matches(t *testing.T) {
+	event := &es_v4.StorageEvent{
+		Action: es_v4.Action[es_v4.Unmarshal]{
+			Creator: "editor",
+			Type:    "user.added",
+		},
+		Aggregate: es_v4.Aggregate{
+			ID:    "user1",
+			Type:  "user",
+			Owner: "org1",
+		},
+	}
+	tests := []struct {
+		name  string
+		query *WatchEventsQuery
+		want  bool
+	}{
+		{
+			name:  "no conditions",
+			query: &WatchEventsQuery{},
+			want:  true,
+		},
+		{
+			name: "all conditions",
+			query: &WatchEventsQuery{
+				AggregateID:   "user1",
+				ResourceOwner: "org1",
+				EditorUserID:  "editor",
+				EventTypes:    []string{"user.changed", "user.added"},
+			},
+			want: true,
+		},
+		{
+			name:  "other aggregate",
+			query: &WatchEventsQuery{AggregateID: "user2"},
+		},
+		{
+			name:  "other resource owner",
+			query: &WatchEventsQuery{ResourceOwner: "org2"},
+		},
+		{
+			name:  "other editor",
+			query: &WatchEventsQuery{EditorUserID: "other"},
+		},
+		{
+			name:  "other event type",
+			query: &WatchEventsQuery{EventTypes: []string{"user.changed"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.matches(event))
+		})
+	}
+}
+
+func TestWatchEventsQuery_filter(t *testing.T) {
+	query := &WatchEventsQuery{
+		AggregateTypes: []string{"user", "org", "user"},
+		EventTypes:     []string{"user.added", "org.added"},
+		ResourceOwner:  "org1",
+	}
+	filter := query.filter(&es_v4.GlobalPosition{Position: 1, InPositionOrder: 2})
+
+	require.Len(t, filter.AggregateFilters(), 2)
+	assert.Equal(t, database.NewTextEqual("org"), filter.AggregateFilters()[0].Type())
+	assert.Equal(t, database.NewTextEqual("user"), filter.AggregateFilters()[1].Type())
+	for _, aggregateFilter := range filter.AggregateFilters() {
+		assert.NotNil(t, aggregateFilter.Owners())
+		assert.Len(t, aggregateFilter.Events(), 1)
+	}
+	assert.Equal(t, &es_v4.GlobalPosition{Position: 1, InPositionOrder: 2}, filter.Pagination().Position().Min())
+	assert.Nil(t, filter.Pagination().Position().Max())
+	assert.Equal(t, uint32(watchEventsBatchSize), filter.Pagination().Pagination().Limit)
+}
+
+func Test_watchEventsReducer_Reduce(t *testing.T) {
+	createdAt := time.Now()
+	reducer := &watchEventsReducer{
+		ctx:   context.Background(),
+		query: &WatchEventsQuery{ResourceOwner: "org1"},
+		editors: map[string]*EventEditor{
+			"instance1:editor": {ID: "editor", DisplayName: "Editor"},
+		},
+	}
+	reducer.reset(EventPosition{Position: 1})
+
+	err := reducer.Reduce(
+		&es_v4.StorageEvent{
+			Action: es_v4.Action[es_v4.Unmarshal]{
+				Creator: "editor",
+				Type:    "user.added",
+				Payload: func(ptr any) error { return nil },
+			},
+			Aggregate: es_v4.Aggregate{ID: "user1", Type: "user", Owner: "org1", Instance: "instance1"},
+			CreatedAt: createdAt,
+			Position:  es_v4.GlobalPosition{Position: 2, InPositionOrder: 1},
+			Sequence:  1,
+		},
+		&es_v4.StorageEvent{
+			Action: es_v4.Action[es_v4.Unmarshal]{
+				Creator: "editor",
+				Type:    "user.added",
+			},
+			Aggregate: es_v4.Aggregate{ID: "user2", Type: "user", Owner: "org2", Instance: "instance1"},
+			Position:  es_v4.GlobalPosition{Position: 3},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, EventPosition{Position: 3}, reducer.position)
+	require.Len(t, reducer.events, 1)
+	assert.Equal(t, &WatchedEvent{
+		Event: &Event{
+			Editor: &EventEditor{ID: "editor", Service: "zitadel", DisplayName: "Editor"},
+			Aggregate: &eventstore.Aggregate{
+				ID:            "user1",
+				Type:          "user",
+				ResourceOwner: "org1",
+				InstanceID:    "instance1",
+			},
+			Sequence:     1,
+			CreationDate: createdAt,
+			Type:         "user.added",
+		},
+		Position: EventPosition{Position: 2, InTxOrder: 1},
+	}, reducer.events[0])
+}
+
+func TestQueries_WatchEvents_instanceMissing(t *testing.T) {
+	err := new(Queries).WatchEvents(context.Background(), &WatchEventsQuery{}, func(*WatchedEvent) error { return nil })
+	assert.ErrorIs(t, err, zerrors.ThrowInvalidArgument(nil, "QUERY-w3Kq8a", "Errors.Instance.IDMissing"))
+}
+
+// watchEventsPersonalDataKeys returns the key of user1, the key of user2 was destroyed
+type watchEventsPersonalDataKeys struct {
+	key []byte
+}
+
+func (k *watchEventsPersonalDataKeys) PersonalDataKey(_ context.Context, _, aggregateID string) ([]byte, error) {
+	if aggregateID == "user1" {
+		return k.key, nil
+	}
+	return nil, nil
+}
+
+func (k *watchEventsPersonalDataKeys) EnsurePersonalDataKey(context.Context, string, string) ([]byte, error) {
+	return nil, errors.New("not allowed")
+}
+
+// encryptWatchedPersonalData encrypts the value like the eventstore encrypts personal data
+func encryptWatchedPersonalData(t *testing.T, key []byte, aggregateID, value string) string {
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	nonce := make([]byte, gcm.NonceSize())
+	return "$pd1:" + base64.RawStdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(value), []byte(aggregateID)))
+}
+
+func TestQueries_WatchEvents_personalData(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encrypted := encryptWatchedPersonalData(t, key, "user1", "gigi@example.com")
+	createdAt := time.Now()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	rows := sqlmock.NewRows([]string{"created_at", "event_type", "sequence", "position", "in_tx_order", "payload", "creator", "owner", "instance_id", "aggregate_type", "aggregate_id", "revision"})
+	for _, userID := range []string{"user1", "user2"} {
+		rows.AddRow(createdAt, string(user.HumanEmailChangedType), uint32(2), float64(10), uint32(0), []byte(`{"email":"`+encrypted+`"}`), "editor", "org1", "instance1", string(user.AggregateType), userID, uint16(1))
+	}
+	mock.ExpectQuery("FROM eventstore.events2").WillReturnRows(rows)
+
+	client := &old_database.DB{DB: db, Database: new(postgres.Config)}
+	q := &Queries{
+		client: client,
+		eventStoreV4: es_v4_pg.New(client, &es_v4_pg.Config{
+			PersonalData: eventstore.NewPersonalDataDecrypter(&watchEventsPersonalDataKeys{key: key}),
+		}),
+	}
+
+	var payloads []string
+	stop := errors.New("stop")
+	err = q.WatchEvents(
+		authz.WithInstanceID(context.Background(), "instance1"),
+		&WatchEventsQuery{InstanceIDs: []string{"instance1"}, EventTypes: []string{string(user.HumanEmailChangedType)}},
+		func(event *WatchedEvent) error {
+			payloads = append(payloads, string(event.Payload))
+			if len(payloads) == 2 {
+				return stop
+			}
+			return nil
+		},
+	)
+	require.ErrorIs(t, err, stop)
+
+	want := []string{`{"email":"gigi@example.com"}`, `{"email":"` + eventstore.PersonalDataErased + `"}`}
+	for i, payload := range payloads {
+		var got, expected map[string]any
+		require.NoError(t, json.Unmarshal([]byte(payload), &got))
+		require.NoError(t, json.Unmarshal([]byte(want[i]), &expected))
+		assert.Equal(t, expected, got)
+	}
+	assert.Len(t, payloads, 2)
+}
@@ -11,8 +11,11 @@ import (
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	exec "github.com/zitadel/zitadel/internal/repository/execution"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -40,6 +43,10 @@ var (
 		name:  projection.ExecutionSequenceCol,
 		table: executionTable,
 	}
+	ExecutionColumnMode = Column{
+		name:  projection.ExecutionModeCol,
+		table: executionTable,
+	}
 
 	executionTargetsTable = table{
 		name:          projection.ExecutionTable + "_" + projection.ExecutionTargetSuffix,
@@ -67,6 +74,8 @@ var (
 	TargetsByExecutionIDQuery string
 	//go:embed targets_by_execution_ids.sql
 	TargetsByExecutionIDsQuery string
+	//go:embed targets_by_event_execution_id.sql
+	TargetsByEventExecutionIDQuery string
 )
 
 type Executions struct {
@@ -83,6 +92,7 @@ type Execution struct {
 	domain.ObjectDetails
 
 	Targets []*exec.Target
+	Mode    domain.ExecutionMode
 }
 
 type ExecutionSearchQueries struct {
@@ -170,7 +180,7 @@ func (q *Queries) TargetsByExecutionID(ctx context.Context, ids []string) (execu
 
 	err = q.client.QueryContext(ctx,
 		func(rows *sql.Rows) error {
-			execution, err = scanExecutionTargets(rows)
+			execution, err = scanExecutionTargets(rows, q.targetEncryption)
 			return err
 		},
 		TargetsByExecutionIDQuery,
@@ -199,7 +209,7 @@ func (q *Queries) TargetsByExecutionIDs(ctx context.Context, ids1, ids2 []string
 
 	err = q.client.QueryContext(ctx,
 		func(rows *sql.Rows) error {
-			execution, err = scanExecutionTargets(rows)
+			execution, err = scanExecutionTargets(rows, q.targetEncryption)
 			return err
 		},
 		TargetsByExecutionIDsQuery,
@@ -210,12 +220,104 @@ func (q *Queries) TargetsByExecutionIDs(ctx context.Context, ids1, ids2 []string
 	return execution, err
 }
 
+// TargetsByEventExecutionID query list of targets for best match of a list of IDs of event executions, for example:
+// [ "event/user.human.added",
+// "event/user.human.*",
+// "event/user.*",
+// "event" ]
+//
+// The targets are resolved from the current state of the executions and targets,
+// the projections are not triggered, see [Queries.EventExecutionIDs].
+// Only executions which were created at or before createdAt are considered,
+// so that setting an execution does not deliver the events which were pushed before.
+// As a consequence, events which are handled again, e.g. after the handler was reset,
+// are not delivered to executions set after the event was pushed,
+// but to the current targets of the executions which existed at that time.
+func (q *Queries) TargetsByEventExecutionID(ctx context.Context, ids []string, createdAt time.Time) (execution []*ExecutionTarget, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.End() }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if instanceID == "" {
+		return nil, nil
+	}
+
+	err = q.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			execution, err = scanExecutionTargets(rows, q.targetEncryption)
+			return err
+		},
+		TargetsByEventExecutionIDQuery,
+		instanceID,
+		database.TextArray[string](ids),
+		createdAt,
+	)
+	return execution, err
+}
+
+// EventExecutionIDs returns the IDs of all executions of type [domain.ExecutionTypeEvent] of the instance.
+// The projections of the executions and targets are triggered before,
+// so that the targets of the executions are up to date for [Queries.TargetsByEventExecutionID].
+func (q *Queries) EventExecutionIDs(ctx context.Context) (ids []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	ctx, err = projection.ExecutionProjection.Trigger(ctx, handler.WithAwaitRunning())
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = projection.TargetProjection.Trigger(ctx, handler.WithAwaitRunning())
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sq.Select(ExecutionColumnID.identifier()).
+		From(executionTable.identifier()).
+		Where(sq.And{
+			sq.Eq{ExecutionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			sq.Like{ExecutionColumnID.identifier(): exec.IDAll(domain.ExecutionTypeEvent) + "%"},
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-wy8nb2rc4e", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		return rows.Err()
+	}, query, args...)
+	return ids, err
+}
+
+// LatestExecutionPosition returns the position of the latest event of the executions of the instance,
+// which changes every time an execution is set or removed.
+func (q *Queries) LatestExecutionPosition(ctx context.Context) (_ float64, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.eventstore.LatestSequence(ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsMaxSequence).
+			AwaitOpenTransactions().
+			AddQuery().
+			AggregateTypes(exec.AggregateType).
+			Builder(),
+	)
+}
+
 func prepareExecutionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*Execution, error)) {
 	return sq.Select(
 			ExecutionColumnInstanceID.identifier(),
 			ExecutionColumnID.identifier(),
 			ExecutionColumnChangeDate.identifier(),
 			ExecutionColumnSequence.identifier(),
+			ExecutionColumnMode.identifier(),
 			executionTargetsListCol.identifier(),
 		).From(executionTable.identifier()).
 			Join("(" + executionTargetsQuery + ") AS " + executionTargetsTableAlias.alias + " ON " +
@@ -232,6 +334,7 @@ func prepareExecutionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			ExecutionColumnID.identifier(),
 			ExecutionColumnChangeDate.identifier(),
 			ExecutionColumnSequence.identifier(),
+			ExecutionColumnMode.identifier(),
 			executionTargetsListCol.identifier(),
 			countColumn.identifier(),
 		).From(executionTable.identifier()).
@@ -244,9 +347,10 @@ func prepareExecutionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 }
 
 type executionTarget struct {
-	Position int    `json:"position,omitempty"`
-	Include  string `json:"include,omitempty"`
-	Target   string `json:"target,omitempty"`
+	Position  int                              `json:"position,omitempty"`
+	Include   string                           `json:"include,omitempty"`
+	Target    string                           `json:"target,omitempty"`
+	Condition *domain.ExecutionTargetCondition `json:"condition,omitempty"`
 }
 
 func scanExecution(row *sql.Row) (*Execution, error) {
@@ -258,6 +362,7 @@ func scanExecution(row *sql.Row) (*Execution, error) {
 		&execution.ID,
 		&execution.EventDate,
 		&execution.Sequence,
+		&execution.Mode,
 		&targets,
 	)
 	if err != nil {
@@ -275,7 +380,7 @@ func scanExecution(row *sql.Row) (*Execution, error) {
 	execution.Targets = make([]*exec.Target, len(executionTargets))
 	for i := range executionTargets {
 		if executionTargets[i].Target != "" {
-			execution.Targets[i] = &exec.Target{Type: domain.ExecutionTargetTypeTarget, Target: executionTargets[i].Target}
+			execution.Targets[i] = &exec.Target{Type: domain.ExecutionTargetTypeTarget, Target: executionTargets[i].Target, Condition: executionTargets[i].Condition}
 		}
 		if executionTargets[i].Include != "" {
 			execution.Targets[i] = &exec.Target{Type: domain.ExecutionTargetTypeInclude, Target: executionTargets[i].Include}
@@ -295,7 +400,7 @@ func executionTargetsUnmarshal(data []byte) ([]*exec.Target, error) {
 	// position starts with 1
 	for _, item := range executionTargets {
 		if item.Target != "" {
-			targets[item.Position-1] = &exec.Target{Type: domain.ExecutionTargetTypeTarget, Target: item.Target}
+			targets[item.Position-1] = &exec.Target{Type: domain.ExecutionTargetTypeTarget, Target: item.Target, Condition: item.Condition}
 		}
 		if item.Include != "" {
 			targets[item.Position-1] = &exec.Target{Type: domain.ExecutionTargetTypeInclude, Target: item.Include}
@@ -317,6 +422,7 @@ func scanExecutions(rows *sql.Rows) (*Executions, error) {
 			&execution.ID,
 			&execution.EventDate,
 			&execution.Sequence,
+			&execution.Mode,
 			&targets,
 			&count,
 		)
@@ -354,6 +460,12 @@ type ExecutionTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	SigningKey       string
+	Auth             *domain.TargetAuth
+	// Condition of the target in the execution, the target is only called if the condition matches
+	Condition *domain.ExecutionTargetCondition
+	// Mode of the execution the target is called by
+	Mode domain.ExecutionMode
 }
 
 func (e *ExecutionTarget) GetExecutionID() string {
@@ -374,8 +486,20 @@ func (e *ExecutionTarget) GetTargetType() domain.TargetType {
 func (e *ExecutionTarget) GetTimeout() time.Duration {
 	return e.Timeout
 }
+func (e *ExecutionTarget) GetSigningKey() string {
+	return e.SigningKey
+}
+func (e *ExecutionTarget) GetAuth() *domain.TargetAuth {
+	return e.Auth
+}
+func (e *ExecutionTarget) GetCondition() *domain.ExecutionTargetCondition {
+	return e.Condition
+}
+func (e *ExecutionTarget) GetExecutionMode() domain.ExecutionMode {
+	return e.Mode
+}
 
-func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
+func scanExecutionTargets(rows *sql.Rows, alg crypto.EncryptionAlgorithm) ([]*ExecutionTarget, error) {
 	targets := make([]*ExecutionTarget, 0)
 	for rows.Next() {
 		target := new(ExecutionTarget)
@@ -388,6 +512,10 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 			endpoint         = &sql.NullString{}
 			timeout          = &sql.NullInt64{}
 			interruptOnError = &sql.NullBool{}
+			signingKey       = new(crypto.CryptoValue)
+			auth             = new(crypto.CryptoValue)
+			condition        []byte
+			mode             = &sql.NullInt32{}
 		)
 
 		err := rows.Scan(
@@ -398,8 +526,22 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 			endpoint,
 			timeout,
 			interruptOnError,
+			signingKey,
+			auth,
+			&condition,
+			mode,
 		)
 
+		if err != nil {
+			return nil, err
+		}
+		if len(signingKey.Crypted) > 0 {
+			target.SigningKey, err = crypto.DecryptString(signingKey, alg)
+			if err != nil {
+				return nil, err
+			}
+		}
+		target.Auth, err = decryptTargetAuth(auth, alg)
 		if err != nil {
 			return nil, err
 		}
@@ -411,6 +553,13 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 		target.Endpoint = endpoint.String
 		target.Timeout = time.Duration(timeout.Int64)
 		target.InterruptOnError = interruptOnError.Bool
+		target.Mode = domain.ExecutionMode(mode.Int32)
+		if len(condition) > 0 {
+			target.Condition = new(domain.ExecutionTargetCondition)
+			if err := json.Unmarshal(condition, target.Condition); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-h5n0t2qzev", "Errors.Internal")
+			}
+		}
 
 		targets = append(targets, target)
 	}
@@ -421,3 +570,19 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 
 	return targets, nil
 }
+
+// decryptTargetAuth returns the credentials of a target, which are nil if the target has no authentication
+func decryptTargetAuth(value *crypto.CryptoValue, alg crypto.EncryptionAlgorithm) (*domain.TargetAuth, error) {
+	if len(value.Crypted) == 0 {
+		return nil, nil
+	}
+	decrypted, err := crypto.Decrypt(value, alg)
+	if err != nil {
+		return nil, err
+	}
+	auth := new(domain.TargetAuth)
+	if err := json.Unmarshal(decrypted, auth); err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-0wd7kj3xrf", "Errors.Internal")
+	}
+	return auth, nil
+}
@@ -4,8 +4,9 @@ SELECT instance_id,
                JSON_OBJECT(
                        'position' : position,
                        'include' : include,
-                       'target' : target_id
+                       'target' : target_id,
+                       'condition' : condition
                    )
            ) as targets
-FROM projections.executions1_targets
+FROM projections.executions2_targets
 GROUP BY instance_id, execution_id
\ No newline at end of file
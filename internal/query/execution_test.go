@@ -14,49 +14,53 @@ import (
 )
 
 var (
-	prepareExecutionsStmt = `SELECT projections.executions1.instance_id,` +
-		` projections.executions1.id,` +
-		` projections.executions1.change_date,` +
-		` projections.executions1.sequence,` +
+	prepareExecutionsStmt = `SELECT projections.executions2.instance_id,` +
+		` projections.executions2.id,` +
+		` projections.executions2.change_date,` +
+		` projections.executions2.sequence,` +
+		` projections.executions2.mode,` +
 		` execution_targets.targets,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.executions1` +
+		` FROM projections.executions2` +
 		` JOIN (` +
-		`SELECT instance_id, execution_id, JSONB_AGG( JSON_OBJECT( 'position' : position, 'include' : include, 'target' : target_id ) ) as targets` +
-		` FROM projections.executions1_targets` +
+		`SELECT instance_id, execution_id, JSONB_AGG( JSON_OBJECT( 'position' : position, 'include' : include, 'target' : target_id, 'condition' : condition ) ) as targets` +
+		` FROM projections.executions2_targets` +
 		` GROUP BY instance_id, execution_id` +
 		`)` +
 		` AS execution_targets` +
-		` ON execution_targets.instance_id = projections.executions1.instance_id` +
-		` AND execution_targets.execution_id = projections.executions1.id`
+		` ON execution_targets.instance_id = projections.executions2.instance_id` +
+		` AND execution_targets.execution_id = projections.executions2.id`
 	prepareExecutionsCols = []string{
 		"instance_id",
 		"id",
 		"change_date",
 		"sequence",
+		"mode",
 		"targets",
 		"count",
 	}
 
-	prepareExecutionStmt = `SELECT projections.executions1.instance_id,` +
-		` projections.executions1.id,` +
-		` projections.executions1.change_date,` +
-		` projections.executions1.sequence,` +
+	prepareExecutionStmt = `SELECT projections.executions2.instance_id,` +
+		` projections.executions2.id,` +
+		` projections.executions2.change_date,` +
+		` projections.executions2.sequence,` +
+		` projections.executions2.mode,` +
 		` execution_targets.targets` +
-		` FROM projections.executions1` +
+		` FROM projections.executions2` +
 		` JOIN (` +
-		`SELECT instance_id, execution_id, JSONB_AGG( JSON_OBJECT( 'position' : position, 'include' : include, 'target' : target_id ) ) as targets` +
-		` FROM projections.executions1_targets` +
+		`SELECT instance_id, execution_id, JSONB_AGG( JSON_OBJECT( 'position' : position, 'include' : include, 'target' : target_id, 'condition' : condition ) ) as targets` +
+		` FROM projections.executions2_targets` +
 		` GROUP BY instance_id, execution_id` +
 		`)` +
 		` AS execution_targets` +
-		` ON execution_targets.instance_id = projections.executions1.instance_id` +
-		` AND execution_targets.execution_id = projections.executions1.id`
+		` ON execution_targets.instance_id = projections.executions2.instance_id` +
+		` AND execution_targets.execution_id = projections.executions2.id`
 	prepareExecutionCols = []string{
 		"instance_id",
 		"id",
 		"change_date",
 		"sequence",
+		"mode",
 		"targets",
 	}
 )
@@ -97,6 +101,7 @@ func Test_ExecutionPrepares(t *testing.T) {
 							"id",
 							testNow,
 							uint64(20211109),
+							domain.ExecutionModeSequential,
 							[]byte(`[{"position" : 1, "target" : "target"}, {"position" : 2, "include" : "include"}]`),
 						},
 					},
@@ -135,6 +140,7 @@ func Test_ExecutionPrepares(t *testing.T) {
 							"id-1",
 							testNow,
 							uint64(20211109),
+							domain.ExecutionModeSequential,
 							[]byte(`[{"position" : 1, "target" : "target"}, {"position" : 2, "include" : "include"}]`),
 						},
 						{
@@ -142,6 +148,7 @@ func Test_ExecutionPrepares(t *testing.T) {
 							"id-2",
 							testNow,
 							uint64(20211110),
+							domain.ExecutionModeSequential,
 							[]byte(`[{"position" : 2, "target" : "target"}, {"position" : 1, "include" : "include"}]`),
 						},
 					},
@@ -226,7 +233,8 @@ func Test_ExecutionPrepares(t *testing.T) {
 						"id",
 						testNow,
 						uint64(20211109),
-						[]byte(`[{"position" : 1, "target" : "target"}, {"position" : 2, "include" : "include"}]`),
+						domain.ExecutionModeParallel,
+						[]byte(`[{"position" : 1, "target" : "target", "condition" : {"path" : "$.userId", "operator" : 1, "value" : "user"}}, {"position" : 2, "include" : "include"}]`),
 					},
 				),
 			},
@@ -238,9 +246,14 @@ func Test_ExecutionPrepares(t *testing.T) {
 					Sequence:      20211109,
 				},
 				Targets: []*exec.Target{
-					{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+					{
+						Type:      domain.ExecutionTargetTypeTarget,
+						Target:    "target",
+						Condition: &domain.ExecutionTargetCondition{Path: "$.userId", Operator: domain.ExecutionTargetConditionOperatorEquals, Value: "user"},
+					},
 					{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 				},
+				Mode: domain.ExecutionModeParallel,
 			},
 		},
 		{
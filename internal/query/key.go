@@ -43,6 +43,20 @@ type PrivateKeys struct {
 	Keys []PrivateKey
 }
 
+// SigningKeyGracefulPeriod is the period before the expiry of a signing key in which it is not used to sign anymore,
+// so the signed tokens can still be verified with it
+const SigningKeyGracefulPeriod = 10 * time.Minute
+
+// ActiveSigningKey returns the key which is used to sign,
+// the keys must be queried by [Queries.ActivePrivateSigningKey] with a time after the [SigningKeyGracefulPeriod].
+// Nil is returned if there is no active key.
+func (k *PrivateKeys) ActiveSigningKey() PrivateKey {
+	if len(k.Keys) == 0 {
+		return nil
+	}
+	return k.Keys[len(k.Keys)-1]
+}
+
 type PublicKeys struct {
 	SearchResponse
 	Keys []PublicKey
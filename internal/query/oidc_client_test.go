@@ -159,28 +159,33 @@ low2kyJov38V4Uk2I8kuXpLcnrpw5Tio2ooiUE27b0vHZqBKOei9Uo88qCrn3EKx
 			name: "secret client",
 			mock: mockQuery(expQuery, cols, []driver.Value{testdataOidcClientSecret}, "instanceID", "clientID", true),
 			want: &OIDCClient{
-				InstanceID:               "230690539048009730",
-				AppID:                    "236646858984783874",
-				State:                    domain.AppStateActive,
-				ClientID:                 "236646858984849410",
-				HashedSecret:             "$2a$14$OzZ0XEZZEtD13py/EPba2evsS6WcKZ5orVMj9pWHEGEHmLu2h3PFq",
-				RedirectURIs:             []string{"http://localhost:9999/auth/callback"},
-				ResponseTypes:            []domain.OIDCResponseType{0},
-				GrantTypes:               []domain.OIDCGrantType{0},
-				ApplicationType:          domain.OIDCApplicationTypeWeb,
-				AuthMethodType:           domain.OIDCAuthMethodTypeBasic,
-				PostLogoutRedirectURIs:   nil,
-				IsDevMode:                true,
-				AccessTokenType:          domain.OIDCTokenTypeBearer,
-				AccessTokenRoleAssertion: false,
-				IDTokenRoleAssertion:     false,
-				IDTokenUserinfoAssertion: false,
-				ClockSkew:                0,
-				AdditionalOrigins:        nil,
-				PublicKeys:               nil,
-				ProjectID:                "236645808328409090",
-				ProjectRoleAssertion:     false,
-				ProjectRoleKeys:          []string{"role1", "role2"},
+				InstanceID:                       "230690539048009730",
+				AppID:                            "236646858984783874",
+				State:                            domain.AppStateActive,
+				ClientID:                         "236646858984849410",
+				HashedSecret:                     "$2a$14$OzZ0XEZZEtD13py/EPba2evsS6WcKZ5orVMj9pWHEGEHmLu2h3PFq",
+				RedirectURIs:                     []string{"http://localhost:9999/auth/callback"},
+				ResponseTypes:                    []domain.OIDCResponseType{0},
+				GrantTypes:                       []domain.OIDCGrantType{0},
+				ApplicationType:                  domain.OIDCApplicationTypeWeb,
+				AuthMethodType:                   domain.OIDCAuthMethodTypeBasic,
+				PostLogoutRedirectURIs:           nil,
+				IsDevMode:                        true,
+				AccessTokenType:                  domain.OIDCTokenTypeBearer,
+				AccessTokenRoleAssertion:         false,
+				IDTokenRoleAssertion:             false,
+				IDTokenUserinfoAssertion:         false,
+				ClockSkew:                        0,
+				AdditionalOrigins:                nil,
+				BackChannelLogoutURI:             "http://localhost:9999/auth/backchannel",
+				BackChannelLogoutSessionRequired: true,
+				RequirePushedAuthRequests:        true,
+				RequireDPoP:                      true,
+				TLSClientAuthSubjectDN:           "CN=client,O=ZITADEL",
+				PublicKeys:                       nil,
+				ProjectID:                        "236645808328409090",
+				ProjectRoleAssertion:             false,
+				ProjectRoleKeys:                  []string{"role1", "role2"},
 				Settings: &OIDCSettings{
 					AccessTokenLifetime: 43200000000000,
 					IdTokenLifetime:     43200000000000,
@@ -38,26 +38,31 @@ const (
 	AppAPIConfigColumnClientSecret = "client_secret"
 	AppAPIConfigColumnAuthMethod   = "auth_method"
 
-	appOIDCTableSuffix                          = "oidc_configs"
-	AppOIDCConfigColumnAppID                    = "app_id"
-	AppOIDCConfigColumnInstanceID               = "instance_id"
-	AppOIDCConfigColumnVersion                  = "version"
-	AppOIDCConfigColumnClientID                 = "client_id"
-	AppOIDCConfigColumnClientSecret             = "client_secret"
-	AppOIDCConfigColumnRedirectUris             = "redirect_uris"
-	AppOIDCConfigColumnResponseTypes            = "response_types"
-	AppOIDCConfigColumnGrantTypes               = "grant_types"
-	AppOIDCConfigColumnApplicationType          = "application_type"
-	AppOIDCConfigColumnAuthMethodType           = "auth_method_type"
-	AppOIDCConfigColumnPostLogoutRedirectUris   = "post_logout_redirect_uris"
-	AppOIDCConfigColumnDevMode                  = "is_dev_mode"
-	AppOIDCConfigColumnAccessTokenType          = "access_token_type"
-	AppOIDCConfigColumnAccessTokenRoleAssertion = "access_token_role_assertion"
-	AppOIDCConfigColumnIDTokenRoleAssertion     = "id_token_role_assertion"
-	AppOIDCConfigColumnIDTokenUserinfoAssertion = "id_token_userinfo_assertion"
-	AppOIDCConfigColumnClockSkew                = "clock_skew"
-	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
-	AppOIDCConfigColumnSkipNativeAppSuccessPage = "skip_native_app_success_page"
+	appOIDCTableSuffix                                  = "oidc_configs"
+	AppOIDCConfigColumnAppID                            = "app_id"
+	AppOIDCConfigColumnInstanceID                       = "instance_id"
+	AppOIDCConfigColumnVersion                          = "version"
+	AppOIDCConfigColumnClientID                         = "client_id"
+	AppOIDCConfigColumnClientSecret                     = "client_secret"
+	AppOIDCConfigColumnRedirectUris                     = "redirect_uris"
+	AppOIDCConfigColumnResponseTypes                    = "response_types"
+	AppOIDCConfigColumnGrantTypes                       = "grant_types"
+	AppOIDCConfigColumnApplicationType                  = "application_type"
+	AppOIDCConfigColumnAuthMethodType                   = "auth_method_type"
+	AppOIDCConfigColumnPostLogoutRedirectUris           = "post_logout_redirect_uris"
+	AppOIDCConfigColumnDevMode                          = "is_dev_mode"
+	AppOIDCConfigColumnAccessTokenType                  = "access_token_type"
+	AppOIDCConfigColumnAccessTokenRoleAssertion         = "access_token_role_assertion"
+	AppOIDCConfigColumnIDTokenRoleAssertion             = "id_token_role_assertion"
+	AppOIDCConfigColumnIDTokenUserinfoAssertion         = "id_token_userinfo_assertion"
+	AppOIDCConfigColumnClockSkew                        = "clock_skew"
+	AppOIDCConfigColumnAdditionalOrigins                = "additional_origins"
+	AppOIDCConfigColumnSkipNativeAppSuccessPage         = "skip_native_app_success_page"
+	AppOIDCConfigColumnBackChannelLogoutURI             = "back_channel_logout_uri"
+	AppOIDCConfigColumnBackChannelLogoutSessionRequired = "back_channel_logout_session_required"
+	AppOIDCConfigColumnRequirePushedAuthRequests        = "require_pushed_auth_requests"
+	AppOIDCConfigColumnRequireDPoP                      = "require_dpop"
+	AppOIDCConfigColumnTLSClientAuthSubjectDN           = "tls_client_auth_subject_dn"
 
 	appSAMLTableSuffix             = "saml_configs"
 	AppSAMLConfigColumnAppID       = "app_id"
@@ -125,6 +130,11 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.NewColumn(AppOIDCConfigColumnClockSkew, handler.ColumnTypeInt64, handler.Default(0)),
 			handler.NewColumn(AppOIDCConfigColumnAdditionalOrigins, handler.ColumnTypeTextArray, handler.Nullable()),
 			handler.NewColumn(AppOIDCConfigColumnSkipNativeAppSuccessPage, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnBackChannelLogoutURI, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(AppOIDCConfigColumnBackChannelLogoutSessionRequired, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnRequirePushedAuthRequests, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnRequireDPoP, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnTLSClientAuthSubjectDN, handler.ColumnTypeText, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -500,6 +510,11 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnClockSkew, e.ClockSkew),
 				handler.NewCol(AppOIDCConfigColumnAdditionalOrigins, database.TextArray[string](e.AdditionalOrigins)),
 				handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, e.SkipNativeAppSuccessPage),
+				handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, e.BackChannelLogoutURI),
+				handler.NewCol(AppOIDCConfigColumnBackChannelLogoutSessionRequired, e.BackChannelLogoutSessionRequired),
+				handler.NewCol(AppOIDCConfigColumnRequirePushedAuthRequests, e.RequirePushedAuthRequests),
+				handler.NewCol(AppOIDCConfigColumnRequireDPoP, e.RequireDPoP),
+				handler.NewCol(AppOIDCConfigColumnTLSClientAuthSubjectDN, e.TLSClientAuthSubjectDN),
 			},
 			handler.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -522,7 +537,7 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-GNHU1", "reduce.wrong.event.type %s", project.OIDCConfigChangedType)
 	}
 
-	cols := make([]handler.Column, 0, 15)
+	cols := make([]handler.Column, 0, 20)
 	if e.Version != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnVersion, *e.Version))
 	}
@@ -568,6 +583,21 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.SkipNativeAppSuccessPage != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, *e.SkipNativeAppSuccessPage))
 	}
+	if e.BackChannelLogoutURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, *e.BackChannelLogoutURI))
+	}
+	if e.BackChannelLogoutSessionRequired != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnBackChannelLogoutSessionRequired, *e.BackChannelLogoutSessionRequired))
+	}
+	if e.RequirePushedAuthRequests != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequirePushedAuthRequests, *e.RequirePushedAuthRequests))
+	}
+	if e.RequireDPoP != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequireDPoP, *e.RequireDPoP))
+	}
+	if e.TLSClientAuthSubjectDN != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnTLSClientAuthSubjectDN, *e.TLSClientAuthSubjectDN))
+	}
 
 	if len(cols) == 0 {
 		return handler.NewNoOpStatement(e), nil
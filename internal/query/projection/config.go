@@ -13,6 +13,7 @@ type Config struct {
 	Customizations        map[string]CustomConfig
 	HandleActiveInstances time.Duration
 	TransactionDuration   time.Duration
+	LagInterval           time.Duration
 }
 
 type CustomConfig struct {
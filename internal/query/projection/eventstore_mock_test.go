@@ -38,6 +38,10 @@ func (m *mockEventStore) Filter(ctx context.Context, queryFactory *eventstore.Se
 	return m.filterResponse[m.filterCounter-1], nil
 }
 
+func (m *mockEventStore) LatestSequence(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) (float64, error) {
+	return 0, nil
+}
+
 func (m *mockEventStore) FilterToQueryReducer(ctx context.Context, reducer eventstore.QueryReducer) error {
 	m.filterCounter++
 	events := m.filterResponse[m.filterCounter-1]
@@ -12,12 +12,13 @@ import (
 )
 
 const (
-	ExecutionTable           = "projections.executions1"
+	ExecutionTable           = "projections.executions2"
 	ExecutionIDCol           = "id"
 	ExecutionCreationDateCol = "creation_date"
 	ExecutionChangeDateCol   = "change_date"
 	ExecutionInstanceIDCol   = "instance_id"
 	ExecutionSequenceCol     = "sequence"
+	ExecutionModeCol         = "mode"
 
 	ExecutionTargetSuffix         = "targets"
 	ExecutionTargetExecutionIDCol = "execution_id"
@@ -25,6 +26,7 @@ const (
 	ExecutionTargetPositionCol    = "position"
 	ExecutionTargetTargetIDCol    = "target_id"
 	ExecutionTargetIncludeCol     = "include"
+	ExecutionTargetConditionCol   = "condition"
 )
 
 type executionProjection struct{}
@@ -45,6 +47,7 @@ func (*executionProjection) Init() *old_handler.Check {
 			handler.NewColumn(ExecutionChangeDateCol, handler.ColumnTypeTimestamp),
 			handler.NewColumn(ExecutionSequenceCol, handler.ColumnTypeInt64),
 			handler.NewColumn(ExecutionInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(ExecutionModeCol, handler.ColumnTypeEnum, handler.Default(0)),
 		},
 			handler.NewPrimaryKey(ExecutionInstanceIDCol, ExecutionIDCol),
 		),
@@ -54,6 +57,7 @@ func (*executionProjection) Init() *old_handler.Check {
 			handler.NewColumn(ExecutionTargetPositionCol, handler.ColumnTypeInt64),
 			handler.NewColumn(ExecutionTargetIncludeCol, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(ExecutionTargetTargetIDCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(ExecutionTargetConditionCol, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(ExecutionTargetInstanceIDCol, ExecutionTargetExecutionIDCol, ExecutionTargetPositionCol),
 			ExecutionTargetSuffix,
@@ -108,6 +112,7 @@ func (p *executionProjection) reduceExecutionSet(event eventstore.Event) (*handl
 				handler.NewCol(ExecutionCreationDateCol, handler.OnlySetValueOnInsert(ExecutionTable, e.CreationDate())),
 				handler.NewCol(ExecutionChangeDateCol, e.CreationDate()),
 				handler.NewCol(ExecutionSequenceCol, e.Sequence()),
+				handler.NewCol(ExecutionModeCol, e.Mode),
 			},
 		),
 		// cleanup execution targets to re-insert them
@@ -123,9 +128,13 @@ func (p *executionProjection) reduceExecutionSet(event eventstore.Event) (*handl
 	if len(e.Targets) > 0 {
 		for i, target := range e.Targets {
 			var targetStr, includeStr string
+			condition := handler.NewCol(ExecutionTargetConditionCol, nil)
 			switch target.Type {
 			case domain.ExecutionTargetTypeTarget:
 				targetStr = target.Target
+				if target.Condition != nil {
+					condition = handler.NewJSONCol(ExecutionTargetConditionCol, target.Condition)
+				}
 			case domain.ExecutionTargetTypeInclude:
 				includeStr = target.Target
 			case domain.ExecutionTargetTypeUnspecified:
@@ -142,6 +151,7 @@ func (p *executionProjection) reduceExecutionSet(event eventstore.Event) (*handl
 						handler.NewCol(ExecutionTargetPositionCol, i+1),
 						handler.NewCol(ExecutionTargetIncludeCol, includeStr),
 						handler.NewCol(ExecutionTargetTargetIDCol, targetStr),
+						condition,
 					},
 					handler.WithTableSuffix(ExecutionTargetSuffix),
 				),
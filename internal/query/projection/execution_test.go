@@ -3,6 +3,7 @@ package projection
 import (
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	exec "github.com/zitadel/zitadel/internal/repository/execution"
@@ -27,7 +28,7 @@ func TestExecutionProjection_reduces(t *testing.T) {
 					testEvent(
 						exec.SetEventV2Type,
 						exec.AggregateType,
-						[]byte(`{"targets": [{"type":2,"target":"target"},{"type":1,"target":"include"}]}`),
+						[]byte(`{"targets": [{"type":2,"target":"target","condition":{"path":"$.userId","operator":3}},{"type":1,"target":"include"}],"mode":1}`),
 					),
 					eventstore.GenericEventMapper[exec.SetEventV2],
 				),
@@ -39,40 +40,43 @@ func TestExecutionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.executions1 (instance_id, id, creation_date, change_date, sequence) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (instance_id, id) DO UPDATE SET (creation_date, change_date, sequence) = (projections.executions1.creation_date, EXCLUDED.change_date, EXCLUDED.sequence)",
+							expectedStmt: "INSERT INTO projections.executions2 (instance_id, id, creation_date, change_date, sequence, mode) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (instance_id, id) DO UPDATE SET (creation_date, change_date, sequence, mode) = (projections.executions2.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.mode)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
 								anyArg{},
 								anyArg{},
 								uint64(15),
+								domain.ExecutionModeParallel,
 							},
 						},
 						{
-							expectedStmt: "DELETE FROM projections.executions1_targets WHERE (instance_id = $1) AND (execution_id = $2)",
+							expectedStmt: "DELETE FROM projections.executions2_targets WHERE (instance_id = $1) AND (execution_id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.executions1_targets (instance_id, execution_id, position, include, target_id) VALUES ($1, $2, $3, $4, $5)",
+							expectedStmt: "INSERT INTO projections.executions2_targets (instance_id, execution_id, position, include, target_id, condition) VALUES ($1, $2, $3, $4, $5, $6)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
 								1,
 								"",
 								"target",
+								[]byte(`{"path":"$.userId","operator":3}`),
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.executions1_targets (instance_id, execution_id, position, include, target_id) VALUES ($1, $2, $3, $4, $5)",
+							expectedStmt: "INSERT INTO projections.executions2_targets (instance_id, execution_id, position, include, target_id, condition) VALUES ($1, $2, $3, $4, $5, $6)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
 								2,
 								"include",
 								"",
+								nil,
 							},
 						},
 					},
@@ -98,7 +102,7 @@ func TestExecutionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.executions1 WHERE (instance_id = $1) AND (id = $2)",
+							expectedStmt: "DELETE FROM projections.executions2 WHERE (instance_id = $1) AND (id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -127,7 +131,7 @@ func TestExecutionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.executions1 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.executions2 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
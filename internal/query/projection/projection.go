@@ -2,12 +2,14 @@ package projection
 
 import (
 	"context"
+	"slices"
 
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/migration"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 const (
@@ -76,6 +78,7 @@ var (
 	InstanceFeatureProjection           *handler.Handler
 	TargetProjection                    *handler.Handler
 	ExecutionProjection                 *handler.Handler
+	TargetDeliveryProjection            *handler.Handler
 	UserSchemaProjection                *handler.Handler
 
 	ProjectGrantFields      *handler.FieldHandler
@@ -86,6 +89,8 @@ type projection interface {
 	Start(ctx context.Context)
 	Init(ctx context.Context) error
 	Trigger(ctx context.Context, opts ...handler.TriggerOpt) (_ context.Context, err error)
+	Rebuild(ctx context.Context) error
+	Lag(ctx context.Context) (*handler.Lag, error)
 	migration.Migration
 }
 
@@ -103,6 +108,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 		MaxFailureCount:       config.MaxFailureCount,
 		RetryFailedAfter:      config.RetryFailedAfter,
 		TransactionDuration:   config.TransactionDuration,
+		LagInterval:           config.LagInterval,
 	}
 
 	OrgProjection = newOrgProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["orgs"]))
@@ -160,6 +166,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	InstanceFeatureProjection = newInstanceFeatureProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["instance_features"]))
 	TargetProjection = newTargetProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["targets"]))
 	ExecutionProjection = newExecutionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["executions"]))
+	TargetDeliveryProjection = newTargetDeliveryProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["target_deliveries"]))
 	UserSchemaProjection = newUserSchemaProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_schemas"]))
 
 	ProjectGrantFields = newFillProjectGrantFields(applyCustomConfig(projectionConfig, config.Customizations[fieldsProjectGrant]))
@@ -198,6 +205,62 @@ func ProjectInstance(ctx context.Context) error {
 	return nil
 }
 
+// Rebuild rebuilds the projections with the given names one after another in shadow tables,
+// the tables of a projection are replaced as soon as its shadow tables caught up.
+func Rebuild(ctx context.Context, names ...string) error {
+	rebuilds, err := projectionsByName(names...)
+	if err != nil {
+		return err
+	}
+	for _, projection := range rebuilds {
+		if err = projection.Rebuild(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lags measures the lag of the projections for the instance of the context,
+// all projections are measured if no names are given
+func Lags(ctx context.Context, names ...string) ([]*handler.Lag, error) {
+	measured := projections
+	if len(names) > 0 {
+		var err error
+		if measured, err = projectionsByName(names...); err != nil {
+			return nil, err
+		}
+	}
+	lags := make([]*handler.Lag, len(measured))
+	for i, projection := range measured {
+		lag, err := projection.Lag(ctx)
+		if err != nil {
+			return nil, err
+		}
+		lags[i] = lag
+	}
+	return lags, nil
+}
+
+// CheckProjectionNames returns an error if one of the names is not a projection
+func CheckProjectionNames(names ...string) error {
+	_, err := projectionsByName(names...)
+	return err
+}
+
+func projectionsByName(names ...string) ([]projection, error) {
+	found := make([]projection, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(projections, func(p projection) bool {
+			return p.String() == name
+		})
+		if i < 0 {
+			return nil, zerrors.ThrowNotFound(nil, "PROJE-Tr5mc2", "Errors.ProjectionName.Invalid")
+		}
+		found = append(found, projections[i])
+	}
+	return found, nil
+}
+
 func ApplyCustomConfig(customConfig CustomConfig) handler.Config {
 	return applyCustomConfig(projectionConfig, customConfig)
 }
@@ -288,6 +351,7 @@ func newProjectionsList() {
 		InstanceFeatureProjection,
 		TargetProjection,
 		ExecutionProjection,
+		TargetDeliveryProjection,
 		UserSchemaProjection,
 	}
 }
@@ -0,0 +1,212 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/delivery"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	TargetDeliveryTable            = "projections.target_deliveries"
+	TargetDeliveryIDCol            = "id"
+	TargetDeliveryCreationDateCol  = "creation_date"
+	TargetDeliveryChangeDateCol    = "change_date"
+	TargetDeliveryResourceOwnerCol = "resource_owner"
+	TargetDeliveryInstanceIDCol    = "instance_id"
+	TargetDeliverySequenceCol      = "sequence"
+	TargetDeliveryTargetIDCol      = "target_id"
+	TargetDeliveryExecutionIDCol   = "execution_id"
+	TargetDeliveryDelivererCol     = "deliverer"
+	TargetDeliveryBodyCol          = "body"
+	TargetDeliveryStateCol         = "state"
+	TargetDeliveryAttemptCol       = "attempt"
+	TargetDeliveryFailureCountCol  = "failure_count"
+	TargetDeliveryNextAttemptCol   = "next_attempt"
+	TargetDeliveryLastErrorCol     = "last_error"
+)
+
+type targetDeliveryProjection struct{}
+
+func newTargetDeliveryProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(targetDeliveryProjection))
+}
+
+func (*targetDeliveryProjection) Name() string {
+	return TargetDeliveryTable
+}
+
+func (*targetDeliveryProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(TargetDeliveryIDCol, handler.ColumnTypeText),
+			handler.NewColumn(TargetDeliveryCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(TargetDeliveryChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(TargetDeliveryResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(TargetDeliveryInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(TargetDeliverySequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(TargetDeliveryTargetIDCol, handler.ColumnTypeText),
+			handler.NewColumn(TargetDeliveryExecutionIDCol, handler.ColumnTypeText),
+			handler.NewColumn(TargetDeliveryDelivererCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(TargetDeliveryBodyCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(TargetDeliveryStateCol, handler.ColumnTypeEnum),
+			handler.NewColumn(TargetDeliveryAttemptCol, handler.ColumnTypeInt64),
+			handler.NewColumn(TargetDeliveryFailureCountCol, handler.ColumnTypeInt64),
+			handler.NewColumn(TargetDeliveryNextAttemptCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(TargetDeliveryLastErrorCol, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(TargetDeliveryInstanceIDCol, TargetDeliveryIDCol),
+			handler.WithIndex(handler.NewIndex("next_attempt", []string{TargetDeliveryStateCol, TargetDeliveryNextAttemptCol})),
+			handler.WithIndex(handler.NewIndex("target_id", []string{TargetDeliveryTargetIDCol})),
+		),
+	)
+}
+
+func (p *targetDeliveryProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: delivery.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  delivery.AddedEventType,
+					Reduce: p.reduceDeliveryAdded,
+				},
+				{
+					Event:  delivery.AttemptStartedEventType,
+					Reduce: p.reduceDeliveryAttemptStarted,
+				},
+				{
+					Event:  delivery.AttemptFailedEventType,
+					Reduce: p.reduceDeliveryAttemptFailed,
+				},
+				{
+					Event:  delivery.SucceededEventType,
+					Reduce: p.reduceDeliverySucceeded,
+				},
+				{
+					Event:  delivery.FailedEventType,
+					Reduce: p.reduceDeliveryFailed,
+				},
+				{
+					Event:  delivery.RedrivenEventType,
+					Reduce: p.reduceDeliveryRedriven,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(TargetDeliveryInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *targetDeliveryProjection) reduceDeliveryAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*delivery.AddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TargetDeliveryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(TargetDeliveryResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(TargetDeliveryIDCol, e.Aggregate().ID),
+			handler.NewCol(TargetDeliveryCreationDateCol, e.CreationDate()),
+			handler.NewCol(TargetDeliveryChangeDateCol, e.CreationDate()),
+			handler.NewCol(TargetDeliverySequenceCol, e.Sequence()),
+			handler.NewCol(TargetDeliveryTargetIDCol, e.TargetID),
+			handler.NewCol(TargetDeliveryExecutionIDCol, e.ExecutionID),
+			handler.NewCol(TargetDeliveryDelivererCol, e.Deliverer),
+			handler.NewCol(TargetDeliveryBodyCol, e.Body),
+			handler.NewCol(TargetDeliveryStateCol, domain.TargetDeliveryStatePending),
+			handler.NewCol(TargetDeliveryAttemptCol, 0),
+			handler.NewCol(TargetDeliveryFailureCountCol, 0),
+			handler.NewCol(TargetDeliveryNextAttemptCol, e.CreationDate()),
+		},
+	), nil
+}
+
+func (p *targetDeliveryProjection) reduceDeliveryAttemptStarted(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*delivery.AttemptStartedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(TargetDeliveryStateCol, domain.TargetDeliveryStateAttempting),
+		handler.NewCol(TargetDeliveryAttemptCol, e.Attempt),
+	), nil
+}
+
+func (p *targetDeliveryProjection) reduceDeliveryAttemptFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*delivery.AttemptFailedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(TargetDeliveryStateCol, domain.TargetDeliveryStatePending),
+		handler.NewIncrementCol(TargetDeliveryFailureCountCol, 1),
+		handler.NewCol(TargetDeliveryNextAttemptCol, e.NextAttempt),
+		handler.NewCol(TargetDeliveryLastErrorCol, e.Error),
+	), nil
+}
+
+func (p *targetDeliveryProjection) reduceDeliverySucceeded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*delivery.SucceededEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(TargetDeliveryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(TargetDeliveryIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *targetDeliveryProjection) reduceDeliveryFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*delivery.FailedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(TargetDeliveryStateCol, domain.TargetDeliveryStateFailed),
+		handler.NewIncrementCol(TargetDeliveryFailureCountCol, 1),
+		handler.NewCol(TargetDeliveryLastErrorCol, e.Error),
+	), nil
+}
+
+func (p *targetDeliveryProjection) reduceDeliveryRedriven(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*delivery.RedrivenEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(TargetDeliveryStateCol, domain.TargetDeliveryStatePending),
+		handler.NewCol(TargetDeliveryFailureCountCol, 0),
+		handler.NewCol(TargetDeliveryNextAttemptCol, e.CreationDate()),
+	), nil
+}
+
+func (p *targetDeliveryProjection) updateStatement(e eventstore.Event, values ...handler.Column) *handler.Statement {
+	return handler.NewUpdateStatement(
+		e,
+		append([]handler.Column{
+			handler.NewCol(TargetDeliveryChangeDateCol, e.CreatedAt()),
+			handler.NewCol(TargetDeliverySequenceCol, e.Sequence()),
+		}, values...),
+		[]handler.Condition{
+			handler.NewCond(TargetDeliveryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(TargetDeliveryIDCol, e.Aggregate().ID),
+		},
+	)
+}
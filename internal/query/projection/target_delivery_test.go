@@ -0,0 +1,278 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/delivery"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestTargetDeliveryProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceDeliveryAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						delivery.AddedEventType,
+						delivery.AggregateType,
+						[]byte(`{"targetId": "target", "executionId": "event", "body": {"cryptoType": 0, "algorithm": "enc", "keyId": "id", "crypted": "eyJrZXkiOiAidmFsdWUifQ=="}}`),
+					),
+					eventstore.GenericEventMapper[delivery.AddedEvent],
+				),
+			},
+			reduce: (&targetDeliveryProjection{}).reduceDeliveryAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("delivery"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.target_deliveries (instance_id, resource_owner, id, creation_date, change_date, sequence, target_id, execution_id, deliverer, body, state, attempt, failure_count, next_attempt) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"ro-id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"target",
+								"event",
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte(`{"key": "value"}`),
+								},
+								domain.TargetDeliveryStatePending,
+								0,
+								0,
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryAttemptStarted",
+			args: args{
+				event: getEvent(
+					testEvent(
+						delivery.AttemptStartedEventType,
+						delivery.AggregateType,
+						[]byte(`{"attempt": 2}`),
+					),
+					eventstore.GenericEventMapper[delivery.AttemptStartedEvent],
+				),
+			},
+			reduce: (&targetDeliveryProjection{}).reduceDeliveryAttemptStarted,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("delivery"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.target_deliveries SET (change_date, sequence, state, attempt) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.TargetDeliveryStateAttempting,
+								uint32(2),
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryAttemptFailed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						delivery.AttemptFailedEventType,
+						delivery.AggregateType,
+						[]byte(`{"attempt": 2, "error": "connection refused", "nextAttempt": "2024-01-01T00:00:00Z"}`),
+					),
+					eventstore.GenericEventMapper[delivery.AttemptFailedEvent],
+				),
+			},
+			reduce: (&targetDeliveryProjection{}).reduceDeliveryAttemptFailed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("delivery"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.target_deliveries SET (change_date, sequence, state, failure_count, next_attempt, last_error) = ($1, $2, $3, failure_count + $4, $5, $6) WHERE (instance_id = $7) AND (id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.TargetDeliveryStatePending,
+								1,
+								anyArg{},
+								"connection refused",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliverySucceeded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						delivery.SucceededEventType,
+						delivery.AggregateType,
+						[]byte(`{"attempt": 2}`),
+					),
+					eventstore.GenericEventMapper[delivery.SucceededEvent],
+				),
+			},
+			reduce: (&targetDeliveryProjection{}).reduceDeliverySucceeded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("delivery"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.target_deliveries WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryFailed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						delivery.FailedEventType,
+						delivery.AggregateType,
+						[]byte(`{"attempt": 2, "error": "connection refused"}`),
+					),
+					eventstore.GenericEventMapper[delivery.FailedEvent],
+				),
+			},
+			reduce: (&targetDeliveryProjection{}).reduceDeliveryFailed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("delivery"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.target_deliveries SET (change_date, sequence, state, failure_count, last_error) = ($1, $2, $3, failure_count + $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.TargetDeliveryStateFailed,
+								1,
+								"connection refused",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryRedriven",
+			args: args{
+				event: getEvent(
+					testEvent(
+						delivery.RedrivenEventType,
+						delivery.AggregateType,
+						[]byte(`{}`),
+					),
+					eventstore.GenericEventMapper[delivery.RedrivenEvent],
+				),
+			},
+			reduce: (&targetDeliveryProjection{}).reduceDeliveryRedriven,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("delivery"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.target_deliveries SET (change_date, sequence, state, failure_count, next_attempt) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.TargetDeliveryStatePending,
+								0,
+								anyArg{},
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					),
+					instance.InstanceRemovedEventMapper,
+				),
+			},
+			reduce: reduceInstanceRemovedHelper(TargetDeliveryInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.target_deliveries WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, TargetDeliveryTable, tt.want)
+		})
+	}
+}
@@ -63,6 +63,10 @@ var (
 		name:  projection.TargetInterruptOnErrorCol,
 		table: targetTable,
 	}
+	TargetColumnAuthType = Column{
+		name:  projection.TargetAuthTypeCol,
+		table: targetTable,
+	}
 )
 
 type Targets struct {
@@ -83,6 +87,8 @@ type Target struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	// AuthType is the type of the authentication, the credentials are never returned
+	AuthType domain.TargetAuthType
 }
 
 type TargetSearchQueries struct {
@@ -134,6 +140,7 @@ func prepareTargetsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			TargetColumnTimeout.identifier(),
 			TargetColumnURL.identifier(),
 			TargetColumnInterruptOnError.identifier(),
+			TargetColumnAuthType.identifier(),
 			countColumn.identifier(),
 		).From(targetTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
@@ -152,6 +159,7 @@ func prepareTargetsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 					&target.Timeout,
 					&target.Endpoint,
 					&target.InterruptOnError,
+					&target.AuthType,
 					&count,
 				)
 				if err != nil {
@@ -184,6 +192,7 @@ func prepareTargetQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			TargetColumnTimeout.identifier(),
 			TargetColumnURL.identifier(),
 			TargetColumnInterruptOnError.identifier(),
+			TargetColumnAuthType.identifier(),
 		).From(targetTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Target, error) {
@@ -198,6 +207,7 @@ func prepareTargetQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 				&target.Timeout,
 				&target.Endpoint,
 				&target.InterruptOnError,
+				&target.AuthType,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
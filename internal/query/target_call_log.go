@@ -0,0 +1,150 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// targetCallLogTable is filled by the target call emitter of the logstore and not by a projection
+var (
+	targetCallLogTable = table{
+		name:          "logstore.target_calls",
+		instanceIDCol: "instance_id",
+	}
+	TargetCallLogColumnLogDate = Column{
+		name:  "log_date",
+		table: targetCallLogTable,
+	}
+	TargetCallLogColumnInstanceID = Column{
+		name:  "instance_id",
+		table: targetCallLogTable,
+	}
+	TargetCallLogColumnTargetID = Column{
+		name:  "target_id",
+		table: targetCallLogTable,
+	}
+	TargetCallLogColumnExecutionID = Column{
+		name:  "execution_id",
+		table: targetCallLogTable,
+	}
+	TargetCallLogColumnStatusCode = Column{
+		name:  "status_code",
+		table: targetCallLogTable,
+	}
+	TargetCallLogColumnTook = Column{
+		name:  "took",
+		table: targetCallLogTable,
+	}
+	TargetCallLogColumnError = Column{
+		name:  "error",
+		table: targetCallLogTable,
+	}
+)
+
+type TargetCallLogs struct {
+	SearchResponse
+	TargetCallLogs []*TargetCallLog
+}
+
+type TargetCallLog struct {
+	LogDate     time.Time
+	TargetID    string
+	ExecutionID string
+	StatusCode  int
+	Took        time.Duration
+	Error       string
+}
+
+type TargetCallLogSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *TargetCallLogSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// SearchTargetCallLogs returns the calls to the targets of the instance, the calls are only stored if the target call quota is enabled
+func (q *Queries) SearchTargetCallLogs(ctx context.Context, queries *TargetCallLogSearchQueries) (_ *TargetCallLogs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		TargetCallLogColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareTargetCallLogsQuery(ctx, q.client)
+	return genericRowsQuery[*TargetCallLogs](ctx, q.client, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func NewTargetCallLogTargetIDSearchQuery(targetID string) (SearchQuery, error) {
+	return NewTextQuery(TargetCallLogColumnTargetID, targetID, TextEquals)
+}
+
+func NewTargetCallLogExecutionIDSearchQuery(executionID string) (SearchQuery, error) {
+	return NewTextQuery(TargetCallLogColumnExecutionID, executionID, TextEquals)
+}
+
+func NewTargetCallLogLogDateSearchQuery(logDate time.Time, compare TimestampComparison) (SearchQuery, error) {
+	return NewTimestampQuery(TargetCallLogColumnLogDate, logDate, compare)
+}
+
+func prepareTargetCallLogsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*TargetCallLogs, error)) {
+	return sq.Select(
+			TargetCallLogColumnLogDate.identifier(),
+			TargetCallLogColumnTargetID.identifier(),
+			TargetCallLogColumnExecutionID.identifier(),
+			TargetCallLogColumnStatusCode.identifier(),
+			TargetCallLogColumnTook.identifier(),
+			TargetCallLogColumnError.identifier(),
+			countColumn.identifier(),
+		).From(targetCallLogTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*TargetCallLogs, error) {
+			logs := make([]*TargetCallLog, 0)
+			var count uint64
+			for rows.Next() {
+				log := new(TargetCallLog)
+				var (
+					took    int64
+					callErr = sql.NullString{}
+				)
+				err := rows.Scan(
+					&log.LogDate,
+					&log.TargetID,
+					&log.ExecutionID,
+					&log.StatusCode,
+					&took,
+					&callErr,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				log.Took = time.Duration(took)
+				log.Error = callErr.String
+				logs = append(logs, log)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-e6u3ol9bjh", "Errors.Query.CloseRows")
+			}
+
+			return &TargetCallLogs{
+				TargetCallLogs: logs,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,129 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	prepareTargetCallLogsStmt = `SELECT logstore.target_calls.log_date,` +
+		` logstore.target_calls.target_id,` +
+		` logstore.target_calls.execution_id,` +
+		` logstore.target_calls.status_code,` +
+		` logstore.target_calls.took,` +
+		` logstore.target_calls.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM logstore.target_calls`
+	prepareTargetCallLogsCols = []string{
+		"log_date",
+		"target_id",
+		"execution_id",
+		"status_code",
+		"took",
+		"error",
+		"count",
+	}
+)
+
+func Test_TargetCallLogPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareTargetCallLogsQuery no result",
+			prepare: prepareTargetCallLogsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareTargetCallLogsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &TargetCallLogs{TargetCallLogs: []*TargetCallLog{}},
+		},
+		{
+			name:    "prepareTargetCallLogsQuery multiple result",
+			prepare: prepareTargetCallLogsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareTargetCallLogsStmt),
+					prepareTargetCallLogsCols,
+					[][]driver.Value{
+						{
+							testNow,
+							"target",
+							"request/zitadel.session.v2.SessionService/ListSessions",
+							200,
+							int64(time.Second),
+							nil,
+						},
+						{
+							testNow,
+							"target",
+							"event/user.human.added",
+							403,
+							int64(time.Millisecond),
+							"ID=EXEC-dra6yamk98 Message=Errors.Execution.Failed",
+						},
+					},
+				),
+			},
+			object: &TargetCallLogs{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				TargetCallLogs: []*TargetCallLog{
+					{
+						LogDate:     testNow,
+						TargetID:    "target",
+						ExecutionID: "request/zitadel.session.v2.SessionService/ListSessions",
+						StatusCode:  200,
+						Took:        time.Second,
+					},
+					{
+						LogDate:     testNow,
+						TargetID:    "target",
+						ExecutionID: "event/user.human.added",
+						StatusCode:  403,
+						Took:        time.Millisecond,
+						Error:       "ID=EXEC-dra6yamk98 Message=Errors.Execution.Failed",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareTargetCallLogsQuery sql err",
+			prepare: prepareTargetCallLogsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareTargetCallLogsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*TargetCallLogs)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+SELECT d.id
+     , d.instance_id
+     , d.resource_owner
+     , d.target_id
+     , d.execution_id
+     , d.deliverer
+     , d.body
+     , d.failure_count
+     , t.target_type
+     , t.endpoint
+     , t.timeout
+     , t.interrupt_on_error
+     , t.signing_key
+     , t.auth
+FROM projections.target_deliveries d
+         LEFT JOIN projections.targets3 t
+                   ON d.instance_id = t.instance_id
+                       AND d.target_id = t.id
+                       AND d.deliverer = ''
+WHERE (d.state = $1 AND d.next_attempt <= $2)
+   OR (d.state = $3 AND d.change_date <= $4)
+ORDER BY d.next_attempt
+LIMIT $5;
@@ -0,0 +1,362 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	targetDeliveryTable = table{
+		name:          projection.TargetDeliveryTable,
+		instanceIDCol: projection.TargetDeliveryInstanceIDCol,
+	}
+	TargetDeliveryColumnID = Column{
+		name:  projection.TargetDeliveryIDCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnCreationDate = Column{
+		name:  projection.TargetDeliveryCreationDateCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnChangeDate = Column{
+		name:  projection.TargetDeliveryChangeDateCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnResourceOwner = Column{
+		name:  projection.TargetDeliveryResourceOwnerCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnInstanceID = Column{
+		name:  projection.TargetDeliveryInstanceIDCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnSequence = Column{
+		name:  projection.TargetDeliverySequenceCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnTargetID = Column{
+		name:  projection.TargetDeliveryTargetIDCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnExecutionID = Column{
+		name:  projection.TargetDeliveryExecutionIDCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnDeliverer = Column{
+		name:  projection.TargetDeliveryDelivererCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnState = Column{
+		name:  projection.TargetDeliveryStateCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnAttempt = Column{
+		name:  projection.TargetDeliveryAttemptCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnFailureCount = Column{
+		name:  projection.TargetDeliveryFailureCountCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnNextAttempt = Column{
+		name:  projection.TargetDeliveryNextAttemptCol,
+		table: targetDeliveryTable,
+	}
+	TargetDeliveryColumnLastError = Column{
+		name:  projection.TargetDeliveryLastErrorCol,
+		table: targetDeliveryTable,
+	}
+)
+
+var (
+	//go:embed target_deliveries_due.sql
+	targetDeliveriesDueQuery string
+)
+
+type TargetDeliveries struct {
+	SearchResponse
+	TargetDeliveries []*TargetDelivery
+}
+
+func (t *TargetDeliveries) SetState(s *State) {
+	t.State = s
+}
+
+type TargetDelivery struct {
+	ID string
+	domain.ObjectDetails
+
+	CreationDate time.Time
+	TargetID     string
+	ExecutionID  string
+	State        domain.TargetDeliveryState
+	Attempt      uint32
+	FailureCount uint32
+	NextAttempt  time.Time
+	LastError    string
+}
+
+type TargetDeliverySearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *TargetDeliverySearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// SearchTargetDeliveries returns the deliveries of targets,
+// deliveries of other deliverers (e.g. back-channel logouts) are not returned.
+func (q *Queries) SearchTargetDeliveries(ctx context.Context, queries *TargetDeliverySearchQueries) (deliveries *TargetDeliveries, err error) {
+	eq := sq.Eq{
+		TargetDeliveryColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		TargetDeliveryColumnDeliverer.identifier():  "",
+	}
+	query, scan := prepareTargetDeliveriesQuery(ctx, q.client)
+	return genericRowsQueryWithState[*TargetDeliveries](ctx, q.client, targetDeliveryTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func (q *Queries) GetTargetDeliveryByID(ctx context.Context, id string) (delivery *TargetDelivery, err error) {
+	eq := sq.Eq{
+		TargetDeliveryColumnID.identifier():         id,
+		TargetDeliveryColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		TargetDeliveryColumnDeliverer.identifier():  "",
+	}
+	query, scan := prepareTargetDeliveryQuery(ctx, q.client)
+	return genericRowQuery[*TargetDelivery](ctx, q.client, query.Where(eq), scan)
+}
+
+func NewTargetDeliveryTargetIDSearchQuery(targetID string) (SearchQuery, error) {
+	return NewTextQuery(TargetDeliveryColumnTargetID, targetID, TextEquals)
+}
+
+func NewTargetDeliveryStateSearchQuery(state domain.TargetDeliveryState) (SearchQuery, error) {
+	return NewNumberQuery(TargetDeliveryColumnState, state, NumberEquals)
+}
+
+func prepareTargetDeliveriesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*TargetDeliveries, error)) {
+	return sq.Select(
+			TargetDeliveryColumnID.identifier(),
+			TargetDeliveryColumnCreationDate.identifier(),
+			TargetDeliveryColumnChangeDate.identifier(),
+			TargetDeliveryColumnResourceOwner.identifier(),
+			TargetDeliveryColumnSequence.identifier(),
+			TargetDeliveryColumnTargetID.identifier(),
+			TargetDeliveryColumnExecutionID.identifier(),
+			TargetDeliveryColumnState.identifier(),
+			TargetDeliveryColumnAttempt.identifier(),
+			TargetDeliveryColumnFailureCount.identifier(),
+			TargetDeliveryColumnNextAttempt.identifier(),
+			TargetDeliveryColumnLastError.identifier(),
+			countColumn.identifier(),
+		).From(targetDeliveryTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*TargetDeliveries, error) {
+			deliveries := make([]*TargetDelivery, 0)
+			var count uint64
+			for rows.Next() {
+				delivery := new(TargetDelivery)
+				lastError := sql.NullString{}
+				err := rows.Scan(
+					&delivery.ID,
+					&delivery.CreationDate,
+					&delivery.EventDate,
+					&delivery.ResourceOwner,
+					&delivery.Sequence,
+					&delivery.TargetID,
+					&delivery.ExecutionID,
+					&delivery.State,
+					&delivery.Attempt,
+					&delivery.FailureCount,
+					&delivery.NextAttempt,
+					&lastError,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				delivery.LastError = lastError.String
+				deliveries = append(deliveries, delivery)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-wn1em8wqeg", "Errors.Query.CloseRows")
+			}
+
+			return &TargetDeliveries{
+				TargetDeliveries: deliveries,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareTargetDeliveryQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*TargetDelivery, error)) {
+	return sq.Select(
+			TargetDeliveryColumnID.identifier(),
+			TargetDeliveryColumnCreationDate.identifier(),
+			TargetDeliveryColumnChangeDate.identifier(),
+			TargetDeliveryColumnResourceOwner.identifier(),
+			TargetDeliveryColumnSequence.identifier(),
+			TargetDeliveryColumnTargetID.identifier(),
+			TargetDeliveryColumnExecutionID.identifier(),
+			TargetDeliveryColumnState.identifier(),
+			TargetDeliveryColumnAttempt.identifier(),
+			TargetDeliveryColumnFailureCount.identifier(),
+			TargetDeliveryColumnNextAttempt.identifier(),
+			TargetDeliveryColumnLastError.identifier(),
+		).From(targetDeliveryTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*TargetDelivery, error) {
+			delivery := new(TargetDelivery)
+			lastError := sql.NullString{}
+			err := row.Scan(
+				&delivery.ID,
+				&delivery.CreationDate,
+				&delivery.EventDate,
+				&delivery.ResourceOwner,
+				&delivery.Sequence,
+				&delivery.TargetID,
+				&delivery.ExecutionID,
+				&delivery.State,
+				&delivery.Attempt,
+				&delivery.FailureCount,
+				&delivery.NextAttempt,
+				&lastError,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-2inzmmziuu", "Errors.Target.Delivery.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-4u3cm1c0pn", "Errors.Internal")
+			}
+			delivery.LastError = lastError.String
+			return delivery, nil
+		}
+}
+
+// DueTargetDelivery is a delivery of any instance which has to be attempted,
+// Target is nil if the target of the delivery does not exist anymore.
+// The Body is stored encrypted and removed as soon as the delivery succeeded.
+type DueTargetDelivery struct {
+	ID            string
+	InstanceID    string
+	ResourceOwner string
+	ExecutionID   string
+	// Deliverer is set if the body is not sent to a target but delivered by the registered deliverer,
+	// the ReceiverID is then the id of the receiver of the deliverer
+	Deliverer    string
+	ReceiverID   string
+	Body         []byte
+	FailureCount uint32
+	Target       *ExecutionTarget
+}
+
+// DueTargetDeliveries returns the pending deliveries of all instances which are due
+// and the deliveries of which the attempt was started before staleBefore.
+func (q *Queries) DueTargetDeliveries(ctx context.Context, now, staleBefore time.Time, limit uint16) (deliveries []*DueTargetDelivery, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	err = q.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			deliveries, err = scanDueTargetDeliveries(rows, q.targetEncryption)
+			return err
+		},
+		targetDeliveriesDueQuery,
+		domain.TargetDeliveryStatePending,
+		now,
+		domain.TargetDeliveryStateAttempting,
+		staleBefore,
+		limit,
+	)
+	return deliveries, err
+}
+
+func scanDueTargetDeliveries(rows *sql.Rows, alg crypto.EncryptionAlgorithm) ([]*DueTargetDelivery, error) {
+	deliveries := make([]*DueTargetDelivery, 0)
+	for rows.Next() {
+		delivery := new(DueTargetDelivery)
+
+		var (
+			targetID         string
+			body             = new(crypto.CryptoValue)
+			targetType       = &sql.NullInt32{}
+			endpoint         = &sql.NullString{}
+			timeout          = &sql.NullInt64{}
+			interruptOnError = &sql.NullBool{}
+			signingKey       = new(crypto.CryptoValue)
+			auth             = new(crypto.CryptoValue)
+		)
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.InstanceID,
+			&delivery.ResourceOwner,
+			&targetID,
+			&delivery.ExecutionID,
+			&delivery.Deliverer,
+			body,
+			&delivery.FailureCount,
+			targetType,
+			endpoint,
+			timeout,
+			interruptOnError,
+			signingKey,
+			auth,
+		)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Body, err = crypto.Decrypt(body, alg)
+		if err != nil {
+			return nil, err
+		}
+		if delivery.Deliverer != "" {
+			delivery.ReceiverID = targetID
+		}
+		if endpoint.Valid {
+			delivery.Target = &ExecutionTarget{
+				InstanceID:       delivery.InstanceID,
+				ExecutionID:      delivery.ExecutionID,
+				TargetID:         targetID,
+				TargetType:       domain.TargetType(targetType.Int32),
+				Endpoint:         endpoint.String,
+				Timeout:          time.Duration(timeout.Int64),
+				InterruptOnError: interruptOnError.Bool,
+			}
+			if len(signingKey.Crypted) > 0 {
+				delivery.Target.SigningKey, err = crypto.DecryptString(signingKey, alg)
+				if err != nil {
+					return nil, err
+				}
+			}
+			delivery.Target.Auth, err = decryptTargetAuth(auth, alg)
+			if err != nil {
+				return nil, err
+			}
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-wj5s1kof7b", "Errors.Query.CloseRows")
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,244 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	prepareTargetDeliveriesStmt = `SELECT projections.target_deliveries.id,` +
+		` projections.target_deliveries.creation_date,` +
+		` projections.target_deliveries.change_date,` +
+		` projections.target_deliveries.resource_owner,` +
+		` projections.target_deliveries.sequence,` +
+		` projections.target_deliveries.target_id,` +
+		` projections.target_deliveries.execution_id,` +
+		` projections.target_deliveries.state,` +
+		` projections.target_deliveries.attempt,` +
+		` projections.target_deliveries.failure_count,` +
+		` projections.target_deliveries.next_attempt,` +
+		` projections.target_deliveries.last_error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.target_deliveries`
+	prepareTargetDeliveriesCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"target_id",
+		"execution_id",
+		"state",
+		"attempt",
+		"failure_count",
+		"next_attempt",
+		"last_error",
+		"count",
+	}
+
+	prepareTargetDeliveryStmt = `SELECT projections.target_deliveries.id,` +
+		` projections.target_deliveries.creation_date,` +
+		` projections.target_deliveries.change_date,` +
+		` projections.target_deliveries.resource_owner,` +
+		` projections.target_deliveries.sequence,` +
+		` projections.target_deliveries.target_id,` +
+		` projections.target_deliveries.execution_id,` +
+		` projections.target_deliveries.state,` +
+		` projections.target_deliveries.attempt,` +
+		` projections.target_deliveries.failure_count,` +
+		` projections.target_deliveries.next_attempt,` +
+		` projections.target_deliveries.last_error` +
+		` FROM projections.target_deliveries`
+	prepareTargetDeliveryCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"target_id",
+		"execution_id",
+		"state",
+		"attempt",
+		"failure_count",
+		"next_attempt",
+		"last_error",
+	}
+)
+
+func Test_TargetDeliveryPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareTargetDeliveriesQuery no result",
+			prepare: prepareTargetDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareTargetDeliveriesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &TargetDeliveries{TargetDeliveries: []*TargetDelivery{}},
+		},
+		{
+			name:    "prepareTargetDeliveriesQuery one result",
+			prepare: prepareTargetDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareTargetDeliveriesStmt),
+					prepareTargetDeliveriesCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							"target",
+							"event",
+							domain.TargetDeliveryStateFailed,
+							uint32(5),
+							uint32(5),
+							testNow,
+							"connection refused",
+						},
+					},
+				),
+			},
+			object: &TargetDeliveries{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				TargetDeliveries: []*TargetDelivery{
+					{
+						ID: "id",
+						ObjectDetails: domain.ObjectDetails{
+							EventDate:     testNow,
+							ResourceOwner: "ro",
+							Sequence:      20211109,
+						},
+						CreationDate: testNow,
+						TargetID:     "target",
+						ExecutionID:  "event",
+						State:        domain.TargetDeliveryStateFailed,
+						Attempt:      5,
+						FailureCount: 5,
+						NextAttempt:  testNow,
+						LastError:    "connection refused",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareTargetDeliveriesQuery sql err",
+			prepare: prepareTargetDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareTargetDeliveriesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*TargetDelivery)(nil),
+		},
+		{
+			name:    "prepareTargetDeliveryQuery no result",
+			prepare: prepareTargetDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareTargetDeliveryStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*TargetDelivery)(nil),
+		},
+		{
+			name:    "prepareTargetDeliveryQuery found",
+			prepare: prepareTargetDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareTargetDeliveryStmt),
+					prepareTargetDeliveryCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211109),
+						"target",
+						"event",
+						domain.TargetDeliveryStatePending,
+						uint32(1),
+						uint32(1),
+						testNow,
+						nil,
+					},
+				),
+			},
+			object: &TargetDelivery{
+				ID: "id",
+				ObjectDetails: domain.ObjectDetails{
+					EventDate:     testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211109,
+				},
+				CreationDate: testNow,
+				TargetID:     "target",
+				ExecutionID:  "event",
+				State:        domain.TargetDeliveryStatePending,
+				Attempt:      1,
+				FailureCount: 1,
+				NextAttempt:  testNow,
+			},
+		},
+		{
+			name:    "prepareTargetDeliveryQuery sql err",
+			prepare: prepareTargetDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareTargetDeliveryStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*TargetDelivery)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -14,17 +14,18 @@ import (
 )
 
 var (
-	prepareTargetsStmt = `SELECT projections.targets1.id,` +
-		` projections.targets1.change_date,` +
-		` projections.targets1.resource_owner,` +
-		` projections.targets1.sequence,` +
-		` projections.targets1.name,` +
-		` projections.targets1.target_type,` +
-		` projections.targets1.timeout,` +
-		` projections.targets1.endpoint,` +
-		` projections.targets1.interrupt_on_error,` +
+	prepareTargetsStmt = `SELECT projections.targets3.id,` +
+		` projections.targets3.change_date,` +
+		` projections.targets3.resource_owner,` +
+		` projections.targets3.sequence,` +
+		` projections.targets3.name,` +
+		` projections.targets3.target_type,` +
+		` projections.targets3.timeout,` +
+		` projections.targets3.endpoint,` +
+		` projections.targets3.interrupt_on_error,` +
+		` projections.targets3.auth_type,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.targets1`
+		` FROM projections.targets3`
 	prepareTargetsCols = []string{
 		"id",
 		"change_date",
@@ -35,19 +36,21 @@ var (
 		"timeout",
 		"endpoint",
 		"interrupt_on_error",
+		"auth_type",
 		"count",
 	}
 
-	prepareTargetStmt = `SELECT projections.targets1.id,` +
-		` projections.targets1.change_date,` +
-		` projections.targets1.resource_owner,` +
-		` projections.targets1.sequence,` +
-		` projections.targets1.name,` +
-		` projections.targets1.target_type,` +
-		` projections.targets1.timeout,` +
-		` projections.targets1.endpoint,` +
-		` projections.targets1.interrupt_on_error` +
-		` FROM projections.targets1`
+	prepareTargetStmt = `SELECT projections.targets3.id,` +
+		` projections.targets3.change_date,` +
+		` projections.targets3.resource_owner,` +
+		` projections.targets3.sequence,` +
+		` projections.targets3.name,` +
+		` projections.targets3.target_type,` +
+		` projections.targets3.timeout,` +
+		` projections.targets3.endpoint,` +
+		` projections.targets3.interrupt_on_error,` +
+		` projections.targets3.auth_type` +
+		` FROM projections.targets3`
 	prepareTargetCols = []string{
 		"id",
 		"change_date",
@@ -58,6 +61,7 @@ var (
 		"timeout",
 		"endpoint",
 		"interrupt_on_error",
+		"auth_type",
 	}
 )
 
@@ -102,6 +106,7 @@ func Test_TargetPrepares(t *testing.T) {
 							1 * time.Second,
 							"https://example.com",
 							true,
+							domain.TargetAuthTypeBearer,
 						},
 					},
 				),
@@ -123,6 +128,7 @@ func Test_TargetPrepares(t *testing.T) {
 						Timeout:          1 * time.Second,
 						Endpoint:         "https://example.com",
 						InterruptOnError: true,
+						AuthType:         domain.TargetAuthTypeBearer,
 					},
 				},
 			},
@@ -145,6 +151,7 @@ func Test_TargetPrepares(t *testing.T) {
 							1 * time.Second,
 							"https://example.com",
 							true,
+							domain.TargetAuthTypeBearer,
 						},
 						{
 							"id-2",
@@ -156,6 +163,7 @@ func Test_TargetPrepares(t *testing.T) {
 							1 * time.Second,
 							"https://example.com",
 							false,
+							domain.TargetAuthTypeNone,
 						},
 						{
 							"id-3",
@@ -167,6 +175,7 @@ func Test_TargetPrepares(t *testing.T) {
 							1 * time.Second,
 							"https://example.com",
 							false,
+							domain.TargetAuthTypeNone,
 						},
 					},
 				),
@@ -188,6 +197,7 @@ func Test_TargetPrepares(t *testing.T) {
 						Timeout:          1 * time.Second,
 						Endpoint:         "https://example.com",
 						InterruptOnError: true,
+						AuthType:         domain.TargetAuthTypeBearer,
 					},
 					{
 						ID: "id-2",
@@ -270,6 +280,7 @@ func Test_TargetPrepares(t *testing.T) {
 						1 * time.Second,
 						"https://example.com",
 						true,
+						domain.TargetAuthTypeBearer,
 					},
 				),
 			},
@@ -285,6 +296,7 @@ func Test_TargetPrepares(t *testing.T) {
 				Timeout:          1 * time.Second,
 				Endpoint:         "https://example.com",
 				InterruptOnError: true,
+				AuthType:         domain.TargetAuthTypeBearer,
 			},
 		},
 		{
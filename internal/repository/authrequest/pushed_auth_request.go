@@ -0,0 +1,114 @@
+package authrequest
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	pushedAuthRequestEventPrefix = authRequestEventPrefix + "pushed."
+	PushedAddedType              = pushedAuthRequestEventPrefix + "added"
+	PushedUsedType               = pushedAuthRequestEventPrefix + "used"
+
+	UniquePushedAuthRequestUsed    = "pushed_auth_request_used"
+	DuplicatePushedAuthRequestUsed = "Errors.AuthRequest.AlreadyHandled"
+)
+
+// PushedAddedEvent stores the verified parameters of an authorization request
+// pushed by a client (RFC 9126) until it's referenced by the request_uri on the authorization endpoint.
+type PushedAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID   string              `json:"client_id"`
+	Parameters map[string][]string `json:"parameters"`
+	Expiration time.Time           `json:"expiration"`
+}
+
+func (e *PushedAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushedAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewPushedAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID string,
+	parameters map[string][]string,
+	expiration time.Time,
+) *PushedAddedEvent {
+	return &PushedAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushedAddedType,
+		),
+		ClientID:   clientID,
+		Parameters: parameters,
+		Expiration: expiration,
+	}
+}
+
+func PushedAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	added := &PushedAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(added)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "AUTHR-Par3m", "unable to unmarshal pushed auth request")
+	}
+
+	return added, nil
+}
+
+// PushedUsedEvent marks the pushed authorization request as used,
+// so the request_uri can't be used a second time.
+type PushedUsedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *PushedUsedEvent) Payload() interface{} {
+	return e
+}
+
+// UniqueConstraints ensures that the request_uri is only used once,
+// even if it's used concurrently, as the removal of a constraint would not fail for the second use
+func (e *PushedUsedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{
+		eventstore.NewAddEventUniqueConstraint(
+			UniquePushedAuthRequestUsed,
+			e.Aggregate().ID,
+			DuplicatePushedAuthRequestUsed,
+		),
+	}
+}
+
+func NewPushedUsedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *PushedUsedEvent {
+	return &PushedUsedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushedUsedType,
+		),
+	}
+}
+
+func PushedUsedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	used := &PushedUsedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(used)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "AUTHR-Par4u", "unable to unmarshal pushed auth request used")
+	}
+
+	return used, nil
+}
@@ -0,0 +1,33 @@
+package delivery
+
+import (
+	"strconv"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueDeliveryAttempt    = "delivery_attempt"
+	DuplicateDeliveryAttempt = "Errors.Target.Delivery.AlreadyAttempted"
+)
+
+// NewAddAttemptUniqueConstraint ensures that an attempt of a delivery is only started once,
+// even if multiple instances of ZITADEL try to deliver it at the same time
+func NewAddAttemptUniqueConstraint(id string, attempt uint32) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueDeliveryAttempt,
+		attemptField(id, attempt),
+		DuplicateDeliveryAttempt,
+	)
+}
+
+func NewRemoveAttemptUniqueConstraint(id string, attempt uint32) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueDeliveryAttempt,
+		attemptField(id, attempt),
+	)
+}
+
+func attemptField(id string, attempt uint32) string {
+	return id + ":" + strconv.FormatUint(uint64(attempt), 10)
+}
@@ -0,0 +1,220 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix         eventstore.EventType = "delivery."
+	AddedEventType                               = eventTypePrefix + "added"
+	AttemptStartedEventType                      = eventTypePrefix + "attempt.started"
+	AttemptFailedEventType                       = eventTypePrefix + "attempt.failed"
+	SucceededEventType                           = eventTypePrefix + "succeeded"
+	FailedEventType                              = eventTypePrefix + "failed"
+	RedrivenEventType                            = eventTypePrefix + "redriven"
+)
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TargetID    string `json:"targetId"`
+	ExecutionID string `json:"executionId,omitempty"`
+	// Deliverer is the name of the deliverer which delivers the body instead of calling the target
+	Deliverer string `json:"deliverer,omitempty"`
+	// Body is the encrypted request body sent to the target, as it can contain personal data
+	Body *crypto.CryptoValue `json:"body,omitempty"`
+}
+
+func (e *AddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *AddedEvent) Payload() any {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	targetID string,
+	executionID string,
+	deliverer string,
+	body *crypto.CryptoValue,
+) *AddedEvent {
+	return &AddedEvent{
+		*eventstore.NewBaseEventForPush(
+			ctx, aggregate, AddedEventType,
+		),
+		targetID, executionID, deliverer, body}
+}
+
+type AttemptStartedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Attempt uint32 `json:"attempt"`
+
+	// staleAttempt is the attempt which was started before but never finished
+	staleAttempt uint32
+}
+
+func (e *AttemptStartedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *AttemptStartedEvent) Payload() any {
+	return e
+}
+
+func (e *AttemptStartedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	constraints := []*eventstore.UniqueConstraint{NewAddAttemptUniqueConstraint(e.Aggregate().ID, e.Attempt)}
+	if e.staleAttempt > 0 {
+		constraints = append(constraints, NewRemoveAttemptUniqueConstraint(e.Aggregate().ID, e.staleAttempt))
+	}
+	return constraints
+}
+
+func NewAttemptStartedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint32,
+	staleAttempt uint32,
+) *AttemptStartedEvent {
+	return &AttemptStartedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, AttemptStartedEventType,
+		),
+		Attempt:      attempt,
+		staleAttempt: staleAttempt,
+	}
+}
+
+type AttemptFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Attempt     uint32    `json:"attempt"`
+	Error       string    `json:"error,omitempty"`
+	NextAttempt time.Time `json:"nextAttempt"`
+}
+
+func (e *AttemptFailedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *AttemptFailedEvent) Payload() any {
+	return e
+}
+
+func (e *AttemptFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemoveAttemptUniqueConstraint(e.Aggregate().ID, e.Attempt)}
+}
+
+func NewAttemptFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint32,
+	err string,
+	nextAttempt time.Time,
+) *AttemptFailedEvent {
+	return &AttemptFailedEvent{
+		*eventstore.NewBaseEventForPush(
+			ctx, aggregate, AttemptFailedEventType,
+		),
+		attempt, err, nextAttempt}
+}
+
+type SucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Attempt uint32 `json:"attempt"`
+}
+
+func (e *SucceededEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *SucceededEvent) Payload() any {
+	return e
+}
+
+func (e *SucceededEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemoveAttemptUniqueConstraint(e.Aggregate().ID, e.Attempt)}
+}
+
+func NewSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint32,
+) *SucceededEvent {
+	return &SucceededEvent{
+		*eventstore.NewBaseEventForPush(
+			ctx, aggregate, SucceededEventType,
+		),
+		attempt}
+}
+
+type FailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Attempt uint32 `json:"attempt"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (e *FailedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *FailedEvent) Payload() any {
+	return e
+}
+
+func (e *FailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemoveAttemptUniqueConstraint(e.Aggregate().ID, e.Attempt)}
+}
+
+func NewFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint32,
+	err string,
+) *FailedEvent {
+	return &FailedEvent{
+		*eventstore.NewBaseEventForPush(
+			ctx, aggregate, FailedEventType,
+		),
+		attempt, err}
+}
+
+type RedrivenEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RedrivenEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *RedrivenEvent) Payload() any {
+	return e
+}
+
+func (e *RedrivenEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewRedrivenEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *RedrivenEvent {
+	return &RedrivenEvent{
+		*eventstore.NewBaseEventForPush(
+			ctx, aggregate, RedrivenEventType,
+		),
+	}
+}
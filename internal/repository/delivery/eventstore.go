@@ -0,0 +1,12 @@
+package delivery
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func init() {
+	eventstore.RegisterFilterEventMapper(AggregateType, AddedEventType, eventstore.GenericEventMapper[AddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, AttemptStartedEventType, eventstore.GenericEventMapper[AttemptStartedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, AttemptFailedEventType, eventstore.GenericEventMapper[AttemptFailedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SucceededEventType, eventstore.GenericEventMapper[SucceededEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, FailedEventType, eventstore.GenericEventMapper[FailedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, RedrivenEventType, eventstore.GenericEventMapper[RedrivenEvent])
+}
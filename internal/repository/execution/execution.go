@@ -36,7 +36,8 @@ func (e *SetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
 type SetEventV2 struct {
 	*eventstore.BaseEvent `json:"-"`
 
-	Targets []*Target `json:"targets"`
+	Targets []*Target            `json:"targets"`
+	Mode    domain.ExecutionMode `json:"mode,omitempty"`
 }
 
 func (e *SetEventV2) SetBaseEvent(b *eventstore.BaseEvent) {
@@ -54,18 +55,22 @@ func (e *SetEventV2) UniqueConstraints() []*eventstore.UniqueConstraint {
 type Target struct {
 	Type   domain.ExecutionTargetType `json:"type"`
 	Target string                     `json:"target"`
+	// Condition is only set for targets, the target is only called if the condition matches
+	Condition *domain.ExecutionTargetCondition `json:"condition,omitempty"`
 }
 
 func NewSetEventV2(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	targets []*Target,
+	mode domain.ExecutionMode,
 ) *SetEventV2 {
 	return &SetEventV2{
 		BaseEvent: eventstore.NewBaseEventForPush(
 			ctx, aggregate, SetEventV2Type,
 		),
 		Targets: targets,
+		Mode:    mode,
 	}
 }
 
@@ -0,0 +1,55 @@
+package instance
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// eventFixture is a payload as it was stored by a previous version of ZITADEL
+type eventFixture struct {
+	EventType eventstore.EventType `json:"eventType"`
+	Version   eventstore.Version   `json:"version"`
+	Payload   json.RawMessage      `json:"payload"`
+	// Want is the payload of the mapped event
+	Want json.RawMessage `json:"want"`
+}
+
+// TestEventFixtures ensures that all historical payloads in testdata/events still map to the current events
+func TestEventFixtures(t *testing.T) {
+	files, err := filepath.Glob("testdata/events/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+			var fixture eventFixture
+			require.NoError(t, json.Unmarshal(data, &fixture))
+
+			event, err := eventstore.MapEvent(&eventstore.BaseEvent{
+				Agg: &eventstore.Aggregate{
+					ID:      "instance",
+					Type:    AggregateType,
+					Version: fixture.Version,
+				},
+				EventType: fixture.EventType,
+				Data:      fixture.Payload,
+			})
+			require.NoError(t, err)
+
+			command, ok := event.(eventstore.Command)
+			require.True(t, ok, "event %T is not mapped", event)
+			payload, err := json.Marshal(command.Payload())
+			require.NoError(t, err)
+			assert.JSONEq(t, string(fixture.Want), string(payload))
+		})
+	}
+}
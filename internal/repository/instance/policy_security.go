@@ -15,9 +15,6 @@ const (
 type SecurityPolicySetEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	// Enabled is a legacy field which was used before for Iframe Embedding.
-	// It is kept so older events can still be reduced.
-	Enabled               *bool     `json:"enabled,omitempty"`
 	EnableIframeEmbedding *bool     `json:"enable_iframe_embedding,omitempty"`
 	AllowedOrigins        *[]string `json:"allowedOrigins,omitempty"`
 	EnableImpersonation   *bool     `json:"enable_impersonation,omitempty"`
@@ -75,6 +72,11 @@ func (e *SecurityPolicySetEvent) UniqueConstraints() []*eventstore.UniqueConstra
 	return nil
 }
 
+// SecurityPolicySetEventUpcaster renames the legacy field "enabled" which was used before for Iframe Embedding.
+var SecurityPolicySetEventUpcaster = eventstore.RenameFields(map[string]string{
+	"enabled": "enable_iframe_embedding",
+})
+
 func SecurityPolicySetEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	securityPolicyAdded := &SecurityPolicySetEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -11,5 +11,6 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, RefreshTokenAddedType, eventstore.GenericEventMapper[RefreshTokenAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, RefreshTokenRenewedType, eventstore.GenericEventMapper[RefreshTokenRenewedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, RefreshTokenRevokedType, eventstore.GenericEventMapper[RefreshTokenRevokedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, BackChannelLogoutScheduledType, eventstore.GenericEventMapper[BackChannelLogoutScheduledEvent])
 
 }
@@ -6,6 +6,7 @@ import (
 
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
@@ -18,6 +19,8 @@ const (
 	RefreshTokenAddedType   = oidcSessionEventPrefix + "refresh_token.added"
 	RefreshTokenRenewedType = oidcSessionEventPrefix + "refresh_token.renewed"
 	RefreshTokenRevokedType = oidcSessionEventPrefix + "refresh_token.revoked"
+
+	BackChannelLogoutScheduledType = oidcSessionEventPrefix + "back_channel_logout.scheduled"
 )
 
 type AddedEvent struct {
@@ -34,12 +37,24 @@ type AddedEvent struct {
 	Nonce             string                      `json:"nonce,omitempty"`
 	PreferredLanguage *language.Tag               `json:"preferredLanguage,omitempty"`
 	UserAgent         *domain.UserAgent           `json:"userAgent,omitempty"`
+	TriggeredAtOrigin string                      `json:"triggerOrigin,omitempty"`
+	// DPoPJKT is the thumbprint of the key the tokens of the session are bound to (RFC 9449)
+	DPoPJKT string `json:"dpopJkt,omitempty"`
+	// CertificateThumbprint is the SHA-256 thumbprint of the client certificate
+	// the tokens of the session are bound to (RFC 8705)
+	CertificateThumbprint string `json:"certificateThumbprint,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
 	return e
 }
 
+// TriggerOrigin returns the origin the tokens were issued at,
+// which is the issuer of the session
+func (e *AddedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
 func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
 	return nil
 }
@@ -61,6 +76,8 @@ func NewAddedEvent(ctx context.Context,
 	nonce string,
 	preferredLanguage *language.Tag,
 	userAgent *domain.UserAgent,
+	dpopJKT,
+	certificateThumbprint string,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -68,17 +85,20 @@ func NewAddedEvent(ctx context.Context,
 			aggregate,
 			AddedType,
 		),
-		UserID:            userID,
-		UserResourceOwner: userResourceOwner,
-		SessionID:         sessionID,
-		ClientID:          clientID,
-		Audience:          audience,
-		Scope:             scope,
-		AuthMethods:       authMethods,
-		AuthTime:          authTime,
-		Nonce:             nonce,
-		PreferredLanguage: preferredLanguage,
-		UserAgent:         userAgent,
+		UserID:                userID,
+		UserResourceOwner:     userResourceOwner,
+		SessionID:             sessionID,
+		ClientID:              clientID,
+		Audience:              audience,
+		Scope:                 scope,
+		AuthMethods:           authMethods,
+		AuthTime:              authTime,
+		Nonce:                 nonce,
+		PreferredLanguage:     preferredLanguage,
+		UserAgent:             userAgent,
+		TriggeredAtOrigin:     http.ComposedOrigin(ctx),
+		DPoPJKT:               dpopJKT,
+		CertificateThumbprint: certificateThumbprint,
 	}
 }
 
@@ -259,3 +279,44 @@ func NewRefreshTokenRevokedEvent(
 		),
 	}
 }
+
+// BackChannelLogoutScheduledEvent is pushed together with the delivery of the logout token
+// to the back-channel logout uri of the client, so the client is notified once about the end of the oidc session
+type BackChannelLogoutScheduledEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SessionID string `json:"sessionID,omitempty"`
+	UserID    string `json:"userID"`
+	ClientID  string `json:"clientID"`
+}
+
+func (e *BackChannelLogoutScheduledEvent) Payload() interface{} {
+	return e
+}
+
+func (e *BackChannelLogoutScheduledEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *BackChannelLogoutScheduledEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewBackChannelLogoutScheduledEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	sessionID,
+	userID,
+	clientID string,
+) *BackChannelLogoutScheduledEvent {
+	return &BackChannelLogoutScheduledEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			BackChannelLogoutScheduledType,
+		),
+		SessionID: sessionID,
+		UserID:    userID,
+		ClientID:  clientID,
+	}
+}
@@ -46,4 +46,7 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationKeyRemovedEventType, ApplicationKeyRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigAddedType, SAMLConfigAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigChangedType, SAMLConfigChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, InitialAccessTokenAddedType, eventstore.GenericEventMapper[InitialAccessTokenAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, InitialAccessTokenRemovedType, eventstore.GenericEventMapper[InitialAccessTokenRemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationRegistrationTokenAddedType, eventstore.GenericEventMapper[ApplicationRegistrationTokenAddedEvent])
 }
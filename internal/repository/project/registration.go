@@ -0,0 +1,124 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	initialAccessTokenEventTypePrefix     = projectEventTypePrefix + "initial.access.token."
+	InitialAccessTokenAddedType           = initialAccessTokenEventTypePrefix + "added"
+	InitialAccessTokenRemovedType         = initialAccessTokenEventTypePrefix + "removed"
+	ApplicationRegistrationTokenAddedType = applicationEventTypePrefix + "oidc.registration.token.added"
+)
+
+// InitialAccessTokenAddedEvent allows the bearer of the token
+// to register OIDC applications in the project using dynamic client registration (RFC 7591)
+type InitialAccessTokenAddedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	TokenID        string    `json:"tokenId"`
+	ExpirationDate time.Time `json:"expirationDate"`
+}
+
+func NewInitialAccessTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+	expirationDate time.Time,
+) *InitialAccessTokenAddedEvent {
+	return &InitialAccessTokenAddedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InitialAccessTokenAddedType,
+		),
+		TokenID:        tokenID,
+		ExpirationDate: expirationDate,
+	}
+}
+
+func (e *InitialAccessTokenAddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *InitialAccessTokenAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *InitialAccessTokenAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type InitialAccessTokenRemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func NewInitialAccessTokenRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *InitialAccessTokenRemovedEvent {
+	return &InitialAccessTokenRemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InitialAccessTokenRemovedType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func (e *InitialAccessTokenRemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *InitialAccessTokenRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *InitialAccessTokenRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+// ApplicationRegistrationTokenAddedEvent sets the registration access token (RFC 7592)
+// of a dynamically registered application, any previous token of the application becomes invalid
+type ApplicationRegistrationTokenAddedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	AppID   string `json:"appId"`
+	TokenID string `json:"tokenId"`
+}
+
+func NewApplicationRegistrationTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	tokenID string,
+) *ApplicationRegistrationTokenAddedEvent {
+	return &ApplicationRegistrationTokenAddedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApplicationRegistrationTokenAddedType,
+		),
+		AppID:   appID,
+		TokenID: tokenID,
+	}
+}
+
+func (e *ApplicationRegistrationTokenAddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *ApplicationRegistrationTokenAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ApplicationRegistrationTokenAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
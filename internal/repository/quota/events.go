@@ -25,6 +25,7 @@ const (
 	Unimplemented Unit = iota
 	RequestsAllAuthenticated
 	ActionsAllRunsSeconds
+	ActionsTargetCalls
 )
 
 func NewRemoveQuotaNameUniqueConstraint(unit Unit) *eventstore.UniqueConstraint {
@@ -20,4 +20,5 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, MetadataSetType, MetadataSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LifetimeSetType, eventstore.GenericEventMapper[LifetimeSetEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, TerminateType, TerminateEventMapper)
+	eventstore.RegisterEndOfLife(AggregateType, TerminateType)
 }
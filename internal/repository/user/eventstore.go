@@ -137,4 +137,35 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretCheckSucceededType, MachineSecretCheckSucceededEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretCheckFailedType, MachineSecretCheckFailedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretHashUpdatedType, eventstore.GenericEventMapper[MachineSecretHashUpdatedEvent])
+
+	eventstore.RegisterPersonalDataFields(AggregateType, HumanAddedType, humanPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(AggregateType, HumanRegisteredType, humanPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(AggregateType, HumanProfileChangedType, "firstName", "lastName", "nickName", "displayName")
+	eventstore.RegisterPersonalDataFields(AggregateType, HumanEmailChangedType, "email")
+	eventstore.RegisterPersonalDataFields(AggregateType, HumanPhoneChangedType, "phone")
+	eventstore.RegisterPersonalDataFields(AggregateType, HumanAddressChangedType, "country", "locality", "postalCode", "region", "streetAddress")
+	eventstore.RegisterPersonalDataFields(AggregateType, UserUserNameChangedType, "userName")
+	eventstore.RegisterPersonalDataFields(AggregateType, UserIDPLinkAddedType, "userId", "displayName")
+	eventstore.RegisterPersonalDataFields(AggregateType, UserIDPLinkRemovedType, "userId")
+	eventstore.RegisterPersonalDataFields(AggregateType, UserIDPLinkCascadeRemovedType, "userId")
+	eventstore.RegisterPersonalDataFields(AggregateType, UserIDPExternalIDMigratedType, "previousId", "newId")
+	eventstore.RegisterPersonalDataFields(AggregateType, UserIDPExternalUsernameChangedType, "userId", "username")
+	eventstore.RegisterPersonalDataErasure(AggregateType, UserRemovedType)
+	eventstore.RegisterEndOfLife(AggregateType, UserRemovedType)
+}
+
+// humanPersonalDataFields are the fields of [HumanAddedEvent] and [HumanRegisteredEvent] which are encrypted with the key of the user
+var humanPersonalDataFields = []string{
+	"userName",
+	"firstName",
+	"lastName",
+	"nickName",
+	"displayName",
+	"email",
+	"phone",
+	"country",
+	"locality",
+	"postalCode",
+	"region",
+	"streetAddress",
 }
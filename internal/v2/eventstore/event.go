@@ -20,6 +20,7 @@ type Action[P Payload] struct {
 type Command struct {
 	Action[any]
 	UniqueConstraints []*UniqueConstraint
+	Fields            []*Field
 }
 
 type StorageEvent struct {
@@ -0,0 +1,34 @@
+package eventstore
+
+// Field is a value of an object stored in the fields table.
+// The field belongs to the aggregate of the command it was added to.
+type Field struct {
+	// Object the field belongs to
+	Object Object
+	// Name of the field
+	Name string
+	// Value is stored as json
+	Value any
+	// ShouldIndex defines if the value is indexed for lookups
+	ShouldIndex bool
+}
+
+type Object struct {
+	// Type of the object
+	Type string
+	// ID of the object
+	ID string
+	// Revision of the object, if an object evolves the revision should be increased
+	Revision uint8
+}
+
+// NewSetField sets the value of the field of the object.
+// An existing value of the same field is overwritten.
+func NewSetField(object Object, name string, value any, shouldIndex bool) *Field {
+	return &Field{
+		Object:      object,
+		Name:        name,
+		Value:       value,
+		ShouldIndex: shouldIndex,
+	}
+}
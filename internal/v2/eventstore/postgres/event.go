@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"time"
@@ -9,7 +10,10 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
-func intentToCommands(intent *intent) (commands []*command, err error) {
+// encryptPayload encrypts the personal data of the payload of a command
+type encryptPayload func(ctx context.Context, instanceID, aggregateType, aggregateID, eventType string, payload []byte) ([]byte, error)
+
+func intentToCommands(ctx context.Context, intent *intent, encrypt encryptPayload) (commands []*command, err error) {
 	commands = make([]*command, len(intent.Commands()))
 
 	for i, cmd := range intent.Commands() {
@@ -23,6 +27,14 @@ func intentToCommands(intent *intent) (commands []*command, err error) {
 			sequence: intent.nextSequence(),
 			payload:  payload,
 		}
+		if encrypt == nil || payload == nil {
+			continue
+		}
+		aggregate := intent.Aggregate()
+		commands[i].encryptedPayload, err = encrypt(ctx, aggregate.Instance, aggregate.Type, aggregate.ID, cmd.Type, payload)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return commands, nil
@@ -40,10 +52,20 @@ type command struct {
 
 	intent *intent
 
-	payload   []byte
-	position  eventstore.GlobalPosition
-	createdAt time.Time
-	sequence  uint32
+	payload []byte
+	// encryptedPayload is stored instead of the payload if the command contains personal data,
+	// the reducer of the intent still receives the plain payload
+	encryptedPayload []byte
+	position         eventstore.GlobalPosition
+	createdAt        time.Time
+	sequence         uint32
+}
+
+func (cmd *command) storedPayload() []byte {
+	if cmd.encryptedPayload != nil {
+		return cmd.encryptedPayload
+	}
+	return cmd.payload
 }
 
 func (cmd *command) toEvent() *eventstore.StorageEvent {
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"github.com/cockroachdb/cockroach-go/v2/crdb"
@@ -57,9 +58,13 @@ func (s *Storage) Push(ctx context.Context, intent *eventstore.PushIntent) (err
 			return zerrors.ThrowInvalidArgument(nil, "POSTG-KOM6E", "Errors.Internal.Eventstore.SequenceNotMatched")
 		}
 
+		var encrypt encryptPayload
+		if s.config.EncryptPersonalData != nil {
+			encrypt = s.config.EncryptPersonalData.Encrypter()
+		}
 		commands := make([]*command, 0, len(intents))
 		for _, intent := range intents {
-			additionalCommands, err := intentToCommands(intent)
+			additionalCommands, err := intentToCommands(ctx, intent, encrypt)
 			if err != nil {
 				return err
 			}
@@ -71,6 +76,11 @@ func (s *Storage) Push(ctx context.Context, intent *eventstore.PushIntent) (err
 			return err
 		}
 
+		err = fields(ctx, tx, commands)
+		if err != nil {
+			return err
+		}
+
 		return push(ctx, tx, intent, commands)
 	})
 }
@@ -166,7 +176,7 @@ func push(ctx context.Context, tx *sql.Tx, reducer eventstore.Reducer, commands
 			cmd.Revision,
 			cmd.Creator,
 			cmd.Type,
-			cmd.payload,
+			cmd.storedPayload(),
 			cmd.sequence,
 			cmd.position.InPositionOrder,
 		)
@@ -247,6 +257,47 @@ func uniqueConstraints(ctx context.Context, tx *sql.Tx, commands []*command) (er
 	return nil
 }
 
+func fields(ctx context.Context, tx *sql.Tx, commands []*command) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	for _, cmd := range commands {
+		for _, field := range cmd.Fields {
+			value, err := json.Marshal(field.Value)
+			if err != nil {
+				return zerrors.ThrowInternal(err, "POSTG-p8Rfa", "Errors.Internal")
+			}
+			aggregate := cmd.intent.PushAggregate.Aggregate()
+			_, err = tx.ExecContext(ctx, setFieldStmt,
+				aggregate.Instance,
+				aggregate.Owner,
+				aggregate.Type,
+				aggregate.ID,
+				field.Object.Type,
+				field.Object.ID,
+				field.Object.Revision,
+				field.Name,
+				value,
+				field.ShouldIndex,
+			)
+			if err != nil {
+				logging.WithFields("field", field.Name).WithError(err).Warn("setting field failed")
+				return zerrors.ThrowInternal(err, "POSTG-sJ2fd", "Errors.Internal")
+			}
+		}
+	}
+
+	return nil
+}
+
+// setFieldStmt updates the field of the object or inserts it if it does not exist yet
+const setFieldStmt = `WITH upsert AS (` +
+	`UPDATE eventstore.fields SET object_revision = $7, value = $9, should_index = $10 ` +
+	`WHERE instance_id = $1 AND resource_owner = $2 AND aggregate_type = $3 AND aggregate_id = $4 AND object_type = $5 AND object_id = $6 AND field_name = $8 ` +
+	`RETURNING *) ` +
+	`INSERT INTO eventstore.fields (instance_id, resource_owner, aggregate_type, aggregate_id, object_type, object_id, object_revision, field_name, value, value_must_be_unique, should_index) ` +
+	`SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE, $10 WHERE NOT EXISTS (SELECT 1 FROM upsert)`
+
 // the query is so complex because we accidentally stored unique constraint case sensitive
 // the query checks first if there is a case sensitive match and afterwards if there is a case insensitive match
 var deleteUniqueConstraintClause = `
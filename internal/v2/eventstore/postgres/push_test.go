@@ -474,6 +474,107 @@ func Test_uniqueConstraints(t *testing.T) {
 	}
 }
 
+func Test_fields(t *testing.T) {
+	type args struct {
+		commands     []*command
+		expectations []mock.Expectation
+	}
+	execErr := errors.New("exec err")
+	tests := []struct {
+		name      string
+		args      args
+		assertErr func(t *testing.T, err error) bool
+	}{
+		{
+			name: "command without fields",
+			args: args{
+				commands: []*command{
+					{
+						Command: &eventstore.Command{},
+					},
+				},
+				expectations: []mock.Expectation{},
+			},
+			assertErr: expectNoErr,
+		},
+		{
+			name: "set 1 field",
+			args: args{
+				commands: []*command{
+					{
+						intent: &intent{
+							PushAggregate: eventstore.NewPushIntent(
+								"instance",
+								eventstore.AppendAggregate("owner", "type", "id"),
+							).Aggregates()[0],
+						},
+						Command: &eventstore.Command{
+							Fields: []*eventstore.Field{
+								eventstore.NewSetField(eventstore.Object{Type: "object", ID: "id", Revision: 1}, "state", 2, true),
+							},
+						},
+					},
+				},
+				expectations: []mock.Expectation{
+					mock.ExpectExec(
+						setFieldStmt,
+						mock.WithExecArgs("instance", "owner", "type", "id", "object", "id", uint8(1), "state", []byte("2"), true),
+						mock.WithExecRowsAffected(1),
+					),
+				},
+			},
+			assertErr: expectNoErr,
+		},
+		{
+			name: "exec fails",
+			args: args{
+				commands: []*command{
+					{
+						intent: &intent{
+							PushAggregate: eventstore.NewPushIntent(
+								"instance",
+								eventstore.AppendAggregate("owner", "type", "id"),
+							).Aggregates()[0],
+						},
+						Command: &eventstore.Command{
+							Fields: []*eventstore.Field{
+								eventstore.NewSetField(eventstore.Object{Type: "object", ID: "id", Revision: 1}, "name", "value", false),
+							},
+						},
+					},
+				},
+				expectations: []mock.Expectation{
+					mock.ExpectExec(
+						setFieldStmt,
+						mock.WithExecArgs("instance", "owner", "type", "id", "object", "id", uint8(1), "name", []byte(`"value"`), false),
+						mock.WithExecErr(execErr),
+					),
+				},
+			},
+			assertErr: func(t *testing.T, err error) bool {
+				is := errors.Is(err, zerrors.ThrowInternal(execErr, "POSTG-sJ2fd", "Errors.Internal"))
+				if !is {
+					t.Errorf("no error expected got: %v", err)
+				}
+				return is
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := mock.NewSQLMock(t, append([]mock.Expectation{mock.ExpectBegin(nil)}, tt.args.expectations...)...)
+			tx, err := dbMock.DB.Begin()
+			if err != nil {
+				t.Errorf("unexpected error in begin: %v", err)
+				t.FailNow()
+			}
+			err = fields(context.Background(), tx, tt.args.commands)
+			tt.assertErr(t, err)
+			dbMock.Assert(t)
+		})
+	}
+}
+
 var errReduce = errors.New("reduce err")
 
 func Test_lockAggregates(t *testing.T) {
@@ -1314,3 +1415,45 @@ func expectNoErr(t *testing.T, err error) bool {
 	}
 	return is
 }
+
+func Test_intentToCommands(t *testing.T) {
+	pushIntent := eventstore.NewPushIntent(
+		"instance",
+		eventstore.AppendAggregate("owner", "user", "user1",
+			eventstore.AppendCommands(
+				&eventstore.Command{Action: eventstore.Action[any]{Type: "user.username.changed", Payload: map[string]string{"userName": "name"}}},
+				&eventstore.Command{Action: eventstore.Action[any]{Type: "user.locked"}},
+			),
+		),
+	)
+	var encrypted []string
+	encrypt := func(_ context.Context, instanceID, aggregateType, aggregateID, eventType string, payload []byte) ([]byte, error) {
+		encrypted = append(encrypted, instanceID+":"+aggregateType+":"+aggregateID+":"+eventType)
+		return []byte(`{"userName":"encrypted"}`), nil
+	}
+
+	commands, err := intentToCommands(context.Background(), &intent{PushAggregate: pushIntent.Aggregates()[0]}, encrypt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"instance:user:user1:user.username.changed"}; !reflect.DeepEqual(encrypted, want) {
+		t.Errorf("encrypted commands: want %v, got %v", want, encrypted)
+	}
+	if got := string(commands[0].payload); got != `{"userName":"name"}` {
+		t.Errorf("the plain payload must be reduced, got %s", got)
+	}
+	if got := string(commands[0].storedPayload()); got != `{"userName":"encrypted"}` {
+		t.Errorf("the encrypted payload must be stored, got %s", got)
+	}
+	if commands[1].storedPayload() != nil {
+		t.Errorf("commands without payload must not be encrypted, got %s", commands[1].storedPayload())
+	}
+
+	encryptErr := errors.New("no key")
+	_, err = intentToCommands(context.Background(), &intent{PushAggregate: pushIntent.Aggregates()[0]}, func(context.Context, string, string, string, string, []byte) ([]byte, error) {
+		return nil, encryptErr
+	})
+	if !errors.Is(err, encryptErr) {
+		t.Errorf("want error %v, got %v", encryptErr, err)
+	}
+}
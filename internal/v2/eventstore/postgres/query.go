@@ -20,14 +20,22 @@ func (s *Storage) Query(ctx context.Context, query *eventstore.Query) (eventCoun
 	var stmt database.Statement
 	writeQuery(&stmt, query)
 
+	var decrypt decryptPayload
+	if s.config.PersonalData != nil {
+		decrypt = s.config.PersonalData.Decrypter()
+	}
+
 	if query.Tx() != nil {
-		return executeQuery(ctx, query.Tx(), &stmt, query)
+		return executeQuery(ctx, query.Tx(), &stmt, query, decrypt)
 	}
 
-	return executeQuery(ctx, s.client.DB, &stmt, query)
+	return executeQuery(ctx, s.client.DB, &stmt, query, decrypt)
 }
 
-func executeQuery(ctx context.Context, tx database.Querier, stmt *database.Statement, reducer eventstore.Reducer) (eventCount int, err error) {
+// decryptPayload decrypts the personal data of the payload of an event
+type decryptPayload func(ctx context.Context, instanceID, aggregateType, aggregateID, eventType string, payload []byte) ([]byte, error)
+
+func executeQuery(ctx context.Context, tx database.Querier, stmt *database.Statement, reducer eventstore.Reducer, decrypt decryptPayload) (eventCount int, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -64,7 +72,15 @@ func executeQuery(ctx context.Context, tx database.Querier, stmt *database.State
 			if len(payload.V) == 0 {
 				return nil
 			}
-			return json.Unmarshal(payload.V, ptr)
+			data := payload.V
+			if decrypt != nil {
+				var err error
+				data, err = decrypt(ctx, e.Aggregate.Instance, e.Aggregate.Type, e.Aggregate.ID, e.Type, payload.V)
+				if err != nil {
+					return err
+				}
+			}
+			return json.Unmarshal(data, ptr)
 		}
 		eventCount++
 
@@ -116,6 +132,9 @@ func writeFilter(stmt *database.Statement, filter *eventstore.Filter) {
 	filter.Parent().Instance().Write(stmt, "instance_id")
 
 	writeAggregateFilters(stmt, filter.AggregateFilters())
+	if filter.Parent().AwaitOpenTransactions() {
+		stmt.WriteString(awaitOpenTransactionsStmt)
+	}
 	writePagination(stmt, filter.Pagination())
 }
 
@@ -727,7 +727,29 @@ func Test_writeQuery(t *testing.T) {
 				args:  []any{"i1", "user", []string{"a", "b"}, "i1", "org", "org.added"},
 			},
 		},
+		{
+			name: "await open transactions",
+			args: args{
+				query: eventstore.NewQuery(
+					"i1",
+					nil,
+					eventstore.AwaitOpenTransactions(),
+					eventstore.AppendFilters(
+						eventstore.NewFilter(
+							eventstore.FilterPagination(
+								eventstore.PositionGreater(123.4, 0),
+							),
+						),
+					),
+				),
+			},
+			want: wantQuery{
+				query: `SELECT created_at, event_type, "sequence", "position", in_tx_order, payload, creator, "owner", instance_id, aggregate_type, aggregate_id, revision FROM ((SELECT created_at, event_type, "sequence", "position", in_tx_order, payload, creator, "owner", instance_id, aggregate_type, aggregate_id, revision FROM eventstore.events2 WHERE instance_id = $1 AND "position" < (SELECT COALESCE(EXTRACT(EPOCH FROM min(xact_start)), EXTRACT(EPOCH FROM now())) FROM pg_stat_activity WHERE datname = current_database() AND application_name = 'zitadel_es_pusher' AND state <> 'idle') AND position > $2 ORDER BY position, in_tx_order)) sub ORDER BY position, in_tx_order`,
+				args:  []any{"i1", 123.4},
+			},
+		},
 	}
+	initAwaitOpenTransactionsStmt("postgres")
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var stmt database.Statement
@@ -1370,7 +1392,7 @@ func Test_executeQuery(t *testing.T) {
 					),
 				),
 			)
-			gotEventCount, err := executeQuery(context.Background(), mockDB.DB, &database.Statement{}, tt.args.reducer)
+			gotEventCount, err := executeQuery(context.Background(), mockDB.DB, &database.Statement{}, tt.args.reducer, nil)
 			tt.want.assertErr(t, err)
 			if gotEventCount != tt.want.eventCount {
 				t.Errorf("executeQuery() = %v, want %v", gotEventCount, tt.want.eventCount)
@@ -6,6 +6,7 @@ import (
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
 	"github.com/zitadel/zitadel/internal/v2/eventstore"
 )
 
@@ -13,7 +14,8 @@ var (
 	_ eventstore.Pusher  = (*Storage)(nil)
 	_ eventstore.Querier = (*Storage)(nil)
 
-	pushPositionStmt string
+	pushPositionStmt          string
+	awaitOpenTransactionsStmt string
 )
 
 type Storage struct {
@@ -23,10 +25,27 @@ type Storage struct {
 
 type Config struct {
 	MaxRetries uint32
+	// PersonalData decrypts the personal data of the queried events, the payloads are returned as stored if nil
+	PersonalData PersonalDataDecrypter
+	// EncryptPersonalData encrypts the personal data of the pushed commands, the payloads are stored as passed if nil
+	EncryptPersonalData PersonalDataEncrypter
+}
+
+// PersonalDataDecrypter is implemented by the PersonalDataDecrypter of the internal/eventstore package
+type PersonalDataDecrypter interface {
+	// Decrypter returns the function decrypting the payloads of the events of a single query
+	Decrypter() func(ctx context.Context, instanceID, aggregateType, aggregateID, eventType string, payload []byte) ([]byte, error)
+}
+
+// PersonalDataEncrypter is implemented by the PersonalDataEncrypter of the internal/eventstore package
+type PersonalDataEncrypter interface {
+	// Encrypter returns the function encrypting the payloads of the commands of a single push
+	Encrypter() func(ctx context.Context, instanceID, aggregateType, aggregateID, eventType string, payload []byte) ([]byte, error)
 }
 
 func New(client *database.DB, config *Config) *Storage {
 	initPushStmt(client.Type())
+	initAwaitOpenTransactionsStmt(client.Type())
 	return &Storage{
 		client: client,
 		config: config,
@@ -44,6 +63,17 @@ func initPushStmt(typ string) {
 	}
 }
 
+func initAwaitOpenTransactionsStmt(typ string) {
+	switch typ {
+	case "cockroach":
+		awaitOpenTransactionsStmt = ` AND hlc_to_timestamp("position") < (SELECT COALESCE(MIN(start), NOW())::TIMESTAMP FROM crdb_internal.cluster_transactions where application_name = '` + dialect.EventstorePusherAppName + `')`
+	case "postgres":
+		awaitOpenTransactionsStmt = ` AND "position" < (SELECT COALESCE(EXTRACT(EPOCH FROM min(xact_start)), EXTRACT(EPOCH FROM now())) FROM pg_stat_activity WHERE datname = current_database() AND application_name = '` + dialect.EventstorePusherAppName + `' AND state <> 'idle')`
+	default:
+		logging.WithFields("database_type", typ).Panic("await open transactions statement for type not implemented")
+	}
+}
+
 // Health implements eventstore.Pusher.
 func (s *Storage) Health(ctx context.Context) error {
 	return s.client.PingContext(ctx)
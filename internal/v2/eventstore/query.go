@@ -21,7 +21,8 @@ type Query struct {
 	tx         *sql.Tx
 	pagination *Pagination
 	reducer    Reducer
-	// TODO: await push
+	// awaitOpenTransactions only returns events older than the open push transactions
+	awaitOpenTransactions bool
 }
 
 func (q *Query) Instance() database.Condition {
@@ -36,6 +37,10 @@ func (q *Query) Tx() *sql.Tx {
 	return q.tx
 }
 
+func (q *Query) AwaitOpenTransactions() bool {
+	return q.awaitOpenTransactions
+}
+
 func (q *Query) Pagination() *Pagination {
 	q.ensurePagination()
 	return q.pagination
@@ -124,6 +129,14 @@ func SetQueryTx(tx *sql.Tx) QueryOpt {
 	}
 }
 
+// AwaitOpenTransactions only returns events with a position before the start of the oldest open push transaction,
+// so events of transactions committed later with a lower position are not skipped by position based pagination
+func AwaitOpenTransactions() QueryOpt {
+	return func(query *Query) {
+		query.awaitOpenTransactions = true
+	}
+}
+
 func QueryPagination(opts ...paginationOpt) QueryOpt {
 	return func(query *Query) {
 		query.ensurePagination()
@@ -0,0 +1,179 @@
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/v2/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	MemberAddedType          = eventTypePrefix + "member.added"
+	MemberChangedType        = eventTypePrefix + "member.changed"
+	MemberRemovedType        = eventTypePrefix + "member.removed"
+	MemberCascadeRemovedType = eventTypePrefix + "member.cascade.removed"
+
+	// uniqueMember is shared with the members of the other aggregates
+	uniqueMember = "member"
+)
+
+type memberPayload struct {
+	Roles  []string `json:"roles,omitempty"`
+	UserID string   `json:"userId"`
+}
+
+type MemberAddedEvent eventstore.Event[memberPayload]
+
+var _ eventstore.TypeChecker = (*MemberAddedEvent)(nil)
+
+// ActionType implements eventstore.Typer.
+func (c *MemberAddedEvent) ActionType() string {
+	return MemberAddedType
+}
+
+func MemberAddedEventFromStorage(event *eventstore.StorageEvent) (e *MemberAddedEvent, _ error) {
+	if event.Type != e.ActionType() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Mq2ba", "Errors.Invalid.Event.Type")
+	}
+
+	payload, err := eventstore.UnmarshalPayload[memberPayload](event.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberAddedEvent{
+		StorageEvent: event,
+		Payload:      payload,
+	}, nil
+}
+
+// NewMemberAddedCommand adds the user as member with the roles to the org with the given id
+func NewMemberAddedCommand(ctx context.Context, id, userID string, roles ...string) *eventstore.Command {
+	return &eventstore.Command{
+		Action: eventstore.Action[any]{
+			Creator:  authz.GetCtxData(ctx).UserID,
+			Type:     MemberAddedType,
+			Revision: 1,
+			Payload: memberPayload{
+				Roles:  roles,
+				UserID: userID,
+			},
+		},
+		UniqueConstraints: []*eventstore.UniqueConstraint{
+			eventstore.NewAddEventUniqueConstraint(uniqueMember, memberUniqueField(id, userID), "Errors.Member.AlreadyExists"),
+		},
+	}
+}
+
+type MemberChangedEvent eventstore.Event[memberPayload]
+
+var _ eventstore.TypeChecker = (*MemberChangedEvent)(nil)
+
+// ActionType implements eventstore.Typer.
+func (c *MemberChangedEvent) ActionType() string {
+	return MemberChangedType
+}
+
+func MemberChangedEventFromStorage(event *eventstore.StorageEvent) (e *MemberChangedEvent, _ error) {
+	if event.Type != e.ActionType() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Mw7ch", "Errors.Invalid.Event.Type")
+	}
+
+	payload, err := eventstore.UnmarshalPayload[memberPayload](event.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberChangedEvent{
+		StorageEvent: event,
+		Payload:      payload,
+	}, nil
+}
+
+// NewMemberChangedCommand replaces the roles of the member
+func NewMemberChangedCommand(ctx context.Context, userID string, roles ...string) *eventstore.Command {
+	return &eventstore.Command{
+		Action: eventstore.Action[any]{
+			Creator:  authz.GetCtxData(ctx).UserID,
+			Type:     MemberChangedType,
+			Revision: 1,
+			Payload: memberPayload{
+				Roles:  roles,
+				UserID: userID,
+			},
+		},
+	}
+}
+
+type MemberRemovedEvent eventstore.Event[memberPayload]
+
+var _ eventstore.TypeChecker = (*MemberRemovedEvent)(nil)
+
+// ActionType implements eventstore.Typer.
+func (c *MemberRemovedEvent) ActionType() string {
+	return MemberRemovedType
+}
+
+func MemberRemovedEventFromStorage(event *eventstore.StorageEvent) (e *MemberRemovedEvent, _ error) {
+	if event.Type != e.ActionType() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Mr4mv", "Errors.Invalid.Event.Type")
+	}
+
+	payload, err := eventstore.UnmarshalPayload[memberPayload](event.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberRemovedEvent{
+		StorageEvent: event,
+		Payload:      payload,
+	}, nil
+}
+
+// NewMemberRemovedCommand removes the user from the members of the org with the given id
+func NewMemberRemovedCommand(ctx context.Context, id, userID string) *eventstore.Command {
+	return &eventstore.Command{
+		Action: eventstore.Action[any]{
+			Creator:  authz.GetCtxData(ctx).UserID,
+			Type:     MemberRemovedType,
+			Revision: 1,
+			Payload: memberPayload{
+				UserID: userID,
+			},
+		},
+		UniqueConstraints: []*eventstore.UniqueConstraint{
+			eventstore.NewRemoveUniqueConstraint(uniqueMember, memberUniqueField(id, userID)),
+		},
+	}
+}
+
+type MemberCascadeRemovedEvent eventstore.Event[memberPayload]
+
+var _ eventstore.TypeChecker = (*MemberCascadeRemovedEvent)(nil)
+
+// ActionType implements eventstore.Typer.
+func (c *MemberCascadeRemovedEvent) ActionType() string {
+	return MemberCascadeRemovedType
+}
+
+func MemberCascadeRemovedEventFromStorage(event *eventstore.StorageEvent) (e *MemberCascadeRemovedEvent, _ error) {
+	if event.Type != e.ActionType() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Mc9rk", "Errors.Invalid.Event.Type")
+	}
+
+	payload, err := eventstore.UnmarshalPayload[memberPayload](event.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberCascadeRemovedEvent{
+		StorageEvent: event,
+		Payload:      payload,
+	}, nil
+}
+
+func memberUniqueField(id, userID string) string {
+	return fmt.Sprintf("%s:%s", id, userID)
+}
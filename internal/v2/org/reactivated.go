@@ -1,6 +1,9 @@
 package org
 
 import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/v2/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -25,3 +28,17 @@ func ReactivatedEventFromStorage(event *eventstore.StorageEvent) (e *Reactivated
 		StorageEvent: event,
 	}, nil
 }
+
+// NewReactivatedCommand reactivates the org with the given id
+func NewReactivatedCommand(ctx context.Context, id string) *eventstore.Command {
+	return &eventstore.Command{
+		Action: eventstore.Action[any]{
+			Creator:  authz.GetCtxData(ctx).UserID,
+			Type:     ReactivatedType,
+			Revision: 1,
+		},
+		Fields: []*eventstore.Field{
+			stateField(id, ActiveState),
+		},
+	}
+}
@@ -1,5 +1,7 @@
 package org
 
+import "github.com/zitadel/zitadel/internal/v2/eventstore"
+
 type State uint8
 
 const (
@@ -34,3 +36,23 @@ func (s State) IsValidStates(states ...State) bool {
 	}
 	return false
 }
+
+const (
+	searchObjectType = "org"
+	stateSearchField = "state"
+)
+
+// stateField sets the state of the org in the fields table
+// it is used for the permission checks of org resources
+func stateField(id string, state State) *eventstore.Field {
+	return eventstore.NewSetField(
+		eventstore.Object{
+			Type:     searchObjectType,
+			ID:       id,
+			Revision: 1,
+		},
+		stateSearchField,
+		state,
+		true,
+	)
+}
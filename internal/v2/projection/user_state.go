@@ -0,0 +1,95 @@
+package projection
+
+import (
+	"github.com/zitadel/zitadel/internal/v2/eventstore"
+	"github.com/zitadel/zitadel/internal/v2/user"
+)
+
+type UserState struct {
+	projection
+
+	id string
+
+	user.State
+}
+
+func NewUserStateProjection(id string) *UserState {
+	return &UserState{
+		id: id,
+	}
+}
+
+func (p *UserState) Filter() []*eventstore.Filter {
+	return []*eventstore.Filter{
+		eventstore.NewFilter(
+			eventstore.FilterPagination(
+				eventstore.GlobalPositionGreater(&p.position),
+			),
+			eventstore.AppendAggregateFilter(
+				user.AggregateType,
+				eventstore.AggregateIDs(p.id),
+				eventstore.AppendEvent(
+					eventstore.SetEventTypes(UserStateEventTypes...),
+				),
+			),
+		),
+	}
+}
+
+// UserStateEventTypes are the event types which change the state of a user
+var UserStateEventTypes = []string{
+	user.HumanAddedType,
+	user.HumanRegisteredType,
+	user.HumanInitCodeAddedType,
+	user.HumanInitCodeSucceededType,
+	user.MachineAddedType,
+	user.LockedType,
+	user.UnlockedType,
+	user.DeactivatedType,
+	user.ReactivatedType,
+	user.RemovedType,
+	user.V1AddedType,
+	user.V1RegisteredType,
+	user.V1InitCodeAddedType,
+	user.V1InitCodeSucceededType,
+}
+
+func (p *UserState) Reduce(events ...*eventstore.StorageEvent) error {
+	for _, event := range events {
+		if !p.shouldReduce(event) {
+			continue
+		}
+
+		switch event.Type {
+		case user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.HumanInitCodeSucceededType,
+			user.MachineAddedType,
+			user.V1AddedType,
+			user.V1RegisteredType,
+			user.V1InitCodeSucceededType:
+			p.State = user.ActiveState
+		case user.HumanInitCodeAddedType,
+			user.V1InitCodeAddedType:
+			p.State = user.InitialState
+		case user.LockedType:
+			if p.State != user.RemovedState {
+				p.State = user.LockedState
+			}
+		case user.UnlockedType, user.ReactivatedType:
+			if p.State != user.RemovedState {
+				p.State = user.ActiveState
+			}
+		case user.DeactivatedType:
+			if p.State != user.RemovedState {
+				p.State = user.InactiveState
+			}
+		case user.RemovedType:
+			p.State = user.RemovedState
+		default:
+			continue
+		}
+		p.reduce(event)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package readmodel
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/v2/eventstore"
+	"github.com/zitadel/zitadel/internal/v2/org"
+)
+
+// OrgMember is the membership of a user in an org required to change it
+type OrgMember struct {
+	OrgID    string
+	UserID   string
+	Roles    []string
+	IsMember bool
+
+	Sequence   uint32
+	ChangeDate time.Time
+	// Owner of the org aggregate, which is the org itself
+	Owner string
+}
+
+func NewOrgMember(orgID, userID string) *OrgMember {
+	return &OrgMember{
+		OrgID:  orgID,
+		UserID: userID,
+		Owner:  orgID,
+	}
+}
+
+func (rm *OrgMember) Filter() []*eventstore.Filter {
+	return []*eventstore.Filter{
+		eventstore.NewFilter(
+			eventstore.AppendAggregateFilter(
+				org.AggregateType,
+				eventstore.SetAggregateID(rm.OrgID),
+				eventstore.AggregateOwnersEqual(rm.OrgID),
+				eventstore.AppendEvent(
+					eventstore.SetEventTypes(
+						org.MemberAddedType,
+						org.MemberChangedType,
+						org.MemberRemovedType,
+						org.MemberCascadeRemovedType,
+					),
+				),
+			),
+		),
+	}
+}
+
+func (rm *OrgMember) Reduce(events ...*eventstore.StorageEvent) error {
+	for _, event := range events {
+		if event.Aggregate.Type != org.AggregateType || event.Aggregate.ID != rm.OrgID {
+			continue
+		}
+		switch event.Type {
+		case org.MemberAddedType:
+			added, err := org.MemberAddedEventFromStorage(event)
+			if err != nil {
+				return err
+			}
+			if added.Payload.UserID != rm.UserID {
+				continue
+			}
+			rm.Roles = added.Payload.Roles
+			rm.IsMember = true
+		case org.MemberChangedType:
+			changed, err := org.MemberChangedEventFromStorage(event)
+			if err != nil {
+				return err
+			}
+			if changed.Payload.UserID != rm.UserID {
+				continue
+			}
+			rm.Roles = changed.Payload.Roles
+		case org.MemberRemovedType:
+			removed, err := org.MemberRemovedEventFromStorage(event)
+			if err != nil {
+				return err
+			}
+			if removed.Payload.UserID != rm.UserID {
+				continue
+			}
+			rm.Roles = nil
+			rm.IsMember = false
+		case org.MemberCascadeRemovedType:
+			removed, err := org.MemberCascadeRemovedEventFromStorage(event)
+			if err != nil {
+				return err
+			}
+			if removed.Payload.UserID != rm.UserID {
+				continue
+			}
+			rm.Roles = nil
+			rm.IsMember = false
+		default:
+			continue
+		}
+		rm.Owner = event.Aggregate.Owner
+		rm.Sequence = event.Sequence
+		rm.ChangeDate = event.CreatedAt
+	}
+	return nil
+}
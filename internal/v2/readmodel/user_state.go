@@ -0,0 +1,55 @@
+package readmodel
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/v2/eventstore"
+	"github.com/zitadel/zitadel/internal/v2/projection"
+	"github.com/zitadel/zitadel/internal/v2/user"
+)
+
+// UserState is the state of a user required to change it
+type UserState struct {
+	ID    string
+	Owner string
+	State *projection.UserState
+
+	Sequence   uint32
+	ChangeDate time.Time
+}
+
+// NewUserState creates the read model of the user
+// if owner is empty the user is not restricted to an organization
+func NewUserState(id, owner string) *UserState {
+	return &UserState{
+		ID:    id,
+		Owner: owner,
+		State: projection.NewUserStateProjection(id),
+	}
+}
+
+func (rm *UserState) Filter() []*eventstore.Filter {
+	opts := []eventstore.AggregateFilterOpt{
+		eventstore.SetAggregateID(rm.ID),
+		eventstore.AppendEvent(
+			eventstore.SetEventTypes(projection.UserStateEventTypes...),
+		),
+	}
+	if rm.Owner != "" {
+		opts = append(opts, eventstore.AggregateOwnersEqual(rm.Owner))
+	}
+	return []*eventstore.Filter{
+		eventstore.NewFilter(
+			eventstore.AppendAggregateFilter(user.AggregateType, opts...),
+		),
+	}
+}
+
+func (rm *UserState) Reduce(events ...*eventstore.StorageEvent) error {
+	for _, event := range events {
+		rm.Owner = event.Aggregate.Owner
+		rm.Sequence = event.Sequence
+		rm.ChangeDate = event.CreatedAt
+	}
+	return rm.State.Reduce(events...)
+}
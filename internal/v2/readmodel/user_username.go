@@ -0,0 +1,67 @@
+package readmodel
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/v2/eventstore"
+	"github.com/zitadel/zitadel/internal/v2/projection"
+	"github.com/zitadel/zitadel/internal/v2/user"
+)
+
+// UserUsername is the username and the state of a user required to change the username
+type UserUsername struct {
+	*UserState
+	Username string
+}
+
+// usernameEventTypes are the event types which set the username of a user
+var usernameEventTypes = []string{
+	user.HumanAddedType,
+	user.HumanRegisteredType,
+	user.MachineAddedType,
+	user.V1AddedType,
+	user.V1RegisteredType,
+	user.UsernameChangedType,
+	user.DomainClaimedType,
+}
+
+// NewUserUsername creates the read model of the user
+// if owner is empty the user is not restricted to an organization
+func NewUserUsername(id, owner string) *UserUsername {
+	return &UserUsername{
+		UserState: NewUserState(id, owner),
+	}
+}
+
+func (rm *UserUsername) Filter() []*eventstore.Filter {
+	opts := []eventstore.AggregateFilterOpt{
+		eventstore.SetAggregateID(rm.ID),
+		eventstore.AppendEvent(
+			eventstore.SetEventTypes(append(slices.Clone(projection.UserStateEventTypes), user.UsernameChangedType, user.DomainClaimedType)...),
+		),
+	}
+	if rm.Owner != "" {
+		opts = append(opts, eventstore.AggregateOwnersEqual(rm.Owner))
+	}
+	return []*eventstore.Filter{
+		eventstore.NewFilter(
+			eventstore.AppendAggregateFilter(user.AggregateType, opts...),
+		),
+	}
+}
+
+func (rm *UserUsername) Reduce(events ...*eventstore.StorageEvent) error {
+	for _, event := range events {
+		if !slices.Contains(usernameEventTypes, event.Type) {
+			continue
+		}
+		payload, err := eventstore.UnmarshalPayload[struct {
+			Username string `json:"userName"`
+		}](event.Payload)
+		if err != nil {
+			return err
+		}
+		rm.Username = payload.Username
+	}
+	return rm.UserState.Reduce(events...)
+}
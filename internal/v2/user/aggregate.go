@@ -11,6 +11,8 @@ const (
 	AggregateType = "user"
 	humanPrefix   = AggregateType + ".human"
 	machinePrefix = AggregateType + ".machine"
+	// revision of the commands pushed to the user aggregate
+	revision = 2
 )
 
 func NewAggregate(ctx context.Context, id string) *eventstore.Aggregate {
@@ -12,7 +12,7 @@ type domainClaimedPayload struct {
 
 type DomainClaimedEvent eventstore.Event[domainClaimedPayload]
 
-const DomainClaimedType = AggregateType + ".domain.claimed.sent"
+const DomainClaimedType = AggregateType + ".domain.claimed"
 
 var _ eventstore.TypeChecker = (*DomainClaimedEvent)(nil)
 
@@ -1,6 +1,9 @@
 package user
 
 import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/v2/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -25,3 +28,14 @@ func DeactivatedEventFromStorage(event *eventstore.StorageEvent) (e *Deactivated
 		StorageEvent: event,
 	}, nil
 }
+
+// NewDeactivatedCommand deactivates the user
+func NewDeactivatedCommand(ctx context.Context) *eventstore.Command {
+	return &eventstore.Command{
+		Action: eventstore.Action[any]{
+			Creator:  authz.GetCtxData(ctx).UserID,
+			Type:     DeactivatedType,
+			Revision: revision,
+		},
+	}
+}
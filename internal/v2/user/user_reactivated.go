@@ -1,6 +1,9 @@
 package user
 
 import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/v2/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -25,3 +28,14 @@ func ReactivatedEventFromStorage(event *eventstore.StorageEvent) (e *Reactivated
 		StorageEvent: event,
 	}, nil
 }
+
+// NewReactivatedCommand reactivates the user
+func NewReactivatedCommand(ctx context.Context) *eventstore.Command {
+	return &eventstore.Command{
+		Action: eventstore.Action[any]{
+			Creator:  authz.GetCtxData(ctx).UserID,
+			Type:     ReactivatedType,
+			Revision: revision,
+		},
+	}
+}
@@ -1,6 +1,9 @@
 package user
 
 import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/v2/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -25,3 +28,14 @@ func UnlockedEventFromStorage(event *eventstore.StorageEvent) (e *UnlockedEvent,
 		StorageEvent: event,
 	}, nil
 }
+
+// NewUnlockedCommand unlocks the user
+func NewUnlockedCommand(ctx context.Context) *eventstore.Command {
+	return &eventstore.Command{
+		Action: eventstore.Action[any]{
+			Creator:  authz.GetCtxData(ctx).UserID,
+			Type:     UnlockedType,
+			Revision: revision,
+		},
+	}
+}
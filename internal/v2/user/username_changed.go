@@ -1,6 +1,9 @@
 package user
 
 import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/v2/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -35,3 +38,32 @@ func UsernameChangedEventFromStorage(event *eventstore.StorageEvent) (e *Usernam
 		Payload:      payload,
 	}, nil
 }
+
+// uniqueUsername is shared with the eventstore v1
+const uniqueUsername = "usernames"
+
+// NewUsernameChangedCommand changes the username of a user of the org with the given id.
+// If the login must be a domain of the org the username is only unique inside the org.
+func NewUsernameChangedCommand(ctx context.Context, orgID, oldUsername, username string, userLoginMustBeDomain bool) *eventstore.Command {
+	return &eventstore.Command{
+		Action: eventstore.Action[any]{
+			Creator:  authz.GetCtxData(ctx).UserID,
+			Type:     UsernameChangedType,
+			Revision: revision,
+			Payload: usernameChangedPayload{
+				Username: username,
+			},
+		},
+		UniqueConstraints: []*eventstore.UniqueConstraint{
+			eventstore.NewRemoveUniqueConstraint(uniqueUsername, usernameUniqueField(orgID, oldUsername, userLoginMustBeDomain)),
+			eventstore.NewAddEventUniqueConstraint(uniqueUsername, usernameUniqueField(orgID, username, userLoginMustBeDomain), "Errors.User.AlreadyExists"),
+		},
+	}
+}
+
+func usernameUniqueField(orgID, username string, userLoginMustBeDomain bool) string {
+	if userLoginMustBeDomain {
+		return username + orgID
+	}
+	return username
+}
@@ -0,0 +1,9 @@
+package user
+
+// event types of the users created before the split into human and machine users
+const (
+	V1AddedType             = AggregateType + ".added"
+	V1RegisteredType        = AggregateType + ".selfregistered"
+	V1InitCodeAddedType     = AggregateType + ".initialization.code.added"
+	V1InitCodeSucceededType = AggregateType + ".initialization.check.succeeded"
+)
@@ -0,0 +1,10 @@
+package system
+
+import "github.com/zitadel/zitadel/internal/api/grpc/server/middleware"
+
+func (resp *WatchEventsResponse) Localizers() []middleware.Localizer {
+	if resp == nil || resp.Event == nil {
+		return nil
+	}
+	return []middleware.Localizer{resp.Event.Type.Localized, resp.Event.Aggregate.Type.Localized}
+}